@@ -28,6 +28,7 @@ func main() {
 		&cfg.Tg,
 		&cfg.TzBotService,
 		&cfg.Redis,
+		&cfg.Session,
 		&cfg.Postgres,
 		&cfg.ChatBotService,
 		&cfg.SearchBotService,
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"os"
 	httpapp "repairCopilotBot/api-gateway-service/internal/app/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/migrator"
 	tgClient "repairCopilotBot/api-gateway-service/internal/pkg/tg"
 	"repairCopilotBot/api-gateway-service/internal/repository"
@@ -35,6 +36,7 @@ func New(
 	TgConfig *tgClient.Config,
 	TzBotClientConfig *tzbotclient.Config,
 	RedisConfig *repository.RedisConfig,
+	SessionConfig *auth.SessionConfig,
 	PostgresConfig *postgres.Config,
 	ChatBotClientConfig *chatBotClient.Config,
 	SearchBotClientConfig *searchBotClient.Config,
@@ -76,7 +78,7 @@ func New(
 
 	searchBotClient, err := searchBotClient.New(SearchBotClientConfig)
 
-	sessionRepo := repository.NewSessionRepository(RedisConfig.Address, RedisConfig.Password)
+	sessionRepo := repository.NewSessionRepository(RedisConfig.Address, RedisConfig.Password, SessionConfig.IdleTimeout, SessionConfig.MaxLifetime)
 
 	//tgBot, err := tgClient.NewBot(TgConfig.Token)
 	//if err != nil {
@@ -85,7 +87,7 @@ func New(
 
 	//tgClient.New(tgBot, TgConfig.ChatID)
 
-	httpApp := httpapp.New(log, httpConfig, tzBotClient, userServiceClient, chatBotClient, searchBotClient, sessionRepo, actionLogRepo)
+	httpApp := httpapp.New(log, httpConfig, tzBotClient, userServiceClient, chatBotClient, searchBotClient, sessionRepo, SessionConfig, actionLogRepo)
 
 	return &App{
 		HTTPServer: httpApp,
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/http/handler"
 	"repairCopilotBot/api-gateway-service/internal/pkg/logger/sl"
 	"repairCopilotBot/api-gateway-service/internal/repository"
@@ -37,151 +38,282 @@ func New(
 	chatBotClient *chatbotclient.ChatBotClient,
 	searchBotClient *searchbotclient.SearchBotClient,
 	sessionRepo *repository.SessionRepository,
+	sessionConfig *auth.SessionConfig,
 	actionLogRepo repository.ActionLogRepository,
 ) *App {
 	router := http.NewServeMux()
 
-	router.HandleFunc(
+	// Роли маршрутов: authenticated - любой пользователь с сессией, adminOnly - администраторы.
+	// Маршруты без обёртки доступны без входа
+	authenticated := auth.Require(log, auth.RoleUser)
+	adminOnly := auth.Require(log, auth.RoleAdmin1, auth.RoleAdmin2)
+
+	router.Handle(
 		"POST /api/tz",
-		handler.NewTzHandler(log, tzBotClient, sessionRepo, userServiceClient, actionLogRepo),
+		authenticated(handler.NewTzHandler(log, tzBotClient, userServiceClient, actionLogRepo)),
+	)
+
+	router.Handle(
+		"POST /api/tz/{spec_id}/versions",
+		authenticated(handler.NewTzVersionHandler(log, tzBotClient, userServiceClient, actionLogRepo)),
 	)
 
 	router.HandleFunc(
 		"POST /api/users/login",
-		handler.LoginHandler(log, userServiceClient, sessionRepo, tzBotClient, actionLogRepo),
+		handler.LoginHandler(log, userServiceClient, sessionRepo, sessionConfig, tzBotClient, actionLogRepo),
 	)
 
 	router.HandleFunc(
 		"POST /api/register",
-		handler.RegisterHandler(log, userServiceClient, sessionRepo, actionLogRepo),
+		handler.RegisterHandler(log, userServiceClient, sessionRepo, sessionConfig, actionLogRepo),
 	)
 
-	router.HandleFunc("POST /api/confirm-email",
-		handler.ConfirmEmail(log, userServiceClient, sessionRepo, chatBotClient))
+	router.Handle("POST /api/confirm-email",
+		authenticated(handler.ConfirmEmail(log, userServiceClient, chatBotClient)))
 
-	router.HandleFunc("POST /api/confirm",
-		handler.ConfirmEmail(log, userServiceClient, sessionRepo, chatBotClient))
+	router.Handle("POST /api/confirm",
+		authenticated(handler.ConfirmEmail(log, userServiceClient, chatBotClient)))
 
 	router.HandleFunc("POST /api/users/recovery",
-		handler.RecoveryHandler(log, userServiceClient))
+		handler.RecoveryHandler(log, userServiceClient, sessionRepo))
 
 	router.HandleFunc(
 		"GET /api/logout",
-		handler.LogoutHandler(log))
+		handler.LogoutHandler(log, sessionRepo, sessionConfig))
 
-	router.HandleFunc(
+	// Сессии текущего пользователя: список, завершение одной и выход на всех устройствах
+	router.Handle(
+		"GET /api/sessions",
+		authenticated(handler.ListSessionsHandler(log, sessionRepo)),
+	)
+
+	router.Handle(
+		"DELETE /api/sessions",
+		authenticated(handler.RevokeAllSessionsHandler(log, sessionRepo, sessionConfig, userServiceClient, actionLogRepo)),
+	)
+
+	router.Handle(
+		"DELETE /api/sessions/{session_id}",
+		authenticated(handler.RevokeSessionHandler(log, sessionRepo, sessionConfig, userServiceClient, actionLogRepo)),
+	)
+
+	router.Handle(
 		"GET /api/me",
-		handler.MeHandler(log, sessionRepo, tzBotClient, userServiceClient, chatBotClient, searchBotClient),
+		authenticated(handler.MeHandler(log, tzBotClient, userServiceClient, chatBotClient, searchBotClient)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/tz/{version_id}",
-		handler.GetVersionHandler(log, tzBotClient),
+		authenticated(handler.GetVersionHandler(log, tzBotClient)),
+	)
+
+	router.Handle(
+		"POST /api/tz/{version_id}/cancel",
+		authenticated(handler.CancelVersionHandler(log, tzBotClient, userServiceClient, actionLogRepo)),
+	)
+
+	router.Handle(
+		"GET /api/tz/{version_id}/annotated-docx",
+		authenticated(handler.ExportAnnotatedDocxHandler(log, tzBotClient, userServiceClient, actionLogRepo)),
+	)
+
+	router.Handle(
+		"GET /api/tz/{version_id}/files/{kind}",
+		authenticated(handler.VersionFileHandler(log, tzBotClient, userServiceClient, actionLogRepo)),
 	)
 
+	router.Handle(
+		"GET /api/tz/{version_id}/export",
+		authenticated(handler.ExportVersionHandler(log, tzBotClient, userServiceClient, actionLogRepo)),
+	)
+
+	router.Handle(
+		"GET /api/tz/{version_id}/events",
+		authenticated(handler.VersionEventsHandler(log, tzBotClient)),
+	)
+
+	router.Handle(
+		"POST /api/tz/{version_id}/share-links",
+		authenticated(handler.CreateShareLinkHandler(log, tzBotClient, userServiceClient, actionLogRepo)),
+	)
+
+	router.Handle(
+		"GET /api/tz/{version_id}/share-links",
+		authenticated(handler.ListShareLinksHandler(log, tzBotClient)),
+	)
+
+	router.Handle(
+		"DELETE /api/share-links/{share_link_id}",
+		authenticated(handler.RevokeShareLinkHandler(log, tzBotClient, userServiceClient, actionLogRepo)),
+	)
+
+	// Ссылки на просмотр открываются без входа, доступ проверяет tz-bot по токену
 	router.HandleFunc(
-		"GET /api/users",
-		handler.GetUsersHandler(log, userServiceClient, sessionRepo),
+		"GET /api/shared/{token}",
+		handler.SharedVersionHandler(log, tzBotClient, actionLogRepo),
 	)
 
 	router.HandleFunc(
+		"GET /api/shared/{token}/export",
+		handler.SharedExportHandler(log, tzBotClient, actionLogRepo),
+	)
+
+	// Профили проверки и каталог замечаний нужны только в интерфейсе после входа
+	router.Handle(
+		"GET /api/check-profiles",
+		authenticated(handler.GetCheckProfiles(log, tzBotClient)),
+	)
+
+	router.Handle(
+		"GET /api/error-catalog",
+		authenticated(handler.GetErrorCatalog(log, tzBotClient)),
+	)
+
+	router.Handle(
+		"GET /api/error-catalog/{code}",
+		authenticated(handler.GetErrorCatalogEntry(log, tzBotClient)),
+	)
+
+	router.Handle(
+		"GET /api/tz/compare",
+		authenticated(handler.CompareVersionsHandler(log, tzBotClient)),
+	)
+
+	router.Handle(
+		"GET /api/users",
+		adminOnly(handler.GetUsersHandler(log, userServiceClient)),
+	)
+
+	router.Handle(
 		"GET /api/users/{user_id}/info",
-		handler.GetUserInfoHandler(log, sessionRepo, tzBotClient, userServiceClient),
+		adminOnly(handler.GetUserInfoHandler(log, sessionRepo, tzBotClient, userServiceClient)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/users/{user_id}",
-		handler.GetUserByIdHandler(log, userServiceClient, sessionRepo, tzBotClient),
+		adminOnly(handler.GetUserByIdHandler(log, userServiceClient, tzBotClient)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/action-logs",
-		handler.GetActionLogsHandler(log, actionLogRepo, sessionRepo),
+		adminOnly(handler.GetActionLogsHandler(log, actionLogRepo)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/admin/dashboard",
-		handler.GetAdminDashboardHandler(log, userServiceClient, tzBotClient, sessionRepo, actionLogRepo),
+		adminOnly(handler.GetAdminDashboardHandler(log, userServiceClient, tzBotClient, actionLogRepo)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/admin/inspections",
-		handler.GetInspectionsHandler(log, tzBotClient, userServiceClient, sessionRepo),
+		adminOnly(handler.GetInspectionsHandler(log, tzBotClient, userServiceClient)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"POST /api/feedback",
-		handler.NewFeedbackErrorHandler(log, tzBotClient, userServiceClient, sessionRepo, actionLogRepo),
+		authenticated(handler.NewFeedbackErrorHandler(log, tzBotClient, userServiceClient, actionLogRepo)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"POST /api/feedback/verification",
-		handler.NewFeedbackVerificationErrorHandler(log, tzBotClient, userServiceClient, sessionRepo, actionLogRepo),
+		authenticated(handler.NewFeedbackVerificationErrorHandler(log, tzBotClient, userServiceClient, actionLogRepo)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/analytics/billing/limits",
-		handler.GetBillingLimits(log, tzBotClient),
+		adminOnly(handler.GetBillingLimits(log, tzBotClient)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/analytics/billing/daily",
-		handler.GetBillingDaily(log, tzBotClient),
+		adminOnly(handler.GetBillingDaily(log, tzBotClient)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/feedbacks",
-		handler.GetFeedbacks(log, tzBotClient, userServiceClient, sessionRepo),
+		adminOnly(handler.GetFeedbacks(log, tzBotClient, userServiceClient)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"POST /api/admin/users/update-inspections-per-day",
-		handler.UpdateInspectionsPerDayHandler(log, userServiceClient, sessionRepo, actionLogRepo),
+		adminOnly(handler.UpdateInspectionsPerDayHandler(log, userServiceClient, actionLogRepo)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"POST /api/admin/users/change-role",
-		handler.ChangeUserRoleHandler(log, userServiceClient, sessionRepo, actionLogRepo),
+		adminOnly(handler.ChangeUserRoleHandler(log, userServiceClient, sessionRepo, actionLogRepo)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/users/inspection-limit",
-		handler.CheckInspectionLimitHandler(log, sessionRepo, userServiceClient),
+		authenticated(handler.CheckInspectionLimitHandler(log, userServiceClient)),
+	)
+
+	// Организации: создаёт, задаёт квоту и добавляет участников администратор системы,
+	// менять роли и исключать участников могут также администраторы организации
+	router.Handle(
+		"POST /api/admin/organizations",
+		adminOnly(handler.CreateOrganizationHandler(log, userServiceClient, actionLogRepo)),
+	)
+
+	router.Handle(
+		"GET /api/organizations/me",
+		authenticated(handler.GetMyOrganizationHandler(log, userServiceClient)),
+	)
+
+	router.Handle(
+		"GET /api/organizations/me/versions",
+		authenticated(handler.GetOrganizationVersionsHandler(log, tzBotClient, userServiceClient)),
+	)
+
+	router.Handle(
+		"POST /api/organizations/{organization_id}/members",
+		authenticated(handler.AddOrganizationMemberHandler(log, userServiceClient, actionLogRepo)),
+	)
+
+	router.Handle(
+		"DELETE /api/organizations/{organization_id}/members/{user_id}",
+		authenticated(handler.RemoveOrganizationMemberHandler(log, userServiceClient, actionLogRepo)),
+	)
+
+	router.Handle(
+		"PUT /api/organizations/{organization_id}/quota",
+		adminOnly(handler.UpdateOrganizationQuotaHandler(log, userServiceClient, actionLogRepo)),
 	)
 
 	// Chat Bot routes
-	router.HandleFunc(
+	router.Handle(
 		"POST /api/searchchat/message",
-		handler.CreateNewSearchMessageHandler(log, sessionRepo, searchBotClient, userServiceClient, actionLogRepo),
+		authenticated(handler.CreateNewSearchMessageHandler(log, searchBotClient, userServiceClient, actionLogRepo)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/searchchat/{chat_id}/messages",
-		handler.GetSearchMessagesHandler(log, sessionRepo, searchBotClient),
+		authenticated(handler.GetSearchMessagesHandler(log, searchBotClient)),
 	)
 
 	// Chat Bot routes
-	router.HandleFunc(
+	router.Handle(
 		"POST /api/chat/message",
-		handler.CreateNewMessageHandler(log, sessionRepo, chatBotClient, userServiceClient, actionLogRepo),
+		authenticated(handler.CreateNewMessageHandler(log, chatBotClient, userServiceClient, actionLogRepo)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/chat/{chat_id}/messages",
-		handler.GetMessagesHandler(log, sessionRepo, chatBotClient),
+		authenticated(handler.GetMessagesHandler(log, chatBotClient)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"POST /api/chat/finish",
-		handler.FinishChatHandler(log, sessionRepo, chatBotClient, actionLogRepo),
+		authenticated(handler.FinishChatHandler(log, chatBotClient, actionLogRepo)),
 	)
 
-	router.HandleFunc(
+	router.Handle(
 		"GET /api/admin/chats/all",
-		handler.GetAllChatsHandler(log, sessionRepo, chatBotClient, userServiceClient),
+		adminOnly(handler.GetAllChatsHandler(log, chatBotClient, userServiceClient)),
 	)
 
-	routerWithCorsHandler := corsMiddleware(log, router)
+	routerWithCorsHandler := corsMiddleware(log, auth.Authenticate(log, sessionRepo)(router))
 
 	srv := &http.Server{
 		Addr:         ":" + strconv.Itoa(config.Port),
@@ -274,7 +406,7 @@ func corsMiddleware(log *slog.Logger, next http.Handler) http.Handler {
 				)
 				w.Header().Set(
 					"Access-Control-Expose-Headers",
-					"Content-Length",
+					"Content-Length, Content-Disposition, X-Export-Schema-Version",
 				)
 				w.Header().Set("Access-Control-Max-Age", "43200") // 12 hours
 			}
@@ -3,6 +3,7 @@ package config
 import (
 	"repairCopilotBot/api-gateway-service/internal/app"
 	httpapp "repairCopilotBot/api-gateway-service/internal/app/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/pkg/tg"
 	"repairCopilotBot/api-gateway-service/internal/repository"
 	"repairCopilotBot/api-gateway-service/internal/repository/postgres"
@@ -23,6 +24,7 @@ type Config struct {
 	ChatBotService   chatBotServiceClient.Config   `env-prefix:"CHAT_POCHEMU_SERVICE_"`
 	SearchBotService searchBotServiceClient.Config `env-prefix:"CHAT_SEARCH_SERVICE_"`
 	Redis            repository.RedisConfig        `env-prefix:"REDIS_"`
+	Session          auth.SessionConfig            `env-prefix:"SESSION_"`
 	Postgres         postgres.Config               `env-prefix:"POSTGRES_"`
 	UserService      userserviceclient.Config      `env-prefix:"USER_SERVICE_"`
 }
@@ -0,0 +1,170 @@
+// Package auth определяет пользователя запроса по сессии и проверяет его роль.
+// Сессия разбирается один раз в Authenticate, обработчики получают пользователя из контекста
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"repairCopilotBot/api-gateway-service/internal/pkg/logger/sl"
+	"repairCopilotBot/api-gateway-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// CookieName - кука с идентификатором сессии
+const CookieName = "auth_token"
+
+// Role - роль, которую требует маршрут
+type Role string
+
+const (
+	// RoleUser - любой пользователь с действующей сессией
+	RoleUser   Role = "user"
+	RoleAdmin1 Role = "admin1"
+	RoleAdmin2 Role = "admin2"
+)
+
+// Principal - пользователь, от имени которого выполняется запрос
+type Principal struct {
+	SessionID string
+	UserID    uuid.UUID
+	Login     string
+	IsAdmin1  bool
+	IsAdmin2  bool
+}
+
+// HasRole проверяет, есть ли у пользователя роль
+func (p *Principal) HasRole(role Role) bool {
+	switch role {
+	case RoleUser:
+		return true
+	case RoleAdmin1:
+		return p.IsAdmin1
+	case RoleAdmin2:
+		return p.IsAdmin2
+	}
+	return false
+}
+
+// IsAdmin - пользователь с любой из администраторских ролей
+func (p *Principal) IsAdmin() bool {
+	return p.IsAdmin1 || p.IsAdmin2
+}
+
+type principalKey struct{}
+
+// WithPrincipal добавляет пользователя в контекст
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext возвращает пользователя запроса. Для анонимного запроса возвращает false
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// MustFromContext возвращает пользователя запроса в обработчиках, закрытых Require.
+// Паника означает, что маршрут зарегистрирован без Require
+func MustFromContext(ctx context.Context) *Principal {
+	principal, ok := FromContext(ctx)
+	if !ok {
+		panic("auth: principal not found in request context, route must be wrapped with auth.Require")
+	}
+	return principal
+}
+
+// Authenticate разбирает сессию из куки и добавляет пользователя в контекст запроса.
+// Запрос без сессии или с недействительной сессией передаётся дальше анонимным:
+// решение об отказе принимает Require
+func Authenticate(log *slog.Logger, sessionRepo *repository.SessionRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const op = "auth.Authenticate"
+
+			cookie, err := r.Cookie(CookieName)
+			if err != nil || cookie.Value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			log := log.With(slog.String("op", op))
+
+			session, err := sessionRepo.GetSession(cookie.Value)
+			if err != nil {
+				log.Error("failed to get session from Redis", sl.Err(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+			if session == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Скользящий срок: каждый запрос продлевает сессию на idle timeout
+			if err := sessionRepo.TouchSession(cookie.Value, session); err != nil {
+				log.Error("failed to touch session in Redis", sl.Err(err))
+			}
+
+			userID, err := uuid.Parse(session.UserID)
+			if err != nil {
+				log.Error("invalid user_id in session", slog.String("user_id", session.UserID))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal := &Principal{
+				SessionID: cookie.Value,
+				UserID:    userID,
+				Login:     session.Login,
+				IsAdmin1:  session.IsAdmin1,
+				IsAdmin2:  session.IsAdmin2,
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// Require пропускает запрос, если у пользователя есть хотя бы одна из ролей.
+// Без сессии возвращает 401, без нужной роли - 403
+func Require(log *slog.Logger, roles ...Role) func(http.HandlerFunc) http.Handler {
+	return func(next http.HandlerFunc) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := FromContext(r.Context())
+			if !ok {
+				WriteError(w, http.StatusUnauthorized, "unauthorized", "authentication required")
+				return
+			}
+
+			for _, role := range roles {
+				if principal.HasRole(role) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			log.Info("access denied",
+				slog.String("user_id", principal.UserID.String()),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path))
+			WriteError(w, http.StatusForbidden, "forbidden", "insufficient permissions")
+		})
+	}
+}
+
+// ErrorResponse - тело ответа 401 и 403
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// WriteError отправляет ошибку авторизации в JSON
+func WriteError(w http.ResponseWriter, statusCode int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{Error: code, Message: message})
+}
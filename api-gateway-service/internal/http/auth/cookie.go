@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+)
+
+// SessionConfig - сроки жизни сессий и параметры куки
+type SessionConfig struct {
+	// IdleTimeout - сессия без запросов дольше этого времени завершается, каждый запрос её продлевает
+	IdleTimeout time.Duration `env:"IDLE_TIMEOUT" env-default:"72h"`
+	// MaxLifetime - максимальный срок сессии независимо от активности
+	MaxLifetime time.Duration `env:"MAX_LIFETIME" env-default:"720h"`
+	// SecureCookie отключается только для локальной разработки без HTTPS
+	SecureCookie bool `env:"SECURE_COOKIE" env-default:"true"`
+}
+
+// SetSessionCookie устанавливает куку сессии. Срок куки - максимальный срок сессии,
+// idle timeout проверяется на стороне Redis
+func SetSessionCookie(w http.ResponseWriter, config *SessionConfig, sessionID string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    sessionID,
+		Path:     "/",
+		MaxAge:   int(config.MaxLifetime.Seconds()),
+		HttpOnly: true,
+		Secure:   config.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// ClearSessionCookie удаляет куку сессии в браузере
+func ClearSessionCookie(w http.ResponseWriter, config *SessionConfig) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   config.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -3,16 +3,17 @@ package handler
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"os"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/repository"
 	"repairCopilotBot/tz-bot/client"
 	userserviceclient "repairCopilotBot/user-service/client"
 	"strings"
 
-	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 //type NewTzResponse struct {
@@ -57,7 +58,6 @@ import (
 func NewTzHandler(
 	log *slog.Logger,
 	tzBotClient *client.Client,
-	sessionRepo *repository.SessionRepository,
 	userServiceClient *userserviceclient.UserClient,
 	actionLogRepo repository.ActionLogRepository,
 ) func(
@@ -69,73 +69,15 @@ func NewTzHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("TZ processing request started")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		uid, err := uuid.Parse(session.UserID)
-		if err != nil {
-			log.Info("failed to parse session uid")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		}
-
-		// Парсим multipart form (максимум 10MB)
-		err = r.ParseMultipartForm(10 << 20)
-		if err != nil {
-			log.Error("failed to parse multipart form", slog.String("error", err.Error()))
-			http.Error(w, "Invalid form data", http.StatusBadRequest)
-			return
-		}
+		principal := auth.MustFromContext(r.Context())
 
-		log.Debug("multipart form parsed successfully")
+		uid := principal.UserID
 
-		// Получаем файл из формы
-		file, header, err := r.FormFile("file")
-		if err != nil {
-			log.Error("failed to get file from form", slog.String("error", err.Error()))
-			http.Error(w, "File not found in request", http.StatusBadRequest)
+		// Получаем файл из формы (не больше, чем пропустит gRPC к tz-bot)
+		fileBytes, filename, ok := readUploadedFile(w, r, log)
+		if !ok {
 			return
 		}
-		defer file.Close()
-
-		log.Info("file received",
-			slog.String("filename", header.Filename),
-			slog.Int64("size", header.Size))
-
-		// Читаем файл в байты
-		fileBytes, err := io.ReadAll(file)
-		if err != nil {
-			log.Error("failed to read file content", slog.String("error", err.Error()))
-			http.Error(w, "Failed to read file", http.StatusInternalServerError)
-			return
-		}
-
-		filename := header.Filename
 
 		//requestID, err := uuid.NewUUID()
 		//if err != nil {
@@ -144,22 +86,35 @@ func NewTzHandler(
 		//	return
 		//}
 
-		log = log.With(slog.String("requestID", session.UserID))
+		log = log.With(slog.String("requestID", principal.UserID.String()))
 		log.Info("processing TZ file", slog.String("filename", filename))
 
-		checkTzResult, err := tzBotClient.CheckTz(r.Context(), fileBytes, filename, uid)
+		checkTzResult, err := tzBotClient.CheckTz(r.Context(), fileBytes, filename, uid, uploadCheckOptions(r))
 		if err != nil {
 			log.Error("TZ processing failed", slog.String("error", err.Error()))
-			http.Error(w, "TZ processing failed", http.StatusInternalServerError)
+
+			switch status.Code(err) {
+			case codes.InvalidArgument:
+				http.Error(w, status.Convert(err).Message(), http.StatusUnsupportedMediaType)
+			case codes.FailedPrecondition:
+				http.Error(w, status.Convert(err).Message(), http.StatusUnprocessableEntity)
+			case codes.NotFound:
+				http.Error(w, "Check profile not found", http.StatusBadRequest)
+			default:
+				http.Error(w, "TZ processing failed", http.StatusInternalServerError)
+			}
 			return
 		}
 
-		log.Info("TZ processing completed successfully")
+		log.Info("TZ processing completed successfully", slog.Bool("duplicate", checkTzResult.Duplicate))
 
 		// Логируем событие отправки документа
 		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), uid)
 		if userInfoErr == nil {
 			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " отправил документ " + filename + " на проверку"
+			if checkTzResult.Duplicate {
+				actionText = "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " повторно загрузил уже проверенный документ " + filename
+			}
 			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, uid, 3); err != nil {
 				log.Error("failed to create action log for TZ submission", slog.String("error", err.Error()))
 			}
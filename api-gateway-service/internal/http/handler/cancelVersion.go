@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
+	"repairCopilotBot/api-gateway-service/internal/repository"
+	"repairCopilotBot/tz-bot/client"
+	userserviceclient "repairCopilotBot/user-service/client"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CancelVersionResponse struct {
+	VersionID string `json:"version_id"`
+	Status    string `json:"status"`
+}
+
+func CancelVersionHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.CancelVersionHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("processing CancelVersion request")
+
+		versionIDStr := r.PathValue("version_id")
+		if versionIDStr == "" {
+			log.Error("version_id parameter is missing")
+			http.Error(w, "version_id parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		versionID, err := uuid.Parse(versionIDStr)
+		if err != nil {
+			log.Error("invalid version_id format", slog.String("version_id", versionIDStr), slog.String("error", err.Error()))
+			http.Error(w, "Invalid version_id format", http.StatusBadRequest)
+			return
+		}
+
+		principal := auth.MustFromContext(r.Context())
+
+		uid := principal.UserID
+
+		log = log.With(slog.String("user_id", principal.UserID.String()), slog.String("version_id", versionID.String()))
+
+		err = tzBotClient.CancelVersion(r.Context(), versionID, uid)
+		if err != nil {
+			log.Error("failed to cancel version in tz-bot", slog.String("error", err.Error()))
+
+			switch status.Code(err) {
+			case codes.NotFound:
+				http.Error(w, "version not found", http.StatusNotFound)
+			case codes.PermissionDenied:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+			case codes.FailedPrecondition:
+				http.Error(w, "only versions in progress can be cancelled", http.StatusConflict)
+			default:
+				http.Error(w, "failed to cancel version", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		// Логируем отмену проверки
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), uid)
+		if userInfoErr == nil {
+			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " отменил проверку документа"
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, uid, 3); err != nil {
+				log.Error("failed to create action log for version cancellation", slog.String("error", err.Error()))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(CancelVersionResponse{
+			VersionID: versionID.String(),
+			Status:    "cancelled",
+		}); err != nil {
+			log.Error("failed to encode response", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("version cancelled successfully")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/repository"
 	userserviceclient "repairCopilotBot/user-service/client"
 
@@ -32,42 +33,9 @@ func ChangeUserRoleHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("change user role request started")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
+		principal := auth.MustFromContext(r.Context())
 
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем права администратора
-		// TODO: раскомментировать когда будет готова проверка прав
-		// if !session.IsAdmin1 && !session.IsAdmin2 {
-		// 	log.Info("user is not admin", slog.String("user_id", session.UserID))
-		// 	http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
-		// 	return
-		// }
+		// Права администратора проверяются в auth.Require при регистрации маршрута
 
 		// Парсим JSON тело запроса
 		var req ChangeUserRoleRequest
@@ -109,6 +77,14 @@ func ChangeUserRoleHandler(
 			return
 		}
 
+		// Роль хранится в сессиях, поэтому все сессии пользователя завершаются и он входит заново
+		revoked, err := sessionRepo.DeleteUserSessions(uuid.MustParse(req.UserID))
+		if err != nil {
+			log.Error("failed to revoke user sessions after role change", slog.String("error", err.Error()))
+		} else {
+			log.Info("user sessions revoked after role change", slog.Int("revoked", revoked))
+		}
+
 		response := ChangeUserRoleResponse{
 			Success: resp.Success,
 			Message: resp.Message,
@@ -123,14 +99,14 @@ func ChangeUserRoleHandler(
 		}
 
 		// Логируем событие смены роли
-		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), uuid.MustParse(session.UserID))
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), principal.UserID)
 		if userInfoErr == nil {
 			targetUserInfo, targetUserInfoErr := userServiceClient.GetUserInfo(r.Context(), uuid.MustParse(req.UserID))
 			if targetUserInfoErr == nil {
 				actionText := "Администратор " + userInfo.FirstName + " " + userInfo.LastName +
 					" изменил роль пользователя " + targetUserInfo.FirstName + " " + targetUserInfo.LastName +
 					" на '" + req.Role + "'"
-				if err := actionLogRepo.CreateActionLog(r.Context(), actionText, uuid.MustParse(session.UserID), 5); err != nil {
+				if err := actionLogRepo.CreateActionLog(r.Context(), actionText, principal.UserID, 5); err != nil {
 					log.Error("failed to create action log for role change", slog.String("error", err.Error()))
 				}
 			}
@@ -139,6 +115,6 @@ func ChangeUserRoleHandler(
 		log.Info("change user role request completed",
 			slog.String("target_user_id", req.UserID),
 			slog.String("new_role", req.Role),
-			slog.String("admin_user_id", session.UserID))
+			slog.String("admin_user_id", principal.UserID.String()))
 	}
-}
\ No newline at end of file
+}
@@ -6,40 +6,37 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"repairCopilotBot/api-gateway-service/internal/repository"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	userserviceclient "repairCopilotBot/user-service/client"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 type CheckInspectionLimitResponse struct {
-	Status         string `json:"status"`                    // "success" или "limit_exhausted"
+	Status          string  `json:"status"`                     // "success" или "limit_exhausted"
 	InspectionsLeft *uint32 `json:"inspections_left,omitempty"` // Количество оставшихся проверок (если лимит не исчерпан)
-	TimeUntilReset *string `json:"time_until_reset,omitempty"`  // Время до полуночи в формате "HH:MM:SS" (если лимит исчерпан)
+	TimeUntilReset  *string `json:"time_until_reset,omitempty"` // Время до полуночи в формате "HH:MM:SS" (если лимит исчерпан)
 }
 
 // calculateTimeUntilMidnight вычисляет время до полуночи
 func calculateTimeUntilMidnight() string {
 	now := time.Now()
-	
+
 	// Находим следующую полночь
 	nextMidnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
-	
+
 	// Вычисляем разность
 	duration := nextMidnight.Sub(now)
-	
+
 	// Преобразуем в часы, минуты, секунды
 	hours := int(duration.Hours())
 	minutes := int(duration.Minutes()) % 60
 	seconds := int(duration.Seconds()) % 60
-	
+
 	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
 }
 
 func CheckInspectionLimitHandler(
 	log *slog.Logger,
-	sessionRepo *repository.SessionRepository,
 	userServiceClient *userserviceclient.UserClient,
 ) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -48,48 +45,9 @@ func CheckInspectionLimitHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("processing check inspection limit request")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
+		principal := auth.MustFromContext(r.Context())
 
-		if session.UserID == "" {
-			log.Info("invalid session data - empty user ID")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Парсим UserID
-		userID, err := uuid.Parse(session.UserID)
-		if err != nil {
-			log.Error("invalid user ID format in session", slog.String("user_id", session.UserID), slog.String("error", err.Error()))
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
+		userID := principal.UserID
 
 		// Проверяем лимит проверок
 		inspectionsLeft, err := userServiceClient.CheckInspectionLimit(r.Context(), userID.String())
@@ -100,8 +58,8 @@ func CheckInspectionLimitHandler(
 				// Лимит исчерпан - возвращаем время до полуночи
 				timeUntilReset := calculateTimeUntilMidnight()
 				response := CheckInspectionLimitResponse{
-					Status:          "limit_exhausted",
-					TimeUntilReset:  &timeUntilReset,
+					Status:         "limit_exhausted",
+					TimeUntilReset: &timeUntilReset,
 				}
 
 				w.Header().Set("Content-Type", "application/json")
@@ -142,4 +100,4 @@ func CheckInspectionLimitHandler(
 			slog.String("user_id", userID.String()),
 			slog.Uint64("inspections_left", uint64(inspectionsLeft)))
 	}
-}
\ No newline at end of file
+}
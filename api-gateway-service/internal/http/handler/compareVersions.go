@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
+	"repairCopilotBot/tz-bot/client"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func CompareVersionsHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.CompareVersionsHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("processing CompareVersions request")
+
+		versionAStr := r.URL.Query().Get("version_a")
+		versionBStr := r.URL.Query().Get("version_b")
+		if versionAStr == "" || versionBStr == "" {
+			log.Error("version_a or version_b not provided")
+			http.Error(w, "version_a and version_b are required", http.StatusBadRequest)
+			return
+		}
+
+		versionAID, err := uuid.Parse(versionAStr)
+		if err != nil {
+			log.Error("invalid version_a format", slog.String("version_a", versionAStr), slog.String("error", err.Error()))
+			http.Error(w, "invalid version_a format", http.StatusBadRequest)
+			return
+		}
+
+		versionBID, err := uuid.Parse(versionBStr)
+		if err != nil {
+			log.Error("invalid version_b format", slog.String("version_b", versionBStr), slog.String("error", err.Error()))
+			http.Error(w, "invalid version_b format", http.StatusBadRequest)
+			return
+		}
+
+		result, err := tzBotClient.CompareVersions(r.Context(), versionAID, versionBID, tzCaller(auth.MustFromContext(r.Context())))
+		if err != nil {
+			log.Error("failed to compare versions in tz-bot", slog.String("error", err.Error()))
+
+			switch status.Code(err) {
+			case codes.NotFound:
+				http.Error(w, "version not found", http.StatusNotFound)
+			case codes.PermissionDenied:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+			case codes.FailedPrecondition:
+				http.Error(w, "version processing is not completed", http.StatusConflict)
+			case codes.InvalidArgument:
+				http.Error(w, "versions belong to different technical specifications", http.StatusBadRequest)
+			default:
+				http.Error(w, "failed to compare versions", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			log.Error("failed to encode response", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("CompareVersions request processed successfully",
+			slog.Int("fixed_count", len(result.Fixed)),
+			slog.Int("persisting_count", len(result.Persisting)),
+			slog.Int("new_count", len(result.New)))
+	}
+}
@@ -4,11 +4,9 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"repairCopilotBot/api-gateway-service/internal/repository"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	chatbotclient "repairCopilotBot/chat-bot/pkg/client"
 	userserviceclient "repairCopilotBot/user-service/client"
-
-	"github.com/google/uuid"
 )
 
 type ConfirmEmailRequest struct {
@@ -18,7 +16,6 @@ type ConfirmEmailRequest struct {
 func ConfirmEmail(
 	log *slog.Logger,
 	userServiceClient *userserviceclient.UserClient,
-	sessionRepo *repository.SessionRepository,
 	chatBotClient *chatbotclient.ChatBotClient,
 ) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -40,43 +37,16 @@ func ConfirmEmail(
 			return
 		}
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
+		principal := auth.MustFromContext(r.Context())
 
-		err = userServiceClient.ConfirmEmail(r.Context(), uuid.MustParse(session.UserID), req.Code)
+		err := userServiceClient.ConfirmEmail(r.Context(), principal.UserID, req.Code)
 		if err != nil {
 			log.Info("failed to confirm email", slog.String("error", err.Error()))
 			http.Error(w, "Unauthorized", http.StatusBadRequest)
 			return
 		}
 
-		err = chatBotClient.User.CreateNewUser(r.Context(), session.UserID)
+		err = chatBotClient.User.CreateNewUser(r.Context(), principal.UserID.String())
 		if err != nil {
 			log.Error("failed to create new user in search-bot service", slog.String("error", err.Error()))
 		}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/repository"
 	chatbotclient "repairCopilotBot/chat-bot/pkg/client"
 	userserviceclient "repairCopilotBot/user-service/client"
@@ -23,7 +24,6 @@ type CreateNewMessageResponse struct {
 
 func CreateNewMessageHandler(
 	log *slog.Logger,
-	sessionRepo *repository.SessionRepository,
 	chatBotClient *chatbotclient.ChatBotClient,
 	userServiceClient *userserviceclient.UserClient,
 	actionLogRepo repository.ActionLogRepository,
@@ -34,42 +34,9 @@ func CreateNewMessageHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("create new message request started")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session.UserID == "" {
-			log.Info("invalid session data - empty user_id")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
+		principal := auth.MustFromContext(r.Context())
 
-		log.Debug("userID: "+session.UserID, slog.String("userID", session.UserID))
+		log.Debug("userID: "+principal.UserID.String(), slog.String("userID", principal.UserID.String()))
 
 		// Парсим тело запроса
 		var req CreateNewMessageRequest
@@ -89,7 +56,7 @@ func CreateNewMessageHandler(
 		_, cancel := context.WithTimeout(r.Context(), 1000*time.Second)
 		defer cancel()
 
-		chatID, responseMessage, err := chatBotClient.Chat.CreateNewMessage(r.Context(), req.ChatID, session.UserID, req.Message)
+		chatID, responseMessage, err := chatBotClient.Chat.CreateNewMessage(r.Context(), req.ChatID, principal.UserID.String(), req.Message)
 		if err != nil {
 			log.Error("failed to create new message", slog.String("error", err.Error()))
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -97,7 +64,7 @@ func CreateNewMessageHandler(
 		}
 
 		// Логируем действие пользователя
-		//userID, err := uuid.Parse(session.UserID)
+		//userID, err := uuid.Parse(principal.UserID.String())
 		//if err == nil {
 		//	actionText := "Пользователь отправил сообщение в чат"
 		//	if err := actionLogRepo.CreateActionLog(ctx, actionText, userID); err != nil {
@@ -120,7 +87,7 @@ func CreateNewMessageHandler(
 		}
 
 		log.Info("create new message request completed successfully",
-			slog.String("user_id", session.UserID),
+			slog.String("user_id", principal.UserID.String()),
 			slog.String("chat_id", chatID))
 	}
 }
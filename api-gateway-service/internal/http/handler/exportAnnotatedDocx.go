@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
+	"repairCopilotBot/api-gateway-service/internal/repository"
+	"repairCopilotBot/tz-bot/client"
+	userserviceclient "repairCopilotBot/user-service/client"
+	"strconv"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const docxContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// ExportAnnotatedDocxHandler отдаёт исходный документ версии, в котором каждое замечание
+// оформлено комментарием Word к цитате
+func ExportAnnotatedDocxHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.ExportAnnotatedDocxHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("processing ExportAnnotatedDocx request")
+
+		versionIDStr := r.PathValue("version_id")
+		if versionIDStr == "" {
+			log.Error("version_id parameter is missing")
+			http.Error(w, "version_id parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		versionID, err := uuid.Parse(versionIDStr)
+		if err != nil {
+			log.Error("invalid version_id format", slog.String("version_id", versionIDStr), slog.String("error", err.Error()))
+			http.Error(w, "Invalid version_id format", http.StatusBadRequest)
+			return
+		}
+
+		principal := auth.MustFromContext(r.Context())
+
+		uid := principal.UserID
+
+		log = log.With(slog.String("user_id", principal.UserID.String()), slog.String("version_id", versionID.String()))
+
+		docx, err := tzBotClient.ExportAnnotatedDocx(r.Context(), versionID, tzCaller(principal))
+		if err != nil {
+			log.Error("failed to export annotated docx from tz-bot", slog.String("error", err.Error()))
+
+			switch status.Code(err) {
+			case codes.InvalidArgument:
+				http.Error(w, "Invalid version_id format", http.StatusBadRequest)
+			case codes.NotFound:
+				http.Error(w, "version not found", http.StatusNotFound)
+			case codes.PermissionDenied:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+			case codes.FailedPrecondition:
+				http.Error(w, "annotated document is not available for this version", http.StatusConflict)
+			default:
+				http.Error(w, "failed to export annotated document", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		// Логируем скачивание документа
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), uid)
+		if userInfoErr == nil {
+			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " скачал документ с комментариями-замечаниями"
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, uid, 3); err != nil {
+				log.Error("failed to create action log for annotated docx export", slog.String("error", err.Error()))
+			}
+		}
+
+		w.Header().Set("Content-Type", docxContentType)
+		w.Header().Set("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(docx.FileName))
+		w.Header().Set("Content-Length", strconv.Itoa(len(docx.Content)))
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := w.Write(docx.Content); err != nil {
+			log.Error("failed to write annotated docx", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("annotated docx exported successfully", slog.String("file_name", docx.FileName))
+	}
+}
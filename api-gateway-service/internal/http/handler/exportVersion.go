@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
+	"repairCopilotBot/api-gateway-service/internal/repository"
+	"repairCopilotBot/tz-bot/client"
+	userserviceclient "repairCopilotBot/user-service/client"
+	"strconv"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExportVersionHandler отдаёт все ошибки и замечания версии в формате json, csv или xlsx
+// (?format=..., по умолчанию json) для выгрузки в трекеры и сравнения проверок
+func ExportVersionHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.ExportVersionHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("processing ExportVersion request")
+
+		versionIDStr := r.PathValue("version_id")
+		if versionIDStr == "" {
+			log.Error("version_id parameter is missing")
+			http.Error(w, "version_id parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		versionID, err := uuid.Parse(versionIDStr)
+		if err != nil {
+			log.Error("invalid version_id format", slog.String("version_id", versionIDStr), slog.String("error", err.Error()))
+			http.Error(w, "Invalid version_id format", http.StatusBadRequest)
+			return
+		}
+
+		principal := auth.MustFromContext(r.Context())
+
+		uid := principal.UserID
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "json"
+		}
+
+		log = log.With(slog.String("user_id", principal.UserID.String()), slog.String("version_id", versionID.String()), slog.String("format", format))
+
+		export, err := tzBotClient.ExportVersion(r.Context(), versionID, format, tzCaller(principal))
+		if err != nil {
+			log.Error("failed to export version from tz-bot", slog.String("error", err.Error()))
+
+			switch status.Code(err) {
+			case codes.InvalidArgument:
+				http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+			case codes.NotFound:
+				http.Error(w, "version not found", http.StatusNotFound)
+			case codes.PermissionDenied:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+			case codes.FailedPrecondition:
+				http.Error(w, "version processing is not completed", http.StatusConflict)
+			default:
+				http.Error(w, "failed to export version", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		// Логируем выгрузку замечаний
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), uid)
+		if userInfoErr == nil {
+			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " выгрузил замечания проверки в формате " + format
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, uid, 3); err != nil {
+				log.Error("failed to create action log for version export", slog.String("error", err.Error()))
+			}
+		}
+
+		w.Header().Set("Content-Type", export.ContentType)
+		w.Header().Set("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(export.FileName))
+		w.Header().Set("Content-Length", strconv.Itoa(len(export.Content)))
+		w.Header().Set("X-Export-Schema-Version", strconv.Itoa(int(export.SchemaVersion)))
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := w.Write(export.Content); err != nil {
+			log.Error("failed to write export", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("version exported successfully", slog.String("file_name", export.FileName))
+	}
+}
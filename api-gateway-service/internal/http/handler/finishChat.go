@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/repository"
 	chatbotclient "repairCopilotBot/chat-bot/pkg/client"
 	"time"
@@ -20,7 +21,6 @@ type FinishChatResponse struct {
 
 func FinishChatHandler(
 	log *slog.Logger,
-	sessionRepo *repository.SessionRepository,
 	chatBotClient *chatbotclient.ChatBotClient,
 	actionLogRepo repository.ActionLogRepository,
 ) func(w http.ResponseWriter, r *http.Request) {
@@ -30,40 +30,7 @@ func FinishChatHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("finish search request started")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session.UserID == "" {
-			log.Info("invalid session data - empty user_id")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
+		principal := auth.MustFromContext(r.Context())
 
 		// Парсим тело запроса
 		var req FinishChatRequest
@@ -84,7 +51,7 @@ func FinishChatHandler(
 		defer cancel()
 
 		// Вызываем метод клиента search-bot
-		message, err := chatBotClient.Chat.FinishChat(ctx, req.ChatID, session.UserID)
+		message, err := chatBotClient.Chat.FinishChat(ctx, req.ChatID, principal.UserID.String())
 		if err != nil {
 			log.Error("failed to finish search", slog.String("error", err.Error()))
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -92,7 +59,7 @@ func FinishChatHandler(
 		}
 
 		// Логируем действие пользователя
-		//userID, err := uuid.Parse(session.UserID)
+		//userID, err := uuid.Parse(principal.UserID.String())
 		//if err == nil {
 		//	actionText := "Пользователь завершил чат"
 		//	if err := actionLogRepo.CreateActionLog(ctx, actionText, userID); err != nil {
@@ -114,7 +81,7 @@ func FinishChatHandler(
 		}
 
 		log.Info("finish search request completed successfully",
-			slog.String("user_id", session.UserID),
+			slog.String("user_id", principal.UserID.String()),
 			slog.String("chat_id", req.ChatID))
 	}
 }
@@ -68,7 +68,6 @@ func GetAdminDashboardHandler(
 	log *slog.Logger,
 	userServiceClient *userserviceclient.UserClient,
 	tzBotClient *client.Client,
-	sessionRepo *repository.SessionRepository,
 	actionLogRepo repository.ActionLogRepository,
 ) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -77,35 +76,6 @@ func GetAdminDashboardHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("admin dashboard request started")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
 		// Создаем контекст с таймаутом для всех запросов
 		ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 		defer cancel()
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"repairCopilotBot/api-gateway-service/internal/repository"
 	chatbotclient "repairCopilotBot/chat-bot/pkg/client"
 	userserviceclient "repairCopilotBot/user-service/client"
 	"time"
@@ -27,7 +26,6 @@ type GetAllChatsResponse struct {
 
 func GetAllChatsHandler(
 	log *slog.Logger,
-	sessionRepo *repository.SessionRepository,
 	chatBotClient *chatbotclient.ChatBotClient,
 	userServiceClient *userserviceclient.UserClient,
 ) func(w http.ResponseWriter, r *http.Request) {
@@ -37,35 +35,6 @@ func GetAllChatsHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("get all chats request started")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
 		// Создаем контекст с таймаутом
 		ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 		defer cancel()
@@ -51,4 +51,4 @@ func GetBillingLimits(log *slog.Logger, tzBotClient *client.Client) http.Handler
 			slog.String("min_date", response.MinDate),
 			slog.String("max_date", response.MaxDate))
 	}
-}
\ No newline at end of file
+}
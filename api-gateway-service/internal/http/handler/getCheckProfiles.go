@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"repairCopilotBot/api-gateway-service/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/client"
+)
+
+type CheckProfileResponse struct {
+	Name        string  `json:"name"`
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	IsDefault   bool    `json:"is_default"`
+}
+
+type CheckProfilesResponse struct {
+	Profiles []CheckProfileResponse `json:"profiles"`
+}
+
+// GetCheckProfiles возвращает профили проверки, которые можно передать в check_profile при загрузке
+func GetCheckProfiles(log *slog.Logger, tzBotClient *client.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.GetCheckProfiles"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", r.Header.Get("X-Request-ID")),
+		)
+
+		profiles, err := tzBotClient.ListCheckProfiles(r.Context())
+		if err != nil {
+			log.Error("failed to list check profiles", sl.Err(err))
+			http.Error(w, "Failed to get check profiles", http.StatusInternalServerError)
+			return
+		}
+
+		response := CheckProfilesResponse{Profiles: make([]CheckProfileResponse, 0, len(profiles))}
+		for _, profile := range profiles {
+			response.Profiles = append(response.Profiles, CheckProfileResponse{
+				Name:        profile.Name,
+				Title:       profile.Title,
+				Description: profile.Description,
+				IsDefault:   profile.IsDefault,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Error("failed to encode response", sl.Err(err))
+			return
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"repairCopilotBot/api-gateway-service/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/client"
+	tzv1 "repairCopilotBot/tz-bot/pkg/tz/v1"
+)
+
+type ErrorCatalogEntryResponse struct {
+	ID           string  `json:"id"`
+	GgID         int32   `json:"gg_id"`
+	Code         string  `json:"code"`
+	Version      int32   `json:"version"`
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Detector     string  `json:"detector"`
+	IsCurrent    bool    `json:"is_current"`
+	CreatedAt    string  `json:"created_at"`
+	SupersededAt *string `json:"superseded_at,omitempty"`
+}
+
+type ErrorCatalogResponse struct {
+	Entries []ErrorCatalogEntryResponse `json:"entries"`
+}
+
+// GetErrorCatalog возвращает каталог ошибок для справочных страниц.
+// Параметры: gg_id, code и include_history=true для устаревших версий
+func GetErrorCatalog(log *slog.Logger, tzBotClient *client.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.GetErrorCatalog"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", r.Header.Get("X-Request-ID")),
+		)
+
+		query := r.URL.Query()
+		req := &tzv1.ListErrorCatalogRequest{
+			IncludeHistory: query.Get("include_history") == "true",
+		}
+		if code := query.Get("code"); code != "" {
+			req.Code = &code
+		}
+		ggID, ok := optionalInt32Param(query.Get("gg_id"))
+		if !ok {
+			http.Error(w, "Invalid gg_id", http.StatusBadRequest)
+			return
+		}
+		req.GgId = ggID
+
+		entries, err := tzBotClient.ListErrorCatalog(r.Context(), req)
+		if err != nil {
+			log.Error("failed to list error catalog", sl.Err(err))
+			http.Error(w, "Failed to get error catalog", http.StatusInternalServerError)
+			return
+		}
+
+		response := ErrorCatalogResponse{Entries: make([]ErrorCatalogEntryResponse, 0, len(entries))}
+		for _, entry := range entries {
+			response.Entries = append(response.Entries, convertErrorCatalogEntry(entry))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Error("failed to encode response", sl.Err(err))
+			return
+		}
+	}
+}
+
+// GetErrorCatalogEntry возвращает описание ошибки по коду. Параметры: gg_id и version,
+// без version возвращается актуальная версия
+func GetErrorCatalogEntry(log *slog.Logger, tzBotClient *client.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.GetErrorCatalogEntry"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", r.Header.Get("X-Request-ID")),
+		)
+
+		code := r.PathValue("code")
+		if code == "" {
+			http.Error(w, "code is required", http.StatusBadRequest)
+			return
+		}
+
+		query := r.URL.Query()
+		ggID, ok := optionalInt32Param(query.Get("gg_id"))
+		if !ok {
+			http.Error(w, "Invalid gg_id", http.StatusBadRequest)
+			return
+		}
+		version, ok := optionalInt32Param(query.Get("version"))
+		if !ok {
+			http.Error(w, "Invalid version", http.StatusBadRequest)
+			return
+		}
+
+		entry, err := tzBotClient.GetErrorCatalogEntry(r.Context(), &tzv1.GetErrorCatalogEntryRequest{
+			Code:    &code,
+			GgId:    ggID,
+			Version: version,
+		})
+		if err != nil {
+			switch status.Code(err) {
+			case codes.NotFound:
+				http.Error(w, "Error catalog entry not found", http.StatusNotFound)
+			case codes.InvalidArgument:
+				http.Error(w, "Invalid request", http.StatusBadRequest)
+			default:
+				log.Error("failed to get error catalog entry", sl.Err(err))
+				http.Error(w, "Failed to get error catalog entry", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(convertErrorCatalogEntry(entry)); err != nil {
+			log.Error("failed to encode response", sl.Err(err))
+			return
+		}
+	}
+}
+
+func convertErrorCatalogEntry(entry *tzv1.ErrorCatalogEntry) ErrorCatalogEntryResponse {
+	resp := ErrorCatalogEntryResponse{
+		ID:          entry.Id,
+		GgID:        entry.GgId,
+		Code:        entry.Code,
+		Version:     entry.Version,
+		Name:        entry.Name,
+		Description: entry.Description,
+		Detector:    entry.Detector,
+		IsCurrent:   entry.IsCurrent,
+		CreatedAt:   entry.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+	if entry.SupersededAt != nil {
+		supersededAt := entry.SupersededAt.AsTime().Format(time.RFC3339)
+		resp.SupersededAt = &supersededAt
+	}
+	return resp
+}
+
+// optionalInt32Param разбирает необязательный числовой параметр запроса. Пустое значение - nil
+func optionalInt32Param(value string) (*int32, bool) {
+	if value == "" {
+		return nil, true
+	}
+	parsed, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || parsed <= 0 {
+		return nil, false
+	}
+	v := int32(parsed)
+	return &v, true
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"repairCopilotBot/api-gateway-service/internal/repository"
 	searchbotclient "repairCopilotBot/search-bot/pkg/client"
 	searchbotchatclient "repairCopilotBot/search-bot/pkg/client/chat"
 	"time"
@@ -17,7 +16,6 @@ type GetSearchMessagesResponse struct {
 
 func GetSearchMessagesHandler(
 	log *slog.Logger,
-	sessionRepo *repository.SessionRepository,
 	searchBotClient *searchbotclient.SearchBotClient,
 ) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -26,35 +24,6 @@ func GetSearchMessagesHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("get messages request started")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
 		// Получаем chat_id из URL параметра
 		chatID := r.PathValue("chat_id")
 		if chatID == "" {
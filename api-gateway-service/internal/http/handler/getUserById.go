@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"repairCopilotBot/api-gateway-service/internal/repository"
 	"repairCopilotBot/tz-bot/client"
 	userserviceclient "repairCopilotBot/user-service/client"
 
@@ -41,7 +40,6 @@ type GetUserByIdResponse struct {
 func GetUserByIdHandler(
 	log *slog.Logger,
 	userServiceClient *userserviceclient.UserClient,
-	sessionRepo *repository.SessionRepository,
 	tzBotClient *client.Client,
 ) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -50,35 +48,6 @@ func GetUserByIdHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("get user by id request started")
 
-		// Получаем токен из куки для проверки аутентификации
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
 		// Получаем user_id из URL параметров
 		userIDStr := r.PathValue("user_id")
 		if userIDStr == "" {
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"repairCopilotBot/api-gateway-service/internal/repository"
 	userserviceclient "repairCopilotBot/user-service/client"
 )
 
@@ -15,7 +14,6 @@ import (
 func GetUsersHandler(
 	log *slog.Logger,
 	userServiceClient *userserviceclient.UserClient,
-	sessionRepo *repository.SessionRepository,
 ) func(
 	w http.ResponseWriter, r *http.Request,
 ) {
@@ -25,35 +23,6 @@ func GetUsersHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("get users request started")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
 		// Получаем список пользователей через user-service
 		users, err := userServiceClient.GetAllUsers(r.Context())
 		if err != nil {
@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/tz-bot/client"
+	"strings"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func GetVersionHandler(
@@ -34,11 +39,29 @@ func GetVersionHandler(
 			return
 		}
 
-		// Вызываем tz-bot для получения версии
-		result, err := tzBotClient.GetVersion(r.Context(), versionID)
+		// Фильтры замечаний: ?priority=high,medium&section=3.2&error_code=E01&sort=priority
+		query := r.URL.Query()
+		filter := client.InstancesFilter{
+			Priorities: queryValues(query, "priority"),
+			Sections:   queryValues(query, "section"),
+			ErrorCodes: queryValues(query, "error_code"),
+			Sort:       query.Get("sort"),
+		}
+
+		// Вызываем tz-bot для получения версии. Доступ к версии проверяет tz-bot
+		result, err := tzBotClient.GetVersion(r.Context(), versionID, filter, tzCaller(auth.MustFromContext(r.Context())))
 		if err != nil {
 			log.Error("failed to get version from tz-bot", slog.String("error", err.Error()))
-			http.Error(w, "failed to get version", http.StatusInternalServerError)
+			switch status.Code(err) {
+			case codes.InvalidArgument:
+				http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+			case codes.NotFound:
+				http.Error(w, "version not found", http.StatusNotFound)
+			case codes.PermissionDenied:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+			default:
+				http.Error(w, "failed to get version", http.StatusInternalServerError)
+			}
 			return
 		}
 
@@ -102,3 +125,22 @@ func GetVersionHandler(
 			slog.Int("invalid_instances_count", len(result.InvalidInstances)))
 	}
 }
+
+// queryValues возвращает значения параметра запроса, переданные как повторением
+// параметра (?priority=high&priority=medium), так и через запятую (?priority=high,medium)
+func queryValues(query url.Values, key string) []string {
+	values := make([]string, 0)
+	for _, raw := range query[key] {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// tzCaller передаёт в tz-bot пользователя запроса для проверки доступа к версии
+func tzCaller(principal *auth.Principal) client.Caller {
+	return client.Caller{UserID: principal.UserID, IsAdmin: principal.IsAdmin()}
+}
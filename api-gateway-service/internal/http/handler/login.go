@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/repository"
 	"repairCopilotBot/tz-bot/client"
 	userserviceclient "repairCopilotBot/user-service/client"
@@ -28,6 +29,7 @@ func LoginHandler(
 	log *slog.Logger,
 	userServiceClient *userserviceclient.UserClient,
 	sessionRepo *repository.SessionRepository,
+	sessionConfig *auth.SessionConfig,
 	tzBotClient *client.Client,
 	actionLogRepo repository.ActionLogRepository,
 ) func(
@@ -80,24 +82,14 @@ func LoginHandler(
 
 		// Создаем сессию
 		sessionId := uuid.New()
-		err = sessionRepo.CreateSession(sessionId, uid, req.Login, loginResp.IsAdmin1, loginResp.IsAdmin2)
+		err = sessionRepo.CreateSession(sessionId, uid, req.Login, loginResp.IsAdmin1, loginResp.IsAdmin2, clientIP(r), r.UserAgent())
 		if err != nil {
 			log.With(slog.String("op", op)).Error("failed to create session", slog.String("error", err.Error()))
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
 			return
 		}
 
-		// Устанавливаем cookie с правильным именем (auth_token, как используется в /me)
-		cookie := &http.Cookie{
-			Name:     "auth_token",
-			Value:    sessionId.String(),
-			Path:     "/",
-			MaxAge:   24 * 60 * 60 * 100, // 24 hours
-			HttpOnly: true,
-			Secure:   false, // set to true in production with HTTPS
-			SameSite: http.SameSiteLaxMode,
-		}
-		http.SetCookie(w, cookie)
+		auth.SetSessionCookie(w, sessionConfig, sessionId.String())
 
 		// Определяем уровень пользователя
 		level := 0
@@ -136,20 +128,22 @@ func LoginHandler(
 	}
 }
 
-func LogoutHandler(log *slog.Logger) func(w http.ResponseWriter, r *http.Request) {
+// LogoutHandler завершает текущую сессию в Redis и удаляет куку
+func LogoutHandler(
+	log *slog.Logger,
+	sessionRepo *repository.SessionRepository,
+	sessionConfig *auth.SessionConfig,
+) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handler.LogoutHandler"
 
-		cookie := &http.Cookie{
-			Name:     "auth_token",
-			Value:    "",
-			Path:     "/",
-			MaxAge:   -1,
-			HttpOnly: true,
-			Secure:   false,
-			SameSite: http.SameSiteLaxMode,
+		if principal, ok := auth.FromContext(r.Context()); ok {
+			if err := sessionRepo.DeleteSession(principal.SessionID); err != nil {
+				log.With(slog.String("op", op)).Error("failed to delete session", slog.String("error", err.Error()))
+			}
 		}
-		http.SetCookie(w, cookie)
+
+		auth.ClearSessionCookie(w, sessionConfig)
 
 		w.WriteHeader(http.StatusOK)
 		log.With(slog.String("op", op)).Info("user logged out successfully")
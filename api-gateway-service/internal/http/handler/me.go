@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"repairCopilotBot/api-gateway-service/internal/repository"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	chatbotclient "repairCopilotBot/chat-bot/pkg/client"
 	chatbotclientChat "repairCopilotBot/chat-bot/pkg/client/chat"
 	searchbotclient "repairCopilotBot/search-bot/pkg/client"
@@ -13,8 +13,6 @@ import (
 	"repairCopilotBot/tz-bot/client"
 	userserviceclient "repairCopilotBot/user-service/client"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 //type TechnicalSpecificationVersion struct {
@@ -39,7 +37,6 @@ type MeResponse struct {
 
 func MeHandler(
 	log *slog.Logger,
-	sessionRepo *repository.SessionRepository,
 	tzBotClient *client.Client,
 	userServiceClient *userserviceclient.UserClient,
 	chatBotClient *chatbotclient.ChatBotClient,
@@ -51,40 +48,8 @@ func MeHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("processing /me request")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session.Login == "" {
-			log.Info("invalid session data - empty login")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
+		principal := auth.MustFromContext(r.Context())
+		userID := principal.UserID
 
 		var userInfo *userserviceclient.GetUserInfoResponse
 		// Определяем уровень пользователя из данных сессии
@@ -92,62 +57,55 @@ func MeHandler(
 		var tzVersions []*client.GetVersionMeResponse
 		var chats []chatbotclientChat.Chat
 		var searchChats []searchbotclientChat.Chat
-		// Получаем версии технических заданий от tz-bot
-		if session.UserID != "" {
-			userID, err := uuid.Parse(session.UserID)
-			if err != nil {
-				log.Error("invalid user ID format in session", slog.String("user_id", session.UserID), slog.String("error", err.Error()))
-			} else {
-				// Получаем информацию о пользователе для логирования
-				userInfoResp, userInfoErr := userServiceClient.GetUserInfo(r.Context(), userID)
-				if userInfoErr == nil {
-					// Логируем событие входа на сайт
-					//actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " зашёл на сайт"
-					//if err := actionLogRepo.CreateActionLog(r.Context(), actionText, userID); err != nil {
-					//	log.Error("failed to create action log for site access", slog.String("error", err.Error()))
-					//}
-
-					userInfo = userInfoResp
-
-					// Асинхронно регистрируем посещение пользователя
-					go func() {
-						ctx, cancelFunc := context.WithTimeout(context.Background(), time.Minute)
-						defer cancelFunc()
-
-						if err := userServiceClient.RegisterVisit(ctx, userID.String()); err != nil {
-							log.Error("failed to register user visit", slog.String("user_id", userID.String()), slog.String("error", err.Error()))
-						}
-					}()
-					if userInfo.IsAdmin1 {
-						level = 1
-					} else if userInfo.IsAdmin2 {
-						level = 2
-					}
+		// Получаем информацию о пользователе
+		userInfoResp, userInfoErr := userServiceClient.GetUserInfo(r.Context(), userID)
+		if userInfoErr == nil {
+			// Логируем событие входа на сайт
+			//actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " зашёл на сайт"
+			//if err := actionLogRepo.CreateActionLog(r.Context(), actionText, userID); err != nil {
+			//	log.Error("failed to create action log for site access", slog.String("error", err.Error()))
+			//}
+
+			userInfo = userInfoResp
+
+			// Асинхронно регистрируем посещение пользователя
+			go func() {
+				ctx, cancelFunc := context.WithTimeout(context.Background(), time.Minute)
+				defer cancelFunc()
+
+				if err := userServiceClient.RegisterVisit(ctx, userID.String()); err != nil {
+					log.Error("failed to register user visit", slog.String("user_id", userID.String()), slog.String("error", err.Error()))
 				}
+			}()
+			if userInfo.IsAdmin1 {
+				level = 1
+			} else if userInfo.IsAdmin2 {
+				level = 2
+			}
+		}
 
-				if userInfo != nil && userInfo.IsConfirmed {
-					tzVersions, err = tzBotClient.GetVersionsMe(r.Context(), userID)
-					if err != nil || tzVersions == nil {
-						log.Error("failed to get technical specification versions", slog.String("error", err.Error()))
-						// Не возвращаем ошибку, продолжаем с пустым массивом версий
-					}
-
-					// Получаем чаты пользователя
-					userIDString := userID.String()
-					chats, err = chatBotClient.Chat.GetChats(r.Context(), &userIDString)
-					if err != nil {
-						log.Error("failed to get user chats", slog.String("error", err.Error()))
-						// Не возвращаем ошибку, продолжаем с пустым массивом чатов
-						chats = []chatbotclientChat.Chat{}
-					}
-
-					searchChats, err = searchbotclient.Chat.GetChats(r.Context(), &userIDString)
-					if err != nil {
-						log.Error("failed to get user chats", slog.String("error", err.Error()))
-						// Не возвращаем ошибку, продолжаем с пустым массивом чатов
-						chats = []chatbotclientChat.Chat{}
-					}
-				}
+		if userInfo != nil && userInfo.IsConfirmed {
+			var err error
+			tzVersions, err = tzBotClient.GetVersionsMe(r.Context(), userID)
+			if err != nil || tzVersions == nil {
+				log.Error("failed to get technical specification versions", slog.String("error", err.Error()))
+				// Не возвращаем ошибку, продолжаем с пустым массивом версий
+			}
+
+			// Получаем чаты пользователя
+			userIDString := userID.String()
+			chats, err = chatBotClient.Chat.GetChats(r.Context(), &userIDString)
+			if err != nil {
+				log.Error("failed to get user chats", slog.String("error", err.Error()))
+				// Не возвращаем ошибку, продолжаем с пустым массивом чатов
+				chats = []chatbotclientChat.Chat{}
+			}
+
+			searchChats, err = searchbotclient.Chat.GetChats(r.Context(), &userIDString)
+			if err != nil {
+				log.Error("failed to get user chats", slog.String("error", err.Error()))
+				// Не возвращаем ошибку, продолжаем с пустым массивом чатов
+				chats = []chatbotclientChat.Chat{}
 			}
 		}
 
@@ -168,7 +126,7 @@ func MeHandler(
 		}
 
 		log.Info("me request processed successfully",
-			slog.String("login", session.Login),
+			slog.String("login", principal.Login),
 			slog.Int("level", level))
 	}
 }
@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/repository"
 	"repairCopilotBot/tz-bot/client"
 	userserviceclient "repairCopilotBot/user-service/client"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type NewFeedbackErrorRequest struct {
@@ -27,7 +30,6 @@ func NewFeedbackErrorHandler(
 	log *slog.Logger,
 	tzBotClient *client.Client,
 	userServiceClient *userserviceclient.UserClient,
-	sessionRepo *repository.SessionRepository,
 	actionLogRepo repository.ActionLogRepository,
 ) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -35,28 +37,7 @@ func NewFeedbackErrorHandler(
 
 		log := log.With(slog.String("op", op))
 
-		// Получение токена из заголовков
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверка сессии
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Error("invalid session", slog.String("error", err.Error()))
-			http.Error(w, "invalid session", http.StatusUnauthorized)
-			return
-		}
+		principal := auth.MustFromContext(r.Context())
 
 		// Парсинг тела запроса
 		var req NewFeedbackErrorRequest
@@ -95,19 +76,20 @@ func NewFeedbackErrorHandler(
 			return
 		}
 
-		// Парсинг userID из сессии
-		userID, err := uuid.Parse(session.UserID)
-		if err != nil {
-			log.Error("invalid session user ID", slog.String("error", err.Error()))
-			http.Error(w, "invalid session", http.StatusInternalServerError)
-			return
-		}
+		userID := principal.UserID
 
 		// Вызов gRPC метода
-		err = tzBotClient.NewFeedbackError(r.Context(), instanceID, req.InstanceType, req.FeedbackMark, req.FeedbackComment, userID, false)
+		err = tzBotClient.NewFeedbackError(r.Context(), instanceID, req.InstanceType, req.FeedbackMark, req.FeedbackComment, userID, false, tzCaller(principal))
 		if err != nil {
 			log.Error("failed to create feedback", slog.String("error", err.Error()))
-			http.Error(w, "failed to create feedback", http.StatusInternalServerError)
+			switch status.Code(err) {
+			case codes.NotFound:
+				http.Error(w, "instance not found", http.StatusNotFound)
+			case codes.PermissionDenied:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+			default:
+				http.Error(w, "failed to create feedback", http.StatusInternalServerError)
+			}
 			return
 		}
 
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
+	"repairCopilotBot/api-gateway-service/internal/repository"
+	"repairCopilotBot/tz-bot/client"
+	userserviceclient "repairCopilotBot/user-service/client"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func NewTzVersionHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(
+	w http.ResponseWriter, r *http.Request,
+) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.NewTzVersionHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("TZ version processing request started")
+
+		specIDStr := r.PathValue("spec_id")
+		if specIDStr == "" {
+			log.Error("spec_id parameter is missing")
+			http.Error(w, "spec_id parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		specID, err := uuid.Parse(specIDStr)
+		if err != nil {
+			log.Error("invalid spec_id format", slog.String("spec_id", specIDStr), slog.String("error", err.Error()))
+			http.Error(w, "Invalid spec_id format", http.StatusBadRequest)
+			return
+		}
+
+		principal := auth.MustFromContext(r.Context())
+
+		uid := principal.UserID
+
+		// Получаем файл из формы (не больше, чем пропустит gRPC к tz-bot)
+		fileBytes, filename, ok := readUploadedFile(w, r, log)
+		if !ok {
+			return
+		}
+
+		log = log.With(slog.String("user_id", principal.UserID.String()), slog.String("spec_id", specID.String()))
+		log.Info("processing TZ version file", slog.String("filename", filename))
+
+		checkTzVersionResult, err := tzBotClient.CheckTzVersion(r.Context(), specID, fileBytes, filename, uid, uploadCheckOptions(r))
+		if err != nil {
+			log.Error("TZ version processing failed", slog.String("error", err.Error()))
+
+			switch status.Code(err) {
+			case codes.NotFound:
+				// Не найдено ТЗ или указанный профиль проверки
+				http.Error(w, status.Convert(err).Message(), http.StatusNotFound)
+			case codes.PermissionDenied:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+			case codes.AlreadyExists:
+				http.Error(w, "Version already exists, try again", http.StatusConflict)
+			case codes.InvalidArgument:
+				http.Error(w, status.Convert(err).Message(), http.StatusUnsupportedMediaType)
+			case codes.FailedPrecondition:
+				http.Error(w, status.Convert(err).Message(), http.StatusUnprocessableEntity)
+			default:
+				http.Error(w, "TZ processing failed", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		log.Info("TZ version processing started successfully",
+			slog.Int("version_number", int(checkTzVersionResult.VersionNumber)),
+			slog.Bool("duplicate", checkTzVersionResult.Duplicate))
+
+		// Логируем событие отправки новой версии документа
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), uid)
+		if userInfoErr == nil {
+			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " отправил исправленную версию документа " + filename + " на проверку"
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, uid, 3); err != nil {
+				log.Error("failed to create action log for TZ version submission", slog.String("error", err.Error()))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(checkTzVersionResult); err != nil {
+			log.Error("failed to encode response", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("TZ version processing request completed successfully")
+	}
+}
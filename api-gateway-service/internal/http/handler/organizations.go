@@ -0,0 +1,416 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
+	"repairCopilotBot/api-gateway-service/internal/repository"
+	"repairCopilotBot/tz-bot/client"
+	userserviceclient "repairCopilotBot/user-service/client"
+	userv1 "repairCopilotBot/user-service/pkg/user/v1"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// Квоты организации: 0 - без соответствующего ограничения. Дневная квота обязательна,
+// чтобы организация не получила безлимит из-за пропущенного поля
+type CreateOrganizationRequest struct {
+	Name                string  `json:"name"`
+	InspectionsPerDay   *uint32 `json:"inspections_per_day"`
+	InspectionsPerMonth uint32  `json:"inspections_per_month"`
+	AdminUserID         string  `json:"admin_user_id"`
+}
+
+type AddOrganizationMemberRequest struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"` // "admin" или "member"
+}
+
+type UpdateOrganizationQuotaRequest struct {
+	InspectionsPerDay   *uint32 `json:"inspections_per_day"`
+	InspectionsPerMonth uint32  `json:"inspections_per_month"`
+}
+
+type OrganizationMemberResponse struct {
+	UserID    string    `json:"user_id"`
+	Role      string    `json:"role"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+type OrganizationResponse struct {
+	ID                  string                       `json:"id"`
+	Name                string                       `json:"name"`
+	InspectionsPerDay   uint32                       `json:"inspections_per_day"`
+	InspectionsPerMonth uint32                       `json:"inspections_per_month"`
+	InspectionsForToday uint32                       `json:"inspections_for_today"`
+	InspectionsForMonth uint32                       `json:"inspections_for_month"`
+	CreatedAt           time.Time                    `json:"created_at"`
+	Members             []OrganizationMemberResponse `json:"members"`
+}
+
+type OrganizationVersionResponse struct {
+	*client.GetVersionMeResponse
+	OwnerFirstName string `json:"owner_first_name"`
+	OwnerLastName  string `json:"owner_last_name"`
+}
+
+// CreateOrganizationHandler создаёт организацию с общей квотой проверок
+func CreateOrganizationHandler(
+	log *slog.Logger,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.CreateOrganizationHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("create organization request started")
+
+		principal := auth.MustFromContext(r.Context())
+
+		// Права администратора проверяются в auth.Require при регистрации маршрута
+
+		var req CreateOrganizationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+
+		if req.Name == "" {
+			http.Error(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		if req.InspectionsPerDay == nil {
+			http.Error(w, "inspections_per_day is required", http.StatusBadRequest)
+			return
+		}
+		if _, err := uuid.Parse(req.AdminUserID); err != nil {
+			http.Error(w, "admin_user_id must be a valid UUID", http.StatusBadRequest)
+			return
+		}
+
+		org, err := userServiceClient.CreateOrganization(r.Context(), req.Name, *req.InspectionsPerDay, req.InspectionsPerMonth, req.AdminUserID)
+		if err != nil {
+			log.Error("failed to create organization", slog.String("error", err.Error()))
+			writeOrganizationError(w, err, "Failed to create organization")
+			return
+		}
+
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), principal.UserID)
+		if userInfoErr == nil {
+			actionText := "Администратор " + userInfo.FirstName + " " + userInfo.LastName +
+				" создал организацию " + org.Name + " с квотой " + quotaText(*req.InspectionsPerDay) + " проверок в день"
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, principal.UserID, 5); err != nil {
+				log.Error("failed to create action log for organization creation", slog.String("error", err.Error()))
+			}
+		}
+
+		writeOrganization(w, log, http.StatusCreated, org)
+
+		log.Info("organization created", slog.String("organization_id", org.Id))
+	}
+}
+
+// GetMyOrganizationHandler возвращает организацию текущего пользователя
+func GetMyOrganizationHandler(
+	log *slog.Logger,
+	userServiceClient *userserviceclient.UserClient,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.GetMyOrganizationHandler"
+
+		log := log.With(slog.String("op", op))
+
+		principal := auth.MustFromContext(r.Context())
+
+		org, err := userServiceClient.GetUserOrganization(r.Context(), principal.UserID.String())
+		if err != nil {
+			log.Info("failed to get user organization", slog.String("error", err.Error()))
+			writeOrganizationError(w, err, "Failed to get organization")
+			return
+		}
+
+		writeOrganization(w, log, http.StatusOK, org)
+	}
+}
+
+// GetOrganizationVersionsHandler возвращает проверки всех участников организации текущего пользователя
+func GetOrganizationVersionsHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	userServiceClient *userserviceclient.UserClient,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.GetOrganizationVersionsHandler"
+
+		log := log.With(slog.String("op", op))
+
+		principal := auth.MustFromContext(r.Context())
+
+		versions, err := tzBotClient.GetVersionsOrganization(r.Context(), principal.UserID)
+		if err != nil {
+			log.Error("failed to get organization versions", slog.String("error", err.Error()))
+			http.Error(w, "Failed to get organization versions", http.StatusInternalServerError)
+			return
+		}
+
+		ownerIDs := make([]string, 0)
+		seen := make(map[string]bool)
+		for _, version := range versions {
+			if !seen[version.UserId] {
+				seen[version.UserId] = true
+				ownerIDs = append(ownerIDs, version.UserId)
+			}
+		}
+
+		names, err := userServiceClient.GetFullNamesById(r.Context(), ownerIDs)
+		if err != nil {
+			log.Error("failed to get owner names", slog.String("error", err.Error()))
+			names = make(map[string]userserviceclient.FullName)
+		}
+
+		response := make([]OrganizationVersionResponse, 0, len(versions))
+		for _, version := range versions {
+			response = append(response, OrganizationVersionResponse{
+				GetVersionMeResponse: version,
+				OwnerFirstName:       names[version.UserId].FirstName,
+				OwnerLastName:        names[version.UserId].LastName,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Error("failed to encode response", slog.String("error", err.Error()))
+			return
+		}
+	}
+}
+
+// AddOrganizationMemberHandler добавляет пользователя в организацию или меняет его роль.
+// Нового участника добавляет администратор системы, роль существующего может менять и
+// администратор организации. Права проверяет user-service
+func AddOrganizationMemberHandler(
+	log *slog.Logger,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.AddOrganizationMemberHandler"
+
+		log := log.With(slog.String("op", op))
+
+		principal := auth.MustFromContext(r.Context())
+		organizationID := r.PathValue("organization_id")
+
+		var req AddOrganizationMemberRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := uuid.Parse(req.UserID); err != nil {
+			http.Error(w, "user_id must be a valid UUID", http.StatusBadRequest)
+			return
+		}
+		if req.Role == "" {
+			req.Role = "member"
+		}
+		if req.Role != "admin" && req.Role != "member" {
+			http.Error(w, "role must be 'admin' or 'member'", http.StatusBadRequest)
+			return
+		}
+
+		org, err := userServiceClient.AddOrganizationMember(r.Context(), organizationID, principal.UserID.String(), req.UserID, req.Role)
+		if err != nil {
+			log.Error("failed to add organization member", slog.String("error", err.Error()))
+			writeOrganizationError(w, err, "Failed to add organization member")
+			return
+		}
+
+		logOrganizationAction(r, log, userServiceClient, actionLogRepo, principal.UserID, req.UserID,
+			"добавил в организацию "+org.Name+" с ролью '"+req.Role+"' пользователя ")
+
+		writeOrganization(w, log, http.StatusOK, org)
+	}
+}
+
+// RemoveOrganizationMemberHandler исключает пользователя из организации
+func RemoveOrganizationMemberHandler(
+	log *slog.Logger,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.RemoveOrganizationMemberHandler"
+
+		log := log.With(slog.String("op", op))
+
+		principal := auth.MustFromContext(r.Context())
+		organizationID := r.PathValue("organization_id")
+		userID := r.PathValue("user_id")
+
+		if _, err := uuid.Parse(userID); err != nil {
+			http.Error(w, "user_id must be a valid UUID", http.StatusBadRequest)
+			return
+		}
+
+		org, err := userServiceClient.RemoveOrganizationMember(r.Context(), organizationID, principal.UserID.String(), userID)
+		if err != nil {
+			log.Error("failed to remove organization member", slog.String("error", err.Error()))
+			writeOrganizationError(w, err, "Failed to remove organization member")
+			return
+		}
+
+		logOrganizationAction(r, log, userServiceClient, actionLogRepo, principal.UserID, userID,
+			"исключил из организации "+org.Name+" пользователя ")
+
+		writeOrganization(w, log, http.StatusOK, org)
+	}
+}
+
+// UpdateOrganizationQuotaHandler изменяет дневную и месячную квоту организации
+func UpdateOrganizationQuotaHandler(
+	log *slog.Logger,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.UpdateOrganizationQuotaHandler"
+
+		log := log.With(slog.String("op", op))
+
+		principal := auth.MustFromContext(r.Context())
+		organizationID := r.PathValue("organization_id")
+
+		var req UpdateOrganizationQuotaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+
+		if req.InspectionsPerDay == nil {
+			http.Error(w, "inspections_per_day is required", http.StatusBadRequest)
+			return
+		}
+
+		org, err := userServiceClient.UpdateOrganizationQuota(r.Context(), organizationID, principal.UserID.String(), *req.InspectionsPerDay, req.InspectionsPerMonth)
+		if err != nil {
+			log.Error("failed to update organization quota", slog.String("error", err.Error()))
+			writeOrganizationError(w, err, "Failed to update organization quota")
+			return
+		}
+
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), principal.UserID)
+		if userInfoErr == nil {
+			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName +
+				" изменил квоту организации " + org.Name + " - " + quotaText(*req.InspectionsPerDay) + " проверок в день, " +
+				quotaText(req.InspectionsPerMonth) + " в месяц"
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, principal.UserID, 5); err != nil {
+				log.Error("failed to create action log for organization quota update", slog.String("error", err.Error()))
+			}
+		}
+
+		writeOrganization(w, log, http.StatusOK, org)
+	}
+}
+
+// quotaText возвращает значение квоты для журнала действий, 0 - без ограничения
+func quotaText(inspections uint32) string {
+	if inspections == 0 {
+		return "без ограничения"
+	}
+	return strconv.Itoa(int(inspections))
+}
+
+// logOrganizationAction записывает в журнал изменение состава организации
+func logOrganizationAction(
+	r *http.Request,
+	log *slog.Logger,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+	actorID uuid.UUID,
+	targetUserID string,
+	action string,
+) {
+	userInfo, err := userServiceClient.GetUserInfo(r.Context(), actorID)
+	if err != nil {
+		return
+	}
+	targetUserInfo, err := userServiceClient.GetUserInfo(r.Context(), uuid.MustParse(targetUserID))
+	if err != nil {
+		return
+	}
+
+	actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " " + action +
+		targetUserInfo.FirstName + " " + targetUserInfo.LastName
+	if err := actionLogRepo.CreateActionLog(r.Context(), actionText, actorID, 5); err != nil {
+		log.Error("failed to create action log for organization change", slog.String("error", err.Error()))
+	}
+}
+
+// writeOrganizationError переводит ошибку user-service при работе с организацией в HTTP-ответ
+func writeOrganizationError(w http.ResponseWriter, err error, message string) {
+	var orgErr userserviceclient.OrganizationError
+	if !errors.As(err, &orgErr) {
+		http.Error(w, message, http.StatusInternalServerError)
+		return
+	}
+
+	switch orgErr.Code {
+	case codes.InvalidArgument:
+		http.Error(w, orgErr.Message, http.StatusBadRequest)
+	case codes.NotFound:
+		http.Error(w, orgErr.Message, http.StatusNotFound)
+	case codes.PermissionDenied:
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		http.Error(w, orgErr.Message, http.StatusConflict)
+	default:
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
+
+func writeOrganization(w http.ResponseWriter, log *slog.Logger, statusCode int, org *userv1.Organization) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(convertOrganization(org)); err != nil {
+		log.Error("failed to encode response", slog.String("error", err.Error()))
+	}
+}
+
+func convertOrganization(org *userv1.Organization) OrganizationResponse {
+	members := make([]OrganizationMemberResponse, 0, len(org.Members))
+	for _, m := range org.Members {
+		members = append(members, OrganizationMemberResponse{
+			UserID:    m.UserId,
+			Role:      m.Role,
+			FirstName: m.FirstName,
+			LastName:  m.LastName,
+			JoinedAt:  m.JoinedAt.AsTime(),
+		})
+	}
+
+	return OrganizationResponse{
+		ID:                  org.Id,
+		Name:                org.Name,
+		InspectionsPerDay:   org.InspectionsPerDay,
+		InspectionsPerMonth: org.InspectionsPerMonth,
+		InspectionsForToday: org.InspectionsForToday,
+		InspectionsForMonth: org.InspectionsForMonth,
+		CreatedAt:           org.CreatedAt.AsTime(),
+		Members:             members,
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/repository"
 	userserviceclient "repairCopilotBot/user-service/client"
+
+	"github.com/google/uuid"
 )
 
 type RecoveryRequest struct {
@@ -19,6 +22,7 @@ type RecoveryResponse struct {
 func RecoveryHandler(
 	log *slog.Logger,
 	userServiceClient *userserviceclient.UserClient,
+	sessionRepo *repository.SessionRepository,
 ) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handler.RecoveryHandler"
@@ -70,6 +74,13 @@ func RecoveryHandler(
 
 		log.Info("account recovery successful", slog.String("email", req.Email))
 
+		// Пароль сменился - завершаем все сессии пользователя
+		if userID, err := uuid.Parse(resp.UserId); err == nil {
+			if _, err := sessionRepo.DeleteUserSessions(userID); err != nil {
+				log.Error("failed to revoke user sessions after password reset", slog.String("error", err.Error()))
+			}
+		}
+
 		// Формируем успешный ответ
 		response := RecoveryResponse{
 			Success: resp.Success,
@@ -86,4 +97,4 @@ func RecoveryHandler(
 
 		log.Info("recovery request processed successfully", slog.String("email", req.Email))
 	}
-}
\ No newline at end of file
+}
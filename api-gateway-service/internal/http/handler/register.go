@@ -4,9 +4,9 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/repository"
 	userserviceclient "repairCopilotBot/user-service/client"
-	"time"
 
 	"github.com/google/uuid"
 )
@@ -29,6 +29,7 @@ func RegisterHandler(
 	log *slog.Logger,
 	userServiceClient *userserviceclient.UserClient,
 	sessionRepo *repository.SessionRepository,
+	sessionConfig *auth.SessionConfig,
 	actionLogRepo repository.ActionLogRepository,
 ) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -91,7 +92,7 @@ func RegisterHandler(
 		//}
 
 		// Создаем сессию в Redis (новый пользователь не админ)
-		err = sessionRepo.CreateSession(sessionID, userID, req.Login, false, false)
+		err = sessionRepo.CreateSession(sessionID, userID, req.Login, false, false, clientIP(r), r.UserAgent())
 		if err != nil {
 			log.Error("failed to create session", slog.String("error", err.Error()))
 			http.Error(w, "Failed to create session", http.StatusInternalServerError)
@@ -103,16 +104,7 @@ func RegisterHandler(
 			slog.String("user_id", userID.String()))
 
 		// Устанавливаем cookie с токеном сессии
-		cookie := &http.Cookie{
-			Name:     "auth_token",
-			Value:    sessionID.String(),
-			Path:     "/",
-			HttpOnly: true,
-			Secure:   false, // В продакшене должно быть true для HTTPS
-			SameSite: http.SameSiteLaxMode,
-			MaxAge:   int((time.Hour * 24 * 100).Seconds()), // 30 дней
-		}
-		http.SetCookie(w, cookie)
+		auth.SetSessionCookie(w, sessionConfig, sessionID.String())
 
 		// Формируем успешный ответ
 		response := RegisterResponse{
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
+	"repairCopilotBot/api-gateway-service/internal/repository"
+	userserviceclient "repairCopilotBot/user-service/client"
+	"strconv"
+	"time"
+)
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	// Current - сессия, с которой пришёл запрос
+	Current bool `json:"current"`
+}
+
+type RevokeAllSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+// ListSessionsHandler возвращает активные сессии текущего пользователя
+func ListSessionsHandler(
+	log *slog.Logger,
+	sessionRepo *repository.SessionRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.ListSessionsHandler"
+
+		principal := auth.MustFromContext(r.Context())
+
+		log := log.With(slog.String("op", op), slog.String("user_id", principal.UserID.String()))
+
+		sessions, err := sessionRepo.ListUserSessions(principal.UserID)
+		if err != nil {
+			log.Error("failed to list user sessions", slog.String("error", err.Error()))
+			http.Error(w, "failed to list sessions", http.StatusInternalServerError)
+			return
+		}
+
+		response := make([]SessionResponse, 0, len(sessions))
+		for _, session := range sessions {
+			response = append(response, SessionResponse{
+				ID:         session.ID,
+				IP:         session.IP,
+				UserAgent:  session.UserAgent,
+				CreatedAt:  session.CreatedAt,
+				LastSeenAt: session.LastSeenAt,
+				Current:    session.Token == principal.SessionID,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Error("failed to encode response", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// RevokeSessionHandler завершает одну сессию текущего пользователя, например забытую на чужом устройстве
+func RevokeSessionHandler(
+	log *slog.Logger,
+	sessionRepo *repository.SessionRepository,
+	sessionConfig *auth.SessionConfig,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.RevokeSessionHandler"
+
+		principal := auth.MustFromContext(r.Context())
+		sessionID := r.PathValue("session_id")
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("user_id", principal.UserID.String()),
+			slog.String("session_id", sessionID),
+		)
+		log.Info("processing RevokeSession request")
+
+		if sessionID == "" {
+			http.Error(w, "session_id is required", http.StatusBadRequest)
+			return
+		}
+
+		current, err := sessionRepo.GetSession(principal.SessionID)
+		if err != nil {
+			log.Error("failed to get current session", slog.String("error", err.Error()))
+			http.Error(w, "failed to revoke session", http.StatusInternalServerError)
+			return
+		}
+
+		deleted, err := sessionRepo.DeleteUserSession(principal.UserID, sessionID)
+		if err != nil {
+			log.Error("failed to revoke session", slog.String("error", err.Error()))
+			http.Error(w, "failed to revoke session", http.StatusInternalServerError)
+			return
+		}
+		if !deleted {
+			http.Error(w, "session not found", http.StatusNotFound)
+			return
+		}
+
+		if current != nil && current.ID == sessionID {
+			auth.ClearSessionCookie(w, sessionConfig)
+		}
+
+		// Логируем завершение сессии
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), principal.UserID)
+		if userInfoErr == nil {
+			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " завершил сессию на другом устройстве"
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, principal.UserID, 1); err != nil {
+				log.Error("failed to create action log for session revocation", slog.String("error", err.Error()))
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		log.Info("session revoked successfully")
+	}
+}
+
+// RevokeAllSessionsHandler завершает все сессии текущего пользователя, включая текущую ("выйти на всех устройствах")
+func RevokeAllSessionsHandler(
+	log *slog.Logger,
+	sessionRepo *repository.SessionRepository,
+	sessionConfig *auth.SessionConfig,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.RevokeAllSessionsHandler"
+
+		principal := auth.MustFromContext(r.Context())
+
+		log := log.With(slog.String("op", op), slog.String("user_id", principal.UserID.String()))
+		log.Info("processing RevokeAllSessions request")
+
+		revoked, err := sessionRepo.DeleteUserSessions(principal.UserID)
+		if err != nil {
+			log.Error("failed to revoke user sessions", slog.String("error", err.Error()))
+			http.Error(w, "failed to revoke sessions", http.StatusInternalServerError)
+			return
+		}
+
+		auth.ClearSessionCookie(w, sessionConfig)
+
+		// Логируем выход на всех устройствах
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), principal.UserID)
+		if userInfoErr == nil {
+			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " вышел на всех устройствах (" + strconv.Itoa(revoked) + " сессий)"
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, principal.UserID, 1); err != nil {
+				log.Error("failed to create action log for sessions revocation", slog.String("error", err.Error()))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(RevokeAllSessionsResponse{Revoked: revoked}); err != nil {
+			log.Error("failed to encode response", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("user sessions revoked successfully", slog.Int("revoked", revoked))
+	}
+}
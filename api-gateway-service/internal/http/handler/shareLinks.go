@@ -0,0 +1,541 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
+	"repairCopilotBot/api-gateway-service/internal/repository"
+	"repairCopilotBot/tz-bot/client"
+	tzv1 "repairCopilotBot/tz-bot/pkg/tz/v1"
+	userserviceclient "repairCopilotBot/user-service/client"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sharedPathPrefix - публичный адрес ссылки на просмотр, к нему дописывается токен
+const sharedPathPrefix = "/api/shared/"
+
+type CreateShareLinkRequest struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	// view | export, без прав ссылка даёт только просмотр
+	Permissions []string `json:"permissions"`
+}
+
+type ShareLinkResponse struct {
+	ID             string     `json:"id"`
+	VersionID      string     `json:"version_id"`
+	Permissions    []string   `json:"permissions"`
+	CreatedBy      string     `json:"created_by"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	AccessCount    int32      `json:"access_count"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type CreateShareLinkResponse struct {
+	ShareLink ShareLinkResponse `json:"share_link"`
+	// Токен показывается один раз, повторно получить его нельзя
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
+
+// SharedVersionResponse - результат проверки для посетителя по ссылке. Содержит только то, что
+// нужно для просмотра отчёта: без стоимости и токенов, ответа LLM, ссылок на файлы,
+// обратной связи и ID пользователей
+type SharedVersionResponse struct {
+	ShareLink SharedLinkInfo `json:"share_link"`
+	Version   SharedVersion  `json:"version"`
+}
+
+type SharedLinkInfo struct {
+	Permissions []string  `json:"permissions"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type SharedVersion struct {
+	Name             string                  `json:"name"`
+	Status           string                  `json:"status"`
+	CreatedAt        *time.Time              `json:"created_at,omitempty"`
+	HtmlText         string                  `json:"html_text"`
+	Css              string                  `json:"css"`
+	NumberOfErrors   int32                   `json:"number_of_errors"`
+	Errors           []SharedError           `json:"errors"`
+	InvalidInstances []SharedInvalidInstance `json:"invalid_instances"`
+}
+
+type SharedError struct {
+	ID               string                  `json:"id"`
+	ErrorCode        string                  `json:"error_code"`
+	Name             string                  `json:"name"`
+	Description      string                  `json:"description"`
+	OrderNumber      int32                   `json:"order_number"`
+	InvalidInstances []SharedInvalidInstance `json:"invalid_instances"`
+	MissingInstances []SharedMissingInstance `json:"missing_instances"`
+}
+
+type SharedInvalidInstance struct {
+	ID              string   `json:"id"`
+	HtmlID          uint32   `json:"html_id"`
+	ErrorID         string   `json:"error_id"`
+	Quote           string   `json:"quote"`
+	Rationale       string   `json:"rationale"`
+	SuggestedFix    string   `json:"suggested_fix"`
+	WhatIsIncorrect *string  `json:"what_is_incorrect,omitempty"`
+	Priority        *string  `json:"priority,omitempty"`
+	Risks           *string  `json:"risks,omitempty"`
+	Sections        []string `json:"sections"`
+	PageNumber      *int32   `json:"page_number,omitempty"`
+	Located         bool     `json:"located"`
+	OrderNumber     int32    `json:"order_number"`
+}
+
+type SharedMissingInstance struct {
+	ID              string   `json:"id"`
+	HtmlID          uint32   `json:"html_id"`
+	ErrorID         string   `json:"error_id"`
+	Rationale       string   `json:"rationale"`
+	SuggestedFix    string   `json:"suggested_fix"`
+	WhatIsIncorrect *string  `json:"what_is_incorrect,omitempty"`
+	Priority        *string  `json:"priority,omitempty"`
+	Risks           *string  `json:"risks,omitempty"`
+	Sections        []string `json:"sections"`
+}
+
+// CreateShareLinkHandler создаёт ссылку на просмотр версии для пользователей без учётной записи
+func CreateShareLinkHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.CreateShareLinkHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("processing CreateShareLink request")
+
+		versionID, err := uuid.Parse(r.PathValue("version_id"))
+		if err != nil {
+			log.Error("invalid version_id format", slog.String("error", err.Error()))
+			http.Error(w, "Invalid version_id format", http.StatusBadRequest)
+			return
+		}
+
+		var req CreateShareLinkRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+
+		if req.ExpiresAt.IsZero() {
+			http.Error(w, "expires_at is required", http.StatusBadRequest)
+			return
+		}
+
+		principal := auth.MustFromContext(r.Context())
+
+		uid := principal.UserID
+
+		log = log.With(slog.String("user_id", uid.String()), slog.String("version_id", versionID.String()))
+
+		resp, err := tzBotClient.CreateShareLink(r.Context(), versionID, req.ExpiresAt, req.Permissions, tzCaller(principal))
+		if err != nil {
+			log.Error("failed to create share link in tz-bot", slog.String("error", err.Error()))
+			writeShareLinkError(w, err, "failed to create share link")
+			return
+		}
+
+		// Логируем создание ссылки
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), uid)
+		if userInfoErr == nil {
+			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " создал ссылку на просмотр проверки до " + req.ExpiresAt.Format("02.01.2006 15:04")
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, uid, 3); err != nil {
+				log.Error("failed to create action log for share link creation", slog.String("error", err.Error()))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		if err := json.NewEncoder(w).Encode(CreateShareLinkResponse{
+			ShareLink: convertShareLink(resp.ShareLink),
+			Token:     resp.Token,
+			Path:      sharedPathPrefix + resp.Token,
+		}); err != nil {
+			log.Error("failed to encode response", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("share link created successfully", slog.String("share_link_id", resp.ShareLink.Id))
+	}
+}
+
+// ListShareLinksHandler возвращает ссылки на просмотр версии, включая отозванные и истёкшие
+func ListShareLinksHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.ListShareLinksHandler"
+
+		log := log.With(slog.String("op", op))
+
+		versionID, err := uuid.Parse(r.PathValue("version_id"))
+		if err != nil {
+			log.Error("invalid version_id format", slog.String("error", err.Error()))
+			http.Error(w, "Invalid version_id format", http.StatusBadRequest)
+			return
+		}
+
+		links, err := tzBotClient.ListShareLinks(r.Context(), versionID, tzCaller(auth.MustFromContext(r.Context())))
+		if err != nil {
+			log.Error("failed to list share links from tz-bot", slog.String("error", err.Error()))
+			writeShareLinkError(w, err, "failed to list share links")
+			return
+		}
+
+		response := make([]ShareLinkResponse, 0, len(links))
+		for _, link := range links {
+			response = append(response, convertShareLink(link))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Error("failed to encode response", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// RevokeShareLinkHandler отзывает ссылку на просмотр. После отзыва ссылка перестаёт открываться
+func RevokeShareLinkHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.RevokeShareLinkHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("processing RevokeShareLink request")
+
+		shareLinkID, err := uuid.Parse(r.PathValue("share_link_id"))
+		if err != nil {
+			log.Error("invalid share_link_id format", slog.String("error", err.Error()))
+			http.Error(w, "Invalid share_link_id format", http.StatusBadRequest)
+			return
+		}
+
+		principal := auth.MustFromContext(r.Context())
+
+		uid := principal.UserID
+
+		log = log.With(slog.String("user_id", uid.String()), slog.String("share_link_id", shareLinkID.String()))
+
+		link, err := tzBotClient.RevokeShareLink(r.Context(), shareLinkID, tzCaller(principal))
+		if err != nil {
+			log.Error("failed to revoke share link in tz-bot", slog.String("error", err.Error()))
+			writeShareLinkError(w, err, "failed to revoke share link")
+			return
+		}
+
+		// Логируем отзыв ссылки
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), uid)
+		if userInfoErr == nil {
+			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " отозвал ссылку на просмотр проверки"
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, uid, 3); err != nil {
+				log.Error("failed to create action log for share link revocation", slog.String("error", err.Error()))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(convertShareLink(link)); err != nil {
+			log.Error("failed to encode response", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("share link revoked successfully")
+	}
+}
+
+// SharedVersionHandler показывает версию по ссылке на просмотр без входа в систему.
+// Каждое открытие ссылки записывается в журнал действий от имени автора ссылки
+func SharedVersionHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.SharedVersionHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("processing SharedVersion request")
+
+		token := r.PathValue("token")
+
+		link, ok := resolveShareLink(w, r, log, tzBotClient, actionLogRepo, "открыта ссылка на просмотр проверки")
+		if !ok {
+			return
+		}
+
+		versionID, err := uuid.Parse(link.VersionId)
+		if err != nil {
+			log.Error("invalid version_id in share link", slog.String("version_id", link.VersionId))
+			http.Error(w, "failed to get version", http.StatusInternalServerError)
+			return
+		}
+
+		query := r.URL.Query()
+		filter := client.InstancesFilter{
+			Priorities: queryValues(query, "priority"),
+			Sections:   queryValues(query, "section"),
+			ErrorCodes: queryValues(query, "error_code"),
+			Sort:       query.Get("sort"),
+		}
+
+		version, err := tzBotClient.GetVersion(r.Context(), versionID, filter, client.SharedCaller(token))
+		if err != nil {
+			log.Error("failed to get shared version from tz-bot", slog.String("error", err.Error()))
+			writeSharedError(w, err, "failed to get version")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(SharedVersionResponse{
+			ShareLink: SharedLinkInfo{Permissions: link.Permissions, ExpiresAt: link.ExpiresAt.AsTime()},
+			Version:   convertSharedVersion(version),
+		}); err != nil {
+			log.Error("failed to encode response", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("shared version returned successfully", slog.String("share_link_id", link.Id))
+	}
+}
+
+// SharedExportHandler выгружает замечания версии по ссылке с правом export
+// (?format=json|csv|xlsx, по умолчанию json)
+func SharedExportHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.SharedExportHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("processing SharedExport request")
+
+		token := r.PathValue("token")
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "json"
+		}
+
+		link, ok := resolveShareLink(w, r, log, tzBotClient, actionLogRepo, "по ссылке на просмотр выгружены замечания проверки в формате "+format)
+		if !ok {
+			return
+		}
+
+		versionID, err := uuid.Parse(link.VersionId)
+		if err != nil {
+			log.Error("invalid version_id in share link", slog.String("version_id", link.VersionId))
+			http.Error(w, "failed to export version", http.StatusInternalServerError)
+			return
+		}
+
+		export, err := tzBotClient.ExportVersion(r.Context(), versionID, format, client.SharedCaller(token))
+		if err != nil {
+			log.Error("failed to export shared version from tz-bot", slog.String("error", err.Error()))
+			writeSharedError(w, err, "failed to export version")
+			return
+		}
+
+		w.Header().Set("Content-Type", export.ContentType)
+		w.Header().Set("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(export.FileName))
+		w.Header().Set("Content-Length", strconv.Itoa(len(export.Content)))
+		w.Header().Set("X-Export-Schema-Version", strconv.Itoa(int(export.SchemaVersion)))
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := w.Write(export.Content); err != nil {
+			log.Error("failed to write export", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("shared version exported successfully", slog.String("share_link_id", link.Id))
+	}
+}
+
+// resolveShareLink проверяет токен из пути и записывает открытие ссылки в журнал действий.
+// При ошибке сам отправляет ответ и возвращает false
+func resolveShareLink(
+	w http.ResponseWriter,
+	r *http.Request,
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	actionLogRepo repository.ActionLogRepository,
+	action string,
+) (*tzv1.ShareLink, bool) {
+	token := r.PathValue("token")
+	if token == "" {
+		http.Error(w, "share link not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	link, err := tzBotClient.ResolveShareLink(r.Context(), token)
+	if err != nil {
+		log.Info("failed to resolve share link", slog.String("error", err.Error()))
+		writeSharedError(w, err, "failed to open share link")
+		return nil, false
+	}
+
+	createdBy, err := uuid.Parse(link.CreatedBy)
+	if err == nil {
+		actionText := "Без входа в систему " + action + " (ссылка " + link.Id + ", IP " + clientIP(r) + ")"
+		if err := actionLogRepo.CreateActionLog(r.Context(), actionText, createdBy, 3); err != nil {
+			log.Error("failed to create action log for share link access", slog.String("error", err.Error()))
+		}
+	}
+
+	return link, true
+}
+
+// clientIP возвращает адрес посетителя с учётом прокси перед api-gateway
+func clientIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	return r.RemoteAddr
+}
+
+// writeShareLinkError переводит ошибку tz-bot при управлении ссылками в HTTP-ответ
+func writeShareLinkError(w http.ResponseWriter, err error, message string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+	case codes.NotFound:
+		http.Error(w, status.Convert(err).Message(), http.StatusNotFound)
+	case codes.PermissionDenied:
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	default:
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
+
+// writeSharedError переводит ошибку tz-bot при открытии ссылки в HTTP-ответ. Посетителю
+// не сообщается, существует ли версия: недействительная ссылка и чужая версия дают 404
+func writeSharedError(w http.ResponseWriter, err error, message string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+	case codes.NotFound, codes.Unauthenticated:
+		http.Error(w, "share link not found", http.StatusNotFound)
+	case codes.FailedPrecondition:
+		http.Error(w, "share link expired or revoked", http.StatusGone)
+	case codes.PermissionDenied:
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	default:
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
+
+func convertShareLink(link *tzv1.ShareLink) ShareLinkResponse {
+	resp := ShareLinkResponse{
+		ID:          link.Id,
+		VersionID:   link.VersionId,
+		Permissions: link.Permissions,
+		CreatedBy:   link.CreatedBy,
+		ExpiresAt:   link.ExpiresAt.AsTime(),
+		AccessCount: link.AccessCount,
+		CreatedAt:   link.CreatedAt.AsTime(),
+	}
+	if link.RevokedAt != nil {
+		revokedAt := link.RevokedAt.AsTime()
+		resp.RevokedAt = &revokedAt
+	}
+	if link.LastAccessedAt != nil {
+		lastAccessedAt := link.LastAccessedAt.AsTime()
+		resp.LastAccessedAt = &lastAccessedAt
+	}
+	return resp
+}
+
+// convertSharedVersion оставляет в результате проверки только поля отчёта для просмотра по ссылке
+func convertSharedVersion(version *client.Version) SharedVersion {
+	resp := SharedVersion{
+		Name:             version.GetName(),
+		Status:           version.GetStatus(),
+		CreatedAt:        version.CreatedAt,
+		HtmlText:         version.GetHtmlText(),
+		Css:              version.GetCss(),
+		NumberOfErrors:   version.GetNumberOfErrors(),
+		Errors:           make([]SharedError, 0, len(version.GetErrors())),
+		InvalidInstances: convertSharedInvalidInstances(version.GetInvalidInstances()),
+	}
+
+	for _, e := range version.GetErrors() {
+		missingInstances := make([]SharedMissingInstance, 0, len(e.GetMissingInstances()))
+		for _, instance := range e.GetMissingInstances() {
+			missingInstances = append(missingInstances, SharedMissingInstance{
+				ID:              instance.GetId(),
+				HtmlID:          instance.GetHtmlId(),
+				ErrorID:         instance.GetErrorId(),
+				Rationale:       instance.GetRationale(),
+				SuggestedFix:    instance.GetSuggestedFix(),
+				WhatIsIncorrect: instance.WhatIsIncorrect,
+				Priority:        instance.Priority,
+				Risks:           instance.Risks,
+				Sections:        instance.GetSections(),
+			})
+		}
+
+		resp.Errors = append(resp.Errors, SharedError{
+			ID:               e.GetId(),
+			ErrorCode:        e.GetErrorCode(),
+			Name:             e.GetName(),
+			Description:      e.GetDescription(),
+			OrderNumber:      e.GetOrderNumber(),
+			InvalidInstances: convertSharedInvalidInstances(e.GetInvalidInstances()),
+			MissingInstances: missingInstances,
+		})
+	}
+
+	return resp
+}
+
+func convertSharedInvalidInstances(instances []*tzv1.InvalidInstance) []SharedInvalidInstance {
+	resp := make([]SharedInvalidInstance, 0, len(instances))
+	for _, instance := range instances {
+		resp = append(resp, SharedInvalidInstance{
+			ID:              instance.GetId(),
+			HtmlID:          instance.GetHtmlId(),
+			ErrorID:         instance.GetErrorId(),
+			Quote:           instance.GetQuote(),
+			Rationale:       instance.GetRationale(),
+			SuggestedFix:    instance.GetSuggestedFix(),
+			WhatIsIncorrect: instance.WhatIsIncorrect,
+			Priority:        instance.Priority,
+			Risks:           instance.Risks,
+			Sections:        instance.GetSections(),
+			PageNumber:      instance.PageNumber,
+			Located:         instance.GetLocated(),
+			OrderNumber:     instance.GetOrderNumber(),
+		})
+	}
+	return resp
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
 	"repairCopilotBot/api-gateway-service/internal/repository"
 	userserviceclient "repairCopilotBot/user-service/client"
 	"strconv"
@@ -25,7 +26,6 @@ type UpdateInspectionsPerDayResponse struct {
 func UpdateInspectionsPerDayHandler(
 	log *slog.Logger,
 	userServiceClient *userserviceclient.UserClient,
-	sessionRepo *repository.SessionRepository,
 	actionLogRepo repository.ActionLogRepository,
 ) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -34,41 +34,9 @@ func UpdateInspectionsPerDayHandler(
 		log := log.With(slog.String("op", op))
 		log.Info("update inspections per day request started")
 
-		// Получаем токен из куки
-		cookie, err := r.Cookie("auth_token")
-		if err != nil {
-			log.Info("no auth token cookie found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		token := cookie.Value
-		if token == "" {
-			log.Info("empty auth token")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
+		principal := auth.MustFromContext(r.Context())
 
-		// Проверяем сессию в Redis
-		session, err := sessionRepo.GetSession(token)
-		if err != nil {
-			log.Info("failed to get session from Redis", slog.String("error", err.Error()))
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		if session == nil {
-			log.Info("session not found")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Проверяем права администратора
-		//if !session.IsAdmin1 && !session.IsAdmin2 {
-		//	log.Info("user is not admin", slog.String("user_id", session.UserID))
-		//	http.Error(w, "Forbidden: admin access required", http.StatusForbidden)
-		//	return
-		//}
+		// Права администратора проверяются в auth.Require при регистрации маршрута
 
 		// Парсим JSON тело запроса
 		var req UpdateInspectionsPerDayRequest
@@ -108,12 +76,13 @@ func UpdateInspectionsPerDayHandler(
 		}
 
 		// Логируем событие отправки документа
-		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), uuid.MustParse(session.UserID))
-		if userInfoErr == nil {
-			reqUserInfo, reqUserInfoErr := userServiceClient.GetUserInfo(r.Context(), uuid.MustParse(session.UserID))
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), principal.UserID)
+		targetUserID, targetUserIDErr := uuid.Parse(req.UserID)
+		if userInfoErr == nil && targetUserIDErr == nil {
+			reqUserInfo, reqUserInfoErr := userServiceClient.GetUserInfo(r.Context(), targetUserID)
 			if reqUserInfoErr == nil {
 				actionText := "Администратор " + userInfo.FirstName + " " + userInfo.LastName + " ограничил количество ежедневных проверок для пользователя " + reqUserInfo.FirstName + " " + reqUserInfo.LastName + " - " + strconv.Itoa(int(req.InspectionsPerDay)) + " проверок в день"
-				if err := actionLogRepo.CreateActionLog(r.Context(), actionText, uuid.MustParse(session.UserID), 5); err != nil {
+				if err := actionLogRepo.CreateActionLog(r.Context(), actionText, principal.UserID, 5); err != nil {
 					log.Error("failed to create action log for TZ submission", slog.String("error", err.Error()))
 				}
 			}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"repairCopilotBot/tz-bot/client"
+	"strconv"
+)
+
+// maxUploadRequestSize - ограничение тела запроса с документом: файл и служебные части multipart
+const maxUploadRequestSize = client.MaxFileSize + 1<<20
+
+// uploadFormMemory - сколько формы держать в памяти, остальное multipart пишет во временные файлы
+const uploadFormMemory = 10 << 20
+
+// readUploadedFile читает файл документа из поля file multipart-формы. Размер файла ограничен
+// тем же пределом, что и gRPC-запрос к tz-bot: файл больше client.MaxFileSize отклоняется с 413
+// до отправки в tz-bot. При ошибке ответ уже записан в w
+func readUploadedFile(w http.ResponseWriter, r *http.Request, log *slog.Logger) ([]byte, string, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadRequestSize)
+
+	if err := r.ParseMultipartForm(uploadFormMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			log.Warn("upload request too large", slog.Int64("limit", maxBytesErr.Limit))
+			http.Error(w, "File is too large", http.StatusRequestEntityTooLarge)
+			return nil, "", false
+		}
+		log.Error("failed to parse multipart form", slog.String("error", err.Error()))
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return nil, "", false
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		log.Error("failed to get file from form", slog.String("error", err.Error()))
+		http.Error(w, "File not found in request", http.StatusBadRequest)
+		return nil, "", false
+	}
+	defer file.Close()
+
+	log.Info("file received",
+		slog.String("filename", header.Filename),
+		slog.Int64("size", header.Size))
+
+	if header.Size > client.MaxFileSize {
+		http.Error(w, "File is too large", http.StatusRequestEntityTooLarge)
+		return nil, "", false
+	}
+
+	fileBytes, err := io.ReadAll(file)
+	if err != nil {
+		log.Error("failed to read file content", slog.String("error", err.Error()))
+		http.Error(w, "Failed to read file", http.StatusInternalServerError)
+		return nil, "", false
+	}
+
+	return fileBytes, header.Filename, true
+}
+
+// uploadCheckOptions читает параметры проверки из параметров запроса или полей формы:
+// force=true - проверить файл заново, даже если он уже проверялся (без него tz-bot вернёт готовую версию),
+// check_profile - код профиля проверки (без него используется профиль по умолчанию)
+func uploadCheckOptions(r *http.Request) client.CheckOptions {
+	force, _ := strconv.ParseBool(uploadParam(r, "force"))
+	return client.CheckOptions{
+		Force:   force,
+		Profile: uploadParam(r, "check_profile"),
+	}
+}
+
+func uploadParam(r *http.Request, name string) string {
+	if value := r.URL.Query().Get(name); value != "" {
+		return value
+	}
+	return r.FormValue(name)
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
+	"repairCopilotBot/tz-bot/client"
+	tzv1 "repairCopilotBot/tz-bot/pkg/tz/v1"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sseHeartbeatInterval - как часто в поток уходит комментарий-пинг, чтобы прокси и браузер
+// не закрывали соединение, пока проверка долго не присылает событий
+const sseHeartbeatInterval = 15 * time.Second
+
+// VersionEventResponse - данные SSE-события. Заполнены только поля, относящиеся к типу события
+type VersionEventResponse struct {
+	ID         int64     `json:"id"`
+	Type       string    `json:"type"`
+	CreatedAt  time.Time `json:"created_at"`
+	Stage      string    `json:"stage,omitempty"`
+	Restored   bool      `json:"restored,omitempty"`
+	GroupIndex int32     `json:"group_index,omitempty"`
+	GroupTotal int32     `json:"group_total,omitempty"`
+	Rubs       *float64  `json:"rubs,omitempty"`
+	Tokens     *int64    `json:"tokens,omitempty"`
+	Status     string    `json:"status,omitempty"`
+}
+
+// VersionEventsHandler транслирует ход проверки версии в виде Server-Sent Events.
+// При переподключении браузер присылает Last-Event-ID, и поток продолжается с него
+func VersionEventsHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.VersionEventsHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("processing VersionEvents request")
+
+		versionIDStr := r.PathValue("version_id")
+		if versionIDStr == "" {
+			log.Error("version_id parameter is missing")
+			http.Error(w, "version_id parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		versionID, err := uuid.Parse(versionIDStr)
+		if err != nil {
+			log.Error("invalid version_id format", slog.String("version_id", versionIDStr), slog.String("error", err.Error()))
+			http.Error(w, "Invalid version_id format", http.StatusBadRequest)
+			return
+		}
+
+		principal := auth.MustFromContext(r.Context())
+
+		var afterEventID int64
+		if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+			afterEventID, err = strconv.ParseInt(lastEventID, 10, 64)
+			if err != nil {
+				log.Error("invalid Last-Event-ID header", slog.String("last_event_id", lastEventID))
+				http.Error(w, "Invalid Last-Event-ID header", http.StatusBadRequest)
+				return
+			}
+		}
+
+		log = log.With(slog.String("user_id", principal.UserID.String()), slog.String("version_id", versionID.String()))
+
+		// Поток живёт, пока идёт проверка, - общий WriteTimeout сервера его бы оборвал
+		rc := http.NewResponseController(w)
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+			log.Error("failed to clear write deadline", slog.String("error", err.Error()))
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		// Пинги пишутся из отдельной горутины, поэтому запись в ответ защищена мьютексом
+		var writeMu sync.Mutex
+		headersSent := false
+
+		stopHeartbeat := make(chan struct{})
+		heartbeatDone := make(chan struct{})
+		go func() {
+			defer close(heartbeatDone)
+
+			ticker := time.NewTicker(sseHeartbeatInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-stopHeartbeat:
+					return
+				case <-ticker.C:
+					writeMu.Lock()
+					if headersSent {
+						if _, err := fmt.Fprint(w, ": ping\n\n"); err == nil {
+							_ = rc.Flush()
+						}
+					}
+					writeMu.Unlock()
+				}
+			}
+		}()
+
+		err = tzBotClient.WatchVersion(r.Context(), versionID, afterEventID, tzCaller(principal), func(event *tzv1.VersionEvent) error {
+			writeMu.Lock()
+			defer writeMu.Unlock()
+
+			if !headersSent {
+				w.Header().Set("Content-Type", "text/event-stream")
+				w.Header().Set("Cache-Control", "no-cache")
+				w.Header().Set("Connection", "keep-alive")
+				w.WriteHeader(http.StatusOK)
+				headersSent = true
+			}
+
+			resp := convertVersionEvent(event)
+			data, err := json.Marshal(resp)
+			if err != nil {
+				return err
+			}
+
+			if resp.ID > 0 {
+				if _, err := fmt.Fprintf(w, "id: %d\n", resp.ID); err != nil {
+					return err
+				}
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", resp.Type, data); err != nil {
+				return err
+			}
+
+			return rc.Flush()
+		})
+
+		close(stopHeartbeat)
+		<-heartbeatDone
+
+		if err != nil {
+			if r.Context().Err() != nil {
+				log.Info("client disconnected from version events stream")
+				return
+			}
+
+			log.Error("failed to watch version in tz-bot", slog.String("error", err.Error()))
+
+			// После начала потока статус ответа уже не изменить - просто закрываем соединение
+			if headersSent {
+				return
+			}
+
+			switch status.Code(err) {
+			case codes.NotFound:
+				http.Error(w, "version not found", http.StatusNotFound)
+			case codes.PermissionDenied:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+			default:
+				http.Error(w, "failed to watch version", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		log.Info("version events stream completed")
+	}
+}
+
+func convertVersionEvent(event *tzv1.VersionEvent) VersionEventResponse {
+	resp := VersionEventResponse{
+		ID:        event.Id,
+		CreatedAt: event.CreatedAt.AsTime(),
+	}
+
+	switch e := event.Event.(type) {
+	case *tzv1.VersionEvent_StageStarted:
+		resp.Type = "stage_started"
+		resp.Stage = e.StageStarted.Stage
+	case *tzv1.VersionEvent_StageFinished:
+		resp.Type = "stage_finished"
+		resp.Stage = e.StageFinished.Stage
+		resp.Restored = e.StageFinished.Restored
+	case *tzv1.VersionEvent_LlmGroupProgress:
+		resp.Type = "llm_group_progress"
+		resp.GroupIndex = e.LlmGroupProgress.GroupIndex
+		resp.GroupTotal = e.LlmGroupProgress.GroupTotal
+	case *tzv1.VersionEvent_Cost:
+		resp.Type = "cost"
+		resp.Rubs = &e.Cost.Rubs
+		resp.Tokens = &e.Cost.Tokens
+	case *tzv1.VersionEvent_FinalStatus:
+		resp.Type = "final_status"
+		resp.Status = e.FinalStatus.Status
+	}
+
+	return resp
+}
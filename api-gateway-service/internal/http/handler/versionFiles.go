@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"repairCopilotBot/api-gateway-service/internal/http/auth"
+	"repairCopilotBot/api-gateway-service/internal/repository"
+	"repairCopilotBot/tz-bot/client"
+	userserviceclient "repairCopilotBot/user-service/client"
+	"strconv"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VersionFileHandler отдаёт исходный документ (kind = original) или отчёт (kind = report) версии.
+// Бакеты S3 наружу не публикуются, доступ к версии проверяет tz-bot
+func VersionFileHandler(
+	log *slog.Logger,
+	tzBotClient *client.Client,
+	userServiceClient *userserviceclient.UserClient,
+	actionLogRepo repository.ActionLogRepository,
+) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.VersionFileHandler"
+
+		log := log.With(slog.String("op", op))
+		log.Info("processing VersionFile request")
+
+		versionIDStr := r.PathValue("version_id")
+		kind := r.PathValue("kind")
+
+		versionID, err := uuid.Parse(versionIDStr)
+		if err != nil {
+			log.Error("invalid version_id format", slog.String("version_id", versionIDStr), slog.String("error", err.Error()))
+			http.Error(w, "Invalid version_id format", http.StatusBadRequest)
+			return
+		}
+
+		principal := auth.MustFromContext(r.Context())
+
+		log = log.With(
+			slog.String("user_id", principal.UserID.String()),
+			slog.String("version_id", versionID.String()),
+			slog.String("kind", kind),
+		)
+
+		file, err := tzBotClient.GetVersionFile(r.Context(), versionID, kind, tzCaller(principal))
+		if err != nil {
+			log.Error("failed to get version file from tz-bot", slog.String("error", err.Error()))
+
+			switch status.Code(err) {
+			case codes.InvalidArgument:
+				http.Error(w, "kind must be original or report", http.StatusBadRequest)
+			case codes.NotFound:
+				http.Error(w, "version not found", http.StatusNotFound)
+			case codes.PermissionDenied:
+				http.Error(w, "Forbidden", http.StatusForbidden)
+			case codes.FailedPrecondition:
+				http.Error(w, "file is not available for this version", http.StatusConflict)
+			default:
+				http.Error(w, "failed to get file", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		// Логируем скачивание файла
+		userInfo, userInfoErr := userServiceClient.GetUserInfo(r.Context(), principal.UserID)
+		if userInfoErr == nil {
+			actionText := "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " скачал исходный документ ТЗ"
+			if kind == "report" {
+				actionText = "Пользователь " + userInfo.FirstName + " " + userInfo.LastName + " скачал отчёт по ТЗ"
+			}
+			if err := actionLogRepo.CreateActionLog(r.Context(), actionText, principal.UserID, 3); err != nil {
+				log.Error("failed to create action log for version file download", slog.String("error", err.Error()))
+			}
+		}
+
+		w.Header().Set("Content-Type", file.ContentType)
+		w.Header().Set("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(file.FileName))
+		w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := w.Write(file.Content); err != nil {
+			log.Error("failed to write version file", slog.String("error", err.Error()))
+			return
+		}
+
+		log.Info("version file downloaded successfully", slog.String("file_name", file.FileName))
+	}
+}
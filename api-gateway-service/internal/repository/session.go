@@ -3,24 +3,43 @@ package repository
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
 	"github.com/google/uuid"
 )
 
+// sessionTouchInterval - как часто запросы продлевают сессию. Чаще не пишем в Redis,
+// idle timeout всё равно на порядки больше
+const sessionTouchInterval = time.Minute
+
+// Session хранится в Redis под ключом session:<токен куки>. ID - публичный идентификатор
+// для списка сессий, сам токен наружу не отдаётся
 type Session struct {
-	UserID   string `json:"user_id"`
-	Login    string `json:"login"`
-	IsAdmin1 bool   `json:"is_admin1"`
-	IsAdmin2 bool   `json:"is_admin2"`
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	Login      string    `json:"login"`
+	IsAdmin1   bool      `json:"is_admin1"`
+	IsAdmin2   bool      `json:"is_admin2"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+
+	// Token заполняется только в ListUserSessions, чтобы отличить текущую сессию
+	Token string `json:"-"`
 }
 
 type SessionRepository struct {
-	pool *redis.Pool
+	pool        *redis.Pool
+	idleTimeout time.Duration
+	maxLifetime time.Duration
 }
 
-func NewSessionRepository(redisAddr, redisPassword string) *SessionRepository {
+// NewSessionRepository создаёт хранилище сессий. Сессия завершается после idleTimeout без
+// запросов и в любом случае через maxLifetime после входа
+func NewSessionRepository(redisAddr, redisPassword string, idleTimeout, maxLifetime time.Duration) *SessionRepository {
 	pool := &redis.Pool{
 		MaxIdle:     3,
 		IdleTimeout: 240 * time.Second,
@@ -43,18 +62,33 @@ func NewSessionRepository(redisAddr, redisPassword string) *SessionRepository {
 		},
 	}
 
-	return &SessionRepository{pool: pool}
+	return &SessionRepository{pool: pool, idleTimeout: idleTimeout, maxLifetime: maxLifetime}
+}
+
+func sessionKey(sessionID string) string {
+	return fmt.Sprintf("session:%s", sessionID)
 }
 
-func (r *SessionRepository) CreateSession(sessionId uuid.UUID, userID uuid.UUID, login string, isAdmin1, isAdmin2 bool) error {
+// userSessionsKey - индекс сессий пользователя: hash публичный ID -> токен
+func userSessionsKey(userID string) string {
+	return fmt.Sprintf("user_sessions:%s", userID)
+}
+
+func (r *SessionRepository) CreateSession(sessionId uuid.UUID, userID uuid.UUID, login string, isAdmin1, isAdmin2 bool, ip, userAgent string) error {
 	conn := r.pool.Get()
 	defer conn.Close()
 
+	now := time.Now().UTC()
 	session := Session{
-		UserID:   userID.String(),
-		Login:    login,
-		IsAdmin1: isAdmin1,
-		IsAdmin2: isAdmin2,
+		ID:         uuid.NewString(),
+		UserID:     userID.String(),
+		Login:      login,
+		IsAdmin1:   isAdmin1,
+		IsAdmin2:   isAdmin2,
+		IP:         ip,
+		UserAgent:  userAgent,
+		CreatedAt:  now,
+		LastSeenAt: now,
 	}
 
 	sessionData, err := json.Marshal(session)
@@ -62,19 +96,196 @@ func (r *SessionRepository) CreateSession(sessionId uuid.UUID, userID uuid.UUID,
 		return fmt.Errorf("failed to marshal session: %w", err)
 	}
 
-	_, err = conn.Do("SETEX", fmt.Sprintf("session:%s", sessionId), 3600*2400, sessionData)
-	if err != nil {
+	indexKey := userSessionsKey(session.UserID)
+
+	conn.Send("MULTI")
+	conn.Send("SET", sessionKey(sessionId.String()), sessionData, "EX", seconds(r.idleTimeout))
+	conn.Send("HSET", indexKey, session.ID, sessionId.String())
+	// Ни одна сессия не живёт дольше maxLifetime, поэтому индекс можно удалить вместе с последней из них
+	conn.Send("EXPIRE", indexKey, seconds(r.maxLifetime))
+	if _, err := conn.Do("EXEC"); err != nil {
 		return fmt.Errorf("failed to save session to redis: %w", err)
 	}
 
 	return nil
 }
 
+// GetSession возвращает сессию по токену или nil, если она истекла или отозвана
 func (r *SessionRepository) GetSession(sessionID string) (*Session, error) {
 	conn := r.pool.Get()
 	defer conn.Close()
 
-	data, err := redis.Bytes(conn.Do("GET", fmt.Sprintf("session:%s", sessionID)))
+	session, err := getSession(conn, sessionID)
+	if err != nil || session == nil {
+		return nil, err
+	}
+
+	if !r.alive(session, time.Now()) {
+		if err := deleteSession(conn, sessionID, session); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	return session, nil
+}
+
+// TouchSession продлевает сессию на idle timeout, но не дальше maxLifetime от входа
+func (r *SessionRepository) TouchSession(sessionID string, session *Session) error {
+	now := time.Now().UTC()
+	if now.Sub(session.LastSeenAt) < sessionTouchInterval {
+		return nil
+	}
+
+	ttl := r.idleTimeout
+	if left := session.CreatedAt.Add(r.maxLifetime).Sub(now); left < ttl {
+		ttl = left
+	}
+	if ttl <= 0 {
+		return nil
+	}
+
+	touched := *session
+	touched.LastSeenAt = now
+
+	sessionData, err := json.Marshal(touched)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	// XX не даёт воскресить сессию, отозванную параллельным запросом
+	_, err = conn.Do("SET", sessionKey(sessionID), sessionData, "EX", seconds(ttl), "XX")
+	if err != nil {
+		return fmt.Errorf("failed to touch session in redis: %w", err)
+	}
+
+	return nil
+}
+
+// ListUserSessions возвращает активные сессии пользователя, последние использованные первыми.
+// Истёкшие сессии удаляются из индекса
+func (r *SessionRepository) ListUserSessions(userID uuid.UUID) ([]*Session, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	indexKey := userSessionsKey(userID.String())
+
+	index, err := redis.StringMap(conn.Do("HGETALL", indexKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user sessions from redis: %w", err)
+	}
+
+	now := time.Now()
+	sessions := make([]*Session, 0, len(index))
+	for publicID, token := range index {
+		session, err := getSession(conn, token)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil || session.ID != publicID || !r.alive(session, now) {
+			if _, err := conn.Do("HDEL", indexKey, publicID); err != nil {
+				return nil, fmt.Errorf("failed to clean user sessions index: %w", err)
+			}
+			continue
+		}
+		session.Token = token
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// DeleteSession завершает сессию по токену куки
+func (r *SessionRepository) DeleteSession(sessionID string) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	session, err := getSession(conn, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return deleteSession(conn, sessionID, session)
+}
+
+// DeleteUserSession завершает сессию пользователя по публичному ID. Возвращает false,
+// если такой сессии у пользователя нет
+func (r *SessionRepository) DeleteUserSession(userID uuid.UUID, publicID string) (bool, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	indexKey := userSessionsKey(userID.String())
+
+	token, err := redis.String(conn.Do("HGET", indexKey, publicID))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get session from index: %w", err)
+	}
+
+	conn.Send("MULTI")
+	conn.Send("DEL", sessionKey(token))
+	conn.Send("HDEL", indexKey, publicID)
+	replies, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return false, fmt.Errorf("failed to delete session from redis: %w", err)
+	}
+
+	deleted, _ := redis.Int(replies[0], nil)
+	return deleted > 0, nil
+}
+
+// DeleteUserSessions завершает все сессии пользователя и возвращает их количество
+func (r *SessionRepository) DeleteUserSessions(userID uuid.UUID) (int, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	indexKey := userSessionsKey(userID.String())
+
+	tokens, err := redis.Strings(conn.Do("HVALS", indexKey))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user sessions from redis: %w", err)
+	}
+
+	args := redis.Args{}
+	for _, token := range tokens {
+		args = args.Add(sessionKey(token))
+	}
+	args = args.Add(indexKey)
+
+	deleted, err := redis.Int(conn.Do("DEL", args...))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions from redis: %w", err)
+	}
+
+	// Индекс тоже удалён, если он существовал
+	if len(tokens) > 0 {
+		deleted--
+	}
+
+	return deleted, nil
+}
+
+func (r *SessionRepository) Close() error {
+	return r.pool.Close()
+}
+
+// alive проверяет максимальный срок сессии. Сессии без created_at созданы до появления
+// индексов и idle timeout и считаются истёкшими
+func (r *SessionRepository) alive(session *Session, now time.Time) bool {
+	return !session.CreatedAt.IsZero() && now.Before(session.CreatedAt.Add(r.maxLifetime))
+}
+
+func getSession(conn redis.Conn, sessionID string) (*Session, error) {
+	data, err := redis.Bytes(conn.Do("GET", sessionKey(sessionID)))
 	if err != nil {
 		if err == redis.ErrNil {
 			return nil, nil
@@ -90,18 +301,23 @@ func (r *SessionRepository) GetSession(sessionID string) (*Session, error) {
 	return &session, nil
 }
 
-func (r *SessionRepository) DeleteSession(sessionID string) error {
-	conn := r.pool.Get()
-	defer conn.Close()
-
-	_, err := conn.Do("DEL", fmt.Sprintf("session:%s", sessionID))
-	if err != nil {
+func deleteSession(conn redis.Conn, sessionID string, session *Session) error {
+	conn.Send("MULTI")
+	conn.Send("DEL", sessionKey(sessionID))
+	if session != nil && session.ID != "" {
+		conn.Send("HDEL", userSessionsKey(session.UserID), session.ID)
+	}
+	if _, err := conn.Do("EXEC"); err != nil {
 		return fmt.Errorf("failed to delete session from redis: %w", err)
 	}
 
 	return nil
 }
 
-func (r *SessionRepository) Close() error {
-	return r.pool.Close()
+// seconds переводит длительность в секунды для EX/EXPIRE, Redis не принимает 0
+func seconds(d time.Duration) int64 {
+	if d < time.Second {
+		return 1
+	}
+	return int64(d / time.Second)
 }
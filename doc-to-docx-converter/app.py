@@ -1,6 +1,7 @@
 import os
 import subprocess
 import tempfile
+import uuid
 import shutil
 from pathlib import Path
 from urllib.parse import quote  # Добавлен импорт
@@ -32,6 +33,22 @@ OUTPUT_DIR = Path("/tmp/outputs")
 UPLOAD_DIR.mkdir(exist_ok=True)
 OUTPUT_DIR.mkdir(exist_ok=True)
 
+# Форматы, которые LibreOffice конвертирует в DOCX
+SUPPORTED_EXTENSIONS = ('.doc', '.odt', '.rtf')
+
+# Страница PDF считается содержащей текст, если в её текстовом слое
+# не меньше MIN_PDF_PAGE_CHARS букв и цифр
+MIN_PDF_PAGE_CHARS = 30
+
+
+def is_supported_file(filename: str) -> bool:
+    return Path(filename).suffix.lower() in SUPPORTED_EXTENSIONS
+
+
+def docx_filename(filename: str) -> str:
+    """Имя DOCX файла, который LibreOffice создаёт из filename"""
+    return f"{Path(filename).stem}.docx"
+
 
 def cleanup_existing_files(input_filename: str):
     """
@@ -44,27 +61,19 @@ def cleanup_existing_files(input_filename: str):
             input_path.unlink()
             logger.info(f"Removed existing input file: {input_path}")
         
-        # Очистка output файла
-        output_filename = input_filename.replace('.doc', '.docx')
-        output_path = OUTPUT_DIR / output_filename
+        # Очистка output файла, созданного LibreOffice
+        output_path = OUTPUT_DIR / docx_filename(input_filename)
         if output_path.exists():
             output_path.unlink()
             logger.info(f"Removed existing output file: {output_path}")
             
-        # Очистка файла созданного LibreOffice (если отличается от желаемого)
-        input_name_without_ext = input_filename.replace('.doc', '')
-        libreoffice_output = OUTPUT_DIR / f"{input_name_without_ext}.docx"
-        if libreoffice_output.exists() and libreoffice_output != output_path:
-            libreoffice_output.unlink()
-            logger.info(f"Removed existing LibreOffice output file: {libreoffice_output}")
-            
     except Exception as e:
         logger.warning(f"Error during cleanup: {str(e)}")
 
 
 def convert_doc_to_docx(input_path: Path, output_path: Path) -> bool:
     """
-    Конвертирует DOC файл в DOCX используя LibreOffice
+    Конвертирует DOC, ODT или RTF файл в DOCX используя LibreOffice
     """
     try:
         # Команда для конвертации через LibreOffice
@@ -110,7 +119,8 @@ async def root():
         "service": "DOC to DOCX Converter",
         "version": "1.0.0",
         "endpoints": {
-            "/convert": "POST - конвертировать DOC в DOCX",
+            "/convert": "POST - конвертировать DOC, ODT или RTF в DOCX",
+            "/extract-pdf-text": "POST - извлечь текстовый слой PDF постранично",
             "/health": "GET - проверка состояния сервиса",
             "/docs": "GET - документация API (Swagger UI)"
         }
@@ -153,23 +163,23 @@ async def convert_document(
         output_filename: Optional[str] = None
 ):
     """
-    Конвертирует загруженный DOC файл в DOCX
+    Конвертирует загруженный DOC, ODT или RTF файл в DOCX
 
     Parameters:
-    - file: DOC файл для конвертации
+    - file: DOC, ODT или RTF файл для конвертации
     - output_filename: имя выходного файла (опционально)
     """
 
     # Проверка расширения файла
-    if not file.filename.lower().endswith('.doc'):
+    if not is_supported_file(file.filename):
         raise HTTPException(
             status_code=400,
-            detail="File must have .doc extension"
+            detail="File must have .doc, .odt or .rtf extension"
         )
 
     # Генерация временных путей
     temp_input = UPLOAD_DIR / file.filename
-    temp_output_name = output_filename or file.filename.replace('.doc', '.docx')
+    temp_output_name = output_filename or docx_filename(file.filename)
     temp_output = OUTPUT_DIR / temp_output_name
 
     try:
@@ -192,9 +202,7 @@ async def convert_document(
             )
 
         # LibreOffice создает файл с тем же именем, но с расширением .docx
-        # Определяем правильное имя выходного файла
-        input_name_without_ext = temp_input.name.replace('.doc', '')
-        expected_output = OUTPUT_DIR / f"{input_name_without_ext}.docx"
+        expected_output = OUTPUT_DIR / docx_filename(temp_input.name)
 
         if not expected_output.exists():
             raise HTTPException(
@@ -237,21 +245,21 @@ async def convert_document(
 @app.post("/convert-batch")
 async def convert_batch(files: list[UploadFile] = File(...)):
     """
-    Конвертирует несколько DOC файлов в DOCX
+    Конвертирует несколько DOC, ODT или RTF файлов в DOCX
     """
     results = []
 
     for file in files:
-        if not file.filename.lower().endswith('.doc'):
+        if not is_supported_file(file.filename):
             results.append({
                 "filename": file.filename,
                 "status": "error",
-                "message": "File must have .doc extension"
+                "message": "File must have .doc, .odt or .rtf extension"
             })
             continue
 
         temp_input = UPLOAD_DIR / file.filename
-        temp_output_name = file.filename.replace('.doc', '.docx')
+        temp_output_name = docx_filename(file.filename)
         temp_output = OUTPUT_DIR / temp_output_name
 
         try:
@@ -266,8 +274,7 @@ async def convert_batch(files: list[UploadFile] = File(...)):
             success = convert_doc_to_docx(temp_input, temp_output)
 
             if success:
-                input_name_without_ext = temp_input.name.replace('.doc', '')
-                expected_output = OUTPUT_DIR / f"{input_name_without_ext}.docx"
+                expected_output = OUTPUT_DIR / docx_filename(temp_input.name)
 
                 if expected_output.exists():
                     if expected_output != temp_output:
@@ -304,5 +311,86 @@ async def convert_batch(files: list[UploadFile] = File(...)):
     return {"results": results}
 
 
+class PdfPasswordError(Exception):
+    """PDF зашифрован паролем на открытие"""
+
+
+def extract_pdf_pages(input_path: Path) -> list[str]:
+    """
+    Извлекает текстовый слой PDF через pdftotext (poppler-utils).
+    pdftotext разделяет страницы символом перевода формата, поэтому
+    номер страницы - это индекс элемента списка + 1
+    """
+    result = subprocess.run(
+        ["pdftotext", "-enc", "UTF-8", str(input_path), "-"],
+        capture_output=True,
+        timeout=60
+    )
+
+    if result.returncode != 0:
+        stderr = result.stderr.decode("utf-8", errors="replace")
+        if "password" in stderr.lower():
+            raise PdfPasswordError(stderr)
+        raise RuntimeError(stderr)
+
+    pages = result.stdout.decode("utf-8", errors="replace").split("\f")
+    # После последней страницы pdftotext тоже ставит разделитель
+    if pages and pages[-1].strip() == "":
+        pages = pages[:-1]
+
+    return pages
+
+
+@app.post("/extract-pdf-text")
+async def extract_pdf_text(file: UploadFile = File(...)):
+    """
+    Возвращает текстовый слой PDF постранично.
+    Для сканов без текстового слоя поле has_text_layer равно false,
+    для PDF с паролем на открытие - password_protected равно true
+    """
+    # Имя временного файла не зависит от имени, присланного клиентом: параллельные
+    # запросы с одинаковыми именами не перезаписывают файлы друг друга
+    temp_input = UPLOAD_DIR / f"{uuid.uuid4().hex}.pdf"
+
+    try:
+        with open(temp_input, "wb") as buffer:
+            shutil.copyfileobj(file.file, buffer)
+
+        pages = extract_pdf_pages(temp_input)
+        pages_with_text = sum(
+            1 for page in pages
+            if sum(ch.isalnum() for ch in page) >= MIN_PDF_PAGE_CHARS
+        )
+
+        logger.info(f"Extracted text from {file.filename}: pages={len(pages)}, pages_with_text={pages_with_text}")
+
+        return {
+            "pages": pages,
+            "page_count": len(pages),
+            "pages_with_text": pages_with_text,
+            "has_text_layer": len(pages) > 0 and pages_with_text * 2 >= len(pages),
+            "password_protected": False
+        }
+
+    except PdfPasswordError:
+        logger.info(f"PDF {file.filename} is password protected")
+        return {
+            "pages": [],
+            "page_count": 0,
+            "pages_with_text": 0,
+            "has_text_layer": False,
+            "password_protected": True
+        }
+    except subprocess.TimeoutExpired:
+        logger.error("PDF text extraction timeout")
+        raise HTTPException(status_code=500, detail="PDF text extraction timeout")
+    except Exception as e:
+        logger.error(f"PDF text extraction error: {str(e)}")
+        raise HTTPException(status_code=422, detail=f"Failed to read PDF: {str(e)}")
+    finally:
+        if temp_input.exists():
+            temp_input.unlink()
+
+
 if __name__ == "__main__":
     uvicorn.run(app, host="0.0.0.0", port=8000)
\ No newline at end of file
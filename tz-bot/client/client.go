@@ -3,7 +3,9 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	tzv1 "repairCopilotBot/tz-bot/pkg/tz/v1"
@@ -11,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Client struct {
@@ -94,11 +97,18 @@ type VersionStatistics struct {
 	AverageInspectionTimeNanoseconds *int64
 }
 
+// MaxMessageSize - ограничение размера gRPC-сообщения, такое же задано на сервере tz-bot
+const MaxMessageSize = 50 * 1024 * 1024
+
+// MaxFileSize - наибольший файл, который можно отправить на проверку: в сообщение
+// кроме файла входят имя и идентификаторы, под них оставлен запас в 1 МБ
+const MaxFileSize = MaxMessageSize - 1024*1024
+
 func New(ctx context.Context, addr string) (*Client, error) {
 	const op = "tz_client.New"
 
 	// Увеличиваем максимальный размер сообщения до 50 МБ
-	maxMsgSize := 50 * 1024 * 1024 // 50 MB
+	maxMsgSize := MaxMessageSize
 
 	cc, err := grpc.NewClient(addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -124,7 +134,34 @@ type CheckTzResponse struct {
 	СreatedAt time.Time `json:"created_at"`
 }
 
-func (c *Client) CheckTz(ctx context.Context, file []byte, filename string, requestID uuid.UUID) (*CheckTzResponse, error) {
+// CheckOptions - параметры проверки загружаемого документа
+type CheckOptions struct {
+	// Force - проверить файл заново, даже если он уже проверялся
+	Force bool
+	// Profile - код профиля проверки, пустой - профиль по умолчанию
+	Profile string
+	// GgID - группа ошибок вместо gg_id профиля
+	GgID *int
+}
+
+func (o CheckOptions) profile() *string {
+	if o.Profile == "" {
+		return nil
+	}
+	return &o.Profile
+}
+
+func (o CheckOptions) ggID() *int32 {
+	if o.GgID == nil {
+		return nil
+	}
+	ggID := int32(*o.GgID)
+	return &ggID
+}
+
+// CheckTz загружает ТЗ на проверку. Если файл уже проверялся, возвращается готовая версия
+// с Duplicate = true; opts.Force запускает проверку заново
+func (c *Client) CheckTz(ctx context.Context, file []byte, filename string, requestID uuid.UUID, opts CheckOptions) (*CheckTzResponse, error) {
 	const op = "tz_client.CheckTz"
 
 	// Создаем контекст с таймаутом 30 минут для gRPC запроса
@@ -133,9 +170,12 @@ func (c *Client) CheckTz(ctx context.Context, file []byte, filename string, requ
 
 	//fmt.Println("точка 11")
 	resp, err := c.api.CheckTz(ctx, &tzv1.CheckTzRequest{
-		File:      file,
-		Filename:  filename,
-		RequestId: requestID.String(),
+		File:         file,
+		Filename:     filename,
+		RequestId:    requestID.String(),
+		Force:        opts.Force,
+		CheckProfile: opts.profile(),
+		GgId:         opts.ggID(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -216,6 +256,37 @@ func (c *Client) CheckTz(ctx context.Context, file []byte, filename string, requ
 	//}, nil
 }
 
+type CheckTzVersionResponse struct {
+	*tzv1.CheckTzVersionResponse
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (c *Client) CheckTzVersion(ctx context.Context, technicalSpecificationID uuid.UUID, file []byte, filename string, userID uuid.UUID, opts CheckOptions) (*CheckTzVersionResponse, error) {
+	const op = "tz_client.CheckTzVersion"
+
+	// Создаем контекст с таймаутом 30 минут для gRPC запроса
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+	defer cancel()
+
+	resp, err := c.api.CheckTzVersion(ctx, &tzv1.CheckTzVersionRequest{
+		TechnicalSpecificationId: technicalSpecificationID.String(),
+		File:                     file,
+		Filename:                 filename,
+		UserId:                   userID.String(),
+		Force:                    opts.Force,
+		CheckProfile:             opts.profile(),
+		GgId:                     opts.ggID(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &CheckTzVersionResponse{
+		CheckTzVersionResponse: resp,
+		CreatedAt:              resp.CreatedAt.AsTime(),
+	}, nil
+}
+
 type GetVersionMeResponse struct {
 	*tzv1.VersionMe
 	CreatedAt time.Time `json:"created_at"`
@@ -253,17 +324,79 @@ func (c *Client) GetVersionsMe(ctx context.Context, userID uuid.UUID) ([]*GetVer
 	return versions, nil
 }
 
+// GetVersionsOrganization возвращает версии ТЗ всех участников организации пользователя,
+// владелец версии - в поле UserId
+func (c *Client) GetVersionsOrganization(ctx context.Context, userID uuid.UUID) ([]*GetVersionMeResponse, error) {
+	const op = "tz_client.GetVersionsOrganization"
+
+	resp, err := c.api.GetVersionsMe(ctx, &tzv1.GetVersionsMeRequest{
+		UserId:       userID.String(),
+		Organization: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	versions := make([]*GetVersionMeResponse, 0, len(resp.Versions))
+	for _, version := range resp.Versions {
+		versions = append(versions, &GetVersionMeResponse{
+			VersionMe: version,
+			CreatedAt: version.CreatedAt.AsTime(),
+		})
+	}
+
+	return versions, nil
+}
+
+// Caller - пользователь, от имени которого запрашиваются результаты проверки.
+// tz-bot отдаёт версию только владельцу ТЗ, администратору или посетителю по ссылке
+type Caller struct {
+	UserID     uuid.UUID
+	IsAdmin    bool
+	ShareToken string // посетитель без учётной записи, открывший ссылку на просмотр
+}
+
+// SharedCaller - посетитель, открывший ссылку на просмотр с токеном token
+func SharedCaller(token string) Caller {
+	return Caller{ShareToken: token}
+}
+
+func (c Caller) proto() *tzv1.Caller {
+	if c.ShareToken != "" {
+		return &tzv1.Caller{Role: "shared", ShareToken: c.ShareToken}
+	}
+
+	role := "user"
+	if c.IsAdmin {
+		role = "admin"
+	}
+	return &tzv1.Caller{UserId: c.UserID.String(), Role: role}
+}
+
 type Version struct {
 	*tzv1.GetVersionResponse
 	Report    json.RawMessage `json:"report"`
 	CreatedAt *time.Time      `json:"createdAt"`
 }
 
-func (c *Client) GetVersion(ctx context.Context, versionID uuid.UUID) (*Version, error) {
+// InstancesFilter - фильтры и сортировка замечаний в GetVersion. Пустые поля не ограничивают выборку
+type InstancesFilter struct {
+	Priorities []string // low | medium | high
+	Sections   []string
+	ErrorCodes []string
+	Sort       string // order (по умолчанию) | priority | error_code
+}
+
+func (c *Client) GetVersion(ctx context.Context, versionID uuid.UUID, filter InstancesFilter, caller Caller) (*Version, error) {
 	const op = "tz_client.GetVersion"
 
 	resp, err := c.api.GetVersion(ctx, &tzv1.GetVersionRequest{
-		VersionId: versionID.String(),
+		VersionId:  versionID.String(),
+		Priorities: filter.Priorities,
+		Sections:   filter.Sections,
+		ErrorCodes: filter.ErrorCodes,
+		Sort:       filter.Sort,
+		Caller:     caller.proto(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -343,7 +476,7 @@ func (c *Client) GetVersionStatistics(ctx context.Context) (*VersionStatistics,
 	}, nil
 }
 
-func (c *Client) NewFeedbackError(ctx context.Context, instanceID uuid.UUID, instanceType string, feedbackMark *bool, feedbackComment *string, userID uuid.UUID, isVerification bool) error {
+func (c *Client) NewFeedbackError(ctx context.Context, instanceID uuid.UUID, instanceType string, feedbackMark *bool, feedbackComment *string, userID uuid.UUID, isVerification bool, caller Caller) error {
 	const op = "tz_client.NewFeedbackError"
 
 	_, err := c.api.NewFeedbackError(ctx, &tzv1.NewFeedbackErrorRequest{
@@ -353,6 +486,7 @@ func (c *Client) NewFeedbackError(ctx context.Context, instanceID uuid.UUID, ins
 		FeedbackComment: feedbackComment,
 		UserId:          userID.String(),
 		IsVerification:  isVerification,
+		Caller:          caller.proto(),
 	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
@@ -482,3 +616,318 @@ func (c *Client) GetFeedbacks(ctx context.Context, userID uuid.UUID) (*[]*GetFee
 	}
 	return &feedbacksResp, nil
 }
+
+func (c *Client) CompareVersions(ctx context.Context, versionAID, versionBID uuid.UUID, caller Caller) (*tzv1.CompareVersionsResponse, error) {
+	const op = "tz_client.CompareVersions"
+
+	resp, err := c.api.CompareVersions(ctx, &tzv1.CompareVersionsRequest{
+		VersionAId: versionAID.String(),
+		VersionBId: versionBID.String(),
+		Caller:     caller.proto(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+// RetryVersion перезапускает упавшую проверку версии и возвращает стадию, с которой она продолжится
+func (c *Client) RetryVersion(ctx context.Context, versionID uuid.UUID, fromStage string, caller Caller) (string, error) {
+	const op = "tz_client.RetryVersion"
+
+	resp, err := c.api.RetryVersion(ctx, &tzv1.RetryVersionRequest{
+		VersionId: versionID.String(),
+		FromStage: fromStage,
+		Caller:    caller.proto(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.ResumeStage, nil
+}
+
+// CancelVersion отменяет проверку версии, которая ещё стоит в очереди или выполняется
+func (c *Client) CancelVersion(ctx context.Context, versionID uuid.UUID, userID uuid.UUID) error {
+	const op = "tz_client.CancelVersion"
+
+	_, err := c.api.CancelVersion(ctx, &tzv1.CancelVersionRequest{
+		VersionId: versionID.String(),
+		UserId:    userID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// WatchVersion передаёт в handle события проверки версии, пока проверка не завершится,
+// handle не вернёт ошибку или не будет отменён ctx
+func (c *Client) WatchVersion(ctx context.Context, versionID uuid.UUID, afterEventID int64, caller Caller, handle func(*tzv1.VersionEvent) error) error {
+	const op = "tz_client.WatchVersion"
+
+	stream, err := c.api.WatchVersion(ctx, &tzv1.WatchVersionRequest{
+		VersionId:    versionID.String(),
+		AfterEventId: afterEventID,
+		Caller:       caller.proto(),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err := handle(event); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+}
+
+// LlmCacheFilter - условия сброса кэша LLM. Пустые поля не учитываются
+type LlmCacheFilter struct {
+	Model *string
+	GgID  *int
+	From  *time.Time
+	To    *time.Time
+}
+
+// InvalidateLlmCache удаляет из кэша LLM ответы, подходящие под фильтр, и возвращает их количество
+func (c *Client) InvalidateLlmCache(ctx context.Context, filter LlmCacheFilter) (int64, error) {
+	const op = "tz_client.InvalidateLlmCache"
+
+	req := &tzv1.InvalidateLlmCacheRequest{
+		Model: filter.Model,
+	}
+	if filter.GgID != nil {
+		ggID := int32(*filter.GgID)
+		req.GgId = &ggID
+	}
+	if filter.From != nil {
+		req.From = timestamppb.New(*filter.From)
+	}
+	if filter.To != nil {
+		req.To = timestamppb.New(*filter.To)
+	}
+
+	resp, err := c.api.InvalidateLlmCache(ctx, req)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Deleted, nil
+}
+
+// GetVersionCostBreakdown возвращает расходы на проверку версии по шагам и группам ошибок.
+// При includeCalls в ответ попадает и каждый запрос к LLM
+func (c *Client) GetVersionCostBreakdown(ctx context.Context, versionID uuid.UUID, includeCalls bool, caller Caller) (*tzv1.GetVersionCostBreakdownResponse, error) {
+	const op = "tz_client.GetVersionCostBreakdown"
+
+	resp, err := c.api.GetVersionCostBreakdown(ctx, &tzv1.GetVersionCostBreakdownRequest{
+		VersionId:    versionID.String(),
+		IncludeCalls: includeCalls,
+		Caller:       caller.proto(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+// ExportAnnotatedDocx возвращает исходный документ версии с замечаниями в виде комментариев Word
+func (c *Client) ExportAnnotatedDocx(ctx context.Context, versionID uuid.UUID, caller Caller) (*tzv1.ExportAnnotatedDocxResponse, error) {
+	const op = "tz_client.ExportAnnotatedDocx"
+
+	resp, err := c.api.ExportAnnotatedDocx(ctx, &tzv1.ExportAnnotatedDocxRequest{
+		VersionId: versionID.String(),
+		Caller:    caller.proto(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+// GetVersionFile скачивает исходный документ (kind = original) или отчёт (kind = report) версии
+func (c *Client) GetVersionFile(ctx context.Context, versionID uuid.UUID, kind string, caller Caller) (*tzv1.GetVersionFileResponse, error) {
+	const op = "tz_client.GetVersionFile"
+
+	resp, err := c.api.GetVersionFile(ctx, &tzv1.GetVersionFileRequest{
+		VersionId: versionID.String(),
+		Kind:      kind,
+		Caller:    caller.proto(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+// ExportVersion выгружает ошибки и замечания версии в формате json, csv или xlsx
+func (c *Client) ExportVersion(ctx context.Context, versionID uuid.UUID, format string, caller Caller) (*tzv1.ExportVersionResponse, error) {
+	const op = "tz_client.ExportVersion"
+
+	resp, err := c.api.ExportVersion(ctx, &tzv1.ExportVersionRequest{
+		VersionId: versionID.String(),
+		Format:    format,
+		Caller:    caller.proto(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+// ListCheckProfiles возвращает профили проверки
+func (c *Client) ListCheckProfiles(ctx context.Context) ([]*tzv1.CheckProfile, error) {
+	const op = "tz_client.ListCheckProfiles"
+
+	resp, err := c.api.ListCheckProfiles(ctx, &tzv1.ListCheckProfilesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Profiles, nil
+}
+
+// CreateCheckProfile создаёт профиль проверки
+func (c *Client) CreateCheckProfile(ctx context.Context, req *tzv1.CreateCheckProfileRequest) (*tzv1.CheckProfile, error) {
+	const op = "tz_client.CreateCheckProfile"
+
+	resp, err := c.api.CreateCheckProfile(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Profile, nil
+}
+
+// UpdateCheckProfile изменяет профиль проверки. Незаданные поля запроса не меняются
+func (c *Client) UpdateCheckProfile(ctx context.Context, req *tzv1.UpdateCheckProfileRequest) (*tzv1.CheckProfile, error) {
+	const op = "tz_client.UpdateCheckProfile"
+
+	resp, err := c.api.UpdateCheckProfile(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Profile, nil
+}
+
+// DeleteCheckProfile удаляет профиль проверки
+func (c *Client) DeleteCheckProfile(ctx context.Context, name string) error {
+	const op = "tz_client.DeleteCheckProfile"
+
+	if _, err := c.api.DeleteCheckProfile(ctx, &tzv1.DeleteCheckProfileRequest{Name: name}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetDefaultCheckProfile назначает профиль по умолчанию
+func (c *Client) SetDefaultCheckProfile(ctx context.Context, name string) (*tzv1.CheckProfile, error) {
+	const op = "tz_client.SetDefaultCheckProfile"
+
+	resp, err := c.api.SetDefaultCheckProfile(ctx, &tzv1.SetDefaultCheckProfileRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Profile, nil
+}
+
+// ListErrorCatalog возвращает записи каталога ошибок
+func (c *Client) ListErrorCatalog(ctx context.Context, req *tzv1.ListErrorCatalogRequest) ([]*tzv1.ErrorCatalogEntry, error) {
+	const op = "tz_client.ListErrorCatalog"
+
+	resp, err := c.api.ListErrorCatalog(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Entries, nil
+}
+
+// GetErrorCatalogEntry возвращает запись каталога ошибок по id или коду
+func (c *Client) GetErrorCatalogEntry(ctx context.Context, req *tzv1.GetErrorCatalogEntryRequest) (*tzv1.ErrorCatalogEntry, error) {
+	const op = "tz_client.GetErrorCatalogEntry"
+
+	resp, err := c.api.GetErrorCatalogEntry(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.Entry, nil
+}
+
+// CreateShareLink создаёт ссылку на просмотр версии. Токен возвращается только здесь
+func (c *Client) CreateShareLink(ctx context.Context, versionID uuid.UUID, expiresAt time.Time, permissions []string, caller Caller) (*tzv1.CreateShareLinkResponse, error) {
+	const op = "tz_client.CreateShareLink"
+
+	resp, err := c.api.CreateShareLink(ctx, &tzv1.CreateShareLinkRequest{
+		VersionId:   versionID.String(),
+		ExpiresAt:   timestamppb.New(expiresAt),
+		Permissions: permissions,
+		Caller:      caller.proto(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+// ListShareLinks возвращает ссылки на просмотр версии
+func (c *Client) ListShareLinks(ctx context.Context, versionID uuid.UUID, caller Caller) ([]*tzv1.ShareLink, error) {
+	const op = "tz_client.ListShareLinks"
+
+	resp, err := c.api.ListShareLinks(ctx, &tzv1.ListShareLinksRequest{
+		VersionId: versionID.String(),
+		Caller:    caller.proto(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.ShareLinks, nil
+}
+
+// RevokeShareLink отзывает ссылку на просмотр
+func (c *Client) RevokeShareLink(ctx context.Context, shareLinkID uuid.UUID, caller Caller) (*tzv1.ShareLink, error) {
+	const op = "tz_client.RevokeShareLink"
+
+	resp, err := c.api.RevokeShareLink(ctx, &tzv1.RevokeShareLinkRequest{
+		ShareLinkId: shareLinkID.String(),
+		Caller:      caller.proto(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.ShareLink, nil
+}
+
+// ResolveShareLink проверяет токен ссылки и учитывает её открытие
+func (c *Client) ResolveShareLink(ctx context.Context, token string) (*tzv1.ShareLink, error) {
+	const op = "tz_client.ResolveShareLink"
+
+	resp, err := c.api.ResolveShareLink(ctx, &tzv1.ResolveShareLinkRequest{Token: token})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.ShareLink, nil
+}
@@ -34,6 +34,9 @@ func main() {
 		&cfg.Postgres,
 		&cfg.TelegramBot,
 		&cfg.TelegramClient,
+		&cfg.Worker,
+		&cfg.Metrics,
+		&cfg.Upload,
 	)
 
 	// Запускаем Telegram-бот если он доступен
@@ -45,6 +48,11 @@ func main() {
 
 	application.GRPCServer.MustRun()
 
+	// Запускаем обработчики очереди проверок (в т.ч. подхватывают задачи, оставшиеся после рестарта)
+	application.Worker.MustRun()
+
+	application.Metrics.MustRun()
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 
@@ -60,6 +68,10 @@ func main() {
 
 	application.GRPCServer.Stop()
 
+	application.Worker.Stop()
+
+	application.Metrics.Stop()
+
 	log.Info("server stopped")
 }
 
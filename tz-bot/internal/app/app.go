@@ -14,7 +14,9 @@ import (
 	"time"
 
 	grpcapp "repairCopilotBot/tz-bot/internal/app/grpc"
+	metricsapp "repairCopilotBot/tz-bot/internal/app/metrics"
 	tgapp "repairCopilotBot/tz-bot/internal/app/tg"
+	workerapp "repairCopilotBot/tz-bot/internal/app/worker"
 	"repairCopilotBot/tz-bot/internal/config"
 	"repairCopilotBot/tz-bot/internal/pkg/llm"
 	"repairCopilotBot/tz-bot/internal/pkg/markdown-service"
@@ -32,6 +34,8 @@ type Config struct {
 type App struct {
 	GRPCServer  *grpcapp.App
 	TelegramBot *tgapp.App
+	Worker      *workerapp.App
+	Metrics     *metricsapp.App
 }
 
 func New(
@@ -47,6 +51,9 @@ func New(
 	postgresConfig *postgres.Config,
 	telegramBotConfig *config.TelegramBotConfig,
 	telegramClientConfig *telegramclient.Config,
+	workerConfig *workerapp.Config,
+	metricsConfig *metricsapp.Config,
+	uploadLimits *tzservice.UploadLimits,
 ) *App {
 	postgresConn, err := postgres.NewConnPool(postgresConfig)
 	if err != nil {
@@ -66,7 +73,7 @@ func New(
 		panic(fmt.Errorf("cannot run migrator - %w", err).Error())
 	}
 
-	llmClient := tz_llm_client.NewWithCache(LlmConfig.Url, LlmConfig.Model, postgres)
+	llmClient := tz_llm_client.NewWithCache(log, *LlmConfig, postgres)
 
 	wordParserClient := word_parser_client.New(WordParserConfig.Url)
 
@@ -103,10 +110,14 @@ func New(
 		telegramClient = nil
 	}
 
-	tzService := tzservice.New(log, wordParserClient, docToDocXConverterClient, reportGeneratorClient, markdownClient, llmClient, prompBuilderClient, userServiceClient, telegramClient, s3Client, postgres)
+	tzService := tzservice.New(log, wordParserClient, docToDocXConverterClient, reportGeneratorClient, markdownClient, llmClient, prompBuilderClient, userServiceClient, telegramClient, s3Client, postgres, *uploadLimits)
 
 	grpcApp := grpcapp.New(log, tzService, grpcConfig)
 
+	workerApp := workerapp.New(log, tzService, workerConfig)
+
+	metricsApp := metricsapp.New(log, metricsConfig, llmClient)
+
 	// Создаем Telegram бот
 	telegramBot, err := tgapp.New(log, telegramBotConfig, tzService)
 	if err != nil {
@@ -118,5 +129,7 @@ func New(
 	return &App{
 		GRPCServer:  grpcApp,
 		TelegramBot: telegramBot,
+		Worker:      workerApp,
+		Metrics:     metricsApp,
 	}
 }
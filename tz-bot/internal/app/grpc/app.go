@@ -2,9 +2,11 @@ package grpcapp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +16,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
 	tzservice "repairCopilotBot/tz-bot/internal/service/tz"
 	tzv1 "repairCopilotBot/tz-bot/pkg/tz/v1"
 )
@@ -127,14 +130,21 @@ func (s *serverAPI) CheckTz(ctx context.Context, req *tzv1.CheckTzRequest) (*tzv
 		return nil, status.Error(codes.InvalidArgument, "filename cannot be empty")
 	}
 
-	id, name, createdAt, err := s.tzService.CheckTz(ctx, req.File, req.Filename, requestID)
+	result, err := s.tzService.CheckTz(ctx, req.File, req.Filename, requestID, checkOptionsFromRequest(req.Force, req.CheckProfile, req.GgId))
 	if err != nil {
 		log.Error("failed to check tz", slog.String("error", err.Error()))
 
-		switch err {
-		case tzservice.ErrConvertWordFile:
+		if validationErr := uploadValidationStatus(err); validationErr != nil {
+			return nil, validationErr
+		}
+		if profileErr := checkProfileStatus(err); profileErr != nil {
+			return nil, profileErr
+		}
+
+		switch {
+		case errors.Is(err, tzservice.ErrConvertWordFile):
 			return nil, status.Error(codes.InvalidArgument, "failed to convert word file")
-		case tzservice.ErrLlmAnalyzeFile:
+		case errors.Is(err, tzservice.ErrLlmAnalyzeFile):
 			return nil, status.Error(codes.Internal, "failed to analyze file with LLM")
 		default:
 			return nil, status.Error(codes.Internal, "internal server error")
@@ -142,9 +152,13 @@ func (s *serverAPI) CheckTz(ctx context.Context, req *tzv1.CheckTzRequest) (*tzv
 	}
 
 	return &tzv1.CheckTzResponse{
-		Id:        id.String(),
-		Name:      name,
-		CreatedAt: timestamppb.New(createdAt),
+		Id:                 result.VersionID.String(),
+		Name:               result.Name,
+		CreatedAt:          timestamppb.New(result.CreatedAt),
+		Duplicate:          result.Duplicate,
+		RecheckOfVersionId: uuidPtrToStringPtr(result.RecheckOfVersionID),
+		CheckProfile:       result.CheckProfile,
+		GgId:               int32(result.GgID),
 	}, nil
 
 	//htmlText, css, docId, errors, invalidInstances, fileId, err := s.tzService.GetVersion(ctx, versionID)
@@ -207,6 +221,75 @@ func (s *serverAPI) CheckTz(ctx context.Context, req *tzv1.CheckTzRequest) (*tzv
 //	return string(out)
 //}
 
+func (s *serverAPI) CheckTzVersion(ctx context.Context, req *tzv1.CheckTzVersionRequest) (*tzv1.CheckTzVersionResponse, error) {
+	const op = "grpc.tz.CheckTzVersion"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("user_id", req.UserId),
+		slog.String("technical_specification_id", req.TechnicalSpecificationId),
+	)
+
+	log.Info("processing CheckTzVersion request")
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		log.Error("invalid user ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID format")
+	}
+
+	technicalSpecificationID, err := uuid.Parse(req.TechnicalSpecificationId)
+	if err != nil {
+		log.Error("invalid technical specification ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid technical specification ID format")
+	}
+
+	if len(req.File) == 0 {
+		log.Error("empty file provided")
+		return nil, status.Error(codes.InvalidArgument, "file cannot be empty")
+	}
+
+	if req.Filename == "" {
+		log.Error("empty filename provided")
+		return nil, status.Error(codes.InvalidArgument, "filename cannot be empty")
+	}
+
+	result, err := s.tzService.CheckTzVersion(ctx, req.File, req.Filename, technicalSpecificationID, userID, checkOptionsFromRequest(req.Force, req.CheckProfile, req.GgId))
+	if err != nil {
+		log.Error("failed to check tz version", slog.String("error", err.Error()))
+
+		if validationErr := uploadValidationStatus(err); validationErr != nil {
+			return nil, validationErr
+		}
+		if profileErr := checkProfileStatus(err); profileErr != nil {
+			return nil, profileErr
+		}
+
+		switch {
+		case errors.Is(err, tzservice.ErrTechnicalSpecificationNotFound):
+			return nil, status.Error(codes.NotFound, "technical specification not found")
+		case errors.Is(err, tzservice.ErrAccessDenied):
+			return nil, status.Error(codes.PermissionDenied, "access denied")
+		case errors.Is(err, tzservice.ErrDuplicateVersion):
+			return nil, status.Error(codes.AlreadyExists, "version with this number already exists")
+		default:
+			return nil, status.Error(codes.Internal, "internal server error")
+		}
+	}
+
+	return &tzv1.CheckTzVersionResponse{
+		Id:                       result.VersionID.String(),
+		Name:                     result.Name,
+		CreatedAt:                timestamppb.New(result.CreatedAt),
+		TechnicalSpecificationId: result.TechnicalSpecificationID.String(),
+		VersionNumber:            int32(result.VersionNumber),
+		Duplicate:                result.Duplicate,
+		RecheckOfVersionId:       uuidPtrToStringPtr(result.RecheckOfVersionID),
+		CheckProfile:             result.CheckProfile,
+		GgId:                     int32(result.GgID),
+	}, nil
+}
+
 func (s *serverAPI) GetVersionsMe(ctx context.Context, req *tzv1.GetVersionsMeRequest) (*tzv1.GetVersionsMeResponse, error) {
 	const op = "grpc.tz.GetTechnicalSpecificationVersions"
 
@@ -223,7 +306,12 @@ func (s *serverAPI) GetVersionsMe(ctx context.Context, req *tzv1.GetVersionsMeRe
 		return nil, status.Error(codes.InvalidArgument, "invalid user ID format")
 	}
 
-	versions, err := s.tzService.GetVersionsMe(ctx, userID)
+	var versions []*tzservice.VersionMe
+	if req.Organization {
+		versions, err = s.tzService.GetVersionsOrganization(ctx, userID)
+	} else {
+		versions, err = s.tzService.GetVersionsMe(ctx, userID)
+	}
 	if err != nil {
 		log.Error("failed to get technical specification versions", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "failed to get technical specification versions")
@@ -241,6 +329,8 @@ func (s *serverAPI) GetVersionsMe(ctx context.Context, req *tzv1.GetVersionsMeRe
 			ReportFileLink:             version.ReportFileLink,
 			Status:                     version.Status,
 			Progress:                   int32(version.Progress),
+			TechnicalSpecificationId:   version.TechnicalSpecificationID.String(),
+			UserId:                     version.UserID.String(),
 		}
 	}
 
@@ -356,15 +446,27 @@ func (s *serverAPI) GetVersion(ctx context.Context, req *tzv1.GetVersionRequest)
 		return nil, status.Error(codes.InvalidArgument, "invalid version ID format")
 	}
 
-	statusTz, createdAt, allRubs, allTokens, inspectionDuration, _, css, docId, errorsTz, invalidInstances, fileId, originalFileSize, numberOfErrors, llmReport, progress, err := s.tzService.GetVersion(ctx, versionID)
+	if err := s.authorizeVersion(ctx, versionID, req.Caller, tzservice.SharePermissionView, log); err != nil {
+		return nil, err
+	}
+
+	filter, err := instancesFilterFromRequest(req)
+	if err != nil {
+		log.Error("invalid instances filter", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	statusTz, createdAt, allRubs, allTokens, inspectionDuration, outHTML, css, _, errorsTz, invalidInstances, _, originalFileSize, numberOfErrors, llmReport, progress, err := s.tzService.GetVersion(ctx, versionID, filter)
 	if err != nil {
 		log.Error("failed to get version", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "failed to get version")
 	}
 
-	if statusTz == "in_progress" {
+	// Выполняющаяся, отменённая или упавшая проверка - отчёта нет
+	if statusTz != "completed" {
 		return &tzv1.GetVersionResponse{
-			Status: statusTz,
+			Status:   statusTz,
+			Progress: int32(progress),
 		}, nil
 	}
 
@@ -398,6 +500,8 @@ func (s *serverAPI) GetVersion(ctx context.Context, req *tzv1.GetVersionRequest)
 			OrderNumber:         int32((*errorsTz)[i].OrderNumber),
 			InvalidInstances:    convertInvalidInstances((*errorsTz)[i].InvalidInstances, nil),
 			MissingInstances:    convertMissingInstances((*errorsTz)[i].MissingInstances),
+			CatalogEntryId:      uuidPtrToStringPtr((*errorsTz)[i].CatalogEntryID),
+			CatalogVersion:      intPtrToInt32Ptr((*errorsTz)[i].CatalogVersion),
 		})
 
 		errorsMap[(*errorsTz)[i].ID.String()] = &tzv1.Error{
@@ -421,15 +525,16 @@ func (s *serverAPI) GetVersion(ctx context.Context, req *tzv1.GetVersionRequest)
 
 	numberOfErrrorsInt32 := int32(numberOfErrors)
 
-	reportLink := "/reports/" + docId + ".docx"
-	htmlEmptyTest := ".."
+	// Бакеты docs и reports не публикуются: файлы скачиваются через GetVersionFile
+	reportLink := tzservice.VersionFileLink(versionID, tzservice.VersionFileReport)
+	originalLink := tzservice.VersionFileLink(versionID, tzservice.VersionFileOriginal)
 	resp := &tzv1.GetVersionResponse{
 		InvalidInstances:                 convertInvalidInstances(invalidInstances, nil),
 		Errors:                           errorsResp,
-		HtmlText:                         &htmlEmptyTest,
+		HtmlText:                         &outHTML,
 		Css:                              &css,
 		DocId:                            &reportLink,
-		FileId:                           &fileId,
+		FileId:                           &originalLink,
 		CreatedAt:                        timestamppb.New(createdAt),
 		TotalTokens:                      &allTokens,
 		TotalRubs:                        &allRubs,
@@ -445,6 +550,35 @@ func (s *serverAPI) GetVersion(ctx context.Context, req *tzv1.GetVersionRequest)
 	return resp, nil
 }
 
+func instancesFilterFromRequest(req *tzv1.GetVersionRequest) (tzservice.InstancesFilter, error) {
+	filter := tzservice.InstancesFilter{
+		Priorities: req.Priorities,
+		Sections:   req.Sections,
+		ErrorCodes: req.ErrorCodes,
+	}
+
+	for _, priority := range req.Priorities {
+		switch strings.ToLower(strings.TrimSpace(priority)) {
+		case tzservice.PriorityHigh, tzservice.PriorityMedium, tzservice.PriorityLow:
+		default:
+			return filter, fmt.Errorf("unknown priority %q, expected low, medium or high", priority)
+		}
+	}
+
+	switch req.Sort {
+	case "", "order":
+		filter.Sort = tzservice.InstancesSortOrder
+	case "priority":
+		filter.Sort = tzservice.InstancesSortPriority
+	case "error_code":
+		filter.Sort = tzservice.InstancesSortErrorCode
+	default:
+		return filter, fmt.Errorf("unknown sort %q, expected order, priority or error_code", req.Sort)
+	}
+
+	return filter, nil
+}
+
 func convertInvalidInstances(invalidInstances *[]tzservice.OutInvalidError, errors []*tzv1.Error) []*tzv1.InvalidInstance {
 	if invalidInstances == nil {
 		return []*tzv1.InvalidInstance{}
@@ -501,6 +635,13 @@ func convertInvalidInstances(invalidInstances *[]tzservice.OutInvalidError, erro
 					StartLineNumber:             startLineNumber,
 					EndLineNumber:               endLineNumber,
 					SystemComment:               (*invalidInstances)[i].SystemComment,
+					Located:                     (*invalidInstances)[i].Located,
+					GroundingScore:              (*invalidInstances)[i].GroundingScore,
+					Priority:                    (*invalidInstances)[i].Priority,
+					Risks:                       (*invalidInstances)[i].Risks,
+					Sections:                    stringsPtrToSlice((*invalidInstances)[i].Sections),
+					WhatIsIncorrect:             (*invalidInstances)[i].WhatIsIncorrect,
+					PageNumber:                  intPtrToInt32Ptr((*invalidInstances)[i].PageNumber),
 					OrderNumber:                 int32((*invalidInstances)[i].OrderNumber),
 					ParentError:                 parentError,
 					FeedbackExists:              (*invalidInstances)[i].FeedbackExists,
@@ -518,6 +659,13 @@ func convertInvalidInstances(invalidInstances *[]tzservice.OutInvalidError, erro
 	}
 }
 
+func stringsPtrToSlice(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
+
 func convertMissingInstances(missingInstances *[]tzservice.OutMissingError) []*tzv1.MissingInstance {
 	if missingInstances == nil {
 		return []*tzv1.MissingInstance{}
@@ -542,6 +690,10 @@ func convertMissingInstances(missingInstances *[]tzservice.OutMissingError) []*t
 					ErrorId:                     (*missingInstances)[i].ErrorID.String(),
 					SuggestedFix:                (*missingInstances)[i].SuggestedFix,
 					Rationale:                   (*missingInstances)[i].Rationale,
+					Priority:                    (*missingInstances)[i].Priority,
+					Risks:                       (*missingInstances)[i].Risks,
+					Sections:                    stringsPtrToSlice((*missingInstances)[i].Sections),
+					WhatIsIncorrect:             (*missingInstances)[i].WhatIsIncorrect,
 					FeedbackExists:              (*missingInstances)[i].FeedbackExists,
 					FeedbackMark:                (*missingInstances)[i].FeedbackMark,
 					FeedbackComment:             (*missingInstances)[i].FeedbackComment,
@@ -587,6 +739,16 @@ func (s *serverAPI) NewFeedbackError(ctx context.Context, req *tzv1.NewFeedbackE
 		return nil, status.Error(codes.InvalidArgument, "instance_type must be 'invalid' or 'missing'")
 	}
 
+	caller, err := callerFromRequest(req.Caller)
+	if err != nil {
+		log.Warn("request without valid caller", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := accessStatus(s.tzService.AuthorizeInstance(ctx, instanceID, req.InstanceType, caller), log); err != nil {
+		return nil, err
+	}
+
 	if req.IsVerification {
 		err = s.tzService.NewVerificationFeedbackError(ctx, instanceID, req.InstanceType, req.FeedbackMark, req.FeedbackComment, userID)
 		if err != nil {
@@ -726,3 +888,1014 @@ func (s *serverAPI) GetFeedbacks(ctx context.Context, req *tzv1.GetFeedbacksRequ
 		Feedbacks: feedbacksOut,
 	}, nil
 }
+
+func (s *serverAPI) CompareVersions(ctx context.Context, req *tzv1.CompareVersionsRequest) (*tzv1.CompareVersionsResponse, error) {
+	const op = "grpc.tz.CompareVersions"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("version_a_id", req.VersionAId),
+		slog.String("version_b_id", req.VersionBId),
+	)
+
+	log.Info("processing CompareVersions request")
+
+	versionAID, err := uuid.Parse(req.VersionAId)
+	if err != nil {
+		log.Error("invalid version a ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid version_a_id format")
+	}
+
+	versionBID, err := uuid.Parse(req.VersionBId)
+	if err != nil {
+		log.Error("invalid version b ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid version_b_id format")
+	}
+
+	for _, versionID := range []uuid.UUID{versionAID, versionBID} {
+		if err := s.authorizeVersion(ctx, versionID, req.Caller, tzservice.SharePermissionView, log); err != nil {
+			return nil, err
+		}
+	}
+
+	comparison, err := s.tzService.CompareVersions(ctx, versionAID, versionBID)
+	if err != nil {
+		log.Error("failed to compare versions", slog.String("error", err.Error()))
+
+		switch {
+		case errors.Is(err, tzservice.ErrVersionNotFound):
+			return nil, status.Error(codes.NotFound, "version not found")
+		case errors.Is(err, tzservice.ErrVersionNotCompleted):
+			return nil, status.Error(codes.FailedPrecondition, "version processing is not completed")
+		case errors.Is(err, tzservice.ErrVersionsFromDifferentSpecs):
+			return nil, status.Error(codes.InvalidArgument, "versions belong to different technical specifications")
+		default:
+			return nil, status.Error(codes.Internal, "failed to compare versions")
+		}
+	}
+
+	log.Info("CompareVersions request processed successfully")
+
+	return &tzv1.CompareVersionsResponse{
+		VersionAId:     comparison.VersionA.ID.String(),
+		VersionANumber: int32(comparison.VersionA.VersionNumber),
+		VersionBId:     comparison.VersionB.ID.String(),
+		VersionBNumber: int32(comparison.VersionB.VersionNumber),
+		Fixed:          convertInstanceComparisons(comparison.Fixed),
+		Persisting:     convertInstanceComparisons(comparison.Persisting),
+		New:            convertInstanceComparisons(comparison.New),
+	}, nil
+}
+
+func convertInstanceComparisons(comparisons []tzservice.InstanceComparison) []*tzv1.InstanceComparison {
+	respComparisons := make([]*tzv1.InstanceComparison, 0, len(comparisons))
+
+	for i := range comparisons {
+		respComparison := &tzv1.InstanceComparison{
+			ErrorCode:  comparisons[i].ErrorCode,
+			Similarity: comparisons[i].Similarity,
+		}
+
+		if comparisons[i].InstanceA != nil {
+			respComparison.InstanceA = convertInvalidInstances(&[]tzservice.OutInvalidError{*comparisons[i].InstanceA}, nil)[0]
+		}
+
+		if comparisons[i].InstanceB != nil {
+			respComparison.InstanceB = convertInvalidInstances(&[]tzservice.OutInvalidError{*comparisons[i].InstanceB}, nil)[0]
+		}
+
+		respComparisons = append(respComparisons, respComparison)
+	}
+
+	return respComparisons
+}
+
+func (s *serverAPI) RetryVersion(ctx context.Context, req *tzv1.RetryVersionRequest) (*tzv1.RetryVersionResponse, error) {
+	const op = "grpc.tz.RetryVersion"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("version_id", req.VersionId),
+		slog.String("from_stage", req.FromStage),
+	)
+
+	log.Info("processing RetryVersion request")
+
+	versionID, err := uuid.Parse(req.VersionId)
+	if err != nil {
+		log.Error("invalid version ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid version_id format")
+	}
+
+	if err := s.authorizeVersion(ctx, versionID, req.Caller, "", log); err != nil {
+		return nil, err
+	}
+
+	resumeStage, err := s.tzService.RetryVersion(ctx, versionID, req.FromStage)
+	if err != nil {
+		log.Error("failed to retry version", slog.String("error", err.Error()))
+
+		switch {
+		case errors.Is(err, tzservice.ErrInvalidStage):
+			return nil, status.Error(codes.InvalidArgument, "invalid from_stage")
+		case errors.Is(err, tzservice.ErrVersionNotFound):
+			return nil, status.Error(codes.NotFound, "version not found")
+		case errors.Is(err, tzservice.ErrVersionNotRetryable):
+			return nil, status.Error(codes.FailedPrecondition, "only failed or cancelled versions can be retried")
+		default:
+			return nil, status.Error(codes.Internal, "failed to retry version")
+		}
+	}
+
+	log.Info("RetryVersion request processed successfully", slog.String("resume_stage", resumeStage))
+
+	return &tzv1.RetryVersionResponse{
+		VersionId:   versionID.String(),
+		ResumeStage: resumeStage,
+	}, nil
+}
+
+func (s *serverAPI) CancelVersion(ctx context.Context, req *tzv1.CancelVersionRequest) (*tzv1.CancelVersionResponse, error) {
+	const op = "grpc.tz.CancelVersion"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("version_id", req.VersionId),
+		slog.String("user_id", req.UserId),
+	)
+
+	log.Info("processing CancelVersion request")
+
+	versionID, err := uuid.Parse(req.VersionId)
+	if err != nil {
+		log.Error("invalid version ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid version_id format")
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		log.Error("invalid user ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id format")
+	}
+
+	err = s.tzService.CancelVersion(ctx, versionID, userID)
+	if err != nil {
+		log.Error("failed to cancel version", slog.String("error", err.Error()))
+
+		switch {
+		case errors.Is(err, tzservice.ErrVersionNotFound):
+			return nil, status.Error(codes.NotFound, "version not found")
+		case errors.Is(err, tzservice.ErrAccessDenied):
+			return nil, status.Error(codes.PermissionDenied, "access denied")
+		case errors.Is(err, tzservice.ErrVersionNotCancellable):
+			return nil, status.Error(codes.FailedPrecondition, "only versions in progress can be cancelled")
+		default:
+			return nil, status.Error(codes.Internal, "failed to cancel version")
+		}
+	}
+
+	log.Info("CancelVersion request processed successfully")
+
+	return &tzv1.CancelVersionResponse{
+		VersionId: versionID.String(),
+		Status:    "cancelled",
+	}, nil
+}
+
+func (s *serverAPI) WatchVersion(req *tzv1.WatchVersionRequest, stream grpc.ServerStreamingServer[tzv1.VersionEvent]) error {
+	const op = "grpc.tz.WatchVersion"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("version_id", req.VersionId),
+		slog.Int64("after_event_id", req.AfterEventId),
+	)
+
+	log.Info("processing WatchVersion request")
+
+	versionID, err := uuid.Parse(req.VersionId)
+	if err != nil {
+		log.Error("invalid version ID format", slog.String("error", err.Error()))
+		return status.Error(codes.InvalidArgument, "invalid version_id format")
+	}
+
+	if err := s.authorizeVersion(stream.Context(), versionID, req.Caller, tzservice.SharePermissionView, log); err != nil {
+		return err
+	}
+
+	err = s.tzService.WatchVersion(stream.Context(), versionID, req.AfterEventId, func(event *tzservice.VersionEvent) error {
+		return stream.Send(convertVersionEvent(event))
+	})
+	if err != nil {
+		if stream.Context().Err() != nil {
+			log.Info("WatchVersion stream closed by client")
+			return status.Error(codes.Canceled, "stream closed")
+		}
+
+		log.Error("failed to watch version", slog.String("error", err.Error()))
+
+		if errors.Is(err, tzservice.ErrVersionNotFound) {
+			return status.Error(codes.NotFound, "version not found")
+		}
+		return status.Error(codes.Internal, "failed to watch version")
+	}
+
+	log.Info("WatchVersion request processed successfully")
+
+	return nil
+}
+
+func convertVersionEvent(event *tzservice.VersionEvent) *tzv1.VersionEvent {
+	respEvent := &tzv1.VersionEvent{
+		Id:        event.ID,
+		VersionId: event.VersionID.String(),
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+
+	switch event.Type {
+	case tzservice.VersionEventStageStarted:
+		respEvent.Event = &tzv1.VersionEvent_StageStarted{StageStarted: &tzv1.StageStartedEvent{
+			Stage: event.Stage,
+		}}
+	case tzservice.VersionEventStageFinished:
+		respEvent.Event = &tzv1.VersionEvent_StageFinished{StageFinished: &tzv1.StageFinishedEvent{
+			Stage:    event.Stage,
+			Restored: event.Restored,
+		}}
+	case tzservice.VersionEventLlmGroupProgress:
+		respEvent.Event = &tzv1.VersionEvent_LlmGroupProgress{LlmGroupProgress: &tzv1.LlmGroupProgressEvent{
+			GroupIndex: int32(event.GroupIndex),
+			GroupTotal: int32(event.GroupTotal),
+		}}
+	case tzservice.VersionEventCost:
+		respEvent.Event = &tzv1.VersionEvent_Cost{Cost: &tzv1.CostEvent{
+			Rubs:   event.Rubs,
+			Tokens: event.Tokens,
+		}}
+	case tzservice.VersionEventFinalStatus:
+		respEvent.Event = &tzv1.VersionEvent_FinalStatus{FinalStatus: &tzv1.FinalStatusEvent{
+			Status: event.Status,
+		}}
+	}
+
+	return respEvent
+}
+
+func (s *serverAPI) InvalidateLlmCache(ctx context.Context, req *tzv1.InvalidateLlmCacheRequest) (*tzv1.InvalidateLlmCacheResponse, error) {
+	const op = "grpc.tz.InvalidateLlmCache"
+
+	log := s.log.With(slog.String("op", op))
+
+	log.Info("processing InvalidateLlmCache request")
+
+	filter := tzservice.LlmCacheFilter{
+		Model: req.Model,
+	}
+	if req.GgId != nil {
+		ggID := int(*req.GgId)
+		filter.GgID = &ggID
+	}
+	if req.From != nil {
+		from := req.From.AsTime()
+		filter.From = &from
+	}
+	if req.To != nil {
+		to := req.To.AsTime()
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	deleted, err := s.tzService.InvalidateLlmCache(ctx, filter)
+	if err != nil {
+		log.Error("failed to invalidate llm cache", slog.String("error", err.Error()))
+
+		if errors.Is(err, tzservice.ErrEmptyCacheFilter) {
+			return nil, status.Error(codes.InvalidArgument, "at least one of model, gg_id, from, to must be set")
+		}
+		return nil, status.Error(codes.Internal, "failed to invalidate llm cache")
+	}
+
+	log.Info("InvalidateLlmCache request processed successfully", slog.Int64("deleted", deleted))
+
+	return &tzv1.InvalidateLlmCacheResponse{
+		Deleted: deleted,
+	}, nil
+}
+
+func (s *serverAPI) GetVersionCostBreakdown(ctx context.Context, req *tzv1.GetVersionCostBreakdownRequest) (*tzv1.GetVersionCostBreakdownResponse, error) {
+	const op = "grpc.tz.GetVersionCostBreakdown"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("version_id", req.VersionId),
+	)
+
+	log.Info("processing GetVersionCostBreakdown request")
+
+	versionID, err := uuid.Parse(req.VersionId)
+	if err != nil {
+		log.Error("invalid version ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid version_id format")
+	}
+
+	if err := s.authorizeVersion(ctx, versionID, req.Caller, "", log); err != nil {
+		return nil, err
+	}
+
+	breakdown, err := s.tzService.GetVersionCostBreakdown(ctx, versionID)
+	if err != nil {
+		log.Error("failed to get version cost breakdown", slog.String("error", err.Error()))
+
+		if errors.Is(err, tzservice.ErrVersionNotFound) {
+			return nil, status.Error(codes.NotFound, "version not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get version cost breakdown")
+	}
+
+	groups := make([]*tzv1.LlmCostGroup, 0, len(breakdown.Groups))
+	for i := range breakdown.Groups {
+		groups = append(groups, convertLlmCostGroup(&breakdown.Groups[i]))
+	}
+
+	resp := &tzv1.GetVersionCostBreakdownResponse{
+		VersionId: breakdown.VersionID.String(),
+		Total:     convertLlmCostGroup(&breakdown.Total),
+		Groups:    groups,
+	}
+
+	if req.IncludeCalls {
+		resp.Calls = make([]*tzv1.LlmCall, 0, len(breakdown.Calls))
+		for _, call := range breakdown.Calls {
+			resp.Calls = append(resp.Calls, &tzv1.LlmCall{
+				Id:               call.ID,
+				Step:             int32(call.Step),
+				GroupId:          intPtrToInt32Ptr(call.GroupID),
+				GroupName:        call.GroupName,
+				Model:            call.Model,
+				PromptTokens:     int32(call.PromptTokens),
+				CompletionTokens: int32(call.CompletionTokens),
+				TotalTokens:      int32(call.TotalTokens),
+				Rubs:             call.Rubs,
+				DurationMs:       call.DurationMs,
+				CacheHit:         call.CacheHit,
+				Attempts:         int32(call.Attempts),
+				Error:            call.Error,
+				CreatedAt:        timestamppb.New(call.CreatedAt),
+			})
+		}
+	}
+
+	log.Info("GetVersionCostBreakdown request processed successfully",
+		slog.Int("groups", len(groups)),
+		slog.Float64("rubs", breakdown.Total.Rubs))
+
+	return resp, nil
+}
+
+func (s *serverAPI) ExportAnnotatedDocx(ctx context.Context, req *tzv1.ExportAnnotatedDocxRequest) (*tzv1.ExportAnnotatedDocxResponse, error) {
+	const op = "grpc.tz.ExportAnnotatedDocx"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("version_id", req.VersionId),
+	)
+
+	log.Info("processing ExportAnnotatedDocx request")
+
+	versionID, err := uuid.Parse(req.VersionId)
+	if err != nil {
+		log.Error("invalid version ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid version_id format")
+	}
+
+	if err := s.authorizeVersion(ctx, versionID, req.Caller, tzservice.SharePermissionExport, log); err != nil {
+		return nil, err
+	}
+
+	docx, err := s.tzService.ExportAnnotatedDocx(ctx, versionID)
+	if err != nil {
+		log.Error("failed to export annotated docx", slog.String("error", err.Error()))
+
+		if errors.Is(err, tzservice.ErrVersionNotFound) {
+			return nil, status.Error(codes.NotFound, "version not found")
+		}
+		if errors.Is(err, tzservice.ErrVersionNotCompleted) || errors.Is(err, tzservice.ErrAnnotatedHtmlUnavailable) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to export annotated docx")
+	}
+
+	log.Info("ExportAnnotatedDocx request processed successfully",
+		slog.String("file_id", docx.FileID),
+		slog.Int("size", len(docx.Content)))
+
+	return &tzv1.ExportAnnotatedDocxResponse{
+		FileName: docx.FileName,
+		FileLink: "/api/tz/" + versionID.String() + "/annotated-docx",
+		Content:  docx.Content,
+	}, nil
+}
+
+func (s *serverAPI) GetVersionFile(ctx context.Context, req *tzv1.GetVersionFileRequest) (*tzv1.GetVersionFileResponse, error) {
+	const op = "grpc.tz.GetVersionFile"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("version_id", req.VersionId),
+		slog.String("kind", req.Kind),
+	)
+
+	log.Info("processing GetVersionFile request")
+
+	versionID, err := uuid.Parse(req.VersionId)
+	if err != nil {
+		log.Error("invalid version ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid version_id format")
+	}
+
+	if req.Kind != tzservice.VersionFileOriginal && req.Kind != tzservice.VersionFileReport {
+		return nil, status.Error(codes.InvalidArgument, "invalid kind, expected original or report")
+	}
+
+	if err := s.authorizeVersion(ctx, versionID, req.Caller, tzservice.VersionFilePermission(req.Kind), log); err != nil {
+		return nil, err
+	}
+
+	file, err := s.tzService.GetVersionFile(ctx, versionID, req.Kind)
+	if err != nil {
+		log.Error("failed to get version file", slog.String("error", err.Error()))
+
+		switch {
+		case errors.Is(err, tzservice.ErrVersionNotFound):
+			return nil, status.Error(codes.NotFound, "version not found")
+		case errors.Is(err, tzservice.ErrVersionFileUnavailable):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, "failed to get version file")
+		}
+	}
+
+	log.Info("GetVersionFile request processed successfully", slog.Int("size", len(file.Content)))
+
+	return &tzv1.GetVersionFileResponse{
+		FileName:    file.FileName,
+		ContentType: file.ContentType,
+		Content:     file.Content,
+	}, nil
+}
+
+func (s *serverAPI) ExportVersion(ctx context.Context, req *tzv1.ExportVersionRequest) (*tzv1.ExportVersionResponse, error) {
+	const op = "grpc.tz.ExportVersion"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("version_id", req.VersionId),
+		slog.String("format", req.Format),
+	)
+
+	log.Info("processing ExportVersion request")
+
+	versionID, err := uuid.Parse(req.VersionId)
+	if err != nil {
+		log.Error("invalid version ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid version_id format")
+	}
+
+	if err := s.authorizeVersion(ctx, versionID, req.Caller, tzservice.SharePermissionExport, log); err != nil {
+		return nil, err
+	}
+
+	export, err := s.tzService.ExportVersion(ctx, versionID, req.Format)
+	if err != nil {
+		log.Error("failed to export version", slog.String("error", err.Error()))
+
+		if errors.Is(err, tzservice.ErrUnsupportedExportFormat) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, tzservice.ErrVersionNotFound) {
+			return nil, status.Error(codes.NotFound, "version not found")
+		}
+		if errors.Is(err, tzservice.ErrVersionNotCompleted) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to export version")
+	}
+
+	log.Info("ExportVersion request processed successfully", slog.Int("size", len(export.Content)))
+
+	return &tzv1.ExportVersionResponse{
+		FileName:      export.FileName,
+		ContentType:   export.ContentType,
+		Content:       export.Content,
+		SchemaVersion: tzservice.ExportSchemaVersion,
+	}, nil
+}
+
+// callerFromRequest разбирает пользователя, от имени которого выполняется запрос.
+// Без пользователя результаты проверки не выдаются
+func callerFromRequest(caller *tzv1.Caller) (tzservice.Caller, error) {
+	if caller == nil {
+		return tzservice.Caller{}, status.Error(codes.Unauthenticated, "caller is required")
+	}
+
+	// Посетитель по ссылке не имеет учётной записи, доступ определяет токен
+	if caller.Role == tzservice.CallerRoleShared {
+		if caller.ShareToken == "" {
+			return tzservice.Caller{}, status.Error(codes.Unauthenticated, "share_token is required")
+		}
+		return tzservice.Caller{Role: caller.Role, ShareToken: caller.ShareToken}, nil
+	}
+
+	userID, err := uuid.Parse(caller.UserId)
+	if err != nil {
+		return tzservice.Caller{}, status.Error(codes.Unauthenticated, "invalid caller user_id format")
+	}
+
+	switch caller.Role {
+	case tzservice.CallerRoleUser, tzservice.CallerRoleAdmin:
+	default:
+		return tzservice.Caller{}, status.Error(codes.Unauthenticated, "invalid caller role")
+	}
+
+	return tzservice.Caller{UserID: userID, Role: caller.Role}, nil
+}
+
+// authorizeVersion проверяет, что пользователь запроса владеет версией, является администратором
+// или открыл ссылку с правом permission
+func (s *serverAPI) authorizeVersion(ctx context.Context, versionID uuid.UUID, reqCaller *tzv1.Caller, permission string, log *slog.Logger) error {
+	caller, err := callerFromRequest(reqCaller)
+	if err != nil {
+		log.Warn("request without valid caller", slog.String("error", err.Error()))
+		return err
+	}
+
+	return accessStatus(s.tzService.AuthorizeVersion(ctx, versionID, caller, permission), log)
+}
+
+// accessStatus переводит результат проверки доступа в gRPC-статус
+func accessStatus(err error, log *slog.Logger) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, tzservice.ErrVersionNotFound):
+		return status.Error(codes.NotFound, "version not found")
+	case errors.Is(err, tzservice.ErrInstanceNotFound):
+		return status.Error(codes.NotFound, "instance not found")
+	case errors.Is(err, tzservice.ErrShareLinkNotFound):
+		return status.Error(codes.NotFound, "share link not found")
+	case errors.Is(err, tzservice.ErrAccessDenied):
+		return status.Error(codes.PermissionDenied, "access denied")
+	default:
+		log.Error("failed to check access", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, "failed to check access")
+	}
+}
+
+// uploadValidationStatus переводит отклонение загруженного файла в gRPC-статус с сообщением
+// для пользователя: неподдерживаемый формат - InvalidArgument, остальные причины - FailedPrecondition.
+// Для остальных ошибок возвращает nil
+func uploadValidationStatus(err error) error {
+	var validationErr *tzservice.UploadValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+
+	code := codes.FailedPrecondition
+	if validationErr.Reason == tzservice.UploadRejectUnsupportedFormat {
+		code = codes.InvalidArgument
+	}
+
+	return status.Error(code, validationErr.Message)
+}
+
+func (s *serverAPI) ListCheckProfiles(ctx context.Context, _ *tzv1.ListCheckProfilesRequest) (*tzv1.ListCheckProfilesResponse, error) {
+	const op = "grpc.tz.ListCheckProfiles"
+
+	log := s.log.With(slog.String("op", op))
+
+	profiles, err := s.tzService.ListCheckProfiles(ctx)
+	if err != nil {
+		log.Error("failed to list check profiles", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to list check profiles")
+	}
+
+	resp := &tzv1.ListCheckProfilesResponse{
+		Profiles: make([]*tzv1.CheckProfile, 0, len(profiles)),
+	}
+	for i := range profiles {
+		resp.Profiles = append(resp.Profiles, convertCheckProfile(&profiles[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) CreateCheckProfile(ctx context.Context, req *tzv1.CreateCheckProfileRequest) (*tzv1.CheckProfileResponse, error) {
+	const op = "grpc.tz.CreateCheckProfile"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("name", req.Name),
+	)
+
+	log.Info("processing CreateCheckProfile request")
+
+	ggID := int(req.GgId)
+	profile, err := s.tzService.CreateCheckProfile(ctx, tzservice.CheckProfileParams{
+		Name:        req.Name,
+		Title:       &req.Title,
+		Description: req.Description,
+		GgID:        &ggID,
+	})
+	if err != nil {
+		log.Error("failed to create check profile", slog.String("error", err.Error()))
+
+		if profileErr := checkProfileStatus(err); profileErr != nil {
+			return nil, profileErr
+		}
+		return nil, status.Error(codes.Internal, "failed to create check profile")
+	}
+
+	return &tzv1.CheckProfileResponse{Profile: convertCheckProfile(profile)}, nil
+}
+
+func (s *serverAPI) UpdateCheckProfile(ctx context.Context, req *tzv1.UpdateCheckProfileRequest) (*tzv1.CheckProfileResponse, error) {
+	const op = "grpc.tz.UpdateCheckProfile"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("name", req.Name),
+	)
+
+	log.Info("processing UpdateCheckProfile request")
+
+	profile, err := s.tzService.UpdateCheckProfile(ctx, tzservice.CheckProfileParams{
+		Name:        req.Name,
+		Title:       req.Title,
+		Description: req.Description,
+		GgID:        int32PtrToIntPtr(req.GgId),
+	})
+	if err != nil {
+		log.Error("failed to update check profile", slog.String("error", err.Error()))
+
+		if profileErr := checkProfileStatus(err); profileErr != nil {
+			return nil, profileErr
+		}
+		return nil, status.Error(codes.Internal, "failed to update check profile")
+	}
+
+	return &tzv1.CheckProfileResponse{Profile: convertCheckProfile(profile)}, nil
+}
+
+func (s *serverAPI) DeleteCheckProfile(ctx context.Context, req *tzv1.DeleteCheckProfileRequest) (*tzv1.DeleteCheckProfileResponse, error) {
+	const op = "grpc.tz.DeleteCheckProfile"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("name", req.Name),
+	)
+
+	log.Info("processing DeleteCheckProfile request")
+
+	if err := s.tzService.DeleteCheckProfile(ctx, req.Name); err != nil {
+		log.Error("failed to delete check profile", slog.String("error", err.Error()))
+
+		if profileErr := checkProfileStatus(err); profileErr != nil {
+			return nil, profileErr
+		}
+		return nil, status.Error(codes.Internal, "failed to delete check profile")
+	}
+
+	return &tzv1.DeleteCheckProfileResponse{}, nil
+}
+
+func (s *serverAPI) SetDefaultCheckProfile(ctx context.Context, req *tzv1.SetDefaultCheckProfileRequest) (*tzv1.CheckProfileResponse, error) {
+	const op = "grpc.tz.SetDefaultCheckProfile"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("name", req.Name),
+	)
+
+	log.Info("processing SetDefaultCheckProfile request")
+
+	profile, err := s.tzService.SetDefaultCheckProfile(ctx, req.Name)
+	if err != nil {
+		log.Error("failed to set default check profile", slog.String("error", err.Error()))
+
+		if profileErr := checkProfileStatus(err); profileErr != nil {
+			return nil, profileErr
+		}
+		return nil, status.Error(codes.Internal, "failed to set default check profile")
+	}
+
+	return &tzv1.CheckProfileResponse{Profile: convertCheckProfile(profile)}, nil
+}
+
+// ListErrorCatalog возвращает записи справочника ошибок с фильтром по группе и коду
+func (s *serverAPI) ListErrorCatalog(ctx context.Context, req *tzv1.ListErrorCatalogRequest) (*tzv1.ListErrorCatalogResponse, error) {
+	const op = "grpc.tz.ListErrorCatalog"
+
+	log := s.log.With(slog.String("op", op))
+
+	filter := modelrepo.ErrorCatalogFilter{
+		GgID:           int32PtrToIntPtr(req.GgId),
+		IncludeHistory: req.IncludeHistory,
+	}
+	if req.Code != nil {
+		filter.Code = *req.Code
+	}
+
+	entries, err := s.tzService.ListErrorCatalog(ctx, filter)
+	if err != nil {
+		log.Error("failed to list error catalog", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to list error catalog")
+	}
+
+	resp := &tzv1.ListErrorCatalogResponse{
+		Entries: make([]*tzv1.ErrorCatalogEntry, 0, len(entries)),
+	}
+	for i := range entries {
+		resp.Entries = append(resp.Entries, convertErrorCatalogEntry(&entries[i]))
+	}
+
+	return resp, nil
+}
+
+// GetErrorCatalogEntry возвращает запись справочника ошибок по идентификатору или коду
+func (s *serverAPI) GetErrorCatalogEntry(ctx context.Context, req *tzv1.GetErrorCatalogEntryRequest) (*tzv1.GetErrorCatalogEntryResponse, error) {
+	const op = "grpc.tz.GetErrorCatalogEntry"
+
+	log := s.log.With(slog.String("op", op))
+
+	query := tzservice.ErrorCatalogQuery{
+		GgID:    int32PtrToIntPtr(req.GgId),
+		Version: int32PtrToIntPtr(req.Version),
+	}
+	if req.Id != nil {
+		id, err := uuid.Parse(*req.Id)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid id format")
+		}
+		query.ID = &id
+	}
+	if req.Code != nil {
+		query.Code = *req.Code
+	}
+
+	entry, err := s.tzService.GetErrorCatalogEntry(ctx, query)
+	if err != nil {
+		switch {
+		case errors.Is(err, tzservice.ErrErrorCatalogEntryNotFound):
+			return nil, status.Error(codes.NotFound, "error catalog entry not found")
+		case errors.Is(err, tzservice.ErrInvalidErrorCatalogQuery):
+			return nil, status.Error(codes.InvalidArgument, "id or code is required")
+		}
+		log.Error("failed to get error catalog entry", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to get error catalog entry")
+	}
+
+	return &tzv1.GetErrorCatalogEntryResponse{Entry: convertErrorCatalogEntry(entry)}, nil
+}
+
+// checkProfileStatus переводит ошибки профилей проверки в коды gRPC, для остальных ошибок возвращает nil
+func checkProfileStatus(err error) error {
+	switch {
+	case errors.Is(err, tzservice.ErrCheckProfileNotFound):
+		return status.Error(codes.NotFound, "check profile not found")
+	case errors.Is(err, tzservice.ErrCheckProfileExists):
+		return status.Error(codes.AlreadyExists, "check profile with this name already exists")
+	case errors.Is(err, tzservice.ErrDefaultCheckProfile):
+		return status.Error(codes.FailedPrecondition, "default check profile cannot be deleted")
+	case errors.Is(err, tzservice.ErrInvalidCheckProfile):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+func checkOptionsFromRequest(force bool, profile *string, ggID *int32) tzservice.CheckOptions {
+	opts := tzservice.CheckOptions{
+		Force: force,
+		GgID:  int32PtrToIntPtr(ggID),
+	}
+	if profile != nil {
+		opts.Profile = *profile
+	}
+	return opts
+}
+
+func convertCheckProfile(profile *modelrepo.CheckProfile) *tzv1.CheckProfile {
+	return &tzv1.CheckProfile{
+		Id:          profile.ID.String(),
+		Name:        profile.Name,
+		Title:       profile.Title,
+		Description: profile.Description,
+		GgId:        int32(profile.GgID),
+		IsDefault:   profile.IsDefault,
+		CreatedAt:   timestamppb.New(profile.CreatedAt),
+		UpdatedAt:   timestamppb.New(profile.UpdatedAt),
+	}
+}
+
+func convertErrorCatalogEntry(entry *modelrepo.ErrorCatalogEntry) *tzv1.ErrorCatalogEntry {
+	resp := &tzv1.ErrorCatalogEntry{
+		Id:          entry.ID.String(),
+		GgId:        int32(entry.GgID),
+		Code:        entry.Code,
+		Version:     int32(entry.Version),
+		Name:        entry.Name,
+		Description: entry.Description,
+		Detector:    entry.Detector,
+		IsCurrent:   entry.IsCurrent,
+		CreatedAt:   timestamppb.New(entry.CreatedAt),
+	}
+	if entry.SupersededAt != nil {
+		resp.SupersededAt = timestamppb.New(*entry.SupersededAt)
+	}
+	return resp
+}
+
+func int32PtrToIntPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	converted := int(*v)
+	return &converted
+}
+
+func uuidPtrToStringPtr(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	v := id.String()
+	return &v
+}
+
+func convertLlmCostGroup(group *tzservice.LlmCostGroup) *tzv1.LlmCostGroup {
+	return &tzv1.LlmCostGroup{
+		Step:             int32(group.Step),
+		GroupId:          intPtrToInt32Ptr(group.GroupID),
+		GroupName:        group.GroupName,
+		Calls:            int32(group.Calls),
+		CacheHits:        int32(group.CacheHits),
+		FailedCalls:      int32(group.FailedCalls),
+		PromptTokens:     group.PromptTokens,
+		CompletionTokens: group.CompletionTokens,
+		TotalTokens:      group.TotalTokens,
+		Rubs:             group.Rubs,
+		DurationMs:       group.DurationMs,
+	}
+}
+
+func intPtrToInt32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	converted := int32(*v)
+	return &converted
+}
+
+func (s *serverAPI) CreateShareLink(ctx context.Context, req *tzv1.CreateShareLinkRequest) (*tzv1.CreateShareLinkResponse, error) {
+	const op = "grpc.tz.CreateShareLink"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("version_id", req.VersionId),
+	)
+
+	log.Info("processing CreateShareLink request")
+
+	versionID, err := uuid.Parse(req.VersionId)
+	if err != nil {
+		log.Error("invalid version ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid version_id format")
+	}
+
+	if req.ExpiresAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "expires_at is required")
+	}
+
+	caller, err := callerFromRequest(req.Caller)
+	if err != nil {
+		return nil, err
+	}
+
+	link, token, err := s.tzService.CreateShareLink(ctx, versionID, req.ExpiresAt.AsTime(), req.Permissions, caller)
+	if err != nil {
+		if errors.Is(err, tzservice.ErrInvalidShareLink) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, accessStatus(err, log)
+	}
+
+	log.Info("CreateShareLink request processed successfully", slog.String("share_link_id", link.ID.String()))
+
+	return &tzv1.CreateShareLinkResponse{
+		ShareLink: convertShareLink(link),
+		Token:     token,
+	}, nil
+}
+
+func (s *serverAPI) ListShareLinks(ctx context.Context, req *tzv1.ListShareLinksRequest) (*tzv1.ListShareLinksResponse, error) {
+	const op = "grpc.tz.ListShareLinks"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("version_id", req.VersionId),
+	)
+
+	versionID, err := uuid.Parse(req.VersionId)
+	if err != nil {
+		log.Error("invalid version ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid version_id format")
+	}
+
+	caller, err := callerFromRequest(req.Caller)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.tzService.ListShareLinks(ctx, versionID, caller)
+	if err != nil {
+		return nil, accessStatus(err, log)
+	}
+
+	resp := &tzv1.ListShareLinksResponse{
+		ShareLinks: make([]*tzv1.ShareLink, 0, len(links)),
+	}
+	for i := range links {
+		resp.ShareLinks = append(resp.ShareLinks, convertShareLink(&links[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) RevokeShareLink(ctx context.Context, req *tzv1.RevokeShareLinkRequest) (*tzv1.RevokeShareLinkResponse, error) {
+	const op = "grpc.tz.RevokeShareLink"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("share_link_id", req.ShareLinkId),
+	)
+
+	log.Info("processing RevokeShareLink request")
+
+	shareLinkID, err := uuid.Parse(req.ShareLinkId)
+	if err != nil {
+		log.Error("invalid share link ID format", slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, "invalid share_link_id format")
+	}
+
+	caller, err := callerFromRequest(req.Caller)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.tzService.RevokeShareLink(ctx, shareLinkID, caller)
+	if err != nil {
+		return nil, accessStatus(err, log)
+	}
+
+	log.Info("RevokeShareLink request processed successfully")
+
+	return &tzv1.RevokeShareLinkResponse{ShareLink: convertShareLink(link)}, nil
+}
+
+func (s *serverAPI) ResolveShareLink(ctx context.Context, req *tzv1.ResolveShareLinkRequest) (*tzv1.ResolveShareLinkResponse, error) {
+	const op = "grpc.tz.ResolveShareLink"
+
+	log := s.log.With(slog.String("op", op))
+
+	link, err := s.tzService.ResolveShareLink(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, tzservice.ErrShareLinkExpired) {
+			return nil, status.Error(codes.FailedPrecondition, "share link expired or revoked")
+		}
+		return nil, accessStatus(err, log)
+	}
+
+	return &tzv1.ResolveShareLinkResponse{ShareLink: convertShareLink(link)}, nil
+}
+
+func convertShareLink(link *modelrepo.ShareLink) *tzv1.ShareLink {
+	resp := &tzv1.ShareLink{
+		Id:          link.ID.String(),
+		VersionId:   link.VersionID.String(),
+		Permissions: link.Permissions,
+		CreatedBy:   link.CreatedBy.String(),
+		ExpiresAt:   timestamppb.New(link.ExpiresAt),
+		AccessCount: int32(link.AccessCount),
+		CreatedAt:   timestamppb.New(link.CreatedAt),
+	}
+	if link.RevokedAt != nil {
+		resp.RevokedAt = timestamppb.New(*link.RevokedAt)
+	}
+	if link.LastAccessedAt != nil {
+		resp.LastAccessedAt = timestamppb.New(*link.LastAccessedAt)
+	}
+	return resp
+}
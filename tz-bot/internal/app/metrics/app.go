@@ -0,0 +1,83 @@
+package metricsapp
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log/slog"
+	"net/http"
+	"time"
+
+	tz_llm_client "repairCopilotBot/tz-bot/internal/pkg/llm"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+)
+
+type Config struct {
+	// Addr - адрес сервера метрик, пусто - метрики не отдаются. Эндпоинт без аутентификации,
+	// поэтому по умолчанию слушает только localhost; открывать наружу - явно, например ":9090"
+	Addr        string        `env:"ADDR" env-default:"127.0.0.1:9090"`
+	StopTimeout time.Duration `env:"STOP_TIMEOUT" env-default:"5s"`
+}
+
+// App отдаёт метрики процесса в формате expvar на /debug/vars
+type App struct {
+	log        *slog.Logger
+	config     *Config
+	httpServer *http.Server
+}
+
+func New(log *slog.Logger, config *Config, llmClient *tz_llm_client.Client) *App {
+	expvar.Publish("tz_llm_client", expvar.Func(func() any {
+		return llmClient.LimiterStats()
+	}))
+	expvar.Publish("tz_llm_cache", expvar.Func(func() any {
+		return llmClient.CacheStats()
+	}))
+
+	router := http.NewServeMux()
+	router.Handle("GET /debug/vars", expvar.Handler())
+
+	return &App{
+		log:    log,
+		config: config,
+		httpServer: &http.Server{
+			Addr:              config.Addr,
+			Handler:           router,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+func (a *App) MustRun() {
+	const op = "metricsapp.Run"
+
+	log := a.log.With(slog.String("op", op))
+
+	if a.config.Addr == "" {
+		log.Info("metrics server disabled")
+		return
+	}
+
+	go func() {
+		if err := a.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("metrics server stopped with error", sl.Err(err))
+		}
+	}()
+
+	log.Info("metrics server started", slog.String("addr", a.config.Addr))
+}
+
+func (a *App) Stop() {
+	const op = "metricsapp.Stop"
+
+	if a.config.Addr == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.config.StopTimeout)
+	defer cancel()
+
+	if err := a.httpServer.Shutdown(ctx); err != nil {
+		a.log.With(slog.String("op", op)).Error("failed to stop metrics server", sl.Err(err))
+	}
+}
@@ -2,14 +2,18 @@ package tgapp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
 	"log/slog"
 	"net/http"
 	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
 	"strconv"
+	"strings"
 	"time"
 
 	"repairCopilotBot/tz-bot/internal/config"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
 	tzservice "repairCopilotBot/tz-bot/internal/service/tz"
 
 	"github.com/go-telegram/bot"
@@ -17,22 +21,25 @@ import (
 )
 
 type App struct {
-	bot         *bot.Bot
-	config      *config.TelegramBotConfig
-	tzService   TZServiceInterface
-	log         *slog.Logger
-	ctx         context.Context
-	cancel      context.CancelFunc
-	waitingGGID map[int64]bool
+	bot       *bot.Bot
+	config    *config.TelegramBotConfig
+	tzService TZServiceInterface
+	log       *slog.Logger
+	ctx       context.Context
+	cancel    context.CancelFunc
 }
 
 type TZServiceInterface interface {
-	SetGGID(newGGID int) int
-	GetGGID() int
+	ListCheckProfiles(ctx context.Context) ([]modelrepo.CheckProfile, error)
+	GetDefaultCheckProfile(ctx context.Context) (*modelrepo.CheckProfile, error)
+	SetDefaultCheckProfile(ctx context.Context, name string) (*modelrepo.CheckProfile, error)
 	SetUseLlmCache(useLlmCache bool) bool
 	GetUseLlmCache() bool
 }
 
+// defaultProfileCallbackPrefix - префикс callback_data кнопки выбора профиля по умолчанию, за ним код профиля
+const defaultProfileCallbackPrefix = "default_profile:"
+
 func New(log *slog.Logger, config *config.TelegramBotConfig, tzService *tzservice.Tz) (*App, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -51,13 +58,12 @@ func New(log *slog.Logger, config *config.TelegramBotConfig, tzService *tzservic
 	}
 
 	app := &App{
-		bot:         b,
-		config:      config,
-		tzService:   tzService,
-		log:         log,
-		ctx:         ctx,
-		cancel:      cancel,
-		waitingGGID: make(map[int64]bool),
+		bot:       b,
+		config:    config,
+		tzService: tzService,
+		log:       log,
+		ctx:       ctx,
+		cancel:    cancel,
 	}
 
 	app.registerHandlers()
@@ -122,9 +128,6 @@ func (a *App) registerHandlers() {
 
 	// Обработчик callback кнопок
 	a.bot.RegisterHandler(bot.HandlerTypeCallbackQueryData, "", bot.MatchTypePrefix, a.handleCallbackQuery)
-
-	// Обработчик текстовых сообщений (для ввода ggID)
-	a.bot.RegisterHandler(bot.HandlerTypeMessageText, "", bot.MatchTypeContains, a.handleTextMessage)
 }
 
 func (a *App) handleStart(ctx context.Context, b *bot.Bot, update *models.Update) {
@@ -134,7 +137,7 @@ func (a *App) handleStart(ctx context.Context, b *bot.Bot, update *models.Update
 				{Text: "📊 Текущие настройки", CallbackData: "status"},
 			},
 			{
-				{Text: "🔢 Изменить ggID", CallbackData: "change_ggid"},
+				{Text: "🗂 Профиль по умолчанию", CallbackData: "change_profile"},
 				{Text: "💾 Переключить кэш", CallbackData: "toggle_cache"},
 			},
 		},
@@ -148,7 +151,7 @@ func (a *App) handleStart(ctx context.Context, b *bot.Bot, update *models.Update
 
 Доступные операции:
 • Просмотр текущих настроек
-• Изменение ggID для системы
+• Выбор профиля проверки по умолчанию
 • Включение/выключение кэша LLM
 
 Выберите действие:`,
@@ -162,7 +165,7 @@ func (a *App) handleStart(ctx context.Context, b *bot.Bot, update *models.Update
 }
 
 func (a *App) handleStatus(ctx context.Context, b *bot.Bot, update *models.Update) {
-	currentGGID := a.tzService.GetGGID()
+	currentProfile := a.defaultProfileText(ctx)
 	currentCache := a.tzService.GetUseLlmCache()
 
 	cacheIcon := "❌"
@@ -175,7 +178,7 @@ func (a *App) handleStatus(ctx context.Context, b *bot.Bot, update *models.Updat
 	keyboard := &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
 			{
-				{Text: "🔢 Изменить ggID", CallbackData: "change_ggid"},
+				{Text: "🗂 Профиль по умолчанию", CallbackData: "change_profile"},
 				{Text: "💾 Переключить кэш", CallbackData: "toggle_cache"},
 			},
 		},
@@ -185,11 +188,11 @@ func (a *App) handleStatus(ctx context.Context, b *bot.Bot, update *models.Updat
 		ChatID: update.Message.Chat.ID,
 		Text: fmt.Sprintf(`📊 <b>Текущие настройки системы</b>
 
-🔢 <b>ggID:</b> <code>%d</code>
+🗂 <b>Профиль по умолчанию:</b> %s
 %s <b>Кэш LLM:</b> %s
 
 <i>Последнее обновление: %s</i>`,
-			currentGGID,
+			currentProfile,
 			cacheIcon,
 			cacheStatus,
 			time.Now().Format("15:04:05 02.01.2006")),
@@ -224,70 +227,20 @@ func (a *App) handleCallbackQuery(ctx context.Context, b *bot.Bot, update *model
 		a.log.Error("Ошибка ответа на callback", slog.Any("error", err))
 	}
 
-	switch callback.Data {
-	case "status":
+	switch {
+	case callback.Data == "status":
 		a.sendStatus(ctx, b, chatID)
-	case "change_ggid":
-		a.handleChangeGGID(ctx, b, chatID)
-	case "toggle_cache":
+	case callback.Data == "change_profile":
+		a.handleChangeDefaultProfile(ctx, b, chatID)
+	case strings.HasPrefix(callback.Data, defaultProfileCallbackPrefix):
+		a.handleSetDefaultProfile(ctx, b, chatID, strings.TrimPrefix(callback.Data, defaultProfileCallbackPrefix))
+	case callback.Data == "toggle_cache":
 		a.handleToggleCache(ctx, b, chatID)
 	}
 }
 
-func (a *App) handleTextMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
-	// Проверяем, ожидается ли ввод ggID от этого пользователя
-	if !a.waitingGGID[update.Message.Chat.ID] {
-		return
-	}
-
-	// Пытаемся преобразовать текст в число
-	newGGID, err := strconv.Atoi(update.Message.Text)
-	if err != nil || newGGID <= 0 {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: update.Message.Chat.ID,
-			Text:   "❌ Неверный формат ggID. Пожалуйста, введите положительное целое число:",
-		})
-		if err != nil {
-			a.log.Error("Ошибка отправки сообщения об ошибке", slog.Any("error", err))
-		}
-		return
-	}
-
-	// Устанавливаем новый ggID
-	actualGGID := a.tzService.SetGGID(newGGID)
-	a.waitingGGID[update.Message.Chat.ID] = false
-
-	keyboard := &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{Text: "📊 Показать статус", CallbackData: "status"},
-			},
-		},
-	}
-
-	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: update.Message.Chat.ID,
-		Text: fmt.Sprintf(`✅ <b>ggID успешно изменен!</b>
-
-🔢 <b>Новое значение:</b> <code>%d</code>
-
-<i>Изменение вступило в силу немедленно.</i>`, actualGGID),
-		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: keyboard,
-	})
-
-	if err != nil {
-		a.log.Error("Ошибка отправки подтверждения", slog.Any("error", err))
-	}
-
-	// Логируем изменение
-	a.log.Info("ggID изменен через Telegram бот",
-		slog.Int("new_ggid", actualGGID),
-		slog.Int64("chat_id", update.Message.Chat.ID))
-}
-
 func (a *App) sendStatus(ctx context.Context, b *bot.Bot, chatID int64) {
-	currentGGID := a.tzService.GetGGID()
+	currentProfile := a.defaultProfileText(ctx)
 	currentCache := a.tzService.GetUseLlmCache()
 
 	cacheIcon := "❌"
@@ -300,7 +253,7 @@ func (a *App) sendStatus(ctx context.Context, b *bot.Bot, chatID int64) {
 	keyboard := &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
 			{
-				{Text: "🔢 Изменить ggID", CallbackData: "change_ggid"},
+				{Text: "🗂 Профиль по умолчанию", CallbackData: "change_profile"},
 				{Text: "💾 Переключить кэш", CallbackData: "toggle_cache"},
 			},
 		},
@@ -310,11 +263,11 @@ func (a *App) sendStatus(ctx context.Context, b *bot.Bot, chatID int64) {
 		ChatID: chatID,
 		Text: fmt.Sprintf(`📊 <b>Текущие настройки системы</b>
 
-🔢 <b>ggID:</b> <code>%d</code>
+🗂 <b>Профиль по умолчанию:</b> %s
 %s <b>Кэш LLM:</b> %s
 
 <i>Последнее обновление: %s</i>`,
-			currentGGID,
+			currentProfile,
 			cacheIcon,
 			cacheStatus,
 			time.Now().Format("15:04:05 02.01.2006")),
@@ -327,31 +280,100 @@ func (a *App) sendStatus(ctx context.Context, b *bot.Bot, chatID int64) {
 	}
 }
 
-func (a *App) handleChangeGGID(ctx context.Context, b *bot.Bot, chatID int64) {
-	a.waitingGGID[chatID] = true
+// defaultProfileText описывает профиль по умолчанию для сообщений бота
+func (a *App) defaultProfileText(ctx context.Context) string {
+	profile, err := a.tzService.GetDefaultCheckProfile(ctx)
+	if err != nil {
+		if !errors.Is(err, tzservice.ErrCheckProfileNotFound) {
+			a.log.Error("Ошибка получения профиля по умолчанию", slog.Any("error", err))
+		}
+		return "не задан"
+	}
+
+	return fmt.Sprintf("%s (<code>%s</code>, ggID <code>%d</code>)", html.EscapeString(profile.Title), profile.Name, profile.GgID)
+}
+
+func (a *App) handleChangeDefaultProfile(ctx context.Context, b *bot.Bot, chatID int64) {
+	profiles, err := a.tzService.ListCheckProfiles(ctx)
+	if err != nil {
+		a.log.Error("Ошибка получения профилей проверки", slog.Any("error", err))
+		return
+	}
+
+	rows := make([][]models.InlineKeyboardButton, 0, len(profiles)+1)
+	for _, profile := range profiles {
+		text := fmt.Sprintf("%s (ggID %d)", profile.Title, profile.GgID)
+		if profile.IsDefault {
+			text = "✅ " + text
+		}
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: text, CallbackData: defaultProfileCallbackPrefix + profile.Name},
+		})
+	}
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "📊 Показать текущий статус", CallbackData: "status"},
+	})
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text: `🗂 <b>Профиль проверки по умолчанию</b>
+
+Выберите профиль, с которым будут проверяться документы, загруженные без явного профиля:
+
+<i>Уже запущенные проверки продолжат работу со своим профилем.</i>`,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: rows},
+	})
+
+	if err != nil {
+		a.log.Error("Ошибка отправки списка профилей", slog.Any("error", err))
+	}
+}
+
+func (a *App) handleSetDefaultProfile(ctx context.Context, b *bot.Bot, chatID int64, name string) {
+	profile, err := a.tzService.SetDefaultCheckProfile(ctx, name)
+	if err != nil {
+		a.log.Error("Ошибка смены профиля по умолчанию", slog.String("profile", name), slog.Any("error", err))
+
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "❌ Не удалось изменить профиль по умолчанию. Возможно, профиль был удалён.",
+		})
+		if err != nil {
+			a.log.Error("Ошибка отправки сообщения об ошибке", slog.Any("error", err))
+		}
+		return
+	}
 
 	keyboard := &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
 			{
-				{Text: "📊 Показать текущий статус", CallbackData: "status"},
+				{Text: "📊 Показать статус", CallbackData: "status"},
 			},
 		},
 	}
 
-	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text: `🔢 <b>Изменение ggID</b>
+		Text: fmt.Sprintf(`✅ <b>Профиль по умолчанию изменен!</b>
 
-Пожалуйста, введите новое значение ggID (положительное целое число):
+🗂 <b>Новый профиль:</b> %s (<code>%s</code>)
+🔢 <b>ggID:</b> <code>%d</code>
 
-<i>Например: 1, 2, 3, etc.</i>`,
+<i>Изменение применяется к новым загрузкам.</i>`, html.EscapeString(profile.Title), profile.Name, profile.GgID),
 		ParseMode:   models.ParseModeHTML,
 		ReplyMarkup: keyboard,
 	})
 
 	if err != nil {
-		a.log.Error("Ошибка запроса ggID", slog.Any("error", err))
+		a.log.Error("Ошибка отправки подтверждения", slog.Any("error", err))
 	}
+
+	// Логируем изменение
+	a.log.Info("Профиль проверки по умолчанию изменен через Telegram бот",
+		slog.String("profile", profile.Name),
+		slog.Int("ggid", profile.GgID),
+		slog.Int64("chat_id", chatID))
 }
 
 func (a *App) handleToggleCache(ctx context.Context, b *bot.Bot, chatID int64) {
@@ -403,7 +425,7 @@ func (a *App) sendStartupMessage() error {
 		return fmt.Errorf("invalid chat_id format: %w", err)
 	}
 
-	currentGGID := a.tzService.GetGGID()
+	currentProfile := a.defaultProfileText(context.Background())
 	currentCache := a.tzService.GetUseLlmCache()
 
 	cacheIcon := "❌"
@@ -426,13 +448,13 @@ func (a *App) sendStartupMessage() error {
 		Text: fmt.Sprintf(`🚀 <b>TZ Bot запущен!</b>
 
 📊 <b>Текущие настройки:</b>
-🔢 <b>ggID:</b> <code>%d</code>
+🗂 <b>Профиль по умолчанию:</b> %s
 %s <b>Кэш LLM:</b> %s
 
 <i>Время запуска: %s</i>
 
 Бот готов к работе! 🤖`,
-			currentGGID,
+			currentProfile,
 			cacheIcon,
 			cacheStatus,
 			time.Now().Format("15:04:05 02.01.2006")),
@@ -0,0 +1,149 @@
+package workerapp
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	tzservice "repairCopilotBot/tz-bot/internal/service/tz"
+)
+
+type Config struct {
+	Concurrency   int           `env:"CONCURRENCY" env-default:"2"`
+	PollInterval  time.Duration `env:"POLL_INTERVAL" env-default:"5s"`
+	LeaseDuration time.Duration `env:"LEASE_DURATION" env-default:"3m"`
+	RetryDelay    time.Duration `env:"RETRY_DELAY" env-default:"1m"`
+	MaxAttempts   int           `env:"MAX_ATTEMPTS" env-default:"3"`
+	JobTimeout    time.Duration `env:"JOB_TIMEOUT" env-default:"100m"`
+	StopTimeout   time.Duration `env:"STOP_TIMEOUT" env-default:"30s"`
+	// CachePurgeInterval - как часто удалять из кэша LLM ответы старше TTL (0 - не удалять)
+	CachePurgeInterval time.Duration `env:"CACHE_PURGE_INTERVAL" env-default:"1h"`
+}
+
+// App - пул обработчиков очереди проверок ТЗ
+type App struct {
+	log       *slog.Logger
+	tzService *tzservice.Tz
+	config    *Config
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+func New(log *slog.Logger, tzService *tzservice.Tz, config *Config) *App {
+	return &App{
+		log:       log,
+		tzService: tzService,
+		config:    config,
+	}
+}
+
+func (a *App) MustRun() {
+	const op = "workerapp.Run"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+
+	concurrency := a.config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	for i := 0; i < concurrency; i++ {
+		a.wg.Add(1)
+		go a.runWorker(ctx, i)
+	}
+
+	// Слушатель уведомлений о событиях проверок для WatchVersion
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		a.tzService.ListenVersionEvents(ctx)
+	}()
+
+	if a.config.CachePurgeInterval > 0 {
+		a.wg.Add(1)
+		go a.runCachePurge(ctx)
+	}
+
+	a.log.With(slog.String("op", op)).
+		Info("processing workers started", slog.Int("concurrency", concurrency))
+}
+
+// runCachePurge периодически удаляет устаревшие ответы из кэша LLM
+func (a *App) runCachePurge(ctx context.Context) {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(a.config.CachePurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Ошибка уже залогирована сервисом, следующая попытка - через интервал
+			_, _ = a.tzService.PurgeExpiredLlmCache(ctx)
+		}
+	}
+}
+
+func (a *App) runWorker(ctx context.Context, workerID int) {
+	defer a.wg.Done()
+
+	log := a.log.With(slog.String("op", "workerapp.runWorker"), slog.Int("worker", workerID))
+
+	opts := tzservice.ProcessingJobOptions{
+		LeaseDuration: a.config.LeaseDuration,
+		RetryDelay:    a.config.RetryDelay,
+		MaxAttempts:   a.config.MaxAttempts,
+		JobTimeout:    a.config.JobTimeout,
+	}
+
+	for {
+		processed, err := a.tzService.ProcessNextJob(ctx, opts)
+		if err != nil {
+			log.Error("failed to process job", sl.Err(err))
+		}
+
+		// Если очередь не пуста - сразу берём следующую задачу
+		if processed && err == nil {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(a.config.PollInterval):
+		}
+	}
+}
+
+func (a *App) Stop() {
+	const op = "workerapp.Stop"
+
+	log := a.log.With(slog.String("op", op))
+	log.Info("stopping processing workers")
+
+	if a.cancel == nil {
+		return
+	}
+	a.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Info("processing workers stopped")
+	case <-time.After(a.config.StopTimeout):
+		log.Warn("processing workers did not stop in time, unfinished jobs will be picked up after lease expiry")
+	}
+}
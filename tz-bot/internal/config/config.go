@@ -2,6 +2,8 @@ package config
 
 import (
 	grpcapp "repairCopilotBot/tz-bot/internal/app/grpc"
+	metricsapp "repairCopilotBot/tz-bot/internal/app/metrics"
+	workerapp "repairCopilotBot/tz-bot/internal/app/worker"
 	doctodocxconverterclient "repairCopilotBot/tz-bot/internal/pkg/docToDocxConverterClient"
 	"repairCopilotBot/tz-bot/internal/pkg/llm"
 	"repairCopilotBot/tz-bot/internal/pkg/markdown-service"
@@ -11,6 +13,7 @@ import (
 	"repairCopilotBot/tz-bot/internal/pkg/word-parser"
 	"repairCopilotBot/tz-bot/internal/repository/postgres"
 	"repairCopilotBot/tz-bot/internal/repository/s3minio"
+	tzservice "repairCopilotBot/tz-bot/internal/service/tz"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -29,6 +32,9 @@ type Config struct {
 	Postgres                 postgres.Config                 `env-prefix:"POSTGRES_"`
 	TelegramBot              TelegramBotConfig               `env-prefix:"TELEGRAM_BOT_"`
 	TelegramClient           telegramclient.Config           `env-prefix:"TELEGRAM_CLIENT_"`
+	Worker                   workerapp.Config                `env-prefix:"WORKER_"`
+	Metrics                  metricsapp.Config               `env-prefix:"METRICS_"`
+	Upload                   tzservice.UploadLimits          `env-prefix:"UPLOAD_"`
 }
 
 type TelegramBotConfig struct {
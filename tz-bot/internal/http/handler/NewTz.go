@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"repairCopilotBot/tz-bot/client"
 	"repairCopilotBot/tz-bot/internal/service/tz"
 	"strings"
 )
@@ -30,7 +31,9 @@ func NewTzHandler(
 	w http.ResponseWriter, r *http.Request,
 ) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Парсим multipart form (максимум 10MB)
+		// Файл ограничен тем же размером, что и в gRPC-запросе CheckTz. 10 МБ формы держим
+		// в памяти, остальное multipart пишет во временные файлы
+		r.Body = http.MaxBytesReader(w, r.Body, client.MaxFileSize+1<<20)
 		err := r.ParseMultipartForm(10 << 20)
 		if err != nil {
 			http.Error(w, "Ошибка парсинга формы", http.StatusBadRequest)
@@ -58,7 +61,10 @@ func NewTzHandler(
 
 		requestID, _ := uuid.NewUUID()
 
-		tzService.CheckTz(r.Context(), fileBytes, filename, requestID)
+		_, err = tzService.CheckTz(r.Context(), fileBytes, filename, requestID, tzservice.CheckOptions{
+			Force:   r.URL.Query().Get("force") == "true",
+			Profile: r.URL.Query().Get("check_profile"),
+		})
 		if err != nil {
 			http.Error(w, "service error: "+err.Error(), http.StatusInternalServerError)
 			return
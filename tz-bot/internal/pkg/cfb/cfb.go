@@ -0,0 +1,299 @@
+// Package cfb читает контейнеры Compound File Binary (OLE2): .doc, .xls, а также
+// зашифрованные паролем docx/xlsx, которые Office сохраняет в OLE-контейнер.
+// Поддерживается только чтение каталога и потоков - этого достаточно, чтобы проверить
+// загруженный файл, не разбирая сам документ
+package cfb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"unicode/utf16"
+)
+
+var (
+	ErrNotCFB    = errors.New("file is not a compound file")
+	ErrCorrupted = errors.New("compound file is corrupted")
+)
+
+// Signature - первые 8 байт любого OLE-контейнера
+var Signature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// EntryType - тип записи каталога
+type EntryType byte
+
+const (
+	EntryStorage EntryType = 1
+	EntryStream  EntryType = 2
+	EntryRoot    EntryType = 5
+)
+
+// Специальные номера секторов
+const (
+	endOfChain = 0xFFFFFFFE
+	freeSector = 0xFFFFFFFF
+)
+
+const (
+	headerSize       = 512
+	directoryEntry   = 128
+	headerDifatCount = 109
+)
+
+// Entry - запись каталога контейнера: хранилище или поток
+type Entry struct {
+	Name        string
+	Type        EntryType
+	StartSector uint32
+	Size        uint64
+}
+
+// File - разобранный OLE-контейнер. Данные файла не копируются
+type File struct {
+	data           []byte
+	sectorSize     int
+	miniSectorSize int
+	miniCutoff     uint64
+	fat            []uint32
+	miniFat        []uint32
+	entries        []Entry
+}
+
+// Open разбирает заголовок, таблицу размещения и каталог контейнера
+func Open(data []byte) (*File, error) {
+	if len(data) < headerSize || !bytes.HasPrefix(data, Signature) {
+		return nil, ErrNotCFB
+	}
+
+	sectorShift := binary.LittleEndian.Uint16(data[0x1E:])
+	miniSectorShift := binary.LittleEndian.Uint16(data[0x20:])
+	if (sectorShift != 9 && sectorShift != 12) || miniSectorShift != 6 {
+		return nil, ErrCorrupted
+	}
+
+	f := &File{
+		data:           data,
+		sectorSize:     1 << sectorShift,
+		miniSectorSize: 1 << miniSectorShift,
+		miniCutoff:     uint64(binary.LittleEndian.Uint32(data[0x38:])),
+	}
+
+	if err := f.readFat(); err != nil {
+		return nil, err
+	}
+
+	dir, err := f.readChain(f.fat, binary.LittleEndian.Uint32(data[0x30:]), -1)
+	if err != nil {
+		return nil, err
+	}
+
+	for off := 0; off+directoryEntry <= len(dir); off += directoryEntry {
+		raw := dir[off : off+directoryEntry]
+		entryType := EntryType(raw[0x42])
+		if entryType != EntryStorage && entryType != EntryStream && entryType != EntryRoot {
+			continue
+		}
+
+		nameLen := int(binary.LittleEndian.Uint16(raw[0x40:]))
+		if nameLen > 64 {
+			return nil, ErrCorrupted
+		}
+
+		f.entries = append(f.entries, Entry{
+			Name:        decodeName(raw[:max(nameLen-2, 0)]),
+			Type:        entryType,
+			StartSector: binary.LittleEndian.Uint32(raw[0x74:]),
+			Size:        binary.LittleEndian.Uint64(raw[0x78:]),
+		})
+	}
+
+	if len(f.entries) == 0 || f.entries[0].Type != EntryRoot {
+		return nil, ErrCorrupted
+	}
+
+	if sectorShift == 9 {
+		// В версии 3 старшие 32 бита размера не определены
+		for i := range f.entries {
+			f.entries[i].Size &= 0xFFFFFFFF
+		}
+	}
+
+	miniFatStart := binary.LittleEndian.Uint32(data[0x3C:])
+	if miniFatStart != endOfChain {
+		miniFat, err := f.readChain(f.fat, miniFatStart, -1)
+		if err != nil {
+			return nil, err
+		}
+		f.miniFat = toUint32s(miniFat)
+	}
+
+	return f, nil
+}
+
+// Entries возвращает все хранилища и потоки контейнера. Первая запись - корневая
+func (f *File) Entries() []Entry {
+	return f.entries
+}
+
+// Find ищет запись по имени без учёта регистра, как это делает Office
+func (f *File) Find(name string) (Entry, bool) {
+	for _, e := range f.entries {
+		if strings.EqualFold(e.Name, name) {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// ReadStream возвращает первые limit байт потока (limit < 0 - весь поток)
+func (f *File) ReadStream(e Entry, limit int) ([]byte, error) {
+	if e.Type != EntryStream {
+		return nil, ErrCorrupted
+	}
+
+	size := int(min(e.Size, uint64(len(f.data))))
+	if limit >= 0 && limit < size {
+		size = limit
+	}
+
+	if e.Size >= f.miniCutoff {
+		return f.readChain(f.fat, e.StartSector, size)
+	}
+
+	// Маленькие потоки лежат в мини-потоке, который хранится в цепочке корневой записи
+	root := f.entries[0]
+	miniStream, err := f.readChain(f.fat, root.StartSector, int(min(root.Size, uint64(len(f.data)))))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, size)
+	sector := e.StartSector
+	for visited := 0; len(out) < size; visited++ {
+		if sector == endOfChain || int(sector) >= len(f.miniFat) || visited > len(f.miniFat) {
+			return nil, ErrCorrupted
+		}
+		off := int(sector) * f.miniSectorSize
+		if off+f.miniSectorSize > len(miniStream) {
+			return nil, ErrCorrupted
+		}
+		out = append(out, miniStream[off:off+f.miniSectorSize]...)
+		sector = f.miniFat[sector]
+	}
+
+	return out[:size], nil
+}
+
+// readFat собирает таблицу размещения секторов из секторов, перечисленных в DIFAT
+func (f *File) readFat() error {
+	fatSectors := make([]uint32, 0, headerDifatCount)
+	for i := 0; i < headerDifatCount; i++ {
+		sector := binary.LittleEndian.Uint32(f.data[0x4C+i*4:])
+		if sector == freeSector || sector == endOfChain {
+			break
+		}
+		fatSectors = append(fatSectors, sector)
+	}
+
+	// Продолжение DIFAT: последние 4 байта каждого сектора - номер следующего сектора DIFAT
+	difatSector := binary.LittleEndian.Uint32(f.data[0x44:])
+	perSector := f.sectorSize/4 - 1
+	for visited := 0; difatSector != endOfChain && difatSector != freeSector; visited++ {
+		sector, err := f.sector(difatSector)
+		if err != nil || visited > len(f.data)/f.sectorSize {
+			return ErrCorrupted
+		}
+		values := toUint32s(sector)
+		if len(values) <= perSector {
+			return ErrCorrupted
+		}
+		for _, v := range values[:perSector] {
+			if v != freeSector && v != endOfChain {
+				fatSectors = append(fatSectors, v)
+			}
+		}
+		difatSector = values[perSector]
+	}
+
+	// Секторов FAT не может быть больше, чем секторов в файле. Повторы пропускаются:
+	// иначе зацикленный или раздутый DIFAT заставит собрать таблицу, во много раз больше файла
+	maxSectors := len(f.data) / f.sectorSize
+	seen := make(map[uint32]struct{}, len(fatSectors))
+	for _, fatSector := range fatSectors {
+		if _, ok := seen[fatSector]; ok {
+			continue
+		}
+		seen[fatSector] = struct{}{}
+		if len(seen) > maxSectors {
+			return ErrCorrupted
+		}
+
+		sector, err := f.sector(fatSector)
+		if err != nil {
+			return err
+		}
+		f.fat = append(f.fat, toUint32s(sector)...)
+	}
+
+	return nil
+}
+
+// readChain читает цепочку секторов, начиная со start. size < 0 - до конца цепочки
+func (f *File) readChain(table []uint32, start uint32, size int) ([]byte, error) {
+	out := make([]byte, 0, max(size, 0))
+	sector := start
+	for visited := 0; sector != endOfChain; visited++ {
+		if size >= 0 && len(out) >= size {
+			break
+		}
+		// Цепочка не может быть длиннее числа секторов - иначе в ней цикл
+		if int(sector) >= len(table) || visited > len(table) {
+			return nil, ErrCorrupted
+		}
+		data, err := f.sector(sector)
+		if err != nil {
+			return nil, err
+		}
+		// Каждый сектор лежит в файле, поэтому цепочка без повторов не длиннее самого файла
+		if len(out)+len(data) > len(f.data) {
+			return nil, ErrCorrupted
+		}
+		out = append(out, data...)
+		sector = table[sector]
+	}
+
+	if size >= 0 {
+		if len(out) < size {
+			return nil, ErrCorrupted
+		}
+		out = out[:size]
+	}
+
+	return out, nil
+}
+
+func (f *File) sector(n uint32) ([]byte, error) {
+	off := (int(n) + 1) * f.sectorSize
+	if n >= endOfChain || off >= len(f.data) || off < 0 {
+		return nil, ErrCorrupted
+	}
+	// Последний сектор некоторые программы записывают не полностью
+	return f.data[off:min(off+f.sectorSize, len(f.data))], nil
+}
+
+func toUint32s(b []byte) []uint32 {
+	out := make([]uint32, len(b)/4)
+	for i := range out {
+		out[i] = binary.LittleEndian.Uint32(b[i*4:])
+	}
+	return out
+}
+
+func decodeName(b []byte) string {
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return string(utf16.Decode(u))
+}
@@ -0,0 +1,197 @@
+package cfb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"unicode/utf16"
+)
+
+const testSectorSize = 512
+
+// testStreamSectors - поток "Data" занимает сектора 2..9 и по размеру равен порогу мини-потока
+const testStreamSectors = 8
+
+// buildFile собирает контейнер версии 3: сектор 0 - FAT, сектор 1 - каталог,
+// сектора 2..9 - поток "Data". patchFat и patchHeader позволяют испортить таблицу и заголовок
+func buildFile(t *testing.T, patchFat func(fat []uint32), patchHeader func(header []byte)) ([]byte, []byte) {
+	t.Helper()
+
+	fat := make([]uint32, testSectorSize/4)
+	for i := range fat {
+		fat[i] = freeSector
+	}
+	fat[0] = 0xFFFFFFFD
+	fat[1] = endOfChain
+	for s := 2; s < 2+testStreamSectors; s++ {
+		fat[s] = uint32(s + 1)
+	}
+	fat[1+testStreamSectors] = endOfChain
+	if patchFat != nil {
+		patchFat(fat)
+	}
+
+	dir := make([]byte, testSectorSize)
+	writeEntry := func(i int, name string, entryType EntryType, start uint32, size int) {
+		raw := dir[i*directoryEntry : (i+1)*directoryEntry]
+		name16 := utf16.Encode([]rune(name))
+		for j, c := range name16 {
+			binary.LittleEndian.PutUint16(raw[j*2:], c)
+		}
+		binary.LittleEndian.PutUint16(raw[0x40:], uint16((len(name16)+1)*2))
+		raw[0x42] = byte(entryType)
+		binary.LittleEndian.PutUint32(raw[0x74:], start)
+		binary.LittleEndian.PutUint32(raw[0x78:], uint32(size))
+	}
+	writeEntry(0, "Root Entry", EntryRoot, endOfChain, 0)
+	writeEntry(1, "Data", EntryStream, 2, testStreamSectors*testSectorSize)
+
+	payload := bytes.Repeat([]byte("0123456789abcdef"), testStreamSectors*testSectorSize/16)
+
+	header := make([]byte, headerSize)
+	copy(header, Signature)
+	binary.LittleEndian.PutUint16(header[0x1E:], 9)
+	binary.LittleEndian.PutUint16(header[0x20:], 6)
+	binary.LittleEndian.PutUint32(header[0x2C:], 1)
+	binary.LittleEndian.PutUint32(header[0x30:], 1)
+	binary.LittleEndian.PutUint32(header[0x38:], 4096)
+	binary.LittleEndian.PutUint32(header[0x3C:], endOfChain)
+	binary.LittleEndian.PutUint32(header[0x44:], endOfChain)
+	for i := 0; i < headerDifatCount; i++ {
+		binary.LittleEndian.PutUint32(header[0x4C+i*4:], freeSector)
+	}
+	binary.LittleEndian.PutUint32(header[0x4C:], 0)
+	if patchHeader != nil {
+		patchHeader(header)
+	}
+
+	fatSector := make([]byte, testSectorSize)
+	for i, v := range fat {
+		binary.LittleEndian.PutUint32(fatSector[i*4:], v)
+	}
+
+	var out []byte
+	out = append(out, header...)
+	out = append(out, fatSector...)
+	out = append(out, dir...)
+	out = append(out, payload...)
+	return out, payload
+}
+
+func TestOpenAndReadStream(t *testing.T) {
+	data, payload := buildFile(t, nil, nil)
+
+	f, err := Open(data)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	entry, ok := f.Find("data")
+	if !ok {
+		t.Fatalf("stream Data not found")
+	}
+
+	got, err := f.ReadStream(entry, -1)
+	if err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("stream content mismatch")
+	}
+}
+
+func TestOpenCorrupted(t *testing.T) {
+	tests := []struct {
+		name        string
+		patchFat    func(fat []uint32)
+		patchHeader func(header []byte)
+		truncate    int
+	}{
+		{
+			name:     "directory chain loops",
+			patchFat: func(fat []uint32) { fat[1] = 1 },
+		},
+		{
+			name:     "truncated before directory",
+			truncate: headerSize + testSectorSize,
+		},
+		{
+			name: "difat lists more fat sectors than file has",
+			patchHeader: func(header []byte) {
+				for i := 0; i < headerDifatCount; i++ {
+					binary.LittleEndian.PutUint32(header[0x4C+i*4:], uint32(i))
+				}
+			},
+		},
+		{
+			name: "difat continuation loops",
+			patchHeader: func(header []byte) {
+				binary.LittleEndian.PutUint32(header[0x44:], 0)
+			},
+			// Последнее значение сектора 0 - номер следующего сектора DIFAT, то есть снова 0
+			patchFat: func(fat []uint32) { fat[len(fat)-1] = 0 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := buildFile(t, tt.patchFat, tt.patchHeader)
+			if tt.truncate > 0 {
+				data = data[:tt.truncate]
+			}
+
+			if _, err := Open(data); !errors.Is(err, ErrCorrupted) {
+				t.Fatalf("expected ErrCorrupted, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOpenSkipsDuplicateFatSectors(t *testing.T) {
+	data, _ := buildFile(t, nil, func(header []byte) {
+		// Все 109 записей DIFAT указывают на один и тот же сектор FAT
+		for i := 0; i < headerDifatCount; i++ {
+			binary.LittleEndian.PutUint32(header[0x4C+i*4:], 0)
+		}
+	})
+
+	f, err := Open(data)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if len(f.fat) != testSectorSize/4 {
+		t.Errorf("expected fat of one sector (%d entries), got %d", testSectorSize/4, len(f.fat))
+	}
+}
+
+func TestReadChainStopsOnLoop(t *testing.T) {
+	data, _ := buildFile(t, func(fat []uint32) {
+		// Последний сектор потока ссылается на первый
+		fat[1+testStreamSectors] = 2
+	}, nil)
+
+	f, err := Open(data)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	if _, err := f.readChain(f.fat, 2, -1); !errors.Is(err, ErrCorrupted) {
+		t.Fatalf("expected ErrCorrupted, got %v", err)
+	}
+}
+
+func TestReadStreamTruncatedFile(t *testing.T) {
+	data, _ := buildFile(t, nil, nil)
+	// Файл обрезан посередине потока: каталог цел, данных не хватает
+	data = data[:headerSize+4*testSectorSize]
+
+	f, err := Open(data)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	entry, _ := f.Find("Data")
+
+	if _, err := f.ReadStream(entry, -1); !errors.Is(err, ErrCorrupted) {
+		t.Fatalf("expected ErrCorrupted, got %v", err)
+	}
+}
@@ -16,7 +16,7 @@ type Config struct {
 	Port int    `env:"PORT" env-required:"true"`
 }
 
-// Client представляет клиент для сервиса конвертации DOC в DOCX
+// Client представляет клиент для сервиса конвертации DOC, ODT и RTF в DOCX
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
@@ -228,6 +228,68 @@ func (c *Client) ConvertBatchWithContext(ctx context.Context, files map[string][
 	return results, nil
 }
 
+// PdfText текстовый слой PDF документа. Pages[i] - текст страницы i+1
+type PdfText struct {
+	Pages         []string `json:"pages"`
+	PageCount     int      `json:"page_count"`
+	PagesWithText int      `json:"pages_with_text"`
+	HasTextLayer  bool     `json:"has_text_layer"`
+	// PasswordProtected - PDF зашифрован паролем на открытие, текст не извлекался
+	PasswordProtected bool `json:"password_protected"`
+}
+
+// ExtractPdfText извлекает текстовый слой PDF файла постранично
+func (c *Client) ExtractPdfText(ctx context.Context, file []byte, filename string) (*PdfText, error) {
+	if len(file) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+
+	if _, err := io.Copy(part, bytes.NewReader(file)); err != nil {
+		return nil, fmt.Errorf("failed to write file to form: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/extract-pdf-text", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pdf text extraction failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var pdfText PdfText
+	if err := json.Unmarshal(respBody, &pdfText); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &pdfText, nil
+}
+
 // HealthCheck проверяет состояние сервиса
 func (c *Client) HealthCheck() (*HealthResponse, error) {
 	return c.HealthCheckWithContext(context.Background())
@@ -0,0 +1,172 @@
+package tz_llm_client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+)
+
+// LLMCacheRepository defines the interface for LLM cache operations
+type LLMCacheRepository interface {
+	// GetCachedResponse retrieves cached response by key, saved not earlier than notBefore
+	GetCachedResponse(ctx context.Context, key modelrepo.LLMCacheKey, notBefore time.Time) (*modelrepo.LLMCache, error)
+
+	// SaveCachedResponse saves a cached response, replacing an existing entry with the same key
+	SaveCachedResponse(ctx context.Context, req *modelrepo.CreateLLMCacheRequest) (*modelrepo.LLMCache, error)
+
+	// DeleteExpiredCachedResponses deletes entries updated before the given time and returns their count
+	DeleteExpiredCachedResponses(ctx context.Context, before time.Time) (int64, error)
+
+	// InvalidateCachedResponses deletes entries matching the filter and returns their count
+	InvalidateCachedResponses(ctx context.Context, filter modelrepo.LLMCacheFilter) (int64, error)
+}
+
+// CacheConfig - параметры кэша ответов LLM
+type CacheConfig struct {
+	TTL time.Duration `env:"TTL" env-default:"720h"`
+	// SimulateLatency - при попадании в кэш выдерживать исходную длительность запроса.
+	// Нужно только для демонстраций и нагрузочных тестов, по умолчанию выключено
+	SimulateLatency bool `env:"SIMULATE_LATENCY" env-default:"false"`
+}
+
+// CacheOptions - параметры кэша для одного запроса
+type CacheOptions struct {
+	Enabled bool
+	// GgID - группа ошибок, для которой сформированы промты. Сохраняется вместе с ответом,
+	// чтобы можно было сбросить кэш после изменения группы
+	GgID int
+}
+
+// CacheStats - счётчики обращений к кэшу с момента запуска процесса
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+	Errors int64
+}
+
+type cacheCounters struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+	errors atomic.Int64
+}
+
+// CacheStats возвращает счётчики попаданий и промахов кэша для метрик
+func (c *Client) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:   c.cacheCounters.hits.Load(),
+		Misses: c.cacheCounters.misses.Load(),
+		Errors: c.cacheCounters.errors.Load(),
+	}
+}
+
+// PurgeExpiredCache удаляет из кэша записи старше TTL. TTL = 0 - записи не устаревают
+// (как и в getCachedResponse), поэтому ничего не удаляется
+func (c *Client) PurgeExpiredCache(ctx context.Context) (int64, error) {
+	if c.repository == nil || c.cache.TTL <= 0 {
+		return 0, nil
+	}
+
+	return c.repository.DeleteExpiredCachedResponses(ctx, time.Now().Add(-c.cache.TTL))
+}
+
+// InvalidateCache удаляет из кэша записи, подходящие под фильтр
+func (c *Client) InvalidateCache(ctx context.Context, filter modelrepo.LLMCacheFilter) (int64, error) {
+	if c.repository == nil {
+		return 0, nil
+	}
+
+	return c.repository.InvalidateCachedResponses(ctx, filter)
+}
+
+// cacheKey вычисляет ключ кэша: модель, хэш схемы ответа и хэш сообщений
+func (c *Client) cacheKey(messages []struct {
+	Role    *string `json:"role"`
+	Content *string `json:"content"`
+}, schema json.RawMessage) (modelrepo.LLMCacheKey, error) {
+	messagesHash, err := calculateMessagesHash(messages)
+	if err != nil {
+		return modelrepo.LLMCacheKey{}, err
+	}
+
+	schemaHash := sha256.Sum256(schema)
+
+	return modelrepo.LLMCacheKey{
+		Model:        c.model,
+		SchemaHash:   hex.EncodeToString(schemaHash[:]),
+		MessagesHash: messagesHash,
+	}, nil
+}
+
+// getCachedResponse ищет ответ в кэше. Ошибки кэша не прерывают запрос - возвращается nil
+func (c *Client) getCachedResponse(ctx context.Context, key modelrepo.LLMCacheKey) (*SuccessResponse, error) {
+	notBefore := time.Time{}
+	if c.cache.TTL > 0 {
+		notBefore = time.Now().Add(-c.cache.TTL)
+	}
+
+	cachedResponse, err := c.repository.GetCachedResponse(ctx, key, notBefore)
+	if err != nil {
+		if errors.Is(err, repository.ErrLLMCacheNotFound) {
+			c.cacheCounters.misses.Add(1)
+		} else {
+			c.cacheCounters.errors.Add(1)
+			c.log.Warn("ошибка проверки кэша", slog.String("error", err.Error()))
+		}
+		return nil, nil
+	}
+
+	var response SuccessResponse
+	if err := json.Unmarshal(cachedResponse.ResponseData, &response); err != nil {
+		c.cacheCounters.errors.Add(1)
+		c.log.Warn("ошибка десериализации кэшированного ответа", slog.String("error", err.Error()))
+		return nil, nil
+	}
+
+	response.CacheHit = true
+
+	c.cacheCounters.hits.Add(1)
+	c.log.Info("найден кэшированный ответ для запроса")
+
+	if c.cache.SimulateLatency && response.Duration != nil {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("запрос к LLM API отменён: %w", ctx.Err())
+		case <-time.After(time.Duration(*response.Duration) * time.Millisecond):
+		}
+	}
+
+	return &response, nil
+}
+
+// saveCachedResponse сохраняет ответ в кэш. Ошибки только логируются
+func (c *Client) saveCachedResponse(ctx context.Context, key modelrepo.LLMCacheKey, ggID int, response *SuccessResponse) {
+	responseData, err := json.Marshal(response)
+	if err != nil {
+		c.log.Warn("ошибка сериализации ответа для кэша", slog.String("error", err.Error()))
+		return
+	}
+
+	now := time.Now()
+	_, err = c.repository.SaveCachedResponse(ctx, &modelrepo.CreateLLMCacheRequest{
+		Key:          key,
+		GgID:         &ggID,
+		ResponseData: responseData,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	if err != nil {
+		c.log.Warn("ошибка сохранения ответа в кэш", slog.String("error", err.Error()))
+		return
+	}
+
+	c.log.Info("ответ успешно сохранён в кэш")
+}
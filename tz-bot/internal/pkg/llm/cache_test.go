@@ -0,0 +1,200 @@
+package tz_llm_client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+)
+
+type memoryCacheRepository struct {
+	entries map[modelrepo.LLMCacheKey]*modelrepo.LLMCache
+}
+
+func newMemoryCacheRepository() *memoryCacheRepository {
+	return &memoryCacheRepository{entries: make(map[modelrepo.LLMCacheKey]*modelrepo.LLMCache)}
+}
+
+func (r *memoryCacheRepository) GetCachedResponse(_ context.Context, key modelrepo.LLMCacheKey, notBefore time.Time) (*modelrepo.LLMCache, error) {
+	entry, ok := r.entries[key]
+	if !ok || entry.UpdatedAt.Before(notBefore) {
+		return nil, repository.ErrLLMCacheNotFound
+	}
+	return entry, nil
+}
+
+func (r *memoryCacheRepository) SaveCachedResponse(_ context.Context, req *modelrepo.CreateLLMCacheRequest) (*modelrepo.LLMCache, error) {
+	entry := &modelrepo.LLMCache{
+		Model:        req.Key.Model,
+		SchemaHash:   req.Key.SchemaHash,
+		MessagesHash: req.Key.MessagesHash,
+		GgID:         req.GgID,
+		ResponseData: req.ResponseData,
+		CreatedAt:    req.CreatedAt,
+		UpdatedAt:    req.UpdatedAt,
+	}
+	r.entries[req.Key] = entry
+	return entry, nil
+}
+
+func (r *memoryCacheRepository) DeleteExpiredCachedResponses(_ context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for key, entry := range r.entries {
+		if entry.UpdatedAt.Before(before) {
+			delete(r.entries, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *memoryCacheRepository) InvalidateCachedResponses(_ context.Context, filter modelrepo.LLMCacheFilter) (int64, error) {
+	var deleted int64
+	for key, entry := range r.entries {
+		if filter.Model != nil && entry.Model != *filter.Model {
+			continue
+		}
+		if filter.GgID != nil && (entry.GgID == nil || *entry.GgID != *filter.GgID) {
+			continue
+		}
+		delete(r.entries, key)
+		deleted++
+	}
+	return deleted, nil
+}
+
+func newCachedTestClient(url string, repo LLMCacheRepository, model string) *Client {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewWithCache(log, Config{
+		Url:   url,
+		Model: model,
+		Retry: RetryConfig{MaxAttempts: 1},
+		Cache: CacheConfig{TTL: time.Hour},
+	}, repo)
+}
+
+func newCountingServer(calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result": {"group_id": 1}, "duration": 60000}`))
+	}))
+}
+
+func TestSendMessageUsesCache(t *testing.T) {
+	var calls atomic.Int32
+	server := newCountingServer(&calls)
+	defer server.Close()
+
+	repo := newMemoryCacheRepository()
+	client := newCachedTestClient(server.URL, repo, "model-a")
+	opts := CacheOptions{Enabled: true, GgID: 6}
+
+	for i := 0; i < 2; i++ {
+		// Длительность исходного запроса не выдерживается, пока не включён SimulateLatency
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		resp, err := client.SendMessage(ctx, testMessages(), json.RawMessage(`{"type":"object"}`), 1, opts)
+		cancel()
+		if err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		if resp.CacheHit != (i == 1) {
+			t.Errorf("запрос %d: неверный признак попадания в кэш %v", i, resp.CacheHit)
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("ожидался 1 запрос к LLM, выполнено %d", calls.Load())
+	}
+	if stats := client.CacheStats(); stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("неверные счётчики кэша: %+v", stats)
+	}
+
+	// Другая схема ответа - другой ключ кэша
+	if _, err := client.SendMessage(context.Background(), testMessages(), json.RawMessage(`{"type":"array"}`), 1, opts); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	// Другая модель - другой ключ кэша
+	if _, err := newCachedTestClient(server.URL, repo, "model-b").SendMessage(context.Background(), testMessages(), json.RawMessage(`{"type":"object"}`), 1, opts); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("ожидалось 3 запроса к LLM, выполнено %d", calls.Load())
+	}
+
+	model := "model-a"
+	deleted, err := client.InvalidateCache(context.Background(), modelrepo.LLMCacheFilter{Model: &model})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if deleted != 2 || len(repo.entries) != 1 {
+		t.Errorf("ожидалось удаление 2 записей model-a, удалено %d, осталось %d", deleted, len(repo.entries))
+	}
+}
+
+func TestSendMessageSkipsCacheWhenDisabled(t *testing.T) {
+	var calls atomic.Int32
+	server := newCountingServer(&calls)
+	defer server.Close()
+
+	repo := newMemoryCacheRepository()
+	client := newCachedTestClient(server.URL, repo, "model-a")
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.SendMessage(context.Background(), testMessages(), json.RawMessage(`{}`), 1, CacheOptions{}); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("ожидалось 2 запроса к LLM, выполнено %d", calls.Load())
+	}
+	// Ответ всё равно сохраняется, чтобы им можно было воспользоваться после включения кэша
+	if len(repo.entries) != 1 {
+		t.Errorf("ожидалась 1 запись в кэше, получено %d", len(repo.entries))
+	}
+}
+
+func TestPurgeExpiredCache(t *testing.T) {
+	repo := newMemoryCacheRepository()
+	client := newCachedTestClient("", repo, "model-a")
+
+	now := time.Now()
+	repo.entries[modelrepo.LLMCacheKey{MessagesHash: "old"}] = &modelrepo.LLMCache{UpdatedAt: now.Add(-2 * time.Hour)}
+	repo.entries[modelrepo.LLMCacheKey{MessagesHash: "new"}] = &modelrepo.LLMCache{UpdatedAt: now}
+
+	deleted, err := client.PurgeExpiredCache(context.Background())
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("ожидалось удаление 1 записи, удалено %d", deleted)
+	}
+	if _, ok := repo.entries[modelrepo.LLMCacheKey{MessagesHash: "new"}]; !ok {
+		t.Error("свежая запись не должна удаляться")
+	}
+}
+
+func TestPurgeExpiredCacheWithoutTTL(t *testing.T) {
+	repo := newMemoryCacheRepository()
+	client := newCachedTestClient("", repo, "model-a")
+	client.cache.TTL = 0
+
+	repo.entries[modelrepo.LLMCacheKey{MessagesHash: "old"}] = &modelrepo.LLMCache{UpdatedAt: time.Now().Add(-24 * time.Hour)}
+
+	deleted, err := client.PurgeExpiredCache(context.Background())
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if deleted != 0 || len(repo.entries) != 1 {
+		t.Errorf("без TTL записи не устаревают, удалено %d", deleted)
+	}
+}
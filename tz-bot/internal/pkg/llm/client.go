@@ -9,40 +9,80 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"math/rand/v2"
 	"net/http"
 	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"strconv"
 	"time"
 
-	"repairCopilotBot/tz-bot/internal/repository"
-
 	"github.com/google/uuid"
 )
 
-// LLMCacheRepository defines the interface for LLM cache operations
-type LLMCacheRepository interface {
-	// GetCachedResponse retrieves cached response by messages hash
-	GetCachedResponse(ctx context.Context, messagesHash string) (*modelrepo.LLMCache, error)
-
-	// SaveCachedResponse saves a new cached response
-	SaveCachedResponse(ctx context.Context, req *modelrepo.CreateLLMCacheRequest) (*modelrepo.LLMCache, error)
-}
 type Config struct {
 	Url   string `env:"URL" env-required:"true"`
 	Model string `env:"MODEL" env-required:"true"`
+	// Timeout - общее время на запрос к LLM вместе со всеми повторами
+	Timeout time.Duration `env:"TIMEOUT" env-default:"30m"`
+	Retry   RetryConfig   `env-prefix:"RETRY_"`
+	Limiter LimiterConfig `env-prefix:"LIMITER_"`
+	Cache   CacheConfig   `env-prefix:"CACHE_"`
+}
+
+// RetryConfig - параметры повтора запросов к LLM API. Повторяются только ответы 429, 5xx и сетевые ошибки
+type RetryConfig struct {
+	MaxAttempts    int           `env:"MAX_ATTEMPTS" env-default:"4"`
+	InitialBackoff time.Duration `env:"INITIAL_BACKOFF" env-default:"5s"`
+	MaxBackoff     time.Duration `env:"MAX_BACKOFF" env-default:"1m"`
+	// AttemptTimeout - время на одну попытку. Зависший запрос прерывается и повторяется,
+	// а не расходует всё время запроса
+	AttemptTimeout time.Duration `env:"ATTEMPT_TIMEOUT" env-default:"10m"`
 }
 
 type Client struct {
+	log        *slog.Logger
 	url        string // URL API для отправки файла (замените на реальный URL) apiURL := "https://your-api-endpoint.com/upload"
 	model      string
+	timeout    time.Duration
+	retry      RetryConfig
+	limiter    *limiter
+	httpClient *http.Client
 	repository LLMCacheRepository
+	cache      CacheConfig
+
+	cacheCounters cacheCounters
 }
 
-func New(url string, model string) *Client {
-	return &Client{url: url, model: model}
+// New создаёт клиент. Лимитер общий для всех запросов клиента, поэтому
+// в процессе должен быть один экземпляр
+func New(log *slog.Logger, cfg Config) *Client {
+	return &Client{
+		log:     log.With(slog.String("component", "tz_llm_client")),
+		url:     cfg.Url,
+		model:   cfg.Model,
+		timeout: cfg.Timeout,
+		retry:   cfg.Retry,
+		limiter: newLimiter(cfg.Limiter),
+		cache:   cfg.Cache,
+		// Время ограничивается контекстом запроса и каждой попытки
+		httpClient: &http.Client{},
+	}
+}
+
+func NewWithCache(log *slog.Logger, cfg Config, repo LLMCacheRepository) *Client {
+	c := New(log, cfg)
+	c.repository = repo
+	return c
 }
 
-func NewWithCache(url string, model string, repo LLMCacheRepository) *Client {
-	return &Client{url: url, model: model, repository: repo}
+// Model возвращает модель, которой клиент отправляет запросы
+func (c *Client) Model() string {
+	return c.model
+}
+
+// LimiterStats возвращает состояние лимитера запросов для метрик
+func (c *Client) LimiterStats() LimiterStats {
+	return c.limiter.stats()
 }
 
 // Request структура для отправки запроса
@@ -80,6 +120,12 @@ type SuccessResponse struct {
 	ModelUri *string `json:"model_uri"`
 	Attempts *int    `json:"attempts"`
 	Duration *int64  `json:"duration"`
+
+	// CacheHit - ответ взят из кэша, запрос к LLM API не выполнялся
+	CacheHit bool `json:"-"`
+	// ClientAttempts - сколько попыток запроса к LLM API выполнил клиент вместе с повторами.
+	// Attempts - счётчик llm-requester внутри одной попытки
+	ClientAttempts int `json:"-"`
 }
 
 type GroupReport struct {
@@ -155,145 +201,293 @@ func calculateMessagesHash(messages []struct {
 	return hex.EncodeToString(hash[:]), nil
 }
 
-func (c *Client) makeHTTPRequest(req Request, stepNumber int) (*SuccessResponse, error) {
+// retryableError - ошибка, после которой запрос к LLM API имеет смысл повторить
+// (429, 5xx, сетевые ошибки). retryAfter - пауза из заголовка Retry-After, если он был
+type retryableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// AttemptsError - ошибка запроса к LLM API с числом выполненных попыток
+type AttemptsError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *AttemptsError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AttemptsError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorAttempts возвращает число попыток запроса, после которых клиент вернул ошибку err
+func ErrorAttempts(err error) int {
+	var attemptsErr *AttemptsError
+	if errors.As(err, &attemptsErr) {
+		return attemptsErr.Attempts
+	}
+	return 0
+}
+
+func (c *Client) makeHTTPRequest(ctx context.Context, req Request, stepNumber int) (*SuccessResponse, error) {
 	jsonData, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка сериализации запроса: %w", err)
 	}
 
-	// 1 попытка сразу + 3 ретрая: 5с, 30с, 60с
-	backoffs := []time.Duration{0, 5 * time.Second, 30 * time.Second, time.Minute}
+	maxAttempts := max(c.retry.MaxAttempts, 1)
+	estimatedTokens := estimateTokens(req.Messages)
 
-	var lastErr error
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		c.log.Info("отправляем запрос к LLM API",
+			slog.String("url", c.url),
+			slog.Int("step", stepNumber),
+			slog.Int("attempt", attempt),
+			slog.Int("maxAttempts", maxAttempts))
 
-	for attempt := 0; attempt < len(backoffs); attempt++ {
-		if attempt > 0 {
-			wait := backoffs[attempt]
-			fmt.Printf("Повторная попытка %d из %d через %s...\n", attempt+1, len(backoffs), wait)
-			time.Sleep(wait)
+		reservation, err := c.acquireLimiter(ctx, estimatedTokens)
+		if err != nil {
+			return nil, &AttemptsError{Attempts: attempt - 1, Err: fmt.Errorf("запрос к LLM API отменён: %w", err)}
 		}
 
-		fmt.Printf("Отправляем запрос к LLM API (попытка %d из %d): %s\n", attempt+1, len(backoffs), c.url)
+		response, err := c.doAttempt(ctx, jsonData, stepNumber)
+		reservation.release(usedTokens(response))
+		if err == nil {
+			response.ClientAttempts = attempt
+			return response, nil
+		}
 
-		httpReq, err := http.NewRequest("POST", c.url, bytes.NewReader(jsonData))
-		if err != nil {
-			lastErr = fmt.Errorf("ошибка создания HTTP запроса: %w", err)
-			continue
+		// Проверка отменена - повторять запрос бессмысленно
+		if ctx.Err() != nil {
+			return nil, &AttemptsError{Attempts: attempt, Err: fmt.Errorf("запрос к LLM API отменён: %w", ctx.Err())}
 		}
-		httpReq.Header.Set("Content-Type", "application/json")
 
-		client := &http.Client{
-			Timeout: 30 * time.Minute,
+		var retryErr *retryableError
+		if !errors.As(err, &retryErr) {
+			return nil, &AttemptsError{Attempts: attempt, Err: err}
 		}
 
-		timeStart := time.Now()
-		resp, err := client.Do(httpReq)
-		if err != nil {
-			fmt.Println("---------ОШИБКА ПРИ ВЫПОЛНЕНИИ HTTP-ЗАПРОСА К LLM-REQUESTER. URL: ", c.url)
-			fmt.Println("ошибка - ", err.Error())
-			fmt.Println("---------КОНЕЦ ОШИБКИ ПРИ ВЫПОЛНЕНИИ HTTP-ЗАПРОСА К LLM-REQUESTER")
-			lastErr = fmt.Errorf("ошибка выполнения HTTP запроса: %w", err)
-			continue
+		if attempt >= maxAttempts {
+			return nil, &AttemptsError{Attempts: attempt, Err: fmt.Errorf("не удалось получить корректный ответ от LLM API после %d попыток: %w", attempt, err)}
 		}
 
-		inspectionTime := time.Since(timeStart).Milliseconds()
+		wait := c.retry.backoff(attempt, retryErr.retryAfter)
+		c.log.Warn("ошибка запроса к LLM API, повторяем",
+			slog.String("error", err.Error()),
+			slog.Int("attempt", attempt),
+			slog.Duration("wait", wait))
 
-		body, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		if err != nil {
-			lastErr = fmt.Errorf("ошибка чтения тела ответа: %w", err)
-			continue
+		select {
+		case <-ctx.Done():
+			return nil, &AttemptsError{Attempts: attempt, Err: fmt.Errorf("запрос к LLM API отменён: %w", ctx.Err())}
+		case <-time.After(wait):
 		}
+	}
+}
 
-		switch resp.StatusCode {
-		case 200:
-			fmt.Println("__________________________")
-			fmt.Println(string(body))
-			fmt.Println("__________________________")
+// acquireLimiter занимает слот общего лимитера и логирует ожидание в очереди
+func (c *Client) acquireLimiter(ctx context.Context, estimatedTokens int) (*reservation, error) {
+	if stats := c.limiter.stats(); stats.Queued > 0 || !c.limiter.hasCapacity(estimatedTokens) {
+		c.log.Info("запрос к LLM API ждёт в очереди лимитера",
+			slog.Int("inFlight", stats.InFlight),
+			slog.Int("queued", stats.Queued),
+			slog.Int("tokensInWindow", stats.TokensInWindow),
+			slog.Int("estimatedTokens", estimatedTokens))
+	}
 
-			var successResp SuccessResponse
-			if err := json.Unmarshal(body, &successResp); err != nil {
-				lastErr = fmt.Errorf("ошибка парсинга успешного ответа: %w", err)
-				continue
-			}
+	return c.limiter.acquire(ctx, estimatedTokens)
+}
 
-			successResp.Duration = &inspectionTime
-
-			if stepNumber == 1 {
-				if err := json.Unmarshal(successResp.ResultRaw, &successResp.Result); err != nil {
-					lastErr = fmt.Errorf("llmSendMessageMakeHTTPReq ошибка парсинга resultRawJson успешного ответа step1: %w", err)
-					continue
-				}
-			} else if stepNumber == 2 {
-				fmt.Println(string(successResp.ResultRaw))
-				if err := json.Unmarshal(successResp.ResultRaw, &successResp.ResultStep2); err != nil {
-					lastErr = fmt.Errorf("llmSendMessageMakeHTTPReq ошибка парсинга resultRawJson успешного ответа step2: %w", err)
-					continue
-				}
-			}
+func usedTokens(response *SuccessResponse) int {
+	if response == nil || response.Usage == nil || response.Usage.TotalTokens == nil {
+		return 0
+	}
+	return *response.Usage.TotalTokens
+}
+
+// doAttempt выполняет одну попытку с ограничением AttemptTimeout. Истечение времени попытки
+// (в отличие от отмены ctx) считается ошибкой, после которой запрос можно повторить
+func (c *Client) doAttempt(ctx context.Context, jsonData []byte, stepNumber int) (*SuccessResponse, error) {
+	if c.retry.AttemptTimeout <= 0 {
+		return c.doHTTPRequest(ctx, jsonData, stepNumber)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, c.retry.AttemptTimeout)
+	defer cancel()
+
+	response, err := c.doHTTPRequest(attemptCtx, jsonData, stepNumber)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		var retryErr *retryableError
+		if !errors.As(err, &retryErr) {
+			err = &retryableError{err: err}
+		}
+		return nil, fmt.Errorf("превышено время попытки запроса к LLM API (%s): %w", c.retry.AttemptTimeout, err)
+	}
 
-			return &successResp, nil
+	return response, err
+}
+
+// doHTTPRequest выполняет одну попытку запроса. Ошибки, после которых запрос можно
+// повторить, возвращаются как *retryableError
+func (c *Client) doHTTPRequest(ctx context.Context, jsonData []byte, stepNumber int) (*SuccessResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания HTTP запроса: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	timeStart := time.Now()
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.log.Error("ошибка при выполнении HTTP-запроса к llm-requester",
+			slog.String("url", c.url),
+			slog.String("error", err.Error()))
+		return nil, &retryableError{err: fmt.Errorf("ошибка выполнения HTTP запроса: %w", err)}
+	}
+
+	inspectionTime := time.Since(timeStart).Milliseconds()
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, &retryableError{err: fmt.Errorf("ошибка чтения тела ответа: %w", err)}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		c.log.Debug("ответ от LLM API", slog.String("body", string(body)))
 
-		case 422:
-			var errorResp interface{}
-			if err := json.Unmarshal(body, &errorResp); err != nil {
-				lastErr = fmt.Errorf("ошибка парсинга ответа с ошибкой валидации: %w", err)
-				continue
+		var successResp SuccessResponse
+		if err := json.Unmarshal(body, &successResp); err != nil {
+			return nil, fmt.Errorf("ошибка парсинга успешного ответа: %w", err)
+		}
+
+		successResp.Duration = &inspectionTime
+
+		if stepNumber == 1 {
+			if err := json.Unmarshal(successResp.ResultRaw, &successResp.Result); err != nil {
+				return nil, fmt.Errorf("llmSendMessageMakeHTTPReq ошибка парсинга resultRawJson успешного ответа step1: %w", err)
 			}
-			lastErr = fmt.Errorf("ошибочный ответ от ллм реквестера - %#v", errorResp)
-			// по требованию — ретраем даже такие ошибки
-			continue
+		} else if stepNumber == 2 {
+			if err := json.Unmarshal(successResp.ResultRaw, &successResp.ResultStep2); err != nil {
+				return nil, fmt.Errorf("llmSendMessageMakeHTTPReq ошибка парсинга resultRawJson успешного ответа step2: %w", err)
+			}
+		}
+
+		return &successResp, nil
+
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, &retryableError{
+			err:        fmt.Errorf("статус код: %d, тело ответа: %s", resp.StatusCode, string(body)),
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+
+	case resp.StatusCode == http.StatusUnprocessableEntity:
+		var errorResp interface{}
+		if err := json.Unmarshal(body, &errorResp); err != nil {
+			return nil, fmt.Errorf("ошибка парсинга ответа с ошибкой валидации: %w", err)
+		}
+		return nil, fmt.Errorf("ошибочный ответ от ллм реквестера - %#v", errorResp)
+
+	default:
+		return nil, fmt.Errorf("неожиданный статус код: %d, тело ответа: %s", resp.StatusCode, string(body))
+	}
+}
+
+// backoff возвращает паузу перед следующей попыткой: экспоненциальный рост от InitialBackoff
+// до MaxBackoff со случайным разбросом в верхней половине интервала. Retry-After от сервера
+// имеет приоритет
+func (r RetryConfig) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	wait := r.InitialBackoff
+	for i := 1; i < attempt && wait < r.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if r.MaxBackoff > 0 && wait > r.MaxBackoff {
+		wait = r.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// parseRetryAfter разбирает заголовок Retry-After: число секунд или HTTP-дата
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
 
-		default:
-			lastErr = fmt.Errorf("неожиданный статус код: %d, тело ответа: %s", resp.StatusCode, string(body))
-			continue
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
 		}
 	}
 
-	return nil, fmt.Errorf("не удалось получить корректный ответ от LLM API после %d попыток: %w", len(backoffs), lastErr)
+	return 0
 }
 
-func (c *Client) SendMessage(Messages []struct {
+func (c *Client) SendMessage(ctx context.Context, Messages []struct {
 	Role    *string `json:"role"`
 	Content *string `json:"content"`
 },
 	Schema json.RawMessage,
 	stepNumber int,
-	useCache bool,
+	cacheOpts CacheOptions,
 ) (*SuccessResponse, error) {
-	ctx := context.Background()
 	if Schema == nil {
 		return nil, errors.New("schema is null")
 	}
 
-	// Если репозиторий настроен, проверяем кэш
-	if c.repository != nil && useCache {
-		// Вычисляем хэш сообщений
-		messagesHash, err := calculateMessagesHash(Messages)
+	// Ответы сохраняются в кэш всегда, когда настроен репозиторий, а читаются из него
+	// только если кэш включён для запроса
+	withCache := c.repository != nil
+
+	var key modelrepo.LLMCacheKey
+	if withCache {
+		var err error
+		key, err = c.cacheKey(Messages, Schema)
 		if err != nil {
-			// Если не удалось вычислить хэш, продолжаем без кэша
-			fmt.Printf("Ошибка вычисления хэша сообщений: %v\n", err)
-		} else {
-			// Проверяем наличие ответа в кэше
-			cachedResponse, err := c.repository.GetCachedResponse(ctx, messagesHash)
-			if err == nil && cachedResponse != nil {
-				//fmt.Println("__________________________")
-				//fmt.Println(string(cachedResponse.ResponseData))
-				//fmt.Println("__________________________")
-				// Десериализуем кэшированный ответ
-				var response SuccessResponse
-				if err := json.Unmarshal(cachedResponse.ResponseData, &response); err == nil {
-					fmt.Printf("Найден кэшированный ответ для запроса\n")
-					if response.Duration != nil {
-						time.Sleep(time.Duration(*response.Duration) * time.Millisecond)
-					}
-					return &response, nil
-				} else {
-					fmt.Printf("Ошибка десериализации кэшированного ответа: %v\n", err)
-				}
-			} else if !errors.Is(err, repository.ErrLLMCacheNotFound) {
-				fmt.Printf("Ошибка проверки кэша: %v\n", err)
-			}
+			// Если не удалось вычислить ключ, продолжаем без кэша
+			c.log.Warn("ошибка вычисления ключа кэша", slog.String("error", err.Error()))
+			withCache = false
+		}
+	}
+
+	if withCache && cacheOpts.Enabled {
+		response, err := c.getCachedResponse(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if response != nil {
+			return response, nil
 		}
 	}
 
@@ -306,40 +500,13 @@ func (c *Client) SendMessage(Messages []struct {
 	}
 
 	// Выполняем запрос
-	response, err := c.makeHTTPRequest(req, stepNumber)
+	response, err := c.makeHTTPRequest(ctx, req, stepNumber)
 	if err != nil {
-
-		//fmt.Println(" отладка 11")
 		return nil, err
 	}
 
-	//fmt.Println(" отладка 12")
-
-	// Если репозиторий настроен, сохраняем ответ в кэш
-	if c.repository != nil && response != nil {
-		messagesHash, hashErr := calculateMessagesHash(Messages)
-		if hashErr == nil {
-			responseData, marshalErr := json.Marshal(response)
-			if marshalErr == nil {
-				now := time.Now()
-				cacheReq := &modelrepo.CreateLLMCacheRequest{
-					MessagesHash: messagesHash,
-					ResponseData: responseData,
-					CreatedAt:    now,
-					UpdatedAt:    now,
-				}
-				_, cacheErr := c.repository.SaveCachedResponse(ctx, cacheReq)
-				if cacheErr != nil {
-					fmt.Printf("Ошибка сохранения ответа в кэш: %v\n", cacheErr)
-				} else {
-					fmt.Printf("Ответ успешно сохранён в кэш\n")
-				}
-			} else {
-				fmt.Printf("Ошибка сериализации ответа для кэша: %v\n", marshalErr)
-			}
-		} else {
-			fmt.Printf("Ошибка вычисления хэша для сохранения в кэш: %v\n", hashErr)
-		}
+	if withCache && response != nil {
+		c.saveCachedResponse(ctx, key, cacheOpts.GgID, response)
 	}
 
 	return response, nil
@@ -0,0 +1,203 @@
+package tz_llm_client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(url string) *Client {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return New(log, Config{
+		Url:   url,
+		Model: "test-model",
+		Retry: RetryConfig{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     5 * time.Millisecond,
+		},
+	})
+}
+
+func testMessages() []struct {
+	Role    *string `json:"role"`
+	Content *string `json:"content"`
+} {
+	role, content := "user", "проверь документ"
+	return []struct {
+		Role    *string `json:"role"`
+		Content *string `json:"content"`
+	}{{Role: &role, Content: &content}}
+}
+
+func TestSendMessageRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"result": {"group_id": 1}}`))
+		}
+	}))
+	defer server.Close()
+
+	resp, err := newTestClient(server.URL).SendMessage(context.Background(), testMessages(), json.RawMessage(`{}`), 1, CacheOptions{})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("ожидалось 3 попытки, выполнено %d", calls.Load())
+	}
+	if resp.ClientAttempts != 3 {
+		t.Errorf("ожидалось ClientAttempts = 3, получено %d", resp.ClientAttempts)
+	}
+	if resp.Result == nil || resp.Result.GroupID == nil || *resp.Result.GroupID != 1 {
+		t.Errorf("неверно разобран ответ: %+v", resp.Result)
+	}
+}
+
+func TestSendMessageDoesNotRetryClientErrors(t *testing.T) {
+	for _, statusCode := range []int{http.StatusBadRequest, http.StatusUnprocessableEntity} {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte(`{"detail": "bad request"}`))
+		}))
+
+		_, err := newTestClient(server.URL).SendMessage(context.Background(), testMessages(), json.RawMessage(`{}`), 1, CacheOptions{})
+		server.Close()
+
+		if err == nil {
+			t.Errorf("статус %d: ожидалась ошибка", statusCode)
+		}
+		if calls.Load() != 1 {
+			t.Errorf("статус %d: запрос не должен повторяться, выполнено %d попыток", statusCode, calls.Load())
+		}
+	}
+}
+
+func TestSendMessageStopsAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).SendMessage(context.Background(), testMessages(), json.RawMessage(`{}`), 1, CacheOptions{})
+	if err == nil {
+		t.Fatal("ожидалась ошибка")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("ожидалось 3 попытки, выполнено %d", calls.Load())
+	}
+	if attempts := ErrorAttempts(err); attempts != 3 {
+		t.Errorf("ошибка должна содержать число попыток 3, получено %d", attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "7", want: 7 * time.Second},
+		{value: "-1", want: 0},
+		{value: now.Add(30 * time.Second).Format(http.TimeFormat), want: 30 * time.Second},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{value: "завтра", want: 0},
+	}
+
+	for _, c := range cases {
+		if got := parseRetryAfter(c.value, now); got != c.want {
+			t.Errorf("parseRetryAfter(%q) = %s, ожидалось %s", c.value, got, c.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	retry := RetryConfig{InitialBackoff: time.Second, MaxBackoff: 8 * time.Second}
+
+	for attempt, base := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 5: 8 * time.Second} {
+		for i := 0; i < 20; i++ {
+			wait := retry.backoff(attempt, 0)
+			if wait < base/2 || wait > base {
+				t.Fatalf("попытка %d: пауза %s вне интервала [%s, %s]", attempt, wait, base/2, base)
+			}
+		}
+	}
+
+	if wait := retry.backoff(1, 42*time.Second); wait != 42*time.Second {
+		t.Errorf("Retry-After должен иметь приоритет, получено %s", wait)
+	}
+}
+
+func TestSendMessageRetriesHungAttempt(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// Первая попытка зависает, пока клиент не оборвёт запрос
+			_, _ = io.Copy(io.Discard, r.Body)
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result": {"group_id": 1}}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newTestClient(server.URL)
+	client.retry.AttemptTimeout = 50 * time.Millisecond
+
+	if _, err := client.SendMessage(context.Background(), testMessages(), json.RawMessage(`{}`), 1, CacheOptions{}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("ожидалось 2 попытки, выполнено %d", calls.Load())
+	}
+}
+
+func TestSendMessageRespectsOverallTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newTestClient(server.URL)
+	client.timeout = 100 * time.Millisecond
+	client.retry.AttemptTimeout = time.Minute
+
+	start := time.Now()
+	if _, err := client.SendMessage(context.Background(), testMessages(), json.RawMessage(`{}`), 1, CacheOptions{}); err == nil {
+		t.Fatal("ожидалась ошибка")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("запрос должен прерываться по общему времени, длился %s", elapsed)
+	}
+}
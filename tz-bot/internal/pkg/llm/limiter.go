@@ -0,0 +1,221 @@
+package tz_llm_client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokensWindow - окно, в котором считается лимит токенов
+const tokensWindow = time.Minute
+
+// LimiterConfig - общий на процесс лимит запросов к LLM API
+type LimiterConfig struct {
+	MaxInFlight     int `env:"MAX_IN_FLIGHT" env-default:"8"`     // 0 - без ограничения
+	TokensPerMinute int `env:"TOKENS_PER_MINUTE" env-default:"0"` // 0 - без ограничения
+}
+
+// LimiterStats - текущее состояние лимитера для метрик
+type LimiterStats struct {
+	InFlight       int   `json:"in_flight"`
+	Queued         int   `json:"queued"`
+	TokensInWindow int   `json:"tokens_in_window"`
+	WaitedTotal    int64 `json:"waited_total"`
+	WaitMsTotal    int64 `json:"wait_ms_total"`
+}
+
+type tokenUsage struct {
+	at     time.Time
+	tokens int
+}
+
+// limiter ограничивает число одновременных запросов и расход токенов в минуту.
+// До ответа расход неизвестен, поэтому резервируется оценка, которая затем
+// заменяется фактическим значением из usage
+type limiter struct {
+	cfg LimiterConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	changed  chan struct{}
+	inFlight int
+	queued   int
+	usage    []*tokenUsage
+	waited   int64
+	waitTime time.Duration
+}
+
+func newLimiter(cfg LimiterConfig) *limiter {
+	return &limiter{
+		cfg:     cfg,
+		now:     time.Now,
+		changed: make(chan struct{}),
+	}
+}
+
+// reservation - занятый слот лимитера. release обязательно вызывать после завершения запроса
+type reservation struct {
+	l     *limiter
+	usage *tokenUsage
+}
+
+// acquire ждёт, пока появится свободный слот и запас токенов под estimatedTokens
+func (l *limiter) acquire(ctx context.Context, estimatedTokens int) (*reservation, error) {
+	started := l.now()
+	waited := false
+
+	l.mu.Lock()
+	for {
+		now := l.now()
+		l.dropExpiredLocked(now)
+
+		if l.canStartLocked(estimatedTokens) {
+			l.inFlight++
+			usage := &tokenUsage{at: now, tokens: estimatedTokens}
+			if l.cfg.TokensPerMinute > 0 {
+				l.usage = append(l.usage, usage)
+			}
+			if waited {
+				l.queued--
+				l.waited++
+				l.waitTime += now.Sub(started)
+			}
+			l.mu.Unlock()
+			return &reservation{l: l, usage: usage}, nil
+		}
+
+		if !waited {
+			waited = true
+			l.queued++
+		}
+
+		changed := l.changed
+		wait := l.nextExpiryLocked(now)
+		l.mu.Unlock()
+
+		var timer *time.Timer
+		var timerC <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			timerC = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			l.mu.Lock()
+			l.queued--
+			l.mu.Unlock()
+			return nil, ctx.Err()
+		case <-changed:
+		case <-timerC:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		l.mu.Lock()
+	}
+}
+
+// release освобождает слот и записывает фактический расход токенов (если он известен)
+func (r *reservation) release(actualTokens int) {
+	l := r.l
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	if actualTokens > 0 {
+		r.usage.tokens = actualTokens
+	}
+	l.notifyLocked()
+}
+
+func (l *limiter) stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.dropExpiredLocked(l.now())
+
+	return LimiterStats{
+		InFlight:       l.inFlight,
+		Queued:         l.queued,
+		TokensInWindow: l.tokensInWindowLocked(),
+		WaitedTotal:    l.waited,
+		WaitMsTotal:    l.waitTime.Milliseconds(),
+	}
+}
+
+// hasCapacity сообщает, начнётся ли запрос сразу, без ожидания в очереди
+func (l *limiter) hasCapacity(estimatedTokens int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.dropExpiredLocked(l.now())
+	return l.canStartLocked(estimatedTokens)
+}
+
+func (l *limiter) canStartLocked(estimatedTokens int) bool {
+	if l.cfg.MaxInFlight > 0 && l.inFlight >= l.cfg.MaxInFlight {
+		return false
+	}
+
+	// Запрос больше всего лимита пропускаем, когда окно пустое - иначе он не выполнится никогда
+	if l.cfg.TokensPerMinute > 0 && len(l.usage) > 0 &&
+		l.tokensInWindowLocked()+estimatedTokens > l.cfg.TokensPerMinute {
+		return false
+	}
+
+	return true
+}
+
+func (l *limiter) tokensInWindowLocked() int {
+	total := 0
+	for _, u := range l.usage {
+		total += u.tokens
+	}
+	return total
+}
+
+func (l *limiter) dropExpiredLocked(now time.Time) {
+	expired := 0
+	for expired < len(l.usage) && now.Sub(l.usage[expired].at) >= tokensWindow {
+		expired++
+	}
+	if expired > 0 {
+		l.usage = l.usage[expired:]
+		l.notifyLocked()
+	}
+}
+
+// nextExpiryLocked возвращает, через сколько освободится самая старая запись окна токенов
+func (l *limiter) nextExpiryLocked(now time.Time) time.Duration {
+	if len(l.usage) == 0 {
+		return 0
+	}
+	return l.usage[0].at.Add(tokensWindow).Sub(now)
+}
+
+func (l *limiter) notifyLocked() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// estimateTokens грубо оценивает число токенов запроса по длине сообщений
+func estimateTokens(messages []struct {
+	Role    *string `json:"role"`
+	Content *string `json:"content"`
+}) int {
+	chars := 0
+	for _, msg := range messages {
+		if msg.Content != nil {
+			chars += len([]rune(*msg.Content))
+		}
+	}
+	// ~3 символа кириллического текста на токен
+	return chars/3 + 1
+}
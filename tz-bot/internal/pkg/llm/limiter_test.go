@@ -0,0 +1,105 @@
+package tz_llm_client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	l := newLimiter(LimiterConfig{MaxInFlight: 1})
+
+	first, err := l.acquire(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	acquired := make(chan *reservation)
+	go func() {
+		r, err := l.acquire(context.Background(), 10)
+		if err != nil {
+			t.Errorf("неожиданная ошибка: %v", err)
+		}
+		acquired <- r
+	}()
+
+	waitForQueued(t, l, 1)
+
+	select {
+	case <-acquired:
+		t.Fatal("второй запрос не должен стартовать, пока занят единственный слот")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	first.release(0)
+
+	select {
+	case r := <-acquired:
+		r.release(0)
+	case <-time.After(time.Second):
+		t.Fatal("второй запрос не стартовал после освобождения слота")
+	}
+
+	stats := l.stats()
+	if stats.InFlight != 0 || stats.Queued != 0 || stats.WaitedTotal != 1 {
+		t.Errorf("неверная статистика лимитера: %+v", stats)
+	}
+}
+
+func TestLimiterTokensPerMinute(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := newLimiter(LimiterConfig{TokensPerMinute: 100})
+	l.now = func() time.Time { return now }
+
+	// Оценка заменяется фактическим расходом из ответа
+	r, err := l.acquire(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	r.release(80)
+
+	if l.hasCapacity(30) {
+		t.Error("запрос сверх лимита токенов не должен стартовать сразу")
+	}
+	if !l.hasCapacity(20) {
+		t.Error("запрос в пределах лимита токенов должен стартовать сразу")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx, 30); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ожидалась отмена ожидания по контексту, получено %v", err)
+	}
+	if stats := l.stats(); stats.Queued != 0 || stats.TokensInWindow != 80 {
+		t.Errorf("неверная статистика лимитера: %+v", stats)
+	}
+
+	// Через минуту расход выпадает из окна
+	now = now.Add(tokensWindow)
+	if !l.hasCapacity(100) {
+		t.Error("после окна лимит токенов должен освободиться")
+	}
+}
+
+func TestLimiterAllowsOversizedRequestWhenWindowIsEmpty(t *testing.T) {
+	l := newLimiter(LimiterConfig{TokensPerMinute: 100})
+
+	r, err := l.acquire(context.Background(), 500)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	r.release(0)
+}
+
+func waitForQueued(t *testing.T, l *limiter, queued int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for l.stats().Queued != queued {
+		if time.Now().After(deadline) {
+			t.Fatalf("в очереди лимитера ожидалось %d запросов", queued)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -41,40 +41,45 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
-// IncrementInspectionsForToday увеличивает счетчик проверок за сегодня для пользователя
-func (c *Client) IncrementInspectionsForToday(ctx context.Context, userID string) error {
+// IncrementInspectionsForToday увеличивает счетчик проверок за сегодня для пользователя и возвращает
+// ID организации, из квоты которой списана проверка. Пустая строка - списан личный лимит
+func (c *Client) IncrementInspectionsForToday(ctx context.Context, userID string) (string, error) {
 	req := &pb.IncrementInspectionsForTodayByUserIdRequest{
 		UserId: userID,
 	}
 
-	_, err := c.client.IncrementInspectionsForTodayByUserId(ctx, req)
+	resp, err := c.client.IncrementInspectionsForTodayByUserId(ctx, req)
 	if err != nil {
 		// Обработка gRPC статусов
 		if st, ok := status.FromError(err); ok {
 			switch st.Code() {
 			case codes.InvalidArgument:
-				return fmt.Errorf("invalid user_id: %s", st.Message())
+				return "", fmt.Errorf("invalid user_id: %s", st.Message())
 			case codes.ResourceExhausted:
-				return fmt.Errorf("daily inspection limit exceeded")
+				return "", fmt.Errorf("daily inspection limit exceeded")
 			case codes.NotFound:
-				return fmt.Errorf("user not found")
+				return "", fmt.Errorf("user not found")
 			case codes.Internal:
-				return fmt.Errorf("internal server error")
+				return "", fmt.Errorf("internal server error")
 			default:
-				return fmt.Errorf("failed to increment inspections for today: %s", st.Message())
+				return "", fmt.Errorf("failed to increment inspections for today: %s", st.Message())
 			}
 		}
-		return err
+		return "", err
 	}
 
-	return nil
+	return resp.GetOrganizationId(), nil
 }
 
-// DecrementInspectionsForToday уменьшает счетчик проверок за сегодня для пользователя
-func (c *Client) DecrementInspectionsForToday(ctx context.Context, userID string) error {
+// DecrementInspectionsForToday уменьшает счетчик проверок за сегодня для пользователя. Проверка
+// возвращается в квоту организации organizationID, из которой была списана, пустой - в личный лимит
+func (c *Client) DecrementInspectionsForToday(ctx context.Context, userID string, organizationID string) error {
 	req := &pb.DecrementInspectionsForTodayByUserIdRequest{
 		UserId: userID,
 	}
+	if organizationID != "" {
+		req.OrganizationId = &organizationID
+	}
 
 	_, err := c.client.DecrementInspectionsForTodayByUserId(ctx, req)
 	if err != nil {
@@ -95,4 +100,31 @@ func (c *Client) DecrementInspectionsForToday(ctx context.Context, userID string
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// UserOrganizationID возвращает ID организации, в которой состоит пользователь.
+// Если пользователь не состоит в организации, возвращает пустую строку
+func (c *Client) UserOrganizationID(ctx context.Context, userID string) (string, error) {
+	req := &pb.GetUserOrganizationRequest{
+		UserId: userID,
+	}
+
+	resp, err := c.client.GetUserOrganization(ctx, req)
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return "", nil
+			case codes.InvalidArgument:
+				return "", fmt.Errorf("invalid user_id: %s", st.Message())
+			case codes.Internal:
+				return "", fmt.Errorf("internal server error")
+			default:
+				return "", fmt.Errorf("failed to get user organization: %s", st.Message())
+			}
+		}
+		return "", err
+	}
+
+	return resp.Organization.GetId(), nil
+}
@@ -34,6 +34,15 @@ type Version struct {
 	Status                   string         `db:"status"`
 	LlmReport                string         `db:"report"`
 	Progress                 int            `db:"progress"`
+	AnnotatedFileID          *string        `db:"annotated_file_id"`
+	FileHash                 *string        `db:"file_hash"`
+	MarkdownHash             *string        `db:"markdown_hash"`
+	RecheckOfVersionID       *uuid.UUID     `db:"recheck_of_version_id"`
+	CheckProfileID           *uuid.UUID     `db:"check_profile_id"`
+	GgID                     *int           `db:"gg_id"`
+	OriginalFileExtension    string         `db:"original_file_extension"`
+	OrganizationID           *uuid.UUID     `db:"organization_id"`
+	ChargedOrganizationID    *uuid.UUID     `db:"charged_organization_id"`
 }
 
 // VersionWithTechnicalSpec represents a version with technical specification info
@@ -53,6 +62,15 @@ type VersionWithTechnicalSpec struct {
 	InspectionTime             *time.Duration `db:"inspection_time"`
 }
 
+// DuplicateVersion - завершённая версия пользователя с тем же содержимым документа
+type DuplicateVersion struct {
+	ID                         uuid.UUID `db:"id"`
+	TechnicalSpecificationID   uuid.UUID `db:"technical_specification_id"`
+	TechnicalSpecificationName string    `db:"technical_specification_name"`
+	VersionNumber              int       `db:"version_number"`
+	CreatedAt                  time.Time `db:"created_at"`
+}
+
 // VersionSummary represents minimal version data for API responses
 //type VersionSummary struct {
 //	ID                         uuid.UUID `db:"id"`
@@ -134,6 +152,16 @@ type CreateVersionRequest struct {
 	NumberOfErrors           int
 	Status                   string
 	Progress                 int
+	FileHash                 *string
+	RecheckOfVersionID       *uuid.UUID
+	CheckProfileID           *uuid.UUID
+	GgID                     *int
+	// OriginalFileExtension - расширение, с которым оригинал сохранён в S3
+	OriginalFileExtension string
+	// OrganizationID - организация, из квоты которой оплачена проверка, nil - личный лимит
+	OrganizationID *uuid.UUID
+	// ChargedOrganizationID - квота, в которую проверка возвращается при отмене или ошибке
+	ChargedOrganizationID *uuid.UUID
 }
 
 // UpdateVersionRequest represents request to update an existing version
@@ -245,6 +273,7 @@ type ErrorData struct {
 	ProcessVerification *string
 	ProcessRetrieval    *[]string
 	Instances           []byte // JSONB
+	CatalogEntryID      *uuid.UUID
 }
 
 // ErrorType represents the type of error (invalid or missing)
@@ -284,16 +313,190 @@ type CreateErrorFeedbackRequest struct {
 // LLMCache represents a cached LLM request and response
 type LLMCache struct {
 	ID           uuid.UUID `db:"id"`
+	Model        string    `db:"model"`
+	SchemaHash   string    `db:"schema_hash"`
 	MessagesHash string    `db:"messages_hash"`
+	GgID         *int      `db:"gg_id"`
 	ResponseData []byte    `db:"response_data"`
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
 }
 
+// LLMCacheKey identifies a cache entry: the same messages sent to another model
+// or with another response schema are cached separately
+type LLMCacheKey struct {
+	Model        string
+	SchemaHash   string
+	MessagesHash string
+}
+
 // CreateLLMCacheRequest represents request to create a new cache entry
 type CreateLLMCacheRequest struct {
-	MessagesHash string
+	Key          LLMCacheKey
+	GgID         *int
 	ResponseData []byte
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
+
+// LLMCacheFilter selects cache entries to invalidate. Nil fields are not filtered on
+type LLMCacheFilter struct {
+	Model *string
+	GgID  *int
+	From  *time.Time
+	To    *time.Time
+}
+
+// CheckProfile represents a named set of error groups an inspection is run with
+type CheckProfile struct {
+	ID          uuid.UUID `db:"id"`
+	Name        string    `db:"name"`
+	Title       string    `db:"title"`
+	Description *string   `db:"description"`
+	GgID        int       `db:"gg_id"`
+	IsDefault   bool      `db:"is_default"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// CreateCheckProfileRequest represents request to create a new check profile
+type CreateCheckProfileRequest struct {
+	ID          uuid.UUID
+	Name        string
+	Title       string
+	Description *string
+	GgID        int
+}
+
+// UpdateCheckProfileRequest represents request to update a check profile by name. Nil fields are not changed
+type UpdateCheckProfileRequest struct {
+	Name        string
+	Title       *string
+	Description *string
+	GgID        *int
+}
+
+// ErrorCatalogEntry represents a version of an error description synced from promt-builder
+type ErrorCatalogEntry struct {
+	ID           uuid.UUID  `db:"id"`
+	GgID         int        `db:"gg_id"`
+	Code         string     `db:"code"`
+	Version      int        `db:"version"`
+	Name         string     `db:"name"`
+	Description  string     `db:"description"`
+	Detector     string     `db:"detector"`
+	ContentHash  string     `db:"content_hash"`
+	IsCurrent    bool       `db:"is_current"`
+	CreatedAt    time.Time  `db:"created_at"`
+	SupersededAt *time.Time `db:"superseded_at"`
+}
+
+// ErrorCatalogEntryData represents an error description received from promt-builder
+type ErrorCatalogEntryData struct {
+	Code        string
+	Name        string
+	Description string
+	Detector    string
+	ContentHash string
+}
+
+// ErrorCatalogFilter represents filters for listing the error catalog. Zero values are not applied
+type ErrorCatalogFilter struct {
+	GgID           *int
+	Code           string
+	IncludeHistory bool
+}
+
+// ShareLink represents a link to view a version without an account
+type ShareLink struct {
+	ID             uuid.UUID  `db:"id"`
+	VersionID      uuid.UUID  `db:"version_id"`
+	TokenHash      string     `db:"token_hash"`
+	Permissions    []string   `db:"permissions"`
+	CreatedBy      uuid.UUID  `db:"created_by"`
+	ExpiresAt      time.Time  `db:"expires_at"`
+	RevokedAt      *time.Time `db:"revoked_at"`
+	AccessCount    int        `db:"access_count"`
+	LastAccessedAt *time.Time `db:"last_accessed_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+}
+
+// CreateShareLinkRequest represents request to create a share link
+type CreateShareLinkRequest struct {
+	ID          uuid.UUID
+	VersionID   uuid.UUID
+	TokenHash   string
+	Permissions []string
+	CreatedBy   uuid.UUID
+	ExpiresAt   time.Time
+}
+
+// ProcessingJob represents a queued inspection of a version
+type ProcessingJob struct {
+	ID              uuid.UUID  `db:"id"`
+	VersionID       uuid.UUID  `db:"version_id"`
+	UserID          uuid.UUID  `db:"user_id"`
+	Filename        string     `db:"filename"`
+	OriginalFileKey string     `db:"original_file_key"`
+	Status          string     `db:"status"`
+	Attempts        int        `db:"attempts"`
+	LastError       *string    `db:"last_error"`
+	RunAfter        time.Time  `db:"run_after"`
+	LeaseExpiresAt  *time.Time `db:"lease_expires_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+// CreateProcessingJobRequest represents request to enqueue a version inspection
+type CreateProcessingJobRequest struct {
+	ID              uuid.UUID `db:"id"`
+	VersionID       uuid.UUID `db:"version_id"`
+	UserID          uuid.UUID `db:"user_id"`
+	Filename        string    `db:"filename"`
+	OriginalFileKey string    `db:"original_file_key"`
+}
+
+// VersionEvent represents a progress event of a version inspection
+type VersionEvent struct {
+	ID        int64     `db:"id"`
+	VersionID uuid.UUID `db:"version_id"`
+	EventType string    `db:"event_type"`
+	Payload   []byte    `db:"payload"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// LLMCall represents a single LLM API request made while inspecting a version
+type LLMCall struct {
+	ID               int64     `db:"id"`
+	VersionID        uuid.UUID `db:"version_id"`
+	Step             int       `db:"step"`
+	GroupID          *int      `db:"group_id"`
+	GroupName        *string   `db:"group_name"`
+	Model            string    `db:"model"`
+	PromptTokens     int       `db:"prompt_tokens"`
+	CompletionTokens int       `db:"completion_tokens"`
+	TotalTokens      int       `db:"total_tokens"`
+	Rubs             float64   `db:"rubs"`
+	DurationMs       int64     `db:"duration_ms"`
+	CacheHit         bool      `db:"cache_hit"`
+	Attempts         int       `db:"attempts"`
+	Error            *string   `db:"error"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// CreateLLMCallRequest represents request to record an LLM API request
+type CreateLLMCallRequest struct {
+	VersionID        uuid.UUID
+	Step             int
+	GroupID          *int
+	GroupName        *string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Rubs             float64
+	DurationMs       int64
+	CacheHit         bool
+	Attempts         int
+	Error            *string
+}
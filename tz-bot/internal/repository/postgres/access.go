@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	repo "repairCopilotBot/tz-bot/internal/repository"
+)
+
+// GetVersionOwnerID возвращает id пользователя, которому принадлежит ТЗ версии
+func (s *Storage) GetVersionOwnerID(ctx context.Context, versionID uuid.UUID) (uuid.UUID, error) {
+	query := `
+		SELECT ts.user_id
+		FROM versions v
+		JOIN technical_specifications ts ON ts.id = v.technical_specification_id
+		WHERE v.id = $1`
+
+	var userID uuid.UUID
+	if err := s.db.QueryRow(ctx, query, versionID).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, repo.ErrVersionNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to get version owner: %w", err)
+	}
+
+	return userID, nil
+}
+
+// GetVersionOrganizationID возвращает организацию, из квоты которой оплачена проверка версии.
+// nil - проверка списана с личного лимита владельца
+func (s *Storage) GetVersionOrganizationID(ctx context.Context, versionID uuid.UUID) (*uuid.UUID, error) {
+	query := `SELECT organization_id FROM versions WHERE id = $1`
+
+	var organizationID *uuid.UUID
+	if err := s.db.QueryRow(ctx, query, versionID).Scan(&organizationID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repo.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get version organization: %w", err)
+	}
+
+	return organizationID, nil
+}
+
+// GetVersionChargedOrganizationID возвращает организацию, из квоты которой списана последняя проверка
+// версии. nil - проверка списана с личного лимита владельца
+func (s *Storage) GetVersionChargedOrganizationID(ctx context.Context, versionID uuid.UUID) (*uuid.UUID, error) {
+	query := `SELECT charged_organization_id FROM versions WHERE id = $1`
+
+	var organizationID *uuid.UUID
+	if err := s.db.QueryRow(ctx, query, versionID).Scan(&organizationID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repo.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get version charged organization: %w", err)
+	}
+
+	return organizationID, nil
+}
+
+// SetVersionChargedOrganizationID запоминает квоту, из которой списана повторная проверка версии
+func (s *Storage) SetVersionChargedOrganizationID(ctx context.Context, versionID uuid.UUID, organizationID *uuid.UUID) error {
+	query := `UPDATE versions SET charged_organization_id = $2 WHERE id = $1`
+
+	result, err := s.db.Exec(ctx, query, versionID, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to set version charged organization: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repo.ErrVersionNotFound
+	}
+
+	return nil
+}
+
+// GetInstanceVersionID возвращает id версии, к которой относится найденное (invalid) или
+// пропущенное (missing) нарушение
+func (s *Storage) GetInstanceVersionID(ctx context.Context, instanceID uuid.UUID, instanceType string) (uuid.UUID, error) {
+	var table string
+	switch instanceType {
+	case "invalid":
+		table = "invalid_instances"
+	case "missing":
+		table = "missing_instances"
+	default:
+		return uuid.Nil, fmt.Errorf("unknown instance type: %s", instanceType)
+	}
+
+	query := `
+		SELECT e.version_id
+		FROM ` + table + ` i
+		JOIN errors e ON e.id = i.error_id
+		WHERE i.id = $1`
+
+	var versionID uuid.UUID
+	if err := s.db.QueryRow(ctx, query, instanceID).Scan(&versionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, repo.ErrInstanceNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to get instance version: %w", err)
+	}
+
+	return versionID, nil
+}
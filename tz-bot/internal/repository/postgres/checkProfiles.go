@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	repo "repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+)
+
+const checkProfileColumns = `id, name, title, description, gg_id, is_default, created_at, updated_at`
+
+func scanCheckProfile(row pgx.Row) (*modelrepo.CheckProfile, error) {
+	var profile modelrepo.CheckProfile
+	err := row.Scan(&profile.ID, &profile.Name, &profile.Title, &profile.Description, &profile.GgID,
+		&profile.IsDefault, &profile.CreatedAt, &profile.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repo.ErrCheckProfileNotFound
+		}
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+// ListCheckProfiles возвращает все профили проверки
+func (s *Storage) ListCheckProfiles(ctx context.Context) ([]modelrepo.CheckProfile, error) {
+	query := `SELECT ` + checkProfileColumns + ` FROM check_profiles ORDER BY name`
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := make([]modelrepo.CheckProfile, 0)
+	for rows.Next() {
+		profile, err := scanCheckProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan check profile: %w", err)
+		}
+		profiles = append(profiles, *profile)
+	}
+
+	return profiles, rows.Err()
+}
+
+// GetCheckProfileByName возвращает профиль проверки по коду
+func (s *Storage) GetCheckProfileByName(ctx context.Context, name string) (*modelrepo.CheckProfile, error) {
+	query := `SELECT ` + checkProfileColumns + ` FROM check_profiles WHERE name = $1`
+
+	profile, err := scanCheckProfile(s.db.QueryRow(ctx, query, name))
+	if err != nil && !errors.Is(err, repo.ErrCheckProfileNotFound) {
+		return nil, fmt.Errorf("failed to get check profile: %w", err)
+	}
+
+	return profile, err
+}
+
+// GetDefaultCheckProfile возвращает профиль по умолчанию
+func (s *Storage) GetDefaultCheckProfile(ctx context.Context) (*modelrepo.CheckProfile, error) {
+	query := `SELECT ` + checkProfileColumns + ` FROM check_profiles WHERE is_default`
+
+	profile, err := scanCheckProfile(s.db.QueryRow(ctx, query))
+	if err != nil && !errors.Is(err, repo.ErrCheckProfileNotFound) {
+		return nil, fmt.Errorf("failed to get default check profile: %w", err)
+	}
+
+	return profile, err
+}
+
+// CreateCheckProfile создаёт профиль проверки
+func (s *Storage) CreateCheckProfile(ctx context.Context, req *modelrepo.CreateCheckProfileRequest) (*modelrepo.CheckProfile, error) {
+	query := `
+		INSERT INTO check_profiles (id, name, title, description, gg_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + checkProfileColumns
+
+	profile, err := scanCheckProfile(s.db.QueryRow(ctx, query, req.ID, req.Name, req.Title, req.Description, req.GgID))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, repo.ErrDuplicateCheckProfile
+		}
+		return nil, fmt.Errorf("failed to create check profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// UpdateCheckProfile изменяет название, описание или gg_id профиля. Незаданные поля не меняются
+func (s *Storage) UpdateCheckProfile(ctx context.Context, req *modelrepo.UpdateCheckProfileRequest) (*modelrepo.CheckProfile, error) {
+	query := `
+		UPDATE check_profiles
+		SET title = COALESCE($2, title), description = COALESCE($3, description), gg_id = COALESCE($4, gg_id), updated_at = NOW()
+		WHERE name = $1
+		RETURNING ` + checkProfileColumns
+
+	profile, err := scanCheckProfile(s.db.QueryRow(ctx, query, req.Name, req.Title, req.Description, req.GgID))
+	if err != nil && !errors.Is(err, repo.ErrCheckProfileNotFound) {
+		return nil, fmt.Errorf("failed to update check profile: %w", err)
+	}
+
+	return profile, err
+}
+
+// DeleteCheckProfile удаляет профиль проверки. У проверенных с ним версий остаётся gg_id
+func (s *Storage) DeleteCheckProfile(ctx context.Context, name string) error {
+	result, err := s.db.Exec(ctx, `DELETE FROM check_profiles WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete check profile: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrCheckProfileNotFound
+	}
+
+	return nil
+}
+
+// SetDefaultCheckProfile делает профиль профилем по умолчанию, снимая признак с предыдущего
+func (s *Storage) SetDefaultCheckProfile(ctx context.Context, name string) (*modelrepo.CheckProfile, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE check_profiles SET is_default = FALSE, updated_at = NOW() WHERE is_default AND name <> $1`, name); err != nil {
+		return nil, fmt.Errorf("failed to reset default check profile: %w", err)
+	}
+
+	query := `
+		UPDATE check_profiles
+		SET is_default = TRUE, updated_at = NOW()
+		WHERE name = $1
+		RETURNING ` + checkProfileColumns
+
+	profile, err := scanCheckProfile(tx.QueryRow(ctx, query, name))
+	if err != nil {
+		if errors.Is(err, repo.ErrCheckProfileNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to set default check profile: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return profile, nil
+}
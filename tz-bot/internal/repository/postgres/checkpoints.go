@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	repo "repairCopilotBot/tz-bot/internal/repository"
+)
+
+// SaveVersionCheckpoint сохраняет (или перезаписывает) результат стадии обработки версии
+func (s *Storage) SaveVersionCheckpoint(ctx context.Context, versionID uuid.UUID, stage string, data []byte) error {
+	query := `
+		INSERT INTO version_checkpoints (version_id, stage, data, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (version_id, stage) DO UPDATE SET data = EXCLUDED.data, created_at = EXCLUDED.created_at`
+
+	_, err := s.db.Exec(ctx, query, versionID, stage, data)
+	if err != nil {
+		return fmt.Errorf("failed to save version checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// GetVersionCheckpoint возвращает сохранённый результат стадии обработки версии
+func (s *Storage) GetVersionCheckpoint(ctx context.Context, versionID uuid.UUID, stage string) ([]byte, error) {
+	query := `SELECT data FROM version_checkpoints WHERE version_id = $1 AND stage = $2`
+
+	var data []byte
+	err := s.db.QueryRow(ctx, query, versionID, stage).Scan(&data)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repo.ErrCheckpointNotFound
+		}
+		return nil, fmt.Errorf("failed to get version checkpoint: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetVersionCheckpointStages возвращает список стадий, для которых у версии есть сохранённый результат
+func (s *Storage) GetVersionCheckpointStages(ctx context.Context, versionID uuid.UUID) ([]string, error) {
+	query := `SELECT stage FROM version_checkpoints WHERE version_id = $1`
+
+	rows, err := s.db.Query(ctx, query, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version checkpoint stages: %w", err)
+	}
+	defer rows.Close()
+
+	stages := make([]string, 0)
+	for rows.Next() {
+		var stage string
+		if err := rows.Scan(&stage); err != nil {
+			return nil, fmt.Errorf("failed to scan version checkpoint stage: %w", err)
+		}
+		stages = append(stages, stage)
+	}
+
+	return stages, rows.Err()
+}
+
+// DeleteVersionCheckpoints удаляет результаты указанных стадий обработки версии
+func (s *Storage) DeleteVersionCheckpoints(ctx context.Context, versionID uuid.UUID, stages []string) error {
+	query := `DELETE FROM version_checkpoints WHERE version_id = $1 AND stage = ANY($2)`
+
+	_, err := s.db.Exec(ctx, query, versionID, stages)
+	if err != nil {
+		return fmt.Errorf("failed to delete version checkpoints: %w", err)
+	}
+
+	return nil
+}
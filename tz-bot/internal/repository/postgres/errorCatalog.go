@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	repo "repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+)
+
+const errorCatalogColumns = `id, gg_id, code, version, name, description, detector, content_hash, is_current, created_at, superseded_at`
+
+// errorCatalogLockKey - ключ advisory-блокировки синхронизации каталога. Вместе с gg_id не даёт
+// параллельным проверкам создать одну и ту же версию записи
+const errorCatalogLockKey = 20013
+
+func scanErrorCatalogEntry(row pgx.Row) (*modelrepo.ErrorCatalogEntry, error) {
+	var entry modelrepo.ErrorCatalogEntry
+	err := row.Scan(&entry.ID, &entry.GgID, &entry.Code, &entry.Version, &entry.Name, &entry.Description,
+		&entry.Detector, &entry.ContentHash, &entry.IsCurrent, &entry.CreatedAt, &entry.SupersededAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repo.ErrErrorCatalogEntryNotFound
+		}
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// SyncErrorCatalog сверяет описания ошибок группы с текущими записями каталога. Для новых кодов
+// создаётся первая версия, для изменившихся - следующая, а предыдущая помечается устаревшей.
+// Возвращает id актуальной записи для каждого кода
+func (s *Storage) SyncErrorCatalog(ctx context.Context, ggID int, entries []modelrepo.ErrorCatalogEntryData) (map[string]uuid.UUID, error) {
+	ids := make(map[string]uuid.UUID, len(entries))
+	if len(entries) == 0 {
+		return ids, nil
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, $2)`, errorCatalogLockKey, ggID); err != nil {
+		return nil, fmt.Errorf("failed to lock error catalog: %w", err)
+	}
+
+	for _, entry := range entries {
+		var currentID uuid.UUID
+		var currentVersion int
+		var currentHash string
+		err := tx.QueryRow(ctx,
+			`SELECT id, version, content_hash FROM error_catalog WHERE gg_id = $1 AND code = $2 AND is_current`,
+			ggID, entry.Code,
+		).Scan(&currentID, &currentVersion, &currentHash)
+		switch {
+		case err == nil && currentHash == entry.ContentHash:
+			ids[entry.Code] = currentID
+			continue
+		case err == nil:
+			if _, err := tx.Exec(ctx,
+				`UPDATE error_catalog SET is_current = FALSE, superseded_at = NOW() WHERE id = $1`, currentID,
+			); err != nil {
+				return nil, fmt.Errorf("failed to supersede error catalog entry: %w", err)
+			}
+		case errors.Is(err, pgx.ErrNoRows):
+			currentVersion = 0
+		default:
+			return nil, fmt.Errorf("failed to get current error catalog entry: %w", err)
+		}
+
+		id := uuid.New()
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO error_catalog (id, gg_id, code, version, name, description, detector, content_hash)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			id, ggID, entry.Code, currentVersion+1, entry.Name, entry.Description, entry.Detector, entry.ContentHash,
+		); err != nil {
+			return nil, fmt.Errorf("failed to create error catalog entry: %w", err)
+		}
+		ids[entry.Code] = id
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return ids, nil
+}
+
+// ListErrorCatalog возвращает записи каталога. Без IncludeHistory - только актуальные версии
+func (s *Storage) ListErrorCatalog(ctx context.Context, filter modelrepo.ErrorCatalogFilter) ([]modelrepo.ErrorCatalogEntry, error) {
+	query := `
+		SELECT ` + errorCatalogColumns + `
+		FROM error_catalog
+		WHERE (@gg_id::INTEGER IS NULL OR gg_id = @gg_id)
+		  AND (@code = '' OR code = @code)
+		  AND (@include_history::BOOLEAN OR is_current)
+		ORDER BY gg_id, code, version DESC`
+
+	args := pgx.NamedArgs{
+		"gg_id":           filter.GgID,
+		"code":            filter.Code,
+		"include_history": filter.IncludeHistory,
+	}
+
+	rows, err := s.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list error catalog: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]modelrepo.ErrorCatalogEntry, 0)
+	for rows.Next() {
+		entry, err := scanErrorCatalogEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan error catalog entry: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetErrorCatalogEntry возвращает запись каталога по id
+func (s *Storage) GetErrorCatalogEntry(ctx context.Context, id uuid.UUID) (*modelrepo.ErrorCatalogEntry, error) {
+	query := `SELECT ` + errorCatalogColumns + ` FROM error_catalog WHERE id = $1`
+
+	entry, err := scanErrorCatalogEntry(s.db.QueryRow(ctx, query, id))
+	if err != nil && !errors.Is(err, repo.ErrErrorCatalogEntryNotFound) {
+		return nil, fmt.Errorf("failed to get error catalog entry: %w", err)
+	}
+
+	return entry, err
+}
+
+// GetErrorCatalogEntryByCode возвращает версию записи каталога по коду ошибки. Если version не задан,
+// возвращается актуальная версия
+func (s *Storage) GetErrorCatalogEntryByCode(ctx context.Context, ggID int, code string, version *int) (*modelrepo.ErrorCatalogEntry, error) {
+	query := `
+		SELECT ` + errorCatalogColumns + `
+		FROM error_catalog
+		WHERE gg_id = $1 AND code = $2
+		  AND (($3::INTEGER IS NULL AND is_current) OR version = $3)`
+
+	entry, err := scanErrorCatalogEntry(s.db.QueryRow(ctx, query, ggID, code, version))
+	if err != nil && !errors.Is(err, repo.ErrErrorCatalogEntryNotFound) {
+		return nil, fmt.Errorf("failed to get error catalog entry: %w", err)
+	}
+
+	return entry, err
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+)
+
+// CreateLLMCall сохраняет запись о запросе к LLM
+func (s *Storage) CreateLLMCall(ctx context.Context, req *modelrepo.CreateLLMCallRequest) error {
+	query := `
+		INSERT INTO llm_calls (version_id, step, group_id, group_name, model, prompt_tokens, completion_tokens,
+		                       total_tokens, rubs, duration_ms, cache_hit, attempts, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err := s.db.Exec(ctx, query,
+		req.VersionID, req.Step, req.GroupID, req.GroupName, req.Model, req.PromptTokens, req.CompletionTokens,
+		req.TotalTokens, req.Rubs, req.DurationMs, req.CacheHit, req.Attempts, req.Error)
+	if err != nil {
+		return fmt.Errorf("failed to create llm call: %w", err)
+	}
+
+	return nil
+}
+
+// GetLLMCallsByVersionID возвращает запросы к LLM, сделанные при проверке версии, в порядке выполнения
+func (s *Storage) GetLLMCallsByVersionID(ctx context.Context, versionID uuid.UUID) ([]modelrepo.LLMCall, error) {
+	query := `
+		SELECT id, version_id, step, group_id, group_name, model, prompt_tokens, completion_tokens,
+		       total_tokens, rubs, duration_ms, cache_hit, attempts, error, created_at
+		FROM llm_calls
+		WHERE version_id = $1
+		ORDER BY id`
+
+	rows, err := s.db.Query(ctx, query, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get llm calls: %w", err)
+	}
+	defer rows.Close()
+
+	calls := make([]modelrepo.LLMCall, 0)
+	for rows.Next() {
+		var call modelrepo.LLMCall
+		if err := rows.Scan(&call.ID, &call.VersionID, &call.Step, &call.GroupID, &call.GroupName, &call.Model,
+			&call.PromptTokens, &call.CompletionTokens, &call.TotalTokens, &call.Rubs, &call.DurationMs,
+			&call.CacheHit, &call.Attempts, &call.Error, &call.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan llm call: %w", err)
+		}
+		calls = append(calls, call)
+	}
+
+	return calls, rows.Err()
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	tz_llm_client "repairCopilotBot/tz-bot/internal/pkg/llm"
@@ -146,12 +147,16 @@ func (s *Storage) DeleteTechnicalSpecification(ctx context.Context, id uuid.UUID
 // Version operations
 func (s *Storage) CreateVersion(ctx context.Context, req *modelrepo.CreateVersionRequest) error {
 	query := `
-		INSERT INTO versions (id, technical_specification_id, version_number, created_at, updated_at, original_file_id, out_html, css, checked_file_id, all_rubs, all_tokens, inspection_time, original_file_size, number_of_errors, status, progress)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+		INSERT INTO versions (id, technical_specification_id, version_number, created_at, updated_at, original_file_id, out_html, css, checked_file_id, all_rubs, all_tokens, inspection_time, original_file_size, number_of_errors, status, progress, file_hash, recheck_of_version_id, check_profile_id, gg_id, original_file_extension, organization_id, charged_organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`
 
 	_, err := s.db.Exec(ctx, query, req.ID, req.TechnicalSpecificationID, req.VersionNumber, req.CreatedAt, req.UpdatedAt,
-		req.OriginalFileID, req.OutHTML, req.CSS, req.CheckedFileID, &req.AllRubs, &req.AllTokens, int64(req.InspectionTime), req.OriginalFileSize, req.NumberOfErrors, req.Status, req.Progress)
+		req.OriginalFileID, req.OutHTML, req.CSS, req.CheckedFileID, &req.AllRubs, &req.AllTokens, int64(req.InspectionTime), req.OriginalFileSize, req.NumberOfErrors, req.Status, req.Progress, req.FileHash, req.RecheckOfVersionID, req.CheckProfileID, req.GgID, req.OriginalFileExtension, req.OrganizationID, req.ChargedOrganizationID)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return repo.ErrDuplicateVersion
+		}
 		return fmt.Errorf("failed to create version: %w", err)
 	}
 
@@ -162,7 +167,7 @@ func (s *Storage) UpdateVersion(ctx context.Context, req *modelrepo.UpdateVersio
 	query := `
 		UPDATE versions 
 		SET updated_at = $2, out_html = $3, css = $4, checked_file_id = $5, all_rubs = $6, all_tokens = $7, inspection_time = $8, number_of_errors = $9, status = $10, html_from_word_parser = $11, html_with_placeholder = $12, html_paragraphs = $13, markdown_from_markdown_service = $14, html_with_ids_from_markdown_service = $15, mappings_from_markdown_service = $16, promts_from_promt_builder = $17, group_reports_from_llm = $18, html_paragraphs_with_wrapped_errors = $19, report = $20 
-		WHERE id = $1`
+		WHERE id = $1 AND status = 'in_progress'`
 
 	result, err := s.db.Exec(ctx, query, req.ID, req.UpdatedAt, req.OutHTML, req.CSS, req.CheckedFileID,
 		&req.AllRubs, &req.AllTokens, int64(req.InspectionTime), req.NumberOfErrors, req.Status, req.HtmlFromWordParser, req.HtmlWithPlacrholder, req.HtmlParagraphs, req.MarkdownFromMarkdownService, req.HtmlWithIdsFromMarkdownService, req.MappingsFromMarkdownService, req.PromtsFromPromtBuilder, req.GroupReportsFromLlm, req.HtmlParagraphsWithWrappesErrors, req.LlmReport)
@@ -170,8 +175,9 @@ func (s *Storage) UpdateVersion(ctx context.Context, req *modelrepo.UpdateVersio
 		return fmt.Errorf("failed to update version: %w", err)
 	}
 
+	// Версию успели отменить - результат не должен перезаписать статус "cancelled"
 	if result.RowsAffected() == 0 {
-		return repo.ErrVersionNotFound
+		return repo.ErrVersionNotInProgress
 	}
 
 	return nil
@@ -195,8 +201,110 @@ func (s *Storage) UpdateVersionProgress(ctx context.Context, id uuid.UUID, progr
 	return nil
 }
 
+func (s *Storage) UpdateVersionStatus(ctx context.Context, id uuid.UUID, status string, progress int) error {
+	query := `
+		UPDATE versions 
+		SET status = $2, progress = $3, updated_at = NOW() 
+		WHERE id = $1`
+
+	result, err := s.db.Exec(ctx, query, id, status, progress)
+	if err != nil {
+		return fmt.Errorf("failed to update version status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrVersionNotFound
+	}
+
+	return nil
+}
+
+// SetVersionAnnotatedFile сохраняет ключ сгенерированного docx с комментариями-замечаниями
+func (s *Storage) SetVersionAnnotatedFile(ctx context.Context, id uuid.UUID, fileID string) error {
+	query := `
+		UPDATE versions 
+		SET annotated_file_id = $2, updated_at = NOW() 
+		WHERE id = $1`
+
+	result, err := s.db.Exec(ctx, query, id, fileID)
+	if err != nil {
+		return fmt.Errorf("failed to set version annotated file: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrVersionNotFound
+	}
+
+	return nil
+}
+
+// SetVersionMarkdownHash сохраняет хэш нормализованного markdown версии и, если передана,
+// ссылку на ранее завершённую версию с тем же содержимым. Уже установленная ссылка не перезаписывается
+func (s *Storage) SetVersionMarkdownHash(ctx context.Context, id uuid.UUID, markdownHash string, recheckOfVersionID *uuid.UUID) error {
+	query := `
+		UPDATE versions 
+		SET markdown_hash = $2, recheck_of_version_id = COALESCE(recheck_of_version_id, $3) 
+		WHERE id = $1`
+
+	result, err := s.db.Exec(ctx, query, id, markdownHash, recheckOfVersionID)
+	if err != nil {
+		return fmt.Errorf("failed to set version markdown hash: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrVersionNotFound
+	}
+
+	return nil
+}
+
+// FindCompletedVersionByFileHash ищет последнюю завершённую версию пользователя или его организации
+// organizationID (nil - только пользователя), загруженную из того же файла и проверенную с тем же gg_id
+func (s *Storage) FindCompletedVersionByFileHash(ctx context.Context, userID uuid.UUID, organizationID *uuid.UUID, fileHash string, ggID int) (*modelrepo.DuplicateVersion, error) {
+	query := `
+		SELECT v.id, ts.id, ts.name, v.version_number, v.created_at
+		FROM versions v
+		JOIN technical_specifications ts ON v.technical_specification_id = ts.id
+		WHERE (ts.user_id = $1 OR v.organization_id = $4) AND v.file_hash = $2 AND v.gg_id = $3 AND v.status = 'completed'
+		ORDER BY v.created_at DESC
+		LIMIT 1`
+
+	return s.findCompletedVersion(ctx, query, userID, fileHash, ggID, organizationID)
+}
+
+// FindCompletedVersionByMarkdownHash ищет последнюю завершённую версию пользователя или организации версии
+// versionID с тем же текстом документа и тем же gg_id, что у версии versionID. Сама версия versionID
+// (текущая проверка) не учитывается
+func (s *Storage) FindCompletedVersionByMarkdownHash(ctx context.Context, userID uuid.UUID, markdownHash string, versionID uuid.UUID) (*modelrepo.DuplicateVersion, error) {
+	query := `
+		SELECT v.id, ts.id, ts.name, v.version_number, v.created_at
+		FROM versions v
+		JOIN technical_specifications ts ON v.technical_specification_id = ts.id
+		WHERE (ts.user_id = $1 OR v.organization_id = (SELECT organization_id FROM versions WHERE id = $3))
+		  AND v.markdown_hash = $2 AND v.status = 'completed' AND v.id <> $3
+		  AND v.gg_id = (SELECT gg_id FROM versions WHERE id = $3)
+		ORDER BY v.created_at DESC
+		LIMIT 1`
+
+	return s.findCompletedVersion(ctx, query, userID, markdownHash, versionID)
+}
+
+func (s *Storage) findCompletedVersion(ctx context.Context, query string, args ...any) (*modelrepo.DuplicateVersion, error) {
+	var version modelrepo.DuplicateVersion
+	err := s.db.QueryRow(ctx, query, args...).
+		Scan(&version.ID, &version.TechnicalSpecificationID, &version.TechnicalSpecificationName, &version.VersionNumber, &version.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repo.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to find version by hash: %w", err)
+	}
+
+	return &version, nil
+}
+
 func (s *Storage) GetVersion(ctx context.Context, id uuid.UUID) (*modelrepo.Version, error) {
-	query := `SELECT id, technical_specification_id, version_number, created_at, updated_at, original_file_id, out_html, css, checked_file_id, all_rubs, all_tokens, inspection_time, original_file_size, number_of_errors, status, report, progress FROM versions WHERE id = $1`
+	query := `SELECT id, technical_specification_id, version_number, created_at, updated_at, original_file_id, out_html, css, checked_file_id, all_rubs, all_tokens, inspection_time, original_file_size, number_of_errors, status, report, progress, annotated_file_id, file_hash, markdown_hash, recheck_of_version_id, check_profile_id, gg_id, original_file_extension, organization_id, charged_organization_id FROM versions WHERE id = $1`
 
 	var version modelrepo.Version
 	var llmReport *string
@@ -204,7 +312,8 @@ func (s *Storage) GetVersion(ctx context.Context, id uuid.UUID) (*modelrepo.Vers
 	err := s.db.QueryRow(ctx, query, id).
 		Scan(&version.ID, &version.TechnicalSpecificationID, &version.VersionNumber,
 			&version.CreatedAt, &version.UpdatedAt, &version.OriginalFileID,
-			&version.OutHTML, &version.CSS, &version.CheckedFileID, &version.AllRubs, &version.AllTokens, &version.InspectionTime, &version.OriginalFileSize, &version.NumberOfErrors, &version.Status, &llmReport, &progress)
+			&version.OutHTML, &version.CSS, &version.CheckedFileID, &version.AllRubs, &version.AllTokens, &version.InspectionTime, &version.OriginalFileSize, &version.NumberOfErrors, &version.Status, &llmReport, &progress, &version.AnnotatedFileID,
+			&version.FileHash, &version.MarkdownHash, &version.RecheckOfVersionID, &version.CheckProfileID, &version.GgID, &version.OriginalFileExtension, &version.OrganizationID, &version.ChargedOrganizationID)
 	if llmReport != nil {
 		version.LlmReport = *llmReport
 	}
@@ -286,17 +395,27 @@ func (s *Storage) GetLatestVersion(ctx context.Context, technicalSpecificationID
 }
 
 func (s *Storage) GetVersionsMeByUserID(ctx context.Context, userID uuid.UUID) ([]*tzservice.VersionMe, error) {
+	return s.getVersionsMe(ctx, "ts.user_id = $1", userID)
+}
+
+// GetVersionsMeByOrganizationID возвращает версии ТЗ, проверки которых оплачены из квоты организации
+func (s *Storage) GetVersionsMeByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*tzservice.VersionMe, error) {
+	return s.getVersionsMe(ctx, "v.organization_id = $1", organizationID)
+}
+
+// getVersionsMe возвращает краткие данные версий, отобранных условием condition с параметром $1
+func (s *Storage) getVersionsMe(ctx context.Context, condition string, arg any) ([]*tzservice.VersionMe, error) {
 	query := `
-		SELECT v.id, ts.name, v.version_number, v.created_at, v.original_file_id, v.checked_file_id, v.status, v.progress
+		SELECT v.id, ts.id, ts.name, ts.user_id, v.version_number, v.created_at, v.original_file_id, v.checked_file_id, v.status, v.progress
 		FROM versions v
 		JOIN technical_specifications ts ON v.technical_specification_id = ts.id
-		WHERE ts.user_id = $1
+		WHERE ` + condition + `
 		ORDER BY v.created_at DESC
 	`
 
-	rows, err := s.db.Query(ctx, query, userID)
+	rows, err := s.db.Query(ctx, query, arg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get versions by user ID: %w", err)
+		return nil, fmt.Errorf("failed to get versions: %w", err)
 	}
 	defer rows.Close()
 
@@ -304,7 +423,7 @@ func (s *Storage) GetVersionsMeByUserID(ctx context.Context, userID uuid.UUID) (
 	for rows.Next() {
 		var version tzservice.VersionMe
 		var progress *int
-		err := rows.Scan(&version.ID, &version.TechnicalSpecificationName,
+		err := rows.Scan(&version.ID, &version.TechnicalSpecificationID, &version.TechnicalSpecificationName, &version.UserID,
 			&version.VersionNumber, &version.CreatedAt, &version.OriginalFileID, &version.ReportFileID, &version.Status, &progress)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan version summary: %w", err)
@@ -656,8 +775,8 @@ func (s *Storage) CreateErrors(ctx context.Context, req *modelrepo.CreateErrorsR
 	}
 
 	query := `
-		INSERT INTO errors (id, version_id, group_id, error_code, order_number, preliminary_notes, overall_critique, verdict, process_analysis, process_critique, process_verification, process_retrieval, instances, name, description, detector)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+		INSERT INTO errors (id, version_id, group_id, error_code, order_number, preliminary_notes, overall_critique, verdict, process_analysis, process_critique, process_verification, process_retrieval, instances, name, description, detector, catalog_entry_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
 
 	for _, errorData := range req.Errors {
 		// Sanitize instances JSON before insertion
@@ -667,7 +786,8 @@ func (s *Storage) CreateErrors(ctx context.Context, req *modelrepo.CreateErrorsR
 			errorData.ID, req.VersionID, errorData.GroupID, errorData.ErrorCode, errorData.OrderNumber,
 			errorData.PreliminaryNotes, errorData.OverallCritique, errorData.Verdict,
 			errorData.ProcessAnalysis, errorData.ProcessCritique, errorData.ProcessVerification,
-			errorData.ProcessRetrieval, sanitizedInstances, errorData.Name, errorData.Description, errorData.Detector)
+			errorData.ProcessRetrieval, sanitizedInstances, errorData.Name, errorData.Description, errorData.Detector,
+			errorData.CatalogEntryID)
 		if err != nil {
 			return fmt.Errorf("failed to create error: %w", err)
 		}
@@ -679,10 +799,12 @@ func (s *Storage) CreateErrors(ctx context.Context, req *modelrepo.CreateErrorsR
 // GetErrorsByVersionID retrieves all errors for a specific version
 func (s *Storage) GetErrorsByVersionID(ctx context.Context, versionID uuid.UUID) (*[]tzservice.Error, error) {
 	query := `
-		SELECT id, version_id, group_id, error_code, order_number, preliminary_notes, overall_critique, verdict, process_analysis, process_critique, process_verification, process_retrieval, instances, name, description, detector
-		FROM errors 
-		WHERE version_id = @version_id
-		ORDER BY order_number`
+		SELECT e.id, e.version_id, e.group_id, e.error_code, e.order_number, e.preliminary_notes, e.overall_critique, e.verdict, e.process_analysis, e.process_critique, e.process_verification, e.process_retrieval, e.instances, e.name, e.description, e.detector,
+		       e.catalog_entry_id, c.version
+		FROM errors e
+		LEFT JOIN error_catalog c ON c.id = e.catalog_entry_id
+		WHERE e.version_id = @version_id
+		ORDER BY e.order_number`
 
 	args := pgx.NamedArgs{
 		"version_id": versionID,
@@ -717,6 +839,8 @@ func (s *Storage) GetErrorsByVersionID(ctx context.Context, versionID uuid.UUID)
 			&name,
 			&description,
 			&detector,
+			&errorItem.CatalogEntryID,
+			&errorItem.CatalogVersion,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan error: %w", err)
@@ -759,8 +883,8 @@ func (s *Storage) SaveInvalidInstances(ctx context.Context, invalidInstances *[]
 	}
 
 	query := `
-		INSERT INTO invalid_instances (id, html_id, error_id, quote, suggested_fix, original_quote, quote_lines, until_the_end_of_sentence, start_line_number, end_line_number, system_comment, order_number, rationale, feedback_exists, feedback_verification_exists)
-		VALUES (@id, @html_id, @error_id, @quote, @suggested_fix, @original_quote, @quote_lines, @until_the_end_of_sentence, @start_line_number, @end_line_number, @system_comment, @order_number, @rationale, @feedback_exists, @feedback_verification_exists)`
+		INSERT INTO invalid_instances (id, html_id, error_id, quote, suggested_fix, original_quote, quote_lines, until_the_end_of_sentence, start_line_number, end_line_number, page_number, system_comment, order_number, rationale, located, grounding_score, priority, risks, sections, what_is_incorrect, feedback_exists, feedback_verification_exists)
+		VALUES (@id, @html_id, @error_id, @quote, @suggested_fix, @original_quote, @quote_lines, @until_the_end_of_sentence, @start_line_number, @end_line_number, @page_number, @system_comment, @order_number, @rationale, @located, @grounding_score, @priority, @risks, @sections, @what_is_incorrect, @feedback_exists, @feedback_verification_exists)`
 
 	for _, instance := range *invalidInstances {
 		args := pgx.NamedArgs{
@@ -774,9 +898,16 @@ func (s *Storage) SaveInvalidInstances(ctx context.Context, invalidInstances *[]
 			"until_the_end_of_sentence":    instance.UntilTheEndOfSentence,
 			"start_line_number":            instance.StartLineNumber,
 			"end_line_number":              instance.EndLineNumber,
+			"page_number":                  instance.PageNumber,
 			"system_comment":               instance.SystemComment,
 			"order_number":                 instance.OrderNumber,
 			"rationale":                    instance.Rationale,
+			"located":                      instance.Located,
+			"grounding_score":              instance.GroundingScore,
+			"priority":                     instance.Priority,
+			"risks":                        instance.Risks,
+			"sections":                     instance.Sections,
+			"what_is_incorrect":            instance.WhatIsIncorrect,
 			"feedback_exists":              false,
 			"feedback_verification_exists": false,
 		}
@@ -793,7 +924,7 @@ func (s *Storage) SaveInvalidInstances(ctx context.Context, invalidInstances *[]
 // GetInvalidInstancesByErrorID retrieves all invalid instances for a specific error
 func (s *Storage) GetInvalidInstancesByErrorID(ctx context.Context, errorID uuid.UUID) (*[]tzservice.OutInvalidError, error) {
 	query := `
-		SELECT id, html_id, error_id, quote, suggested_fix, original_quote, quote_lines, until_the_end_of_sentence, start_line_number, end_line_number, system_comment, order_number, rationale, feedback_exists, feedback_mark, feedback_comment, feedback_user, feedback_verification_exists, feedback_verification_mark, feedback_verification_comment, feedback_verification_user
+		SELECT id, html_id, error_id, quote, suggested_fix, original_quote, quote_lines, until_the_end_of_sentence, start_line_number, end_line_number, page_number, system_comment, order_number, rationale, located, grounding_score, priority, risks, sections, what_is_incorrect, feedback_exists, feedback_mark, feedback_comment, feedback_user, feedback_verification_exists, feedback_verification_mark, feedback_verification_comment, feedback_verification_user
 		FROM invalid_instances 
 		WHERE error_id = @error_id 
 		ORDER BY order_number`
@@ -823,9 +954,16 @@ func (s *Storage) GetInvalidInstancesByErrorID(ctx context.Context, errorID uuid
 			&instance.UntilTheEndOfSentence,
 			&instance.StartLineNumber,
 			&instance.EndLineNumber,
+			&instance.PageNumber,
 			&instance.SystemComment,
 			&instance.OrderNumber,
 			&rationale,
+			&instance.Located,
+			&instance.GroundingScore,
+			&instance.Priority,
+			&instance.Risks,
+			&instance.Sections,
+			&instance.WhatIsIncorrect,
 			&instance.FeedbackExists,
 			&instance.FeedbackMark,
 			&instance.FeedbackComment,
@@ -857,8 +995,8 @@ func (s *Storage) SaveMissingInstances(ctx context.Context, missingInstances *[]
 	}
 
 	query := `
-		INSERT INTO missing_instances (id, html_id, error_id, suggested_fix, rationale, feedback_exists, feedback_verification_exists)
-		VALUES (@id, @html_id, @error_id, @suggested_fix, @rationale, @feedback_exists, @feedback_verification_exists)`
+		INSERT INTO missing_instances (id, html_id, error_id, suggested_fix, rationale, priority, risks, sections, what_is_incorrect, feedback_exists, feedback_verification_exists)
+		VALUES (@id, @html_id, @error_id, @suggested_fix, @rationale, @priority, @risks, @sections, @what_is_incorrect, @feedback_exists, @feedback_verification_exists)`
 
 	for _, instance := range *missingInstances {
 		args := pgx.NamedArgs{
@@ -867,6 +1005,10 @@ func (s *Storage) SaveMissingInstances(ctx context.Context, missingInstances *[]
 			"error_id":                     instance.ErrorID,
 			"suggested_fix":                instance.SuggestedFix,
 			"rationale":                    instance.Rationale,
+			"priority":                     instance.Priority,
+			"risks":                        instance.Risks,
+			"sections":                     instance.Sections,
+			"what_is_incorrect":            instance.WhatIsIncorrect,
 			"feedback_exists":              false,
 			"feedback_verification_exists": false,
 		}
@@ -883,7 +1025,7 @@ func (s *Storage) SaveMissingInstances(ctx context.Context, missingInstances *[]
 // GetMissingInstancesByErrorID retrieves all missing instances for a specific error
 func (s *Storage) GetMissingInstancesByErrorID(ctx context.Context, errorID uuid.UUID) (*[]tzservice.OutMissingError, error) {
 	query := `
-		SELECT id, html_id, error_id, suggested_fix, rationale, feedback_exists, feedback_mark, feedback_comment, feedback_user, feedback_verification_exists, feedback_verification_mark, feedback_verification_comment, feedback_verification_user
+		SELECT id, html_id, error_id, suggested_fix, rationale, priority, risks, sections, what_is_incorrect, feedback_exists, feedback_mark, feedback_comment, feedback_user, feedback_verification_exists, feedback_verification_mark, feedback_verification_comment, feedback_verification_user
 		FROM missing_instances 
 		WHERE error_id = @error_id`
 
@@ -906,6 +1048,10 @@ func (s *Storage) GetMissingInstancesByErrorID(ctx context.Context, errorID uuid
 			&instance.ErrorID,
 			&instance.SuggestedFix,
 			&instance.Rationale,
+			&instance.Priority,
+			&instance.Risks,
+			&instance.Sections,
+			&instance.WhatIsIncorrect,
 			&instance.FeedbackExists,
 			&instance.FeedbackMark,
 			&instance.FeedbackComment,
@@ -1020,12 +1166,16 @@ func (s *Storage) CreateErrorFeedback(ctx context.Context, req *modelrepo.Create
 
 // LLMCacheRepository implementation
 
-func (s *Storage) GetCachedResponse(ctx context.Context, messagesHash string) (*modelrepo.LLMCache, error) {
-	query := `SELECT id, messages_hash, response_data, created_at, updated_at FROM llm_cache WHERE messages_hash = $1 ORDER BY created_at DESC LIMIT 1`
+// GetCachedResponse возвращает ответ из кэша, сохранённый не раньше notBefore
+func (s *Storage) GetCachedResponse(ctx context.Context, key modelrepo.LLMCacheKey, notBefore time.Time) (*modelrepo.LLMCache, error) {
+	query := `
+		SELECT id, model, schema_hash, messages_hash, gg_id, response_data, created_at, updated_at
+		FROM llm_cache
+		WHERE model = $1 AND schema_hash = $2 AND messages_hash = $3 AND updated_at >= $4`
 
 	var cache modelrepo.LLMCache
-	err := s.db.QueryRow(ctx, query, messagesHash).Scan(
-		&cache.ID, &cache.MessagesHash, &cache.ResponseData, &cache.CreatedAt, &cache.UpdatedAt)
+	err := s.db.QueryRow(ctx, query, key.Model, key.SchemaHash, key.MessagesHash, notBefore).Scan(
+		&cache.ID, &cache.Model, &cache.SchemaHash, &cache.MessagesHash, &cache.GgID, &cache.ResponseData, &cache.CreatedAt, &cache.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, repo.ErrLLMCacheNotFound
@@ -1036,14 +1186,19 @@ func (s *Storage) GetCachedResponse(ctx context.Context, messagesHash string) (*
 	return &cache, nil
 }
 
+// SaveCachedResponse сохраняет ответ в кэш. Устаревшая запись с тем же ключом перезаписывается
 func (s *Storage) SaveCachedResponse(ctx context.Context, req *modelrepo.CreateLLMCacheRequest) (*modelrepo.LLMCache, error) {
 	id := uuid.New()
-	query := `INSERT INTO llm_cache (id, messages_hash, response_data, created_at, updated_at) 
-			  VALUES ($1, $2, $3, $4, $5) RETURNING id, messages_hash, response_data, created_at, updated_at`
+	query := `
+		INSERT INTO llm_cache (id, model, schema_hash, messages_hash, gg_id, response_data, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (model, schema_hash, messages_hash) DO UPDATE
+		SET gg_id = EXCLUDED.gg_id, response_data = EXCLUDED.response_data, updated_at = EXCLUDED.updated_at
+		RETURNING id, model, schema_hash, messages_hash, gg_id, response_data, created_at, updated_at`
 
 	var cache modelrepo.LLMCache
-	err := s.db.QueryRow(ctx, query, id, req.MessagesHash, req.ResponseData, req.CreatedAt, req.UpdatedAt).Scan(
-		&cache.ID, &cache.MessagesHash, &cache.ResponseData, &cache.CreatedAt, &cache.UpdatedAt)
+	err := s.db.QueryRow(ctx, query, id, req.Key.Model, req.Key.SchemaHash, req.Key.MessagesHash, req.GgID, req.ResponseData, req.CreatedAt, req.UpdatedAt).Scan(
+		&cache.ID, &cache.Model, &cache.SchemaHash, &cache.MessagesHash, &cache.GgID, &cache.ResponseData, &cache.CreatedAt, &cache.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save cached response: %w", err)
 	}
@@ -1051,6 +1206,33 @@ func (s *Storage) SaveCachedResponse(ctx context.Context, req *modelrepo.CreateL
 	return &cache, nil
 }
 
+// DeleteExpiredCachedResponses удаляет записи кэша, обновлённые раньше before
+func (s *Storage) DeleteExpiredCachedResponses(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.Exec(ctx, `DELETE FROM llm_cache WHERE updated_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired cached responses: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// InvalidateCachedResponses удаляет записи кэша, подходящие под все заданные условия фильтра
+func (s *Storage) InvalidateCachedResponses(ctx context.Context, filter modelrepo.LLMCacheFilter) (int64, error) {
+	query := `
+		DELETE FROM llm_cache
+		WHERE ($1::text IS NULL OR model = $1)
+		  AND ($2::integer IS NULL OR gg_id = $2)
+		  AND ($3::timestamptz IS NULL OR created_at >= $3)
+		  AND ($4::timestamptz IS NULL OR created_at < $4)`
+
+	result, err := s.db.Exec(ctx, query, filter.Model, filter.GgID, filter.From, filter.To)
+	if err != nil {
+		return 0, fmt.Errorf("failed to invalidate cached responses: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
 func (s *Storage) GetVersionsDateRange(ctx context.Context) (string, string, error) {
 	query := `
 		SELECT 
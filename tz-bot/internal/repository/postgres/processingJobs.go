@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	repo "repairCopilotBot/tz-bot/internal/repository"
+	"repairCopilotBot/tz-bot/internal/repository/models"
+)
+
+const processingJobColumns = `id, version_id, user_id, filename, original_file_key, status, attempts, last_error, run_after, lease_expires_at, created_at, updated_at`
+
+func scanProcessingJob(row pgx.Row) (*modelrepo.ProcessingJob, error) {
+	var job modelrepo.ProcessingJob
+	err := row.Scan(&job.ID, &job.VersionID, &job.UserID, &job.Filename, &job.OriginalFileKey, &job.Status,
+		&job.Attempts, &job.LastError, &job.RunAfter, &job.LeaseExpiresAt, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// CreateProcessingJob ставит проверку версии в очередь
+func (s *Storage) CreateProcessingJob(ctx context.Context, req *modelrepo.CreateProcessingJobRequest) error {
+	query := `
+		INSERT INTO processing_jobs (id, version_id, user_id, filename, original_file_key, status, attempts, run_after, created_at, updated_at)
+		VALUES (@id, @version_id, @user_id, @filename, @original_file_key, 'pending', 0, NOW(), NOW(), NOW())`
+
+	args := pgx.NamedArgs{
+		"id":                req.ID,
+		"version_id":        req.VersionID,
+		"user_id":           req.UserID,
+		"filename":          req.Filename,
+		"original_file_key": req.OriginalFileKey,
+	}
+
+	_, err := s.db.Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("failed to create processing job: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimProcessingJob захватывает следующую задачу из очереди: ожидающую запуска или
+// "осиротевшую" задачу, аренда которой истекла (например, после падения tz-bot).
+// Параллельные воркеры не блокируют друг друга благодаря SKIP LOCKED.
+func (s *Storage) ClaimProcessingJob(ctx context.Context, leaseExpiresAt time.Time) (*modelrepo.ProcessingJob, error) {
+	query := `
+		UPDATE processing_jobs
+		SET status = 'running', attempts = attempts + 1, lease_expires_at = @lease_expires_at, updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM processing_jobs
+			WHERE (status = 'pending' AND run_after <= NOW())
+			   OR (status = 'running' AND lease_expires_at < NOW())
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + processingJobColumns
+
+	args := pgx.NamedArgs{
+		"lease_expires_at": leaseExpiresAt,
+	}
+
+	job, err := scanProcessingJob(s.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repo.ErrProcessingJobNotFound
+		}
+		return nil, fmt.Errorf("failed to claim processing job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ExtendProcessingJobLease продлевает аренду выполняющейся задачи
+func (s *Storage) ExtendProcessingJobLease(ctx context.Context, id uuid.UUID, leaseExpiresAt time.Time) error {
+	query := `UPDATE processing_jobs SET lease_expires_at = $2, updated_at = NOW() WHERE id = $1 AND status = 'running'`
+
+	result, err := s.db.Exec(ctx, query, id, leaseExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to extend processing job lease: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrProcessingJobNotFound
+	}
+
+	return nil
+}
+
+// CompleteProcessingJob отмечает задачу выполненной
+func (s *Storage) CompleteProcessingJob(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE processing_jobs SET status = 'completed', lease_expires_at = NULL, updated_at = NOW() WHERE id = $1 AND status = 'running'`
+
+	_, err := s.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to complete processing job: %w", err)
+	}
+
+	return nil
+}
+
+// RetryProcessingJob возвращает задачу в очередь для повторной попытки не раньше runAfter
+func (s *Storage) RetryProcessingJob(ctx context.Context, id uuid.UUID, lastError string, runAfter time.Time) error {
+	query := `
+		UPDATE processing_jobs
+		SET status = 'pending', last_error = $2, run_after = $3, lease_expires_at = NULL, updated_at = NOW()
+		WHERE id = $1`
+
+	_, err := s.db.Exec(ctx, query, id, lastError, runAfter)
+	if err != nil {
+		return fmt.Errorf("failed to retry processing job: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseProcessingJob возвращает задачу в очередь без расходования попытки
+// (используется при остановке сервиса)
+func (s *Storage) ReleaseProcessingJob(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE processing_jobs
+		SET status = 'pending', attempts = GREATEST(attempts - 1, 0), lease_expires_at = NULL, updated_at = NOW()
+		WHERE id = $1`
+
+	_, err := s.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to release processing job: %w", err)
+	}
+
+	return nil
+}
+
+// FailProcessingJob отмечает задачу окончательно проваленной
+func (s *Storage) FailProcessingJob(ctx context.Context, id uuid.UUID, lastError string) error {
+	query := `UPDATE processing_jobs SET status = 'failed', last_error = $2, lease_expires_at = NULL, updated_at = NOW() WHERE id = $1`
+
+	_, err := s.db.Exec(ctx, query, id, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to fail processing job: %w", err)
+	}
+
+	return nil
+}
+
+// CancelVersionProcessing в одной транзакции переводит версию из "in_progress" в "cancelled"
+// и отменяет её задачу. Если версия уже не выполняется (например, проверка только что
+// завершилась), ничего не меняет и возвращает ErrVersionNotInProgress
+func (s *Storage) CancelVersionProcessing(ctx context.Context, versionID uuid.UUID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE versions
+		SET status = 'cancelled', updated_at = NOW()
+		WHERE id = $1 AND status = 'in_progress'`, versionID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel version: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrVersionNotInProgress
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE processing_jobs
+		SET status = 'cancelled', lease_expires_at = NULL, updated_at = NOW()
+		WHERE version_id = $1 AND status IN ('pending', 'running')`, versionID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel processing job: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ResetProcessingJob заново ставит в очередь задачу версии с обнулённым счётчиком попыток
+func (s *Storage) ResetProcessingJob(ctx context.Context, versionID uuid.UUID) error {
+	query := `
+		UPDATE processing_jobs
+		SET status = 'pending', attempts = 0, last_error = NULL, run_after = NOW(), lease_expires_at = NULL, updated_at = NOW()
+		WHERE version_id = $1`
+
+	result, err := s.db.Exec(ctx, query, versionID)
+	if err != nil {
+		return fmt.Errorf("failed to reset processing job: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrProcessingJobNotFound
+	}
+
+	return nil
+}
+
+// DeleteVersionFindings удаляет ошибки и замечания версии, сохранённые неудачной попыткой проверки,
+// и сбрасывает построенный по ним аннотированный документ
+func (s *Storage) DeleteVersionFindings(ctx context.Context, versionID uuid.UUID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := []string{
+		`DELETE FROM invalid_instances WHERE error_id IN (SELECT id FROM errors WHERE version_id = $1)`,
+		`DELETE FROM missing_instances WHERE error_id IN (SELECT id FROM errors WHERE version_id = $1)`,
+		`DELETE FROM errors WHERE version_id = $1`,
+		`UPDATE versions SET annotated_file_id = NULL WHERE id = $1`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(ctx, query, versionID); err != nil {
+			return fmt.Errorf("failed to delete version findings: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	repo "repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+)
+
+const shareLinkColumns = `id, version_id, token_hash, permissions, created_by, expires_at, revoked_at, access_count, last_accessed_at, created_at`
+
+func scanShareLink(row pgx.Row) (*modelrepo.ShareLink, error) {
+	var link modelrepo.ShareLink
+	err := row.Scan(&link.ID, &link.VersionID, &link.TokenHash, &link.Permissions, &link.CreatedBy,
+		&link.ExpiresAt, &link.RevokedAt, &link.AccessCount, &link.LastAccessedAt, &link.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repo.ErrShareLinkNotFound
+		}
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// CreateShareLink создаёт ссылку на просмотр версии
+func (s *Storage) CreateShareLink(ctx context.Context, req *modelrepo.CreateShareLinkRequest) (*modelrepo.ShareLink, error) {
+	query := `
+		INSERT INTO share_links (id, version_id, token_hash, permissions, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + shareLinkColumns
+
+	link, err := scanShareLink(s.db.QueryRow(ctx, query, req.ID, req.VersionID, req.TokenHash, req.Permissions, req.CreatedBy, req.ExpiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return link, nil
+}
+
+// GetShareLink возвращает ссылку по id
+func (s *Storage) GetShareLink(ctx context.Context, id uuid.UUID) (*modelrepo.ShareLink, error) {
+	query := `SELECT ` + shareLinkColumns + ` FROM share_links WHERE id = $1`
+
+	link, err := scanShareLink(s.db.QueryRow(ctx, query, id))
+	if err != nil && !errors.Is(err, repo.ErrShareLinkNotFound) {
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return link, err
+}
+
+// GetShareLinkByTokenHash возвращает ссылку по sha256 токена
+func (s *Storage) GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (*modelrepo.ShareLink, error) {
+	query := `SELECT ` + shareLinkColumns + ` FROM share_links WHERE token_hash = $1`
+
+	link, err := scanShareLink(s.db.QueryRow(ctx, query, tokenHash))
+	if err != nil && !errors.Is(err, repo.ErrShareLinkNotFound) {
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return link, err
+}
+
+// ListShareLinksByVersionID возвращает ссылки версии, начиная с последней созданной
+func (s *Storage) ListShareLinksByVersionID(ctx context.Context, versionID uuid.UUID) ([]modelrepo.ShareLink, error) {
+	query := `SELECT ` + shareLinkColumns + ` FROM share_links WHERE version_id = $1 ORDER BY created_at DESC`
+
+	rows, err := s.db.Query(ctx, query, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+	defer rows.Close()
+
+	links := make([]modelrepo.ShareLink, 0)
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan share link: %w", err)
+		}
+		links = append(links, *link)
+	}
+
+	return links, rows.Err()
+}
+
+// RevokeShareLink отзывает ссылку. Повторный отзыв не меняет время отзыва
+func (s *Storage) RevokeShareLink(ctx context.Context, id uuid.UUID) (*modelrepo.ShareLink, error) {
+	query := `
+		UPDATE share_links
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1
+		RETURNING ` + shareLinkColumns
+
+	link, err := scanShareLink(s.db.QueryRow(ctx, query, id))
+	if err != nil && !errors.Is(err, repo.ErrShareLinkNotFound) {
+		return nil, fmt.Errorf("failed to revoke share link: %w", err)
+	}
+
+	return link, err
+}
+
+// RegisterShareLinkAccess увеличивает счётчик открытий ссылки
+func (s *Storage) RegisterShareLinkAccess(ctx context.Context, id uuid.UUID) error {
+	result, err := s.db.Exec(ctx, `UPDATE share_links SET access_count = access_count + 1, last_accessed_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to register share link access: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrShareLinkNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+)
+
+// versionEventsChannel - канал LISTEN/NOTIFY, в который при сохранении события уходит ID версии
+const versionEventsChannel = "version_events"
+
+// CreateVersionEvent сохраняет событие хода проверки версии и уведомляет подписчиков через NOTIFY
+func (s *Storage) CreateVersionEvent(ctx context.Context, versionID uuid.UUID, eventType string, payload []byte) error {
+	query := `
+		WITH inserted AS (
+			INSERT INTO version_events (version_id, event_type, payload) VALUES ($1, $2, $3)
+			RETURNING version_id
+		)
+		SELECT pg_notify('` + versionEventsChannel + `', version_id::text) FROM inserted`
+
+	_, err := s.db.Exec(ctx, query, versionID, eventType, payload)
+	if err != nil {
+		return fmt.Errorf("failed to create version event: %w", err)
+	}
+
+	return nil
+}
+
+// GetVersionEvents возвращает события версии с идентификатором больше afterID в порядке возникновения
+func (s *Storage) GetVersionEvents(ctx context.Context, versionID uuid.UUID, afterID int64) ([]modelrepo.VersionEvent, error) {
+	query := `
+		SELECT id, version_id, event_type, payload, created_at
+		FROM version_events
+		WHERE version_id = $1 AND id > $2
+		ORDER BY id`
+
+	rows, err := s.db.Query(ctx, query, versionID, afterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]modelrepo.VersionEvent, 0)
+	for rows.Next() {
+		var event modelrepo.VersionEvent
+		if err := rows.Scan(&event.ID, &event.VersionID, &event.EventType, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan version event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// ListenVersionEvents держит отдельное соединение с LISTEN и передаёт в notify ID версий,
+// для которых появились новые события. Возвращается при отмене ctx или потере соединения
+func (s *Storage) ListenVersionEvents(ctx context.Context, notify func(versionID uuid.UUID)) error {
+	pooled, err := s.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	// Соединение с подпиской забираем из пула насовсем и закрываем сами
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+versionEventsChannel); err != nil {
+		return fmt.Errorf("failed to listen version events: %w", err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for version event notification: %w", err)
+		}
+
+		versionID, err := uuid.Parse(notification.Payload)
+		if err != nil {
+			continue
+		}
+
+		notify(versionID)
+	}
+}
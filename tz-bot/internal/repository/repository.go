@@ -7,7 +7,15 @@ import (
 var (
 	ErrTechnicalSpecificationNotFound = errors.New("technical specification not found")
 	ErrVersionNotFound                = errors.New("version not found")
+	ErrVersionNotInProgress           = errors.New("version is not in progress")
 	ErrDuplicateVersion               = errors.New("version with this number already exists for this technical specification")
 	ErrLLMCacheNotFound               = errors.New("llm cache not found")
 	ErrErrorNotFound                  = errors.New("error not found")
+	ErrProcessingJobNotFound          = errors.New("processing job not found")
+	ErrCheckpointNotFound             = errors.New("version checkpoint not found")
+	ErrCheckProfileNotFound           = errors.New("check profile not found")
+	ErrDuplicateCheckProfile          = errors.New("check profile with this name already exists")
+	ErrErrorCatalogEntryNotFound      = errors.New("error catalog entry not found")
+	ErrInstanceNotFound               = errors.New("error instance not found")
+	ErrShareLinkNotFound              = errors.New("share link not found")
 )
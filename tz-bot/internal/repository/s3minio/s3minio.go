@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log"
 
 	"github.com/minio/minio-go/v7"
@@ -349,6 +350,27 @@ func (s *MinioRepository) SaveDocument(
 	return nil
 }
 
+func (s *MinioRepository) GetDocument(
+	ctx context.Context,
+	bucketName string,
+	key string,
+) ([]byte, error) {
+	log.Printf("getting document: bucket=%s, key=%s", bucketName, key)
+
+	object, err := s.Session.GetObject(ctx, bucketName, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document from s3 bucket: %w", err)
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document from s3 bucket: %w", err)
+	}
+
+	return data, nil
+}
+
 //func (s *MinioRepository) uploadPhoto(
 //	key string,
 //	buildName string,
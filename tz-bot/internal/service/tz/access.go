@@ -0,0 +1,141 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// Роли пользователя, от имени которого выполняется запрос. CallerRoleShared - посетитель
+// без учётной записи, открывший ссылку на просмотр
+const (
+	CallerRoleUser   = "user"
+	CallerRoleAdmin  = "admin"
+	CallerRoleShared = "shared"
+)
+
+// Caller - пользователь, от имени которого запрашиваются результаты проверки
+type Caller struct {
+	UserID     uuid.UUID
+	Role       string
+	ShareToken string // только для CallerRoleShared
+}
+
+// IsAdmin - администратору доступны версии всех пользователей
+func (c Caller) IsAdmin() bool {
+	return c.Role == CallerRoleAdmin
+}
+
+// AuthorizeVersion проверяет, что пользователь владеет ТЗ версии или является администратором.
+// Посетителю по ссылке нужно право permission этой ссылки, пустое permission - действие
+// доступно только владельцу и администратору. Участникам организации, из квоты которой
+// оплачена проверка, доступны просмотр и выгрузка (непустое permission)
+func (tz *Tz) AuthorizeVersion(ctx context.Context, versionID uuid.UUID, caller Caller, permission string) error {
+	const op = "Tz.AuthorizeVersion"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("versionID", versionID.String()),
+		slog.String("userID", caller.UserID.String()),
+	)
+
+	if caller.Role == CallerRoleShared {
+		return tz.authorizeShareLink(ctx, versionID, caller.ShareToken, permission, log)
+	}
+
+	ownerID, err := tz.repo.GetVersionOwnerID(ctx, versionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionNotFound) {
+			return ErrVersionNotFound
+		}
+		log.Error("failed to get version owner: ", sl.Err(err))
+		return fmt.Errorf("failed to get version owner: %w", err)
+	}
+
+	if caller.IsAdmin() {
+		return nil
+	}
+
+	if ownerID == caller.UserID {
+		return nil
+	}
+
+	if permission != "" {
+		colleague, err := tz.versionOrganizationMember(ctx, versionID, caller.UserID)
+		if err != nil {
+			log.Error("failed to check organization membership: ", sl.Err(err))
+			return fmt.Errorf("failed to check organization membership: %w", err)
+		}
+		if colleague {
+			return nil
+		}
+	}
+
+	log.Warn("user is not the owner of technical specification", slog.String("ownerID", ownerID.String()))
+	return ErrAccessDenied
+}
+
+// userOrganizationID возвращает организацию, в которой сейчас состоит пользователь.
+// Без user-service или вне организации возвращает nil
+func (tz *Tz) userOrganizationID(ctx context.Context, userID uuid.UUID) (*uuid.UUID, error) {
+	if tz.userServiceClient == nil {
+		return nil, nil
+	}
+
+	organizationID, err := tz.userServiceClient.UserOrganizationID(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if organizationID == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid organization id %q: %w", organizationID, err)
+	}
+
+	return &id, nil
+}
+
+// versionOrganizationMember проверяет, что пользователь состоит в организации, из квоты которой
+// оплачена проверка версии. Версии с личного лимита коллегам не видны
+func (tz *Tz) versionOrganizationMember(ctx context.Context, versionID uuid.UUID, userID uuid.UUID) (bool, error) {
+	versionOrganizationID, err := tz.repo.GetVersionOrganizationID(ctx, versionID)
+	if err != nil || versionOrganizationID == nil {
+		return false, err
+	}
+
+	userOrganizationID, err := tz.userOrganizationID(ctx, userID)
+	if err != nil || userOrganizationID == nil {
+		return false, err
+	}
+
+	return *userOrganizationID == *versionOrganizationID, nil
+}
+
+// AuthorizeInstance проверяет доступ к версии, в которой найдено нарушение.
+// Ссылки на просмотр не дают права оставлять обратную связь
+func (tz *Tz) AuthorizeInstance(ctx context.Context, instanceID uuid.UUID, instanceType string, caller Caller) error {
+	const op = "Tz.AuthorizeInstance"
+
+	if caller.Role == CallerRoleShared {
+		return ErrAccessDenied
+	}
+
+	versionID, err := tz.repo.GetInstanceVersionID(ctx, instanceID, instanceType)
+	if err != nil {
+		if errors.Is(err, repository.ErrInstanceNotFound) {
+			return ErrInstanceNotFound
+		}
+		tz.log.With(slog.String("op", op)).Error("failed to get instance version: ", sl.Err(err))
+		return fmt.Errorf("failed to get instance version: %w", err)
+	}
+
+	return tz.AuthorizeVersion(ctx, versionID, caller, "")
+}
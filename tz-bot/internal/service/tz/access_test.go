@@ -0,0 +1,140 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// accessRepoStub - репозиторий с владельцами версий, их организациями и ссылками на просмотр.
+// Остальные методы Repository не используются и вызывают панику
+type accessRepoStub struct {
+	Repository
+	owners        map[uuid.UUID]uuid.UUID
+	organizations map[uuid.UUID]uuid.UUID
+	shareLinks    map[string]*modelrepo.ShareLink
+}
+
+func (r *accessRepoStub) GetVersionOwnerID(_ context.Context, versionID uuid.UUID) (uuid.UUID, error) {
+	ownerID, ok := r.owners[versionID]
+	if !ok {
+		return uuid.Nil, repository.ErrVersionNotFound
+	}
+	return ownerID, nil
+}
+
+func (r *accessRepoStub) GetVersionOrganizationID(_ context.Context, versionID uuid.UUID) (*uuid.UUID, error) {
+	if _, ok := r.owners[versionID]; !ok {
+		return nil, repository.ErrVersionNotFound
+	}
+	organizationID, ok := r.organizations[versionID]
+	if !ok {
+		return nil, nil
+	}
+	return &organizationID, nil
+}
+
+func (r *accessRepoStub) GetShareLinkByTokenHash(_ context.Context, tokenHash string) (*modelrepo.ShareLink, error) {
+	link, ok := r.shareLinks[tokenHash]
+	if !ok {
+		return nil, repository.ErrShareLinkNotFound
+	}
+	return link, nil
+}
+
+func TestAuthorizeVersion(t *testing.T) {
+	ownerID, strangerID := uuid.New(), uuid.New()
+	versionID, otherVersionID := uuid.New(), uuid.New()
+
+	repo := &accessRepoStub{
+		owners:        map[uuid.UUID]uuid.UUID{versionID: ownerID, otherVersionID: strangerID},
+		organizations: map[uuid.UUID]uuid.UUID{versionID: uuid.New()},
+		shareLinks: map[string]*modelrepo.ShareLink{
+			shareLinkTokenHash("view-token"): {
+				VersionID:   versionID,
+				Permissions: []string{SharePermissionView},
+				ExpiresAt:   time.Now().Add(time.Hour),
+			},
+		},
+	}
+	tz := &Tz{log: slog.New(slog.NewTextHandler(io.Discard, nil)), repo: repo}
+
+	owner := Caller{UserID: ownerID, Role: CallerRoleUser}
+	stranger := Caller{UserID: strangerID, Role: CallerRoleUser}
+	admin := Caller{UserID: strangerID, Role: CallerRoleAdmin}
+	shared := Caller{Role: CallerRoleShared, ShareToken: "view-token"}
+
+	cases := []struct {
+		name       string
+		versionID  uuid.UUID
+		caller     Caller
+		permission string
+		wantErr    error
+	}{
+		{name: "owner views", versionID: versionID, caller: owner, permission: SharePermissionView},
+		{name: "owner retries", versionID: versionID, caller: owner, permission: ""},
+		{name: "admin retries other's version", versionID: versionID, caller: admin, permission: ""},
+		{name: "stranger views organization version", versionID: versionID, caller: stranger, permission: SharePermissionView, wantErr: ErrAccessDenied},
+		{name: "stranger downloads report", versionID: versionID, caller: stranger, permission: VersionFilePermission(VersionFileReport), wantErr: ErrAccessDenied},
+		{name: "stranger retries", versionID: versionID, caller: stranger, permission: "", wantErr: ErrAccessDenied},
+		{name: "owner of other version", versionID: otherVersionID, caller: owner, permission: SharePermissionView, wantErr: ErrAccessDenied},
+		{name: "missing version", versionID: uuid.New(), caller: owner, permission: SharePermissionView, wantErr: ErrVersionNotFound},
+		{name: "share link views", versionID: versionID, caller: shared, permission: SharePermissionView},
+		{name: "share link without export", versionID: versionID, caller: shared, permission: SharePermissionExport, wantErr: ErrAccessDenied},
+		{name: "share link downloads original", versionID: versionID, caller: shared, permission: VersionFilePermission(VersionFileOriginal), wantErr: ErrAccessDenied},
+		{name: "share link of other version", versionID: otherVersionID, caller: shared, permission: SharePermissionView, wantErr: ErrAccessDenied},
+		{name: "unknown share token", versionID: versionID, caller: Caller{Role: CallerRoleShared, ShareToken: "bad"}, permission: SharePermissionView, wantErr: ErrAccessDenied},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := tz.AuthorizeVersion(context.Background(), c.versionID, c.caller, c.permission)
+			if c.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.wantErr != nil && !errors.Is(err, c.wantErr) {
+				t.Fatalf("expected %v, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCallerIsAdmin(t *testing.T) {
+	userID := uuid.New()
+
+	if (Caller{UserID: userID, Role: CallerRoleUser}).IsAdmin() {
+		t.Errorf("user role must not bypass the ownership check")
+	}
+	if !(Caller{UserID: userID, Role: CallerRoleAdmin}).IsAdmin() {
+		t.Errorf("admin role must have access to all versions")
+	}
+	if (Caller{UserID: userID}).IsAdmin() {
+		t.Errorf("empty role must be treated as a regular user")
+	}
+}
+
+func TestVersionOrganizationMemberWithoutUserService(t *testing.T) {
+	userID, versionID := uuid.New(), uuid.New()
+	repo := &accessRepoStub{
+		owners:        map[uuid.UUID]uuid.UUID{versionID: uuid.New()},
+		organizations: map[uuid.UUID]uuid.UUID{versionID: uuid.New()},
+	}
+	tz := &Tz{repo: repo}
+
+	organizationID, err := tz.userOrganizationID(context.Background(), userID)
+	if err != nil || organizationID != nil {
+		t.Errorf("userOrganizationID = %v, %v, want nil without user-service", organizationID, err)
+	}
+
+	colleague, err := tz.versionOrganizationMember(context.Background(), versionID, userID)
+	if err != nil || colleague {
+		t.Errorf("versionOrganizationMember = %v, %v, want false without user-service", colleague, err)
+	}
+}
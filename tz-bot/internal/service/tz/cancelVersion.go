@@ -0,0 +1,93 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// CancelVersion отменяет проверку версии, которая ещё стоит в очереди или выполняется.
+// Выполняющиеся запросы к LLM прерываются, версия переводится в статус "cancelled",
+// а проверка возвращается в дневной лимит пользователя
+func (tz *Tz) CancelVersion(ctx context.Context, versionID uuid.UUID, userID uuid.UUID) error {
+	const op = "Tz.CancelVersion"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("versionID", versionID.String()),
+		slog.String("userID", userID.String()),
+	)
+
+	version, err := tz.repo.GetVersion(ctx, versionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionNotFound) {
+			return ErrVersionNotFound
+		}
+		log.Error("failed to get version: ", sl.Err(err))
+		return fmt.Errorf("failed to get version: %w", err)
+	}
+
+	ts, err := tz.repo.GetTechnicalSpecification(ctx, version.TechnicalSpecificationID)
+	if err != nil {
+		log.Error("failed to get technical specification: ", sl.Err(err))
+		return fmt.Errorf("failed to get technical specification: %w", err)
+	}
+
+	if ts.UserID != userID {
+		log.Warn("user is not the owner of technical specification", slog.String("ownerID", ts.UserID.String()))
+		return ErrAccessDenied
+	}
+
+	if version.Status != "in_progress" {
+		return ErrVersionNotCancellable
+	}
+
+	// Статус меняется условно: если проверка успела завершиться, отмена не перезапишет
+	// результат и проверка не будет возвращена в лимит
+	if err := tz.repo.CancelVersionProcessing(ctx, versionID); err != nil {
+		if errors.Is(err, repository.ErrVersionNotInProgress) {
+			return ErrVersionNotCancellable
+		}
+		log.Error("failed to cancel version processing: ", sl.Err(err))
+		return fmt.Errorf("failed to cancel version processing: %w", err)
+	}
+
+	tz.publishVersionEvent(ctx, versionID, VersionEvent{Type: VersionEventFinalStatus, Status: "cancelled"})
+
+	// Если проверка выполняется в другом экземпляре сервиса, её остановит продление аренды
+	tz.cancelActiveJob(versionID)
+
+	tz.decrementInspectionsForUser(ctx, ts.UserID, version.ChargedOrganizationID, log)
+
+	log.Info("version processing cancelled")
+
+	return nil
+}
+
+func (tz *Tz) registerActiveJob(versionID uuid.UUID, cancel context.CancelCauseFunc) {
+	tz.activeJobsMu.Lock()
+	defer tz.activeJobsMu.Unlock()
+
+	tz.activeJobs[versionID] = cancel
+}
+
+func (tz *Tz) unregisterActiveJob(versionID uuid.UUID) {
+	tz.activeJobsMu.Lock()
+	defer tz.activeJobsMu.Unlock()
+
+	delete(tz.activeJobs, versionID)
+}
+
+func (tz *Tz) cancelActiveJob(versionID uuid.UUID) {
+	tz.activeJobsMu.Lock()
+	defer tz.activeJobsMu.Unlock()
+
+	if cancel, ok := tz.activeJobs[versionID]; ok {
+		cancel(ErrVersionCancelled)
+	}
+}
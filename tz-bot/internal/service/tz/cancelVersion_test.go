@@ -0,0 +1,40 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCancelActiveJob(t *testing.T) {
+	tz := &Tz{activeJobs: make(map[uuid.UUID]context.CancelCauseFunc)}
+
+	versionID := uuid.New()
+	otherVersionID := uuid.New()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	otherCtx, otherCancel := context.WithCancelCause(context.Background())
+	defer otherCancel(nil)
+
+	tz.registerActiveJob(versionID, cancel)
+	tz.registerActiveJob(otherVersionID, otherCancel)
+
+	tz.cancelActiveJob(versionID)
+
+	if !errors.Is(context.Cause(ctx), ErrVersionCancelled) {
+		t.Errorf("ожидалась отмена проверки с причиной ErrVersionCancelled, получено %v", context.Cause(ctx))
+	}
+	if otherCtx.Err() != nil {
+		t.Errorf("проверка другой версии не должна отменяться")
+	}
+
+	// Отмена уже завершённой проверки ничего не делает
+	tz.unregisterActiveJob(otherVersionID)
+	tz.cancelActiveJob(otherVersionID)
+	if otherCtx.Err() != nil {
+		t.Errorf("снятая с учёта проверка не должна отменяться")
+	}
+}
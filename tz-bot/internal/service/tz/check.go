@@ -3,27 +3,46 @@ package tzservice
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"regexp"
-	promt_builder "repairCopilotBot/tz-bot/internal/pkg/promt-builder"
+	markdown_service_client "repairCopilotBot/tz-bot/internal/pkg/markdown-service"
 	"runtime"
+	"slices"
 
 	//docxToDocx2007clientclient "repairCopilotBot/tz-bot/internal/pkg/docxToDocx2007client"
 	tz_llm_client "repairCopilotBot/tz-bot/internal/pkg/llm"
 	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
 	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
 	"sort"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
-func (tz *Tz) CheckTz(ctx context.Context, file []byte, filename string, userID uuid.UUID) (uuid.UUID, string, time.Time, error) {
+type CheckTzResult struct {
+	VersionID uuid.UUID
+	Name      string
+	CreatedAt time.Time
+	// Duplicate - файл уже проверялся: вместо новой проверки возвращена завершённая версия VersionID
+	Duplicate bool
+	// RecheckOfVersionID - ранее проверенная версия с тем же файлом, если проверка запущена повторно (force)
+	RecheckOfVersionID *uuid.UUID
+	// CheckProfile и GgID - профиль и группа ошибок, с которыми проверяется версия
+	CheckProfile string
+	GgID         int
+}
+
+// CheckTz создаёт ТЗ из загруженного файла и ставит его проверку в очередь с профилем из opts.
+// Если пользователь уже проверял этот же файл с той же группой ошибок, возвращается готовая версия
+// без списания проверки. opts.Force запускает новую проверку в любом случае, она связывается
+// с предыдущей как повторная
+func (tz *Tz) CheckTz(ctx context.Context, file []byte, filename string, userID uuid.UUID, opts CheckOptions) (*CheckTzResult, error) {
 	const op = "Tz.CheckTz"
 
 	log := tz.log.With(
@@ -33,45 +52,65 @@ func (tz *Tz) CheckTz(ctx context.Context, file []byte, filename string, userID
 
 	log.Info("checking tz - creating initial records")
 
-	var tz_name string
-
-	isDocFormat, err := IsDocFormat(filename)
+	settings, err := tz.resolveCheckSettings(ctx, opts)
 	if err != nil {
-		return uuid.Nil, "", time.Time{}, fmt.Errorf("ошибка сохранения файла в S3: %w", err)
+		log.Warn("failed to resolve check profile", slog.String("profile", opts.Profile), sl.Err(err))
+		return nil, err
 	}
 
-	if isDocFormat {
-		tz_name = RemoveDocExtension(filename)
-	} else {
-		tz_name = RemoveDocxExtension(filename)
-		//DocxToDocx2007ConverterClient, err := docxToDocx2007clientclient.New("localhost", 8000)
-		//if err != nil || DocxToDocx2007ConverterClient == nil {
-		//	if err != nil {
-		//		log.Error("error initializing docx to docx 2007 converter client", sl.Err(err))
-		//	}
-		//	if DocxToDocx2007ConverterClient == nil {
-		//		log.Error("error initializing docx to docx 2007 converter client")
-		//	}
-		//} else {
-		//	newFile, err := DocxToDocx2007ConverterClient.Convert(ctx, file, filename)
-		//	if err != nil {
-		//		log.Error("error in convert docx to docx 2007 converter client", sl.Err(err))
-		//	} else {
-		//		file = newFile
-		//	}
-		//
-		//}
+	fileHash := fileContentHash(file)
+	duplicate := tz.findDuplicateVersion(ctx, userID, fileHash, settings.GgID, log)
+	if duplicate != nil && !opts.Force {
+		log.Info("file has already been checked, returning previous version",
+			slog.String("version_id", duplicate.ID.String()))
+		return &CheckTzResult{
+			VersionID:    duplicate.ID,
+			Name:         duplicate.TechnicalSpecificationName,
+			CreatedAt:    duplicate.CreatedAt,
+			Duplicate:    true,
+			CheckProfile: settings.ProfileName,
+			GgID:         settings.GgID,
+		}, nil
 	}
 
+	var recheckOf *uuid.UUID
+	if duplicate != nil {
+		recheckOf = &duplicate.ID
+	}
+
+	doc, err := tz.prepareUploadedDocument(ctx, file, filename)
+	if err != nil {
+		log.Warn("uploaded document rejected", slog.String("filename", filename), sl.Err(err))
+		return nil, err
+	}
+	tz_name := doc.Name
+	log.Info("document format detected",
+		slog.String("format", string(doc.Format)),
+		slog.Int("pages", doc.Stats.Pages),
+		slog.Int("chars", doc.Stats.Chars))
+
+	//DocxToDocx2007ConverterClient, err := docxToDocx2007clientclient.New("localhost", 8000)
+	//if err != nil || DocxToDocx2007ConverterClient == nil {
+	//	if err != nil {
+	//		log.Error("error initializing docx to docx 2007 converter client", sl.Err(err))
+	//	}
+	//	if DocxToDocx2007ConverterClient == nil {
+	//		log.Error("error initializing docx to docx 2007 converter client")
+	//	}
+	//} else {
+	//	newFile, err := DocxToDocx2007ConverterClient.Convert(ctx, file, filename)
+	//	if err != nil {
+	//		log.Error("error in convert docx to docx 2007 converter client", sl.Err(err))
+	//	} else {
+	//		file = newFile
+	//	}
+	//
+	//}
+
 	// Инкрементируем счетчик проверок для пользователя (проверяем лимит)
-	if tz.userServiceClient != nil {
-		err = tz.userServiceClient.IncrementInspectionsForToday(ctx, userID.String())
-		if err != nil {
-			log.Error("failed to increment inspections for today", sl.Err(err))
-			return uuid.Nil, "", time.Time{}, fmt.Errorf("inspection limit exceeded or user service error: %w", err)
-		} else {
-			log.Info("inspections counter incremented successfully")
-		}
+	organizationID, err := tz.incrementInspectionsForUser(ctx, userID, log)
+	if err != nil {
+		return nil, err
 	}
 
 	// Создаем техническую спецификацию
@@ -84,26 +123,19 @@ func (tz *Tz) CheckTz(ctx context.Context, file []byte, filename string, userID
 		UpdatedAt: time.Now(),
 	})
 	if err != nil {
-		tz.decrementInspectionsForUser(ctx, userID, log)
-		return uuid.Nil, "", time.Time{}, fmt.Errorf("failed to create technical specification: %w", err)
+		tz.decrementInspectionsForUser(ctx, userID, organizationID, log)
+		return nil, fmt.Errorf("failed to create technical specification: %w", err)
 	}
 	log.Info("technical specification created", slog.String("ts_id", ts.ID.String()))
 
 	originalFileName := tz_name + GetCurrentDateTimeString()
+	extension := doc.Format.Extension()
 	// Сохраняем оригинальный файл в S3
-	err = tz.s3.SaveDocument(ctx, originalFileName, file, "docs",
-		func() string {
-			if isDocFormat {
-				return ".doc"
-			} else {
-				return ".docx"
-			}
-		}(),
-	)
+	err = tz.s3.SaveDocument(ctx, originalFileName, file, "docs", extension)
 	if err != nil {
 		log.Error("ошибка сохранения оригинального файла в S3: ", sl.Err(err))
-		tz.decrementInspectionsForUser(ctx, userID, log)
-		return uuid.Nil, "", time.Time{}, fmt.Errorf("ошибка сохранения файла в S3: %w", err)
+		tz.decrementInspectionsForUser(ctx, userID, organizationID, log)
+		return nil, fmt.Errorf("ошибка сохранения файла в S3: %w", err)
 	}
 	log.Info("оригинальный файл успешно сохранён в S3", slog.String("file_id", originalFileName))
 
@@ -116,6 +148,7 @@ func (tz *Tz) CheckTz(ctx context.Context, file []byte, filename string, userID
 		CreatedAt:                time.Now(),
 		UpdatedAt:                time.Now(),
 		OriginalFileID:           originalFileName,
+		OriginalFileExtension:    extension,
 		OutHTML:                  "",
 		CSS:                      "",
 		CheckedFileID:            "",
@@ -126,19 +159,39 @@ func (tz *Tz) CheckTz(ctx context.Context, file []byte, filename string, userID
 		NumberOfErrors:           0,
 		Status:                   "in_progress",
 		Progress:                 3,
+		FileHash:                 &fileHash,
+		RecheckOfVersionID:       recheckOf,
+		CheckProfileID:           settings.ProfileID,
+		GgID:                     &settings.GgID,
+		OrganizationID:           organizationID,
+		ChargedOrganizationID:    organizationID,
 	}
 	err = tz.repo.CreateVersion(ctx, versionReq)
 	if err != nil {
-		tz.decrementInspectionsForUser(ctx, userID, log)
-		return uuid.Nil, "", time.Time{}, fmt.Errorf("failed to create version: %w", err)
+		tz.decrementInspectionsForUser(ctx, userID, organizationID, log)
+		return nil, fmt.Errorf("failed to create version: %w", err)
 	}
 	log.Info("version created with status 'in_progress'", slog.String("version_id", newVersionID.String()))
 
-	// Запускаем асинхронную обработку
-	go tz.ProcessTzAsync(file, filename, newVersionID, originalFileName, isDocFormat, tz_name, userID)
+	tz.saveUploadedDocumentCheckpoint(ctx, newVersionID, doc, log)
 
-	log.Info("async processing started")
-	return newVersionID, RemoveDocxExtension(filename), time.Now(), nil
+	// Ставим проверку в очередь
+	err = tz.enqueueVersionProcessing(ctx, newVersionID, userID, filename, originalFileName+extension)
+	if err != nil {
+		tz.updateVersionWithError(ctx, newVersionID, "error")
+		tz.decrementInspectionsForUser(ctx, userID, organizationID, log)
+		return nil, fmt.Errorf("failed to enqueue version processing: %w", err)
+	}
+
+	log.Info("version processing enqueued")
+	return &CheckTzResult{
+		VersionID:          newVersionID,
+		Name:               tz_name,
+		CreatedAt:          time.Now(),
+		RecheckOfVersionID: recheckOf,
+		CheckProfile:       settings.ProfileName,
+		GgID:               settings.GgID,
+	}, nil
 }
 
 type Error struct {
@@ -159,22 +212,25 @@ type Error struct {
 	Instances           *[]tz_llm_client.Instance `json:"instances"`
 	InvalidInstances    *[]OutInvalidError        `json:"invalid_instances,omitempty"`
 	MissingInstances    *[]OutMissingError        `json:"missing_instances,omitempty"`
+	CatalogEntryID      *uuid.UUID                `json:"catalog_entry_id,omitempty"`
+	CatalogVersion      *int                      `json:"catalog_version,omitempty"`
 }
 
-func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID, _ string, isDocFormat bool, tzName string, userID uuid.UUID) {
-	const op = "Tz.ProcessTzAsync"
+// ProcessTz выполняет полный цикл проверки версии. Ошибка возвращается вызывающему коду
+// (обработчику очереди), который решает, повторить попытку или перевести версию в статус "error"
+func (tz *Tz) ProcessTz(ctx context.Context, file []byte, filename string, versionID uuid.UUID, format DocumentFormat, tzName string, userID uuid.UUID, ggID int) error {
+	const op = "Tz.ProcessTz"
 
 	log := tz.log.With(
 		slog.String("op", op),
 		slog.String("versionID", versionID.String()),
 		slog.String("tzName", tzName),
 		slog.String("userID", userID.String()),
+		slog.String("format", string(format)),
+		slog.Int("ggID", ggID),
 	)
 
-	log.Info("starting async processing")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Minute)
-	defer cancel()
+	log.Info("starting processing")
 
 	//htmlText, css, err := tz.wordConverterClient.Convert(file, filename)
 	//if err != nil {
@@ -185,274 +241,61 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 
 	now := time.Now()
 
-	if isDocFormat {
-		newFile, err := tz.docToDocXConverterClient.Convert(file, filename)
-		if err != nil {
-			log.Error("ошибка при конвертации doc в docx: ", sl.Err(err))
-			tz.handleProcessingError(ctx, versionID, userID, "ошибка при конвертации doc в docx: "+err.Error(), log)
-			return
-		}
-
-		file = newFile
+	// Результат каждой стадии сохраняется в version_checkpoints: при повторной попытке
+	// или RetryVersion уже выполненные стадии (и запросы к LLM) не повторяются
+	htmlStage, err := runStage(ctx, tz, versionID, StageHTML, log, func() (*htmlCheckpoint, error) {
+		return tz.convertDocumentToHtml(ctx, file, filename, format, log)
+	})
+	if err != nil {
+		return err
 	}
 
-	//oldVersion := false
-
-	var paragraphs *string
-
+	html := htmlStage.Html
+	paragraphs := &html
 	htmlWithPlaceholder := ""
 
-	//html, err := tz.wordConverterClient2.Convert(file, filename)
-	//if err != nil {
-	//	log.Error("ошибка при обращении к wordParserClient2: ", sl.Err(err))
-	//} else {
-	//	respHtmlWithPlaceholdersStr, respParagraphsStr := paragraphsproc.ExtractParagraphs(html)
-	//	paragraphs = &respParagraphsStr
-	//	htmlWithPlaceholder = respHtmlWithPlaceholdersStr
-	//resultExtractParagraphs := word_parser2.ExtractParagraphs(html)
-	//paragraphs = &resultExtractParagraphs.Paragraphs
-	//htmlWithPlaceholder = resultExtractParagraphs.HTMLWithPlaceholder
-	//}
-	//if paragraphs == nil || *paragraphs == "" {
-	//	err = errors.New("failed to extract paragraphs")
-	//}
-	//if err != nil {
-	//log.Error("ошибка при обращении к wordParserClient2: ", sl.Err(err))
-	log.Info("пробуем старый word_parser")
-	//oldVersion = true
-	paragraphsFromWordConverterClient, _, wordConverterClientErr := tz.wordConverterClient.Convert(file, RemoveDocExtension(filename)+".docx")
-	if wordConverterClientErr != nil {
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка при обращении к wordParserClient: "+wordConverterClientErr.Error(), log)
-		return
-	}
-
-	html := *paragraphsFromWordConverterClient
-
-	paragraphs = paragraphsFromWordConverterClient
-	//}
-
-	log.Info("конвертация word файла в htmlText успешна")
-
-	markdownResponse, err := tz.markdownClient.Convert(*paragraphs)
+	markdownResponse, err := runStage(ctx, tz, versionID, StageMarkdown, log, func() (*markdown_service_client.ConvertResponse, error) {
+		return tz.convertHtmlToMarkdown(ctx, html, tzName, log)
+	})
 	if err != nil {
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка конвертации HTML в markdown: "+err.Error(), log)
-		return
+		return err
 	}
 
-	log.Info("конвертация HTML в markdown успешна")
-
-	markdownResponse.Markdown = RemoveBase64Images(markdownResponse.Markdown)
-
-	// Сохраняем markdown документ в S3
-	markdownFileName := tzName + "_" + GetCurrentDateTimeString()
-	err = tz.s3.SaveDocument(ctx, markdownFileName, []byte(markdownResponse.Markdown), "mds", ".md")
-	if err != nil {
-		log.Error("ошибка сохранения markdown файла в S3: ", sl.Err(err))
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка сохранения markdown файла в S3: "+err.Error(), log)
-		return
-	} else {
-		log.Info("markdown файл успешно сохранён в S3", slog.String("file_id", markdownFileName))
-	}
-	log.Info(fmt.Sprintf("получены дополнительные данные: message=%s, mappings_count=%d", markdownResponse.Message, len(markdownResponse.Mappings)))
+	tz.saveMarkdownHash(ctx, versionID, userID, markdownResponse.Markdown, log)
 
-	tz.mu.RLock()
-	promts, schema, errorsDescrptions, err := tz.promtBuilderClient.GeneratePromts(markdownResponse.Markdown, tz.ggID)
-	tz.mu.RUnlock()
+	promptsStage, err := runStage(ctx, tz, versionID, StagePrompts, log, func() (*promptsCheckpoint, error) {
+		return tz.generateStep1Promts(markdownResponse.Markdown, ggID)
+	})
 	if err != nil {
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка генерации промтов: "+err.Error(), log)
-		return
+		return err
 	}
 
-	if schema == nil {
-		tz.handleProcessingError(ctx, versionID, userID, "схема пустая", log)
-		return
-	}
-
-	groupReports := make([]tz_llm_client.GroupReport, 0, len(*promts))
-	allRubs := float64(0)
-	allTokens := int64(0)
-
-	// Создаем канал для результатов и waitgroup для синхронизации
-	resultChan := make(chan llmRequestResult, len(*promts))
-	var wg sync.WaitGroup
-
-	progressNumberSteps := len(*promts) + 1
-	progressOneStep := 100 / progressNumberSteps
-	progressSteps := 0
-	var progressStepsMu sync.RWMutex
-
-	// Запускаем горутины для параллельной обработки запросов
-	for _, v := range *promts {
-		wg.Add(1)
-		go func(messagesFromPromtBuilder *[]promt_builder.Message, schema json.RawMessage) {
-			defer wg.Done()
-
-			defer func() {
-				if r := recover(); r != nil {
-					log.Error("паника в goroutine: ", slog.Any("panic", r))
-					resultChan <- llmRequestResult{err: fmt.Errorf("паника в goroutine: %v", r)}
-				}
-			}()
-
-			messages := make([]struct {
-				Role    *string `json:"role"`
-				Content *string `json:"content"`
-			},
-				0)
-
-			for _, msg := range *messagesFromPromtBuilder {
-				messages = append(messages, struct {
-					Role    *string `json:"role"`
-					Content *string `json:"content"`
-				}{
-					Role:    msg.Role,
-					Content: msg.Content,
-				})
-			}
-
-			tz.mu.RLock()
-			llmResp, err := tz.llmClient.SendMessage(messages, schema, 1, tz.useLlmCache)
-			tz.mu.RUnlock()
-			if err != nil {
-				log.Error("ошибка от llm request: ", sl.Err(err))
-				resultChan <- llmRequestResult{err: err}
-				return
-			}
-
-			if llmResp.Result == nil {
-				log.Error("ошибка: в ответе от llm поле result пустое")
-				resultChan <- llmRequestResult{err: fmt.Errorf("пустое поле result в ответе от llm")}
-				return
-			}
-
-			result := llmRequestResult{
-				groupReport: llmResp.Result,
-				ResultRaw:   string(llmResp.ResultRaw),
-				duration:    *llmResp.Duration,
-			}
+	promts := promptsStage.Promts
+	errorsDescrptions := promptsStage.ErrorsDescriptions
+	catalogEntryIDs := tz.syncErrorCatalog(ctx, ggID, errorsDescrptions, log)
 
-			if llmResp.Cost != nil {
-				result.cost = llmResp.Cost.TotalRub
-			}
-
-			if llmResp.Usage != nil && llmResp.Usage.TotalTokens != nil {
-				tokens := int64(*llmResp.Usage.TotalTokens)
-				result.tokens = &tokens
-			}
-
-			resultChan <- result
-		}(v.Messages, schema)
-	}
-
-	// Закрываем канал после завершения всех горутин
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	// Собираем результаты
-	expectedResults := len(*promts)
-	receivedResults := 0
-	hasErrors := false
-	var firstError error
-
-	for result := range resultChan {
-		progressStepsMu.Lock()
-		progressSteps += 1
-		progressStepsMu.Unlock()
-
-		progressStepsMu.RLock()
-		go func() {
-			UpdateVersionProgressErr := tz.repo.UpdateVersionProgress(ctx, versionID, progressSteps*progressOneStep)
-			if UpdateVersionProgressErr != nil {
-				log.Error("Error in UpdateVersionProgress: ", UpdateVersionProgressErr.Error())
-			} else {
-				fmt.Println("прогресс обновлён")
-			}
-		}()
-		progressStepsMu.RUnlock()
-
-		receivedResults++
-
-		if result.err != nil {
-			log.Error("ошибка в результате: ", sl.Err(result.err))
-			hasErrors = true
-			if firstError == nil {
-				firstError = result.err
-			}
-			continue
-		}
-
-		if result.groupReport != nil {
-			groupReports = append(groupReports, *result.groupReport)
-		}
-
-		if result.cost != nil {
-			allRubs += *result.cost
-		}
-
-		if result.tokens != nil {
-			allTokens += *result.tokens
-		}
-
-		if receivedResults >= expectedResults {
-			break
-		}
-	}
-
-	// Проверяем, были ли критические ошибки
-	if hasErrors && firstError != nil {
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка при обработке запросов к LLM: "+firstError.Error(), log)
-		return
-	}
-
-	SortGroupReports(groupReports)
-
-	rawGroupAnalizeResult, err := json.Marshal(groupReports)
+	step1, err := runStage(ctx, tz, versionID, StageStep1, log, func() (*step1Checkpoint, error) {
+		return tz.runStep1(ctx, versionID, promts, promptsStage.Schema, ggID, log)
+	})
 	if err != nil {
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка rawGroupAnalizeResult: "+err.Error(), log)
-		return
+		return err
 	}
 
-	messagesFromPromtBuilder, step2schema, GenerateStep2PromtsErr := tz.promtBuilderClient.GenerateStep2Promts(string(rawGroupAnalizeResult), markdownResponse.Markdown)
-	if GenerateStep2PromtsErr != nil {
-		log.Error("GenerateStep2Promts error: " + GenerateStep2PromtsErr.Error())
+	groupReports := step1.GroupReports
 
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка messagesFromPromtBuilder: "+GenerateStep2PromtsErr.Error(), log)
-		return
-	}
-
-	messages := make([]struct {
-		Role    *string `json:"role"`
-		Content *string `json:"content"`
-	},
-		0)
-
-	for _, msg := range *messagesFromPromtBuilder {
-		messages = append(messages, struct {
-			Role    *string `json:"role"`
-			Content *string `json:"content"`
-		}{
-			Role:    msg.Role,
-			Content: msg.Content,
-		})
-	}
-
-	step2LlmResponse, step2LlmError := tz.llmClient.SendMessage(messages, step2schema, 2, tz.useLlmCache)
-	if step2LlmError != nil {
-		log.Error("step2Llm error: " + step2LlmError.Error())
-
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка step2Llm send message: "+step2LlmError.Error(), log)
-		return
+	step2, err := runStage(ctx, tz, versionID, StageStep2, log, func() (*step2Checkpoint, error) {
+		return tz.runStep2(ctx, versionID, groupReports, markdownResponse.Markdown, ggID, log)
+	})
+	if err != nil {
+		return err
 	}
 
-	if step2LlmResponse.Cost != nil && step2LlmResponse.Cost.TotalRub != nil {
-		allRubs += *step2LlmResponse.Cost.TotalRub
-	}
+	llmReport := step2.Report
+	allRubs := step1.Rubs + step2.Rubs
+	allTokens := step1.Tokens + step2.Tokens
 
-	if step2LlmResponse.Usage != nil && step2LlmResponse.Usage.TotalTokens != nil {
-		allTokens += int64(*step2LlmResponse.Usage.TotalTokens)
-	}
+	tz.publishVersionEvent(ctx, versionID, VersionEvent{Type: VersionEventCost, Rubs: allRubs, Tokens: allTokens})
+	tz.publishVersionEvent(ctx, versionID, VersionEvent{Type: VersionEventStageStarted, Stage: StageReport})
 
 	//llmReportRaw := string(step2LlmResponse.ResultRaw)
 	//log.Info(llmReportRaw)
@@ -476,19 +319,19 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 		}
 	}
 
-	for i := range *step2LlmResponse.ResultStep2.Sections {
-		for j := range *(*step2LlmResponse.ResultStep2.Sections)[i].FinalInstanceIds {
+	for i := range *llmReport.Sections {
+		for j := range *(*llmReport.Sections)[i].FinalInstanceIds {
 			for _, step1groupReport := range groupReports {
 				for _, step1error := range *step1groupReport.Errors {
 					for _, step1instance := range *step1error.Instances {
-						if *step1instance.LlmId == (*(*step2LlmResponse.ResultStep2.Sections)[i].FinalInstanceIds)[j] {
-							if (*step2LlmResponse.ResultStep2.Sections)[i].Instances == nil {
+						if *step1instance.LlmId == (*(*llmReport.Sections)[i].FinalInstanceIds)[j] {
+							if (*llmReport.Sections)[i].Instances == nil {
 								insts := make([]tz_llm_client.LlmStep2Instance, 0)
-								(*step2LlmResponse.ResultStep2.Sections)[i].Instances = &insts
+								(*llmReport.Sections)[i].Instances = &insts
 							}
 
 							errorID := step1error.ID.String()
-							*(*step2LlmResponse.ResultStep2.Sections)[i].Instances = append(*(*step2LlmResponse.ResultStep2.Sections)[i].Instances, tz_llm_client.LlmStep2Instance{
+							*(*llmReport.Sections)[i].Instances = append(*(*llmReport.Sections)[i].Instances, tz_llm_client.LlmStep2Instance{
 								WhatIsIncorrect: step1instance.WhatIsIncorrect,
 								Fix:             step1instance.Fix,
 								ErrorID:         &errorID,
@@ -503,11 +346,10 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 		}
 	}
 
-	llmFinalReport, llmFinalReportMarshalErr := json.Marshal(*step2LlmResponse.ResultStep2)
+	llmFinalReport, llmFinalReportMarshalErr := json.Marshal(*llmReport)
 	if llmFinalReportMarshalErr != nil {
 		log.Error("llmFinalReport Marshal error: " + llmFinalReportMarshalErr.Error())
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка llmFinalReportMarshalErr: "+llmFinalReportMarshalErr.Error(), log)
-		return
+		return errors.New("ошибка llmFinalReportMarshalErr: " + llmFinalReportMarshalErr.Error())
 	}
 
 	errorsInTz := ErrorsFormation(groupReports, errorsDescrptions)
@@ -516,16 +358,26 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 		errorsInTz[i].OrderNumber = i
 	}
 
-	outInvalidErrors, outMissingErrors, _ := HandleErrors(&groupReports, &markdownResponse.Mappings)
-	htmlParagrapsWithWrappedErrors := "......................................."
-	outHtml := "....................................................."
-
-	//if oldVersion {
-	//	outHtml = htmlParagrapsWithWrappedErrors
-	//} else {
-	//	//outHtml = word_parser2.InsertParagraphs(htmlWithPlaceholder, htmlParagrapsWithWrappedErrors)
-	//	outHtml = paragraphsproc.InsertParagraphs(htmlWithPlaceholder, htmlParagrapsWithWrappedErrors)
-	//}
+	// Цитаты оборачиваются в копии блоков: исходный маппинг сохраняется в версии без изменений
+	htmlBlocks := slices.Clone(markdownResponse.Mappings)
+	outInvalidErrors, outMissingErrors, htmlParagrapsWithWrappedErrors, groundingStats, injectErrors := HandleErrors(&groupReports, markdownResponse.Markdown, &htmlBlocks)
+	outHtml := htmlParagrapsWithWrappedErrors
+	assignPdfPages(outInvalidErrors, markdownResponse.Mappings)
+
+	log.Info("цитаты замечаний сверены с markdown документа",
+		slog.Int("grounded", groundingStats.Grounded),
+		slog.Int("lines_corrected", groundingStats.LinesCorrected),
+		slog.Int("flagged", groundingStats.Flagged),
+		slog.Int("dropped", groundingStats.Dropped))
+
+	if len(injectErrors) > 0 {
+		log.Warn("не все цитаты найдены в html документа",
+			slog.Int("not_located", countNotLocated(outInvalidErrors)),
+			slog.Int("invalid_instances", len(*outInvalidErrors)))
+		for _, injectErr := range injectErrors {
+			log.Debug("цитата не вставлена в html", sl.Err(injectErr))
+		}
+	}
 
 	for i := range *outInvalidErrors {
 		(*outInvalidErrors)[i].OrderNumber = i
@@ -550,60 +402,8 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 		errorsInTz[i].MissingInstances = &missingInstances
 	}
 
-	if outInvalidErrors != nil {
-		err = tz.repo.SaveInvalidInstances(ctx, outInvalidErrors)
-		if err != nil {
-			tz.handleProcessingError(ctx, versionID, userID, "ошибка сохранения invalid instances: "+err.Error(), log)
-			return
-		}
-	}
-
-	if outMissingErrors != nil {
-		err = tz.repo.SaveMissingInstances(ctx, outMissingErrors)
-		if err != nil {
-			tz.handleProcessingError(ctx, versionID, userID, "ошибка сохранения missing instances: "+err.Error(), log)
-			return
-		}
-	}
-
-	invalidInstances2 := make([]OutInvalidError, 0)
-	for i := range errorsInTz {
-		invalidInstancesFromDb, err := tz.repo.GetInvalidInstancesByErrorID(ctx, errorsInTz[i].ID)
-		if err != nil {
-			log.Error("failed to get version errors: ", sl.Err(err))
-		} else {
-			for j := range *invalidInstancesFromDb {
-				(*invalidInstancesFromDb)[j].HtmlIDStr = strconv.Itoa(int((*invalidInstancesFromDb)[j].HtmlID))
-			}
-			invalidInstances2 = append(invalidInstances2, *invalidInstancesFromDb...)
-			errorsInTz[i].InvalidInstances = invalidInstancesFromDb
-		}
-
-		missingInstances, err := tz.repo.GetMissingInstancesByErrorID(ctx, errorsInTz[i].ID)
-		if err != nil {
-			log.Error("failed to get version missing instances: ", sl.Err(err))
-		} else {
-			errorsInTz[i].MissingInstances = missingInstances
-		}
-	}
-
-	var reportFilename string
-
-	reportCodument, err := tz.reportGeneratorClient.GenerateReport(ctx, step2LlmResponse.ResultStep2)
-	if err != nil {
-		log.Error("ошибка генерации docx-отчёта: ", sl.Err(err))
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка генерации docx-отчёта: "+err.Error(), log)
-		return
-	} else {
-		reportFilename = "отчёт_" + tzName + "_" + GetCurrentDateTimeString()
-		err = tz.s3.SaveDocument(ctx, reportFilename, reportCodument, "reports", ".docx")
-		if err != nil {
-			log.Error("ошибка сохранения docx отчёта в s3: ", sl.Err(err))
-			tz.handleProcessingError(ctx, versionID, userID, "ошибка сохранения docx отчёта в s3: "+err.Error(), log)
-			return
-		}
-	}
-
+	// Ошибки сохраняются раньше замечаний: DeleteVersionFindings находит замечания неудачной
+	// попытки через errors.version_id, и замечания без своих ошибок остались бы в базе навсегда
 	if errorsInTz != nil && len(errorsInTz) > 0 {
 		errorData := make([]modelrepo.ErrorData, 0, len(errorsInTz))
 		for _, err := range errorsInTz {
@@ -637,6 +437,7 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 				ProcessVerification: sanitizeStringPointer(err.ProcessVerification),
 				ProcessRetrieval:    sanitizeStringSlice(err.ProcessRetrieval),
 				Instances:           instancesJSON,
+				CatalogEntryID:      catalogEntryID(catalogEntryIDs, sanitizedErrorCode),
 			})
 		}
 
@@ -648,13 +449,62 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 		err = tz.repo.CreateErrors(ctx, errorsReq)
 		if err != nil {
 			log.Error("ошибка сохранения errors: ", sl.Err(err))
-			tz.handleProcessingError(ctx, versionID, userID, "ошибка сохранения errors: "+err.Error(), log)
-			return
+			return errors.New("ошибка сохранения errors: " + err.Error())
 		} else {
 			log.Info("errors saved", slog.Int("count", len(errorData)))
 		}
 	}
 
+	if outInvalidErrors != nil {
+		err = tz.repo.SaveInvalidInstances(ctx, outInvalidErrors)
+		if err != nil {
+			return errors.New("ошибка сохранения invalid instances: " + err.Error())
+		}
+	}
+
+	if outMissingErrors != nil {
+		err = tz.repo.SaveMissingInstances(ctx, outMissingErrors)
+		if err != nil {
+			return errors.New("ошибка сохранения missing instances: " + err.Error())
+		}
+	}
+
+	invalidInstances2 := make([]OutInvalidError, 0)
+	for i := range errorsInTz {
+		invalidInstancesFromDb, err := tz.repo.GetInvalidInstancesByErrorID(ctx, errorsInTz[i].ID)
+		if err != nil {
+			log.Error("failed to get version errors: ", sl.Err(err))
+		} else {
+			for j := range *invalidInstancesFromDb {
+				(*invalidInstancesFromDb)[j].HtmlIDStr = strconv.Itoa(int((*invalidInstancesFromDb)[j].HtmlID))
+			}
+			invalidInstances2 = append(invalidInstances2, *invalidInstancesFromDb...)
+			errorsInTz[i].InvalidInstances = invalidInstancesFromDb
+		}
+
+		missingInstances, err := tz.repo.GetMissingInstancesByErrorID(ctx, errorsInTz[i].ID)
+		if err != nil {
+			log.Error("failed to get version missing instances: ", sl.Err(err))
+		} else {
+			errorsInTz[i].MissingInstances = missingInstances
+		}
+	}
+
+	var reportFilename string
+
+	reportCodument, err := tz.reportGeneratorClient.GenerateReport(ctx, llmReport)
+	if err != nil {
+		log.Error("ошибка генерации docx-отчёта: ", sl.Err(err))
+		return errors.New("ошибка генерации docx-отчёта: " + err.Error())
+	} else {
+		reportFilename = "отчёт_" + tzName + "_" + GetCurrentDateTimeString()
+		err = tz.s3.SaveDocument(ctx, reportFilename, reportCodument, "reports", ".docx")
+		if err != nil {
+			log.Error("ошибка сохранения docx отчёта в s3: ", sl.Err(err))
+			return errors.New("ошибка сохранения docx отчёта в s3: " + err.Error())
+		}
+	}
+
 	// ОПТИМИЗИРОВАНО: убрали бессмысленное выделение 1 байта, json.Marshal сам выделит нужный размер
 	// После каждого тяжелого маршалинга принудительно вызываем GC для освобождения памяти
 
@@ -671,8 +521,7 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 	mappingsFromMarkdownServiceJSON, mappingsFromMarkdownServiceJSONErr := json.Marshal(markdownResponse.Mappings)
 	if mappingsFromMarkdownServiceJSONErr != nil {
 		log.Error("ошибка сериализации mappingsFromMarkdownService: ", sl.Err(mappingsFromMarkdownServiceJSONErr))
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка сериализации mappingsFromMarkdownService: "+mappingsFromMarkdownServiceJSONErr.Error(), log)
-		return
+		return errors.New("ошибка сериализации mappingsFromMarkdownService: " + mappingsFromMarkdownServiceJSONErr.Error())
 	}
 	log.Info("mappings marshalled", slog.Int("json_size_mb", len(mappingsFromMarkdownServiceJSON)/1024/1024))
 	runtime.GC() // Принудительная сборка мусора после тяжелой операции
@@ -688,8 +537,7 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 	promtsFromPromtBuilderJSON, promtsFromPromtBuilderJSONErr := json.Marshal(promts)
 	if promtsFromPromtBuilderJSONErr != nil {
 		log.Error("ошибка сериализации promtsFromPromtBuilder: ", sl.Err(promtsFromPromtBuilderJSONErr))
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка сериализации promtsFromPromtBuilder: "+promtsFromPromtBuilderJSONErr.Error(), log)
-		return
+		return errors.New("ошибка сериализации promtsFromPromtBuilder: " + promtsFromPromtBuilderJSONErr.Error())
 	}
 	log.Info("promts marshalled", slog.Int("json_size_mb", len(promtsFromPromtBuilderJSON)/1024/1024))
 	runtime.GC() // Принудительная сборка мусора после тяжелой операции
@@ -705,14 +553,18 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 	groupReportsFromLlmJSON, groupReportsFromLlmJSONErr := json.Marshal(groupReports)
 	if groupReportsFromLlmJSONErr != nil {
 		log.Error("ошибка сериализации groupReportsFromLlm: ", sl.Err(groupReportsFromLlmJSONErr))
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка сериализации groupReportsFromLlm: "+groupReportsFromLlmJSONErr.Error(), log)
-		return
+		return errors.New("ошибка сериализации groupReportsFromLlm: " + groupReportsFromLlmJSONErr.Error())
 	}
 	log.Info("group reports marshalled", slog.Int("json_size_mb", len(groupReportsFromLlmJSON)/1024/1024))
 	runtime.GC() // Принудительная сборка мусора после тяжелой операции
 
 	inspectionTime := time.Since(now)
 
+	// Проверку могли отменить, пока формировался отчёт
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Обновляем версию с результатами обработки
 	// Санитизируем строковые поля перед сохранением в БД
 	updateReq := &modelrepo.UpdateVersionRequest{
@@ -739,11 +591,18 @@ func (tz *Tz) ProcessTzAsync(file []byte, filename string, versionID uuid.UUID,
 	}
 	err = tz.repo.UpdateVersion(ctx, updateReq)
 	if err != nil {
-		tz.handleProcessingError(ctx, versionID, userID, "ошибка обновления версии: "+err.Error(), log)
-		return
+		if errors.Is(err, repository.ErrVersionNotInProgress) {
+			return ErrVersionCancelled
+		}
+		return errors.New("ошибка обновления версии: " + err.Error())
 	}
 
-	log.Info("async processing completed successfully")
+	tz.publishVersionEvent(ctx, versionID, VersionEvent{Type: VersionEventStageFinished, Stage: StageReport})
+	tz.publishVersionEvent(ctx, versionID, VersionEvent{Type: VersionEventFinalStatus, Status: "completed"})
+
+	log.Info("processing completed successfully")
+
+	return nil
 }
 
 func SortGroupReports(reports []tz_llm_client.GroupReport) {
@@ -781,7 +640,9 @@ func SortGroupReports(reports []tz_llm_client.GroupReport) {
 //	Instances          *[]Instance `json:"instances"`
 //}
 
-func (tz *Tz) updateVersionWithError(ctx context.Context, versionID uuid.UUID, status string) {
+// updateVersionWithError переводит выполняющуюся версию в статус ошибки. Возвращает false,
+// если версия уже не выполняется (её отменили) или обновить её не удалось
+func (tz *Tz) updateVersionWithError(ctx context.Context, versionID uuid.UUID, status string) bool {
 	updateReq := &modelrepo.UpdateVersionRequest{
 		ID:             versionID,
 		UpdatedAt:      time.Now(),
@@ -798,27 +659,30 @@ func (tz *Tz) updateVersionWithError(ctx context.Context, versionID uuid.UUID, s
 	err := tz.repo.UpdateVersion(ctx, updateReq)
 	if err != nil {
 		tz.log.Error("failed to update version with error status", slog.String("versionID", versionID.String()), slog.Any("error", err))
+		return false
 	}
+
+	return true
 }
 
-// handleProcessingError обрабатывает ошибку в асинхронной обработке:
+// handleProcessingError обрабатывает окончательную ошибку обработки версии:
 // 1. Обновляет статус версии на "error"
 // 2. Декрементирует счетчик проверок пользователя
 // 3. Отправляет уведомление в Telegram
 func (tz *Tz) handleProcessingError(ctx context.Context, versionID uuid.UUID, userID uuid.UUID, errorMsg string, log *slog.Logger) {
 	log.Error(errorMsg)
 
-	// Обновляем статус версии на "error"
-	tz.updateVersionWithError(ctx, versionID, "error")
+	// Обновляем статус версии на "error". Отменённая версия статус не меняет,
+	// а проверку ей уже вернул CancelVersion
+	if !tz.updateVersionWithError(ctx, versionID, "error") {
+		log.Warn("version is not in progress, skipping refund")
+		return
+	}
+	tz.publishVersionEvent(ctx, versionID, VersionEvent{Type: VersionEventFinalStatus, Status: "error"})
 
-	// Декрементируем счетчик проверок пользователя
+	// Возвращаем проверку в квоту, из которой она была списана
 	if tz.userServiceClient != nil {
-		err := tz.userServiceClient.DecrementInspectionsForToday(ctx, userID.String())
-		if err != nil {
-			log.Error("failed to decrement inspections for today", sl.Err(err))
-		} else {
-			log.Info("inspections counter decremented due to processing error")
-		}
+		tz.refundVersionInspection(ctx, versionID, userID, log)
 	}
 
 	// Отправляем уведомление в Telegram
@@ -838,34 +702,59 @@ func (tz *Tz) handleProcessingError(ctx context.Context, versionID uuid.UUID, us
 	}
 }
 
-// decrementInspectionsForUser декрементирует счетчик проверок пользователя
-func (tz *Tz) decrementInspectionsForUser(ctx context.Context, userID uuid.UUID, log *slog.Logger) {
-	if tz.userServiceClient != nil {
-		err := tz.userServiceClient.DecrementInspectionsForToday(ctx, userID.String())
-		if err != nil {
-			log.Error("failed to decrement inspections for today", sl.Err(err))
-		} else {
-			log.Info("inspections counter decremented due to early error")
-		}
+// incrementInspectionsForUser списывает проверку пользователя (проверяет лимит) и возвращает
+// организацию, из квоты которой она списана. nil - личный лимит или user-service не подключён
+func (tz *Tz) incrementInspectionsForUser(ctx context.Context, userID uuid.UUID, log *slog.Logger) (*uuid.UUID, error) {
+	if tz.userServiceClient == nil {
+		return nil, nil
+	}
+
+	organizationID, err := tz.userServiceClient.IncrementInspectionsForToday(ctx, userID.String())
+	if err != nil {
+		log.Error("failed to increment inspections for today", sl.Err(err))
+		return nil, fmt.Errorf("inspection limit exceeded or user service error: %w", err)
+	}
+	log.Info("inspections counter incremented successfully", slog.String("organizationID", organizationID))
+
+	if organizationID == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(organizationID)
+	if err != nil {
+		log.Error("invalid organization id from user service", slog.String("organizationID", organizationID), sl.Err(err))
+		return nil, nil
 	}
+
+	return &id, nil
 }
 
-// RemoveDocxExtension удаляет расширение ".docx" из конца строки (регистронезависимо)
-func RemoveDocxExtension(filename string) string {
-	// Проверяем, заканчивается ли строка на ".docx" (регистронезависимо)
-	if strings.HasSuffix(strings.ToLower(filename), ".docx") {
-		return filename[:len(filename)-5]
+// refundVersionInspection возвращает проверку версии в квоту, из которой она была списана
+func (tz *Tz) refundVersionInspection(ctx context.Context, versionID uuid.UUID, userID uuid.UUID, log *slog.Logger) {
+	organizationID, err := tz.repo.GetVersionChargedOrganizationID(ctx, versionID)
+	if err != nil {
+		log.Error("failed to get version charged organization, inspection is not refunded", sl.Err(err))
+		return
 	}
-	return filename
+
+	tz.decrementInspectionsForUser(ctx, userID, organizationID, log)
 }
 
-// RemoveDocxExtension удаляет расширение ".doc" из конца строки (регистронезависимо)
-func RemoveDocExtension(filename string) string {
-	// Проверяем, заканчивается ли строка на ".docx" (регистронезависимо)
-	if strings.HasSuffix(strings.ToLower(filename), ".doc") {
-		return filename[:len(filename)-4]
+// decrementInspectionsForUser возвращает проверку пользователя в квоту организации organizationID,
+// из которой она была списана, или в личный лимит, если organizationID = nil
+func (tz *Tz) decrementInspectionsForUser(ctx context.Context, userID uuid.UUID, organizationID *uuid.UUID, log *slog.Logger) {
+	if tz.userServiceClient != nil {
+		var chargedOrganizationID string
+		if organizationID != nil {
+			chargedOrganizationID = organizationID.String()
+		}
+
+		err := tz.userServiceClient.DecrementInspectionsForToday(ctx, userID.String(), chargedOrganizationID)
+		if err != nil {
+			log.Error("failed to decrement inspections for today", sl.Err(err))
+		} else {
+			log.Info("inspections counter decremented", slog.String("organizationID", chargedOrganizationID))
+		}
 	}
-	return filename
 }
 
 // SortErrorsByCode сортирует массив ошибок по ErrorCode
@@ -923,17 +812,6 @@ func SortErrorsByCode(errors []Error) {
 	})
 }
 
-func IsDocFormat(filename string) (bool, error) {
-	lower := strings.ToLower(filename)
-	if strings.HasSuffix(lower, ".doc") && !strings.HasSuffix(lower, ".docx") {
-		return true, nil
-	}
-	if strings.HasSuffix(lower, ".docx") {
-		return false, nil
-	}
-	return false, fmt.Errorf("file must have .doc or .docx extension")
-}
-
 func GetCurrentDateTimeString() string {
 	now := time.Now()
 	return fmt.Sprintf("%d.%d.%d.%02d.%02d.%02d.%02d",
@@ -0,0 +1,251 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// checkProfileName - допустимый код профиля: строчные латинские буквы, цифры, "_" и "-".
+// Длина ограничена 48 символами, чтобы код помещался в callback_data кнопки Telegram (64 байта)
+var checkProfileName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,47}$`)
+
+// CheckOptions - параметры проверки, переданные при загрузке документа
+type CheckOptions struct {
+	// Force - проверить файл заново, даже если он уже проверялся
+	Force bool
+	// Profile - код профиля проверки. Пустой - профиль по умолчанию
+	Profile string
+	// GgID - группа ошибок промт-билдера. Если задана, заменяет gg_id профиля
+	GgID *int
+}
+
+// checkSettings - профиль и gg_id, с которыми будет проверена версия
+type checkSettings struct {
+	ProfileID   *uuid.UUID
+	ProfileName string
+	GgID        int
+}
+
+// CheckProfileParams - поля профиля проверки при создании и изменении. При изменении
+// пустые поля не меняются
+type CheckProfileParams struct {
+	Name        string
+	Title       *string
+	Description *string
+	GgID        *int
+}
+
+// resolveCheckSettings определяет профиль и gg_id проверки. Если профиль не указан, берётся профиль
+// по умолчанию, а без него - tz.ggID
+func (tz *Tz) resolveCheckSettings(ctx context.Context, opts CheckOptions) (*checkSettings, error) {
+	if opts.GgID != nil && *opts.GgID <= 0 {
+		return nil, fmt.Errorf("%w: gg_id must be positive", ErrInvalidCheckProfile)
+	}
+
+	var profile *modelrepo.CheckProfile
+	var err error
+	if opts.Profile != "" {
+		profile, err = tz.repo.GetCheckProfileByName(ctx, opts.Profile)
+	} else {
+		profile, err = tz.repo.GetDefaultCheckProfile(ctx)
+	}
+
+	settings := &checkSettings{}
+	switch {
+	case err == nil:
+		settings.ProfileID = &profile.ID
+		settings.ProfileName = profile.Name
+		settings.GgID = profile.GgID
+	case errors.Is(err, repository.ErrCheckProfileNotFound) && opts.Profile != "":
+		return nil, ErrCheckProfileNotFound
+	case errors.Is(err, repository.ErrCheckProfileNotFound):
+		settings.GgID = tz.defaultGgID()
+	default:
+		return nil, fmt.Errorf("failed to get check profile: %w", err)
+	}
+
+	if opts.GgID != nil {
+		settings.GgID = *opts.GgID
+	}
+
+	return settings, nil
+}
+
+// versionGgID возвращает gg_id, с которым запущена проверка версии. Для версий, созданных до появления
+// профилей, используется текущий профиль по умолчанию
+func (tz *Tz) versionGgID(ctx context.Context, versionID uuid.UUID) (int, error) {
+	version, err := tz.repo.GetVersion(ctx, versionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get version: %w", err)
+	}
+	if version.GgID != nil {
+		return *version.GgID, nil
+	}
+
+	settings, err := tz.resolveCheckSettings(ctx, CheckOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return settings.GgID, nil
+}
+
+func (tz *Tz) defaultGgID() int {
+	tz.mu.RLock()
+	defer tz.mu.RUnlock()
+	return tz.ggID
+}
+
+// ListCheckProfiles возвращает все профили проверки
+func (tz *Tz) ListCheckProfiles(ctx context.Context) ([]modelrepo.CheckProfile, error) {
+	const op = "Tz.ListCheckProfiles"
+
+	profiles, err := tz.repo.ListCheckProfiles(ctx)
+	if err != nil {
+		tz.log.With(slog.String("op", op)).Error("failed to list check profiles: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to list check profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// GetDefaultCheckProfile возвращает профиль по умолчанию
+func (tz *Tz) GetDefaultCheckProfile(ctx context.Context) (*modelrepo.CheckProfile, error) {
+	profile, err := tz.repo.GetDefaultCheckProfile(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrCheckProfileNotFound) {
+			return nil, ErrCheckProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to get default check profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// CreateCheckProfile создаёт профиль проверки. Код, название и gg_id обязательны
+func (tz *Tz) CreateCheckProfile(ctx context.Context, params CheckProfileParams) (*modelrepo.CheckProfile, error) {
+	const op = "Tz.CreateCheckProfile"
+
+	log := tz.log.With(slog.String("op", op), slog.String("name", params.Name))
+
+	if !checkProfileName.MatchString(params.Name) {
+		return nil, fmt.Errorf("%w: name must match %s", ErrInvalidCheckProfile, checkProfileName)
+	}
+	if params.Title == nil || strings.TrimSpace(*params.Title) == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidCheckProfile)
+	}
+	if params.GgID == nil || *params.GgID <= 0 {
+		return nil, fmt.Errorf("%w: gg_id must be positive", ErrInvalidCheckProfile)
+	}
+
+	profile, err := tz.repo.CreateCheckProfile(ctx, &modelrepo.CreateCheckProfileRequest{
+		ID:          uuid.New(),
+		Name:        params.Name,
+		Title:       strings.TrimSpace(*params.Title),
+		Description: params.Description,
+		GgID:        *params.GgID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateCheckProfile) {
+			return nil, ErrCheckProfileExists
+		}
+		log.Error("failed to create check profile: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to create check profile: %w", err)
+	}
+
+	log.Info("check profile created", slog.Int("ggID", profile.GgID))
+
+	return profile, nil
+}
+
+// UpdateCheckProfile изменяет профиль проверки. Уже запущенные проверки не затрагиваются:
+// gg_id сохраняется в версии при загрузке
+func (tz *Tz) UpdateCheckProfile(ctx context.Context, params CheckProfileParams) (*modelrepo.CheckProfile, error) {
+	const op = "Tz.UpdateCheckProfile"
+
+	log := tz.log.With(slog.String("op", op), slog.String("name", params.Name))
+
+	if params.Title != nil && strings.TrimSpace(*params.Title) == "" {
+		return nil, fmt.Errorf("%w: title cannot be empty", ErrInvalidCheckProfile)
+	}
+	if params.GgID != nil && *params.GgID <= 0 {
+		return nil, fmt.Errorf("%w: gg_id must be positive", ErrInvalidCheckProfile)
+	}
+
+	profile, err := tz.repo.UpdateCheckProfile(ctx, &modelrepo.UpdateCheckProfileRequest{
+		Name:        params.Name,
+		Title:       params.Title,
+		Description: params.Description,
+		GgID:        params.GgID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrCheckProfileNotFound) {
+			return nil, ErrCheckProfileNotFound
+		}
+		log.Error("failed to update check profile: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to update check profile: %w", err)
+	}
+
+	log.Info("check profile updated", slog.Int("ggID", profile.GgID))
+
+	return profile, nil
+}
+
+// DeleteCheckProfile удаляет профиль проверки. Профиль по умолчанию удалить нельзя -
+// сначала нужно назначить другой
+func (tz *Tz) DeleteCheckProfile(ctx context.Context, name string) error {
+	const op = "Tz.DeleteCheckProfile"
+
+	log := tz.log.With(slog.String("op", op), slog.String("name", name))
+
+	profile, err := tz.repo.GetCheckProfileByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrCheckProfileNotFound) {
+			return ErrCheckProfileNotFound
+		}
+		return fmt.Errorf("failed to get check profile: %w", err)
+	}
+	if profile.IsDefault {
+		return ErrDefaultCheckProfile
+	}
+
+	if err := tz.repo.DeleteCheckProfile(ctx, name); err != nil {
+		if errors.Is(err, repository.ErrCheckProfileNotFound) {
+			return ErrCheckProfileNotFound
+		}
+		log.Error("failed to delete check profile: ", sl.Err(err))
+		return fmt.Errorf("failed to delete check profile: %w", err)
+	}
+
+	log.Info("check profile deleted")
+
+	return nil
+}
+
+// SetDefaultCheckProfile назначает профиль по умолчанию для загрузок без явного профиля
+func (tz *Tz) SetDefaultCheckProfile(ctx context.Context, name string) (*modelrepo.CheckProfile, error) {
+	const op = "Tz.SetDefaultCheckProfile"
+
+	log := tz.log.With(slog.String("op", op), slog.String("name", name))
+
+	profile, err := tz.repo.SetDefaultCheckProfile(ctx, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrCheckProfileNotFound) {
+			return nil, ErrCheckProfileNotFound
+		}
+		log.Error("failed to set default check profile: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to set default check profile: %w", err)
+	}
+
+	log.Info("default check profile changed", slog.Int("ggID", profile.GgID))
+
+	return profile, nil
+}
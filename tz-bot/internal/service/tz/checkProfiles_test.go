@@ -0,0 +1,22 @@
+package tzservice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckProfileName(t *testing.T) {
+	valid := []string{"default", "44-fz", "construction_v2", "a", strings.Repeat("a", 48)}
+	for _, name := range valid {
+		if !checkProfileName.MatchString(name) {
+			t.Errorf("name %q must be valid", name)
+		}
+	}
+
+	invalid := []string{"", "Default", "-fz", "с пробелом", "строительство", "a:b", strings.Repeat("a", 49)}
+	for _, name := range invalid {
+		if checkProfileName.MatchString(name) {
+			t.Errorf("name %q must be invalid", name)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxCreateVersionAttempts - сколько раз пытаемся занять следующий номер версии,
+// если параллельная загрузка успела создать версию с тем же номером
+const maxCreateVersionAttempts = 3
+
+type CheckTzVersionResult struct {
+	VersionID                uuid.UUID
+	TechnicalSpecificationID uuid.UUID
+	Name                     string
+	VersionNumber            int
+	CreatedAt                time.Time
+	// Duplicate - файл уже проверялся: вместо новой версии возвращена завершённая версия VersionID,
+	// она может относиться к другому ТЗ пользователя
+	Duplicate bool
+	// RecheckOfVersionID - ранее проверенная версия с тем же файлом, если проверка запущена повторно (force)
+	RecheckOfVersionID *uuid.UUID
+	CheckProfile       string
+	GgID               int
+}
+
+// CheckTzVersion загружает исправленный документ как версию N+1 уже существующего ТЗ
+// и запускает его проверку. Профиль проверки и повторная загрузка уже проверенного файла
+// обрабатываются так же, как в CheckTz
+func (tz *Tz) CheckTzVersion(ctx context.Context, file []byte, filename string, technicalSpecificationID uuid.UUID, userID uuid.UUID, opts CheckOptions) (*CheckTzVersionResult, error) {
+	const op = "Tz.CheckTzVersion"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("userID", userID.String()),
+		slog.String("technicalSpecificationID", technicalSpecificationID.String()),
+	)
+
+	log.Info("checking new tz version - creating initial records")
+
+	doc, err := tz.prepareUploadedDocument(ctx, file, filename)
+	if err != nil {
+		log.Warn("uploaded document rejected", slog.String("filename", filename), sl.Err(err))
+		return nil, err
+	}
+	tzName := doc.Name
+
+	ts, err := tz.repo.GetTechnicalSpecification(ctx, technicalSpecificationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTechnicalSpecificationNotFound) {
+			return nil, ErrTechnicalSpecificationNotFound
+		}
+		log.Error("failed to get technical specification: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get technical specification: %w", err)
+	}
+
+	if ts.UserID != userID {
+		log.Warn("user is not the owner of technical specification", slog.String("ownerID", ts.UserID.String()))
+		return nil, ErrAccessDenied
+	}
+
+	settings, err := tz.resolveCheckSettings(ctx, opts)
+	if err != nil {
+		log.Warn("failed to resolve check profile", slog.String("profile", opts.Profile), sl.Err(err))
+		return nil, err
+	}
+
+	fileHash := fileContentHash(file)
+	duplicate := tz.findDuplicateVersion(ctx, userID, fileHash, settings.GgID, log)
+	if duplicate != nil && !opts.Force {
+		log.Info("file has already been checked, returning previous version",
+			slog.String("version_id", duplicate.ID.String()))
+		return &CheckTzVersionResult{
+			VersionID:                duplicate.ID,
+			TechnicalSpecificationID: duplicate.TechnicalSpecificationID,
+			Name:                     duplicate.TechnicalSpecificationName,
+			VersionNumber:            duplicate.VersionNumber,
+			CreatedAt:                duplicate.CreatedAt,
+			Duplicate:                true,
+			CheckProfile:             settings.ProfileName,
+			GgID:                     settings.GgID,
+		}, nil
+	}
+
+	var recheckOf *uuid.UUID
+	if duplicate != nil {
+		recheckOf = &duplicate.ID
+	}
+
+	// Инкрементируем счетчик проверок для пользователя (проверяем лимит)
+	organizationID, err := tz.incrementInspectionsForUser(ctx, userID, log)
+	if err != nil {
+		return nil, err
+	}
+
+	originalFileName := tzName + GetCurrentDateTimeString()
+	extension := doc.Format.Extension()
+
+	// Сохраняем оригинальный файл в S3
+	err = tz.s3.SaveDocument(ctx, originalFileName, file, "docs", extension)
+	if err != nil {
+		log.Error("ошибка сохранения оригинального файла в S3: ", sl.Err(err))
+		tz.decrementInspectionsForUser(ctx, userID, organizationID, log)
+		return nil, fmt.Errorf("ошибка сохранения файла в S3: %w", err)
+	}
+	log.Info("оригинальный файл успешно сохранён в S3", slog.String("file_id", originalFileName))
+
+	newVersionID := uuid.New()
+	var versionNumber int
+	for attempt := 1; ; attempt++ {
+		versionNumber, err = tz.nextVersionNumber(ctx, technicalSpecificationID)
+		if err != nil {
+			log.Error("failed to get next version number: ", sl.Err(err))
+			tz.decrementInspectionsForUser(ctx, userID, organizationID, log)
+			return nil, fmt.Errorf("failed to get next version number: %w", err)
+		}
+
+		err = tz.repo.CreateVersion(ctx, &modelrepo.CreateVersionRequest{
+			ID:                       newVersionID,
+			TechnicalSpecificationID: technicalSpecificationID,
+			VersionNumber:            versionNumber,
+			CreatedAt:                time.Now(),
+			UpdatedAt:                time.Now(),
+			OriginalFileID:           originalFileName,
+			OriginalFileExtension:    extension,
+			OriginalFileSize:         int64(len(file)),
+			Status:                   "in_progress",
+			Progress:                 3,
+			FileHash:                 &fileHash,
+			RecheckOfVersionID:       recheckOf,
+			CheckProfileID:           settings.ProfileID,
+			GgID:                     &settings.GgID,
+			OrganizationID:           organizationID,
+			ChargedOrganizationID:    organizationID,
+		})
+		if err == nil {
+			break
+		}
+
+		if !errors.Is(err, repository.ErrDuplicateVersion) || attempt >= maxCreateVersionAttempts {
+			log.Error("failed to create version: ", sl.Err(err))
+			tz.decrementInspectionsForUser(ctx, userID, organizationID, log)
+			if errors.Is(err, repository.ErrDuplicateVersion) {
+				return nil, ErrDuplicateVersion
+			}
+			return nil, fmt.Errorf("failed to create version: %w", err)
+		}
+
+		log.Warn("version number already taken, retrying", slog.Int("versionNumber", versionNumber))
+	}
+	log.Info("version created with status 'in_progress'",
+		slog.String("version_id", newVersionID.String()),
+		slog.Int("versionNumber", versionNumber))
+
+	tz.saveUploadedDocumentCheckpoint(ctx, newVersionID, doc, log)
+
+	// Ставим проверку в очередь
+	err = tz.enqueueVersionProcessing(ctx, newVersionID, userID, filename, originalFileName+extension)
+	if err != nil {
+		log.Error("failed to enqueue version processing: ", sl.Err(err))
+		tz.updateVersionWithError(ctx, newVersionID, "error")
+		tz.decrementInspectionsForUser(ctx, userID, organizationID, log)
+		return nil, fmt.Errorf("failed to enqueue version processing: %w", err)
+	}
+
+	log.Info("version processing enqueued")
+
+	return &CheckTzVersionResult{
+		VersionID:                newVersionID,
+		TechnicalSpecificationID: technicalSpecificationID,
+		Name:                     ts.Name,
+		VersionNumber:            versionNumber,
+		CreatedAt:                time.Now(),
+		RecheckOfVersionID:       recheckOf,
+		CheckProfile:             settings.ProfileName,
+		GgID:                     settings.GgID,
+	}, nil
+}
+
+// nextVersionNumber возвращает номер, который получит следующая версия ТЗ
+func (tz *Tz) nextVersionNumber(ctx context.Context, technicalSpecificationID uuid.UUID) (int, error) {
+	latest, err := tz.repo.GetLatestVersion(ctx, technicalSpecificationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionNotFound) {
+			return 1, nil
+		}
+		return 0, err
+	}
+
+	return latest.VersionNumber + 1, nil
+}
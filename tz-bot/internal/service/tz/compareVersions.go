@@ -0,0 +1,208 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// quoteMatchThreshold - минимальная похожесть нормализованных цитат,
+// при которой замечания двух версий считаются одним и тем же
+const quoteMatchThreshold = 0.6
+
+// InstanceComparison - пара замечаний из двух версий. Для исправленных замечаний
+// заполнен только InstanceA, для новых - только InstanceB
+type InstanceComparison struct {
+	ErrorCode  string
+	InstanceA  *OutInvalidError
+	InstanceB  *OutInvalidError
+	Similarity float64
+}
+
+type VersionsComparison struct {
+	VersionA   *modelrepo.Version
+	VersionB   *modelrepo.Version
+	Fixed      []InstanceComparison
+	Persisting []InstanceComparison
+	New        []InstanceComparison
+}
+
+// CompareVersions сопоставляет invalid_instances двух версий одного ТЗ по коду ошибки
+// и нормализованной цитате и возвращает исправленные, оставшиеся и новые замечания
+func (tz *Tz) CompareVersions(ctx context.Context, versionAID, versionBID uuid.UUID) (*VersionsComparison, error) {
+	const op = "Tz.CompareVersions"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("versionAID", versionAID.String()),
+		slog.String("versionBID", versionBID.String()),
+	)
+
+	versionA, err := tz.getCompletedVersion(ctx, versionAID)
+	if err != nil {
+		log.Error("failed to get version a: ", sl.Err(err))
+		return nil, err
+	}
+
+	versionB, err := tz.getCompletedVersion(ctx, versionBID)
+	if err != nil {
+		log.Error("failed to get version b: ", sl.Err(err))
+		return nil, err
+	}
+
+	if versionA.TechnicalSpecificationID != versionB.TechnicalSpecificationID {
+		return nil, ErrVersionsFromDifferentSpecs
+	}
+
+	instancesA, err := tz.getVersionInvalidInstances(ctx, versionAID)
+	if err != nil {
+		log.Error("failed to get invalid instances of version a: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get invalid instances: %w", err)
+	}
+
+	instancesB, err := tz.getVersionInvalidInstances(ctx, versionBID)
+	if err != nil {
+		log.Error("failed to get invalid instances of version b: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get invalid instances: %w", err)
+	}
+
+	fixed, persisting, newInstances := matchInvalidInstances(instancesA, instancesB)
+
+	log.Info("versions compared",
+		slog.Int("fixed", len(fixed)),
+		slog.Int("persisting", len(persisting)),
+		slog.Int("new", len(newInstances)))
+
+	return &VersionsComparison{
+		VersionA:   versionA,
+		VersionB:   versionB,
+		Fixed:      fixed,
+		Persisting: persisting,
+		New:        newInstances,
+	}, nil
+}
+
+func (tz *Tz) getCompletedVersion(ctx context.Context, versionID uuid.UUID) (*modelrepo.Version, error) {
+	version, err := tz.repo.GetVersion(ctx, versionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionNotFound) {
+			return nil, ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+
+	if version.Status != "completed" {
+		return nil, ErrVersionNotCompleted
+	}
+
+	return version, nil
+}
+
+// getVersionInvalidInstances возвращает все invalid_instances версии с заполненным ParentError
+func (tz *Tz) getVersionInvalidInstances(ctx context.Context, versionID uuid.UUID) ([]OutInvalidError, error) {
+	errorsInTz, err := tz.repo.GetErrorsByVersionID(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidInstances := make([]OutInvalidError, 0)
+	for i := range *errorsInTz {
+		instances, err := tz.repo.GetInvalidInstancesByErrorID(ctx, (*errorsInTz)[i].ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for j := range *instances {
+			(*instances)[j].ParentError = (*errorsInTz)[i]
+		}
+		invalidInstances = append(invalidInstances, *instances...)
+	}
+
+	SortOutInvalidErrorsByOrderNumber(&invalidInstances)
+
+	return invalidInstances, nil
+}
+
+// matchInvalidInstances жадно сопоставляет замечания версий A и B: сначала
+// забираются самые похожие пары с одинаковым кодом ошибки
+func matchInvalidInstances(instancesA, instancesB []OutInvalidError) (fixed, persisting, newInstances []InstanceComparison) {
+	type candidate struct {
+		a, b       int
+		similarity float64
+	}
+
+	normalizedB := make([]string, len(instancesB))
+	for j := range instancesB {
+		normalizedB[j] = normalizeText(instancesB[j].Quote)
+	}
+
+	candidates := make([]candidate, 0)
+	for i := range instancesA {
+		normalizedA := normalizeText(instancesA[i].Quote)
+		for j := range instancesB {
+			if instancesA[i].ParentError.ErrorCode != instancesB[j].ParentError.ErrorCode {
+				continue
+			}
+
+			similarity := calculateTextSimilarity(normalizedA, normalizedB[j])
+			if similarity >= quoteMatchThreshold {
+				candidates = append(candidates, candidate{a: i, b: j, similarity: similarity})
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].similarity > candidates[j].similarity
+	})
+
+	matchedA := make([]bool, len(instancesA))
+	matchedB := make([]bool, len(instancesB))
+	persisting = make([]InstanceComparison, 0)
+	for _, c := range candidates {
+		if matchedA[c.a] || matchedB[c.b] {
+			continue
+		}
+		matchedA[c.a] = true
+		matchedB[c.b] = true
+
+		persisting = append(persisting, InstanceComparison{
+			ErrorCode:  instancesA[c.a].ParentError.ErrorCode,
+			InstanceA:  &instancesA[c.a],
+			InstanceB:  &instancesB[c.b],
+			Similarity: c.similarity,
+		})
+	}
+
+	sort.SliceStable(persisting, func(i, j int) bool {
+		return persisting[i].InstanceB.OrderNumber < persisting[j].InstanceB.OrderNumber
+	})
+
+	fixed = make([]InstanceComparison, 0)
+	for i := range instancesA {
+		if !matchedA[i] {
+			fixed = append(fixed, InstanceComparison{
+				ErrorCode: instancesA[i].ParentError.ErrorCode,
+				InstanceA: &instancesA[i],
+			})
+		}
+	}
+
+	newInstances = make([]InstanceComparison, 0)
+	for j := range instancesB {
+		if !matchedB[j] {
+			newInstances = append(newInstances, InstanceComparison{
+				ErrorCode: instancesB[j].ParentError.ErrorCode,
+				InstanceB: &instancesB[j],
+			})
+		}
+	}
+
+	return fixed, persisting, newInstances
+}
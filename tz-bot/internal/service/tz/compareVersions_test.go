@@ -0,0 +1,65 @@
+package tzservice
+
+import (
+	"testing"
+)
+
+func TestMatchInvalidInstances(t *testing.T) {
+	instancesA := []OutInvalidError{
+		{Quote: "Система должна обеспечивать работу пользователей в рамках отведенной им роли", OrderNumber: 1, ParentError: Error{ErrorCode: "E01"}},
+		{Quote: "Срок поставки оборудования определяется отдельно", OrderNumber: 2, ParentError: Error{ErrorCode: "E02"}},
+		{Quote: "Требования к надежности не предъявляются", OrderNumber: 3, ParentError: Error{ErrorCode: "E03"}},
+	}
+
+	instancesB := []OutInvalidError{
+		// Та же цитата с другими пробелами и регистром - замечание не исправлено
+		{Quote: "система  должна обеспечивать работу пользователей в рамках отведенной им роли", OrderNumber: 1, ParentError: Error{ErrorCode: "E01"}},
+		// Та же цитата, но другой код ошибки - это новое замечание
+		{Quote: "Срок поставки оборудования определяется отдельно", OrderNumber: 2, ParentError: Error{ErrorCode: "E04"}},
+		{Quote: "Гарантийный срок составляет не менее 12 месяцев", OrderNumber: 3, ParentError: Error{ErrorCode: "E05"}},
+	}
+
+	fixed, persisting, newInstances := matchInvalidInstances(instancesA, instancesB)
+
+	if len(persisting) != 1 {
+		t.Fatalf("ожидалось 1 оставшееся замечание, получено %d", len(persisting))
+	}
+	if persisting[0].ErrorCode != "E01" || persisting[0].Similarity != 1.0 {
+		t.Errorf("неверно сопоставлено оставшееся замечание: %+v", persisting[0])
+	}
+
+	if len(fixed) != 2 {
+		t.Fatalf("ожидалось 2 исправленных замечания, получено %d", len(fixed))
+	}
+	if fixed[0].ErrorCode != "E02" || fixed[1].ErrorCode != "E03" || fixed[0].InstanceB != nil {
+		t.Errorf("неверный список исправленных замечаний: %+v", fixed)
+	}
+
+	if len(newInstances) != 2 {
+		t.Fatalf("ожидалось 2 новых замечания, получено %d", len(newInstances))
+	}
+	if newInstances[0].ErrorCode != "E04" || newInstances[1].ErrorCode != "E05" || newInstances[0].InstanceA != nil {
+		t.Errorf("неверный список новых замечаний: %+v", newInstances)
+	}
+}
+
+func TestMatchInvalidInstancesPrefersClosestQuote(t *testing.T) {
+	instancesA := []OutInvalidError{
+		{Quote: "поставщик обязан предоставить документацию на оборудование", OrderNumber: 1, ParentError: Error{ErrorCode: "E01"}},
+	}
+
+	instancesB := []OutInvalidError{
+		{Quote: "поставщик обязан предоставить документацию на программное оборудование", OrderNumber: 1, ParentError: Error{ErrorCode: "E01"}},
+		{Quote: "поставщик обязан предоставить документацию на оборудование", OrderNumber: 2, ParentError: Error{ErrorCode: "E01"}},
+	}
+
+	fixed, persisting, newInstances := matchInvalidInstances(instancesA, instancesB)
+
+	if len(fixed) != 0 || len(persisting) != 1 || len(newInstances) != 1 {
+		t.Fatalf("неожиданный результат: fixed=%d persisting=%d new=%d", len(fixed), len(persisting), len(newInstances))
+	}
+
+	if persisting[0].InstanceB.OrderNumber != 2 {
+		t.Errorf("ожидалось сопоставление с точной цитатой, получено замечание %d", persisting[0].InstanceB.OrderNumber)
+	}
+}
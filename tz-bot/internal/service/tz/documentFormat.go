@@ -0,0 +1,286 @@
+package tzservice
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	markdown_service_client "repairCopilotBot/tz-bot/internal/pkg/markdown-service"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// DocumentFormat - формат загруженного документа, определённый по содержимому файла
+type DocumentFormat string
+
+const (
+	FormatDOCX DocumentFormat = "docx"
+	FormatDOC  DocumentFormat = "doc"
+	FormatPDF  DocumentFormat = "pdf"
+	FormatODT  DocumentFormat = "odt"
+	FormatRTF  DocumentFormat = "rtf"
+)
+
+// Сигнатуры форматов в начале файла
+var (
+	pdfSignature = []byte("%PDF-")
+	rtfSignature = []byte(`{\rtf`)
+	oleSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+	zipSignature = []byte("PK\x03\x04")
+)
+
+// odtMimetype - содержимое файла mimetype в архиве ODT
+const odtMimetype = "application/vnd.oasis.opendocument.text"
+
+// pdfSignatureOffset - насколько далеко от начала файла может стоять %PDF-.
+// Некоторые генераторы пишут перед заголовком мусор, Acrobat допускает до 1024 байт
+const pdfSignatureOffset = 1024
+
+// Extension возвращает расширение файла формата с точкой
+func (f DocumentFormat) Extension() string {
+	return "." + string(f)
+}
+
+// needsDocxConversion сообщает, что перед разбором документ нужно сконвертировать в docx
+func (f DocumentFormat) needsDocxConversion() bool {
+	return f == FormatDOC || f == FormatODT || f == FormatRTF
+}
+
+// DetectDocumentFormat определяет формат документа по сигнатуре содержимого. Расширение
+// имени файла не учитывается: .doc, переименованный в .docx, или docx, сохранённый
+// с расширением .pdf, будут обработаны по фактическому формату
+func DetectDocumentFormat(file []byte) (DocumentFormat, error) {
+	switch {
+	case bytes.HasPrefix(file, oleSignature):
+		return FormatDOC, nil
+	case bytes.HasPrefix(file, rtfSignature):
+		return FormatRTF, nil
+	case bytes.HasPrefix(file, zipSignature):
+		return detectZipDocumentFormat(file)
+	case bytes.Contains(file[:min(len(file), pdfSignatureOffset)], pdfSignature):
+		return FormatPDF, nil
+	}
+
+	return "", ErrUnsupportedFileFormat
+}
+
+// detectZipDocumentFormat различает docx и odt: оба формата - zip-архивы
+func detectZipDocumentFormat(file []byte) (DocumentFormat, error) {
+	archive, err := zip.NewReader(bytes.NewReader(file), int64(len(file)))
+	if err != nil {
+		return "", fmt.Errorf("%w: повреждённый архив: %w", ErrUnsupportedFileFormat, err)
+	}
+
+	for _, f := range archive.File {
+		switch f.Name {
+		case "word/document.xml":
+			return FormatDOCX, nil
+		case "mimetype":
+			rc, err := f.Open()
+			if err != nil {
+				continue
+			}
+			mimetype, err := io.ReadAll(io.LimitReader(rc, 256))
+			rc.Close()
+			if err == nil && strings.TrimSpace(string(mimetype)) == odtMimetype {
+				return FormatODT, nil
+			}
+		}
+	}
+
+	return "", ErrUnsupportedFileFormat
+}
+
+// documentBaseName возвращает имя файла без расширения поддерживаемого формата,
+// под этим именем ТЗ показывается пользователю
+func documentBaseName(filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, format := range []DocumentFormat{FormatDOCX, FormatDOC, FormatPDF, FormatODT, FormatRTF} {
+		if ext == format.Extension() {
+			return filename[:len(filename)-len(ext)]
+		}
+	}
+	return filename
+}
+
+// extractPdfHtml извлекает текстовый слой PDF и собирает из него HTML для конвейера
+// проверки. Сканы без текстового слоя и PDF с паролем отклоняются с UploadValidationError
+func (tz *Tz) extractPdfHtml(ctx context.Context, file []byte, filename string) (*htmlCheckpoint, error) {
+	pdfText, err := tz.docToDocXConverterClient.ExtractPdfText(ctx, file, documentBaseName(filename)+FormatPDF.Extension())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка извлечения текста из pdf: %w", err)
+	}
+
+	if err := tz.uploadLimits.validatePdfText(pdfText); err != nil {
+		return nil, err
+	}
+
+	return &htmlCheckpoint{Html: pdfPagesToHtml(pdfText.Pages)}, nil
+}
+
+// pdfPagesToHtml собирает HTML из текста страниц PDF. Абзацы разделяются пустыми строками,
+// строки внутри абзаца склеиваются, перенос слова по дефису убирается. Каждый абзац
+// помечается атрибутом data-pdf-page с номером страницы: markdown-service сохраняет
+// html_content элемента в mappings, поэтому номер страницы доступен для любой строки markdown
+func pdfPagesToHtml(pages []string) string {
+	var b strings.Builder
+	b.WriteString("<html><body>")
+
+	for i, page := range pages {
+		pageNumber := strconv.Itoa(i + 1)
+		for _, paragraph := range pdfPageParagraphs(page) {
+			b.WriteString(`<p data-pdf-page="` + pageNumber + `">`)
+			b.WriteString(html.EscapeString(paragraph))
+			b.WriteString("</p>")
+		}
+	}
+
+	b.WriteString("</body></html>")
+	return b.String()
+}
+
+// pdfPageAttrRe находит номер страницы, проставленный pdfPagesToHtml, в html_content маппинга
+var pdfPageAttrRe = regexp.MustCompile(`data-pdf-page="(\d+)"`)
+
+// assignPdfPages проставляет замечаниям номер страницы PDF по первому HTML-блоку,
+// пересекающемуся со строками markdown замечания. Для документов не из PDF
+// атрибута data-pdf-page в блоках нет, и PageNumber остаётся пустым
+func assignPdfPages(invalidErrors *[]OutInvalidError, htmlBlocks []markdown_service_client.Mapping) {
+	if invalidErrors == nil {
+		return
+	}
+
+	for i := range *invalidErrors {
+		invalidError := &(*invalidErrors)[i]
+		if invalidError.StartLineNumber == nil || invalidError.EndLineNumber == nil {
+			continue
+		}
+
+		startLine, endLine := *invalidError.StartLineNumber, *invalidError.EndLineNumber
+		if endLine < startLine {
+			startLine, endLine = endLine, startLine
+		}
+
+		for _, block := range htmlBlocks {
+			if block.MarkdownEnd < startLine || block.MarkdownStart > endLine {
+				continue
+			}
+			match := pdfPageAttrRe.FindStringSubmatch(block.HtmlContent)
+			if match == nil {
+				continue
+			}
+			if page, err := strconv.Atoi(match[1]); err == nil {
+				invalidError.PageNumber = &page
+				break
+			}
+		}
+	}
+}
+
+func pdfPageParagraphs(page string) []string {
+	paragraphs := make([]string, 0)
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			paragraphs = append(paragraphs, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, line := range strings.Split(page, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			flush()
+			continue
+		}
+
+		if current.Len() == 0 {
+			current.WriteString(line)
+			continue
+		}
+
+		// "техни-\nческого" -> "технического", но "Москва -\nфилиал" остаётся с пробелами
+		prev := current.String()
+		if strings.HasSuffix(prev, "-") && !strings.HasSuffix(prev, " -") && startsWithLower(line) {
+			current.Reset()
+			current.WriteString(strings.TrimSuffix(prev, "-"))
+		} else {
+			current.WriteString(" ")
+		}
+		current.WriteString(line)
+	}
+	flush()
+
+	return paragraphs
+}
+
+func startsWithLower(s string) bool {
+	for _, r := range s {
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// uploadedDocument - загруженный пользователем файл, прошедший проверку формата
+type uploadedDocument struct {
+	Format DocumentFormat
+	Stats  documentStats
+	// Name - имя ТЗ: имя файла без расширения
+	Name string
+	// Html - текст PDF, извлечённый при загрузке. Сохраняется контрольной точкой стадии html,
+	// чтобы не извлекать его повторно при обработке
+	Html *htmlCheckpoint
+}
+
+// prepareUploadedDocument определяет формат загруженного файла и проверяет его до списания
+// проверки и сохранения файла в S3. Отклонённые файлы возвращают UploadValidationError
+func (tz *Tz) prepareUploadedDocument(ctx context.Context, file []byte, filename string) (*uploadedDocument, error) {
+	format, stats, err := tz.uploadLimits.validateUpload(file)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &uploadedDocument{
+		Format: format,
+		Stats:  stats,
+		Name:   documentBaseName(filename),
+	}
+
+	if format == FormatPDF {
+		doc.Html, err = tz.extractPdfHtml(ctx, file, filename)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+// saveUploadedDocumentCheckpoint сохраняет извлечённый при загрузке HTML как результат стадии html.
+// Ошибка не критична: при обработке текст будет извлечён заново
+func (tz *Tz) saveUploadedDocumentCheckpoint(ctx context.Context, versionID uuid.UUID, doc *uploadedDocument, log *slog.Logger) {
+	if doc.Html == nil {
+		return
+	}
+
+	data, err := json.Marshal(doc.Html)
+	if err != nil {
+		log.Error("ошибка сериализации html документа: ", sl.Err(err))
+		return
+	}
+
+	if err := tz.repo.SaveVersionCheckpoint(ctx, versionID, StageHTML, data); err != nil {
+		log.Error("ошибка сохранения контрольной точки html: ", sl.Err(err))
+	}
+}
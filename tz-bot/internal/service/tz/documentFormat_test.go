@@ -0,0 +1,133 @@
+package tzservice
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	markdown_service_client "repairCopilotBot/tz-bot/internal/pkg/markdown-service"
+	"testing"
+)
+
+func zipWithFiles(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestDetectDocumentFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    []byte
+		want    DocumentFormat
+		wantErr error
+	}{
+		{name: "docx", file: zipWithFiles(t, map[string]string{"[Content_Types].xml": "", "word/document.xml": "<w:document/>"}), want: FormatDOCX},
+		{name: "odt", file: zipWithFiles(t, map[string]string{"mimetype": odtMimetype, "content.xml": ""}), want: FormatODT},
+		{name: "doc", file: append([]byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}, make([]byte, 64)...), want: FormatDOC},
+		{name: "rtf", file: []byte(`{\rtf1\ansi Техническое задание}`), want: FormatRTF},
+		{name: "pdf", file: []byte("%PDF-1.7\n%âãÏÓ\n1 0 obj"), want: FormatPDF},
+		{name: "pdf with leading garbage", file: append(bytes.Repeat([]byte{' '}, 100), []byte("%PDF-1.4")...), want: FormatPDF},
+		{name: "xlsx", file: zipWithFiles(t, map[string]string{"xl/workbook.xml": ""}), wantErr: ErrUnsupportedFileFormat},
+		{name: "ods", file: zipWithFiles(t, map[string]string{"mimetype": "application/vnd.oasis.opendocument.spreadsheet"}), wantErr: ErrUnsupportedFileFormat},
+		{name: "broken zip", file: []byte("PK\x03\x04 not a zip"), wantErr: ErrUnsupportedFileFormat},
+		{name: "png", file: []byte("\x89PNG\r\n\x1a\n"), wantErr: ErrUnsupportedFileFormat},
+		{name: "plain text", file: []byte("Техническое задание"), wantErr: ErrUnsupportedFileFormat},
+		{name: "empty", file: nil, wantErr: ErrUnsupportedFileFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectDocumentFormat(tt.file)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got format %q, err %v", tt.wantErr, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDocumentBaseName(t *testing.T) {
+	tests := map[string]string{
+		"ТЗ на поставку.docx": "ТЗ на поставку",
+		"ТЗ на поставку.DOC":  "ТЗ на поставку",
+		"spec.v2.pdf":         "spec.v2",
+		"spec.odt":            "spec",
+		"spec.rtf":            "spec",
+		"spec.txt":            "spec.txt",
+		"spec":                "spec",
+	}
+
+	for filename, want := range tests {
+		if got := documentBaseName(filename); got != want {
+			t.Errorf("documentBaseName(%q) = %q, want %q", filename, got, want)
+		}
+	}
+}
+
+func TestPdfPagesToHtml(t *testing.T) {
+	pages := []string{
+		"1. Общие положения\n\nПоставщик обязан обеспечить постав-\nку оборудования в срок.\n",
+		"   2. Требования   к  качеству\nСтандарт ГОСТ 123 -\nобязателен & <важен>\n",
+		"",
+	}
+
+	got := pdfPagesToHtml(pages)
+	want := `<html><body>` +
+		`<p data-pdf-page="1">1. Общие положения</p>` +
+		`<p data-pdf-page="1">Поставщик обязан обеспечить поставку оборудования в срок.</p>` +
+		`<p data-pdf-page="2">2. Требования к качеству Стандарт ГОСТ 123 - обязателен &amp; &lt;важен&gt;</p>` +
+		`</body></html>`
+
+	if got != want {
+		t.Errorf("unexpected html:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestAssignPdfPages(t *testing.T) {
+	blocks := []markdown_service_client.Mapping{
+		{HtmlContent: `<p data-pdf-page="1">1. Общие положения</p>`, MarkdownStart: 1, MarkdownEnd: 1},
+		{HtmlContent: `<p data-pdf-page="2">Поставщик обязан</p>`, MarkdownStart: 3, MarkdownEnd: 4},
+		{HtmlContent: `<p>Без страницы</p>`, MarkdownStart: 6, MarkdownEnd: 6},
+	}
+	line := func(n int) *int { return &n }
+
+	invalidErrors := []OutInvalidError{
+		{StartLineNumber: line(4), EndLineNumber: line(3)},
+		{StartLineNumber: line(6), EndLineNumber: line(6)},
+		{},
+	}
+	assignPdfPages(&invalidErrors, blocks)
+
+	if invalidErrors[0].PageNumber == nil || *invalidErrors[0].PageNumber != 2 {
+		t.Errorf("expected page 2, got %v", invalidErrors[0].PageNumber)
+	}
+	if invalidErrors[1].PageNumber != nil {
+		t.Errorf("expected no page for block without data-pdf-page, got %d", *invalidErrors[1].PageNumber)
+	}
+	if invalidErrors[2].PageNumber != nil {
+		t.Errorf("expected no page without line numbers, got %d", *invalidErrors[2].PageNumber)
+	}
+}
@@ -0,0 +1,93 @@
+package tzservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"regexp"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// markdownLineNumber - номер строки "[12] ", который markdown-service добавляет в начало строк
+var markdownLineNumber = regexp.MustCompile(`^\[\d+\]\s*`)
+
+// fileContentHash возвращает sha256 содержимого загруженного файла
+func fileContentHash(file []byte) string {
+	sum := sha256.Sum256(file)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeMarkdown приводит markdown к виду, не зависящему от оформления: убирает номера строк,
+// пустые строки и повторяющиеся пробелы, переводит текст в нижний регистр и заменяет "ё" на "е".
+// Документ, пересохранённый в другом редакторе или формате, даёт тот же результат
+func normalizeMarkdown(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	normalized := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		line = markdownLineNumber.ReplaceAllString(strings.TrimSpace(line), "")
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			continue
+		}
+		line = strings.ReplaceAll(strings.ToLower(line), "ё", "е")
+		normalized = append(normalized, line)
+	}
+
+	return strings.Join(normalized, "\n")
+}
+
+// markdownContentHash возвращает sha256 нормализованного markdown документа
+func markdownContentHash(markdown string) string {
+	sum := sha256.Sum256([]byte(normalizeMarkdown(markdown)))
+	return hex.EncodeToString(sum[:])
+}
+
+// findDuplicateVersion ищет завершённую версию пользователя или его организации, загруженную из того же
+// файла и проверенную с той же группой ошибок. Ошибка поиска не мешает загрузке: в худшем случае
+// документ будет проверен повторно
+func (tz *Tz) findDuplicateVersion(ctx context.Context, userID uuid.UUID, fileHash string, ggID int, log *slog.Logger) *modelrepo.DuplicateVersion {
+	organizationID, err := tz.userOrganizationID(ctx, userID)
+	if err != nil {
+		log.Error("ошибка получения организации пользователя: ", sl.Err(err))
+	}
+
+	duplicate, err := tz.repo.FindCompletedVersionByFileHash(ctx, userID, organizationID, fileHash, ggID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrVersionNotFound) {
+			log.Error("ошибка поиска ранее проверенной версии: ", sl.Err(err))
+		}
+		return nil
+	}
+
+	return duplicate
+}
+
+// saveMarkdownHash сохраняет хэш нормализованного markdown версии. Если документ с тем же
+// текстом уже проверялся (например, тот же docx, пересохранённый в pdf), версия связывается
+// с ним как повторная проверка. Ошибки не критичны для проверки и только логируются
+func (tz *Tz) saveMarkdownHash(ctx context.Context, versionID, userID uuid.UUID, markdown string, log *slog.Logger) {
+	markdownHash := markdownContentHash(markdown)
+
+	var recheckOf *uuid.UUID
+	duplicate, err := tz.repo.FindCompletedVersionByMarkdownHash(ctx, userID, markdownHash, versionID)
+	switch {
+	case err == nil:
+		recheckOf = &duplicate.ID
+		log.Info("текст документа совпадает с ранее проверенной версией",
+			slog.String("duplicate_version_id", duplicate.ID.String()))
+	case !errors.Is(err, repository.ErrVersionNotFound):
+		log.Error("ошибка поиска версии с тем же текстом: ", sl.Err(err))
+	}
+
+	if err := tz.repo.SetVersionMarkdownHash(ctx, versionID, markdownHash, recheckOf); err != nil {
+		log.Error("ошибка сохранения хэша markdown: ", sl.Err(err))
+	}
+}
@@ -0,0 +1,37 @@
+package tzservice
+
+import "testing"
+
+func TestNormalizeMarkdown(t *testing.T) {
+	markdown := "[1] # Техническое   задание\r\n\r\n[2]   Поставщик обязан   ВЫПОЛНИТЬ работы в срок.  \n\n\n[3] Ещё один пункт\n"
+
+	want := "# техническое задание\nпоставщик обязан выполнить работы в срок.\nеще один пункт"
+	if got := normalizeMarkdown(markdown); got != want {
+		t.Errorf("unexpected normalized markdown:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestMarkdownContentHash(t *testing.T) {
+	original := "[1] # Техническое задание\n[2] Поставщик обязан выполнить работы."
+	resaved := "# Техническое  задание\n\n\nПоставщик обязан выполнить работы.\n"
+	changed := "[1] # Техническое задание\n[2] Поставщик обязан выполнить работы в срок."
+
+	if markdownContentHash(original) != markdownContentHash(resaved) {
+		t.Errorf("formatting differences must not change the hash")
+	}
+	if markdownContentHash(original) == markdownContentHash(changed) {
+		t.Errorf("text changes must change the hash")
+	}
+	if len(markdownContentHash(original)) != 64 {
+		t.Errorf("hash must be a hex encoded sha256")
+	}
+}
+
+func TestFileContentHash(t *testing.T) {
+	if fileContentHash([]byte("a")) == fileContentHash([]byte("b")) {
+		t.Errorf("different files must have different hashes")
+	}
+	if got := fileContentHash(nil); got != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("unexpected sha256 of empty file: %s", got)
+	}
+}
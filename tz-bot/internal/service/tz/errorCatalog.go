@@ -0,0 +1,134 @@
+package tzservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	promt_builder "repairCopilotBot/tz-bot/internal/pkg/promt-builder"
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// ErrorCatalogQuery - поиск записи каталога: по id или по коду ошибки. Без GgID используется
+// группа профиля по умолчанию, без Version - актуальная версия
+type ErrorCatalogQuery struct {
+	ID      *uuid.UUID
+	GgID    *int
+	Code    string
+	Version *int
+}
+
+// errorCatalogEntryHash возвращает sha256 названия, описания и детектора. По нему определяется,
+// изменилось ли описание ошибки в промт-билдере
+func errorCatalogEntryHash(name, description, detector string) string {
+	sum := sha256.Sum256([]byte(name + "\x00" + description + "\x00" + detector))
+	return hex.EncodeToString(sum[:])
+}
+
+// errorCatalogEntries преобразует описания ошибок из ответа промт-билдера в записи каталога,
+// упорядоченные по коду
+func errorCatalogEntries(descriptions map[string]promt_builder.ErrorDescription) []modelrepo.ErrorCatalogEntryData {
+	entries := make([]modelrepo.ErrorCatalogEntryData, 0, len(descriptions))
+	for code, description := range descriptions {
+		code = sanitizeString(code)
+		if code == "" {
+			continue
+		}
+
+		name := sanitizeString(description.Name)
+		desc := sanitizeString(description.Desc)
+		detector := sanitizeString(description.Detector)
+		entries = append(entries, modelrepo.ErrorCatalogEntryData{
+			Code:        code,
+			Name:        name,
+			Description: desc,
+			Detector:    detector,
+			ContentHash: errorCatalogEntryHash(name, desc, detector),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Code < entries[j].Code
+	})
+
+	return entries
+}
+
+// syncErrorCatalog сохраняет описания ошибок, полученные от промт-билдера, в каталог и возвращает id
+// записей по кодам. Ошибка синхронизации не прерывает проверку: ошибки сохранятся без ссылки на каталог
+func (tz *Tz) syncErrorCatalog(ctx context.Context, ggID int, descriptions map[string]promt_builder.ErrorDescription, log *slog.Logger) map[string]uuid.UUID {
+	entryIDs, err := tz.repo.SyncErrorCatalog(ctx, ggID, errorCatalogEntries(descriptions))
+	if err != nil {
+		log.Error("ошибка синхронизации каталога ошибок: ", sl.Err(err))
+		return nil
+	}
+
+	return entryIDs
+}
+
+// catalogEntryID возвращает id записи каталога для кода ошибки или nil, если кода нет в каталоге
+func catalogEntryID(entryIDs map[string]uuid.UUID, code string) *uuid.UUID {
+	id, ok := entryIDs[code]
+	if !ok {
+		return nil
+	}
+	return &id
+}
+
+// ListErrorCatalog возвращает записи каталога ошибок
+func (tz *Tz) ListErrorCatalog(ctx context.Context, filter modelrepo.ErrorCatalogFilter) ([]modelrepo.ErrorCatalogEntry, error) {
+	const op = "Tz.ListErrorCatalog"
+
+	entries, err := tz.repo.ListErrorCatalog(ctx, filter)
+	if err != nil {
+		tz.log.With(slog.String("op", op)).Error("failed to list error catalog: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to list error catalog: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetErrorCatalogEntry возвращает запись каталога ошибок по id или коду
+func (tz *Tz) GetErrorCatalogEntry(ctx context.Context, query ErrorCatalogQuery) (*modelrepo.ErrorCatalogEntry, error) {
+	const op = "Tz.GetErrorCatalogEntry"
+
+	log := tz.log.With(slog.String("op", op))
+
+	var entry *modelrepo.ErrorCatalogEntry
+	var err error
+	switch {
+	case query.ID != nil:
+		entry, err = tz.repo.GetErrorCatalogEntry(ctx, *query.ID)
+	case query.Code != "":
+		ggID := 0
+		if query.GgID != nil {
+			ggID = *query.GgID
+		} else {
+			settings, settingsErr := tz.resolveCheckSettings(ctx, CheckOptions{})
+			if settingsErr != nil {
+				return nil, settingsErr
+			}
+			ggID = settings.GgID
+		}
+		entry, err = tz.repo.GetErrorCatalogEntryByCode(ctx, ggID, query.Code, query.Version)
+	default:
+		return nil, ErrInvalidErrorCatalogQuery
+	}
+
+	if err != nil {
+		if errors.Is(err, repository.ErrErrorCatalogEntryNotFound) {
+			return nil, ErrErrorCatalogEntryNotFound
+		}
+		log.Error("failed to get error catalog entry: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get error catalog entry: %w", err)
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,60 @@
+package tzservice
+
+import (
+	"testing"
+
+	promt_builder "repairCopilotBot/tz-bot/internal/pkg/promt-builder"
+
+	"github.com/google/uuid"
+)
+
+func TestErrorCatalogEntries(t *testing.T) {
+	descriptions := map[string]promt_builder.ErrorDescription{
+		"E02": {Name: "Срок", Desc: "Не указан срок поставки", Detector: "deadline"},
+		"E01": {Name: "Цена", Desc: "Не указана цена\x00", Detector: "price"},
+		"":    {Name: "Без кода"},
+	}
+
+	entries := errorCatalogEntries(descriptions)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Code != "E01" || entries[1].Code != "E02" {
+		t.Errorf("entries must be ordered by code: %s, %s", entries[0].Code, entries[1].Code)
+	}
+	if entries[0].Description != "Не указана цена" {
+		t.Errorf("description must be sanitized: %q", entries[0].Description)
+	}
+	if entries[0].ContentHash != errorCatalogEntryHash("Цена", "Не указана цена", "price") {
+		t.Errorf("content hash must be computed from sanitized fields")
+	}
+}
+
+func TestErrorCatalogEntryHash(t *testing.T) {
+	base := errorCatalogEntryHash("Цена", "Не указана цена", "price")
+
+	if base != errorCatalogEntryHash("Цена", "Не указана цена", "price") {
+		t.Errorf("hash must be stable")
+	}
+	if base == errorCatalogEntryHash("Цена", "Не указана цена товара", "price") {
+		t.Errorf("description change must change the hash")
+	}
+	if errorCatalogEntryHash("ab", "c", "") == errorCatalogEntryHash("a", "bc", "") {
+		t.Errorf("field boundaries must affect the hash")
+	}
+}
+
+func TestCatalogEntryID(t *testing.T) {
+	id := uuid.New()
+	entryIDs := map[string]uuid.UUID{"E01": id}
+
+	if got := catalogEntryID(entryIDs, "E01"); got == nil || *got != id {
+		t.Errorf("expected %s, got %v", id, got)
+	}
+	if got := catalogEntryID(entryIDs, "E02"); got != nil {
+		t.Errorf("unknown code must have no catalog entry, got %s", *got)
+	}
+	if got := catalogEntryID(nil, "E01"); got != nil {
+		t.Errorf("failed sync must leave errors without catalog entry")
+	}
+}
@@ -24,7 +24,14 @@ type OutInvalidError struct {
 	UntilTheEndOfSentence       bool
 	StartLineNumber             *int
 	EndLineNumber               *int
+	PageNumber                  *int // страница исходного PDF, см. assignPdfPages
 	SystemComment               string
+	Located                     bool     // цитата найдена в HTML и обёрнута в span с error-id = HtmlIDStr
+	GroundingScore              *float64 // похожесть цитаты на текст markdown документа, см. groundInvalidInstances
+	Priority                    *string  // low | medium | high
+	Risks                       *string
+	Sections                    *[]string
+	WhatIsIncorrect             *string
 	OrderNumber                 int
 	ParentError                 Error
 	FeedbackExists              bool
@@ -35,7 +42,6 @@ type OutInvalidError struct {
 	FeedbackVerificationMark    *bool
 	FeedbackVerificationComment *string
 	FeedbackVerificationUser    *uuid.UUID
-	sections                    *[]string
 }
 
 type OutMissingError struct {
@@ -44,7 +50,11 @@ type OutMissingError struct {
 	HtmlIDStr                   string
 	ErrorID                     uuid.UUID
 	Rationale                   string
-	SuggestedFix                string `json:"suggested_fix"`
+	SuggestedFix                string  `json:"suggested_fix"`
+	Priority                    *string // low | medium | high
+	Risks                       *string
+	Sections                    *[]string
+	WhatIsIncorrect             *string
 	FeedbackExists              bool
 	FeedbackMark                *bool
 	FeedbackComment             *string
@@ -55,33 +65,24 @@ type OutMissingError struct {
 	FeedbackVerificationUser    *uuid.UUID
 }
 
-func HandleErrors(report *[]tz_llm_client.GroupReport, htmlBlocks *[]markdown_service_client.Mapping) (*[]OutInvalidError, *[]OutMissingError, string) {
+// HandleErrors формирует замечания из отчётов LLM, сверяет их цитаты с markdown документа
+// и оборачивает цитаты в HTML-блоках. Возвращает замечания в порядке появления в документе,
+// HTML с обёрнутыми цитатами, итоги сверки и ошибки поиска цитат
+func HandleErrors(report *[]tz_llm_client.GroupReport, markdown string, htmlBlocks *[]markdown_service_client.Mapping) (*[]OutInvalidError, *[]OutMissingError, string, QuoteGroundingStats, []error) {
 	startId := uint32(1)
-	//fmt.Println("отладка 21")
 	outInvalidErrors, lastId := NewInvalidErrorsSet(startId, report)
-	//fmt.Println("отладка 22")
 	missingErrors, lastId := NewIMissingErrorsSet(lastId, report)
-	//fmt.Println("отладка 23")
-	errors := InjectInvalidErrorsToHtmlBlocks(outInvalidErrors, htmlBlocks)
-	if len(errors) > 0 {
-		//fmt.Println("отладка 24")
-		for _, err := range errors {
-			fmt.Println(err.Error())
-		}
-	}
-	//fmt.Println("отладка 25")
-	html := ""
-	fmt.Println("отладка 26")
+	outInvalidErrors, groundingStats := groundInvalidInstances(outInvalidErrors, markdown)
+	injectErrors := InjectInvalidErrorsToHtmlBlocks(outInvalidErrors, htmlBlocks)
 
+	var html strings.Builder
 	for i := range *htmlBlocks {
-		html = html + (*htmlBlocks)[i].HtmlContent
+		html.WriteString((*htmlBlocks)[i].HtmlContent)
 	}
-	//fmt.Println("отладка 27")
 
 	// Сортируем ошибки по порядку их появления в HTML тексте
-	sortedInvalidErrors := sortInvalidErrorsByHtmlOrder(outInvalidErrors, html)
-	//fmt.Println("отладка 28")
-	return sortedInvalidErrors, missingErrors, html
+	sortedInvalidErrors := sortInvalidErrorsByHtmlOrder(outInvalidErrors, html.String())
+	return sortedInvalidErrors, missingErrors, html.String(), groundingStats, injectErrors
 }
 
 // extractErrorIdsFromHtml извлекает error-id из span тегов в HTML в порядке их появления
@@ -0,0 +1,133 @@
+package tzservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// annotatedDocxExtension - расширение аннотированного документа в бакете reports
+const annotatedDocxExtension = ".docx"
+
+// AnnotatedDocx - исходный документ версии, в котором каждое найденное замечание
+// оформлено комментарием Word, привязанным к цитате
+type AnnotatedDocx struct {
+	FileID   string
+	FileName string
+	Content  []byte
+}
+
+// ExportAnnotatedDocx возвращает docx версии с замечаниями в виде комментариев Word.
+// Документ строится один раз из out_html версии и сохраняется в бакет reports рядом с отчётом,
+// повторные запросы отдают сохранённый файл
+func (tz *Tz) ExportAnnotatedDocx(ctx context.Context, versionID uuid.UUID) (*AnnotatedDocx, error) {
+	const op = "Tz.ExportAnnotatedDocx"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("versionID", versionID.String()),
+	)
+
+	version, err := tz.getCompletedVersion(ctx, versionID)
+	if err != nil {
+		log.Error("failed to get version: ", sl.Err(err))
+		return nil, err
+	}
+
+	if version.AnnotatedFileID != nil && *version.AnnotatedFileID != "" {
+		content, err := tz.s3.GetDocument(ctx, "reports", *version.AnnotatedFileID+annotatedDocxExtension)
+		if err == nil {
+			return &AnnotatedDocx{
+				FileID:   *version.AnnotatedFileID,
+				FileName: *version.AnnotatedFileID + annotatedDocxExtension,
+				Content:  content,
+			}, nil
+		}
+		// Файл могли удалить из бакета - строим документ заново
+		log.Warn("failed to get annotated docx from s3, rebuilding", sl.Err(err))
+	}
+
+	if strings.Trim(version.OutHTML, ". \n") == "" {
+		return nil, ErrAnnotatedHtmlUnavailable
+	}
+
+	invalidInstances, err := tz.getVersionInvalidInstances(ctx, versionID)
+	if err != nil {
+		log.Error("failed to get invalid instances: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get invalid instances: %w", err)
+	}
+
+	comments := buildAnnotatedDocxComments(invalidInstances)
+
+	content, err := tz.wordConverterClient.CreateDocumentFromHTML(version.OutHTML, comments)
+	if err != nil {
+		log.Error("ошибка генерации аннотированного docx: ", sl.Err(err))
+		return nil, fmt.Errorf("%w: %w", ErrGenerateDocxFile, err)
+	}
+
+	specName := "тз"
+	ts, err := tz.repo.GetTechnicalSpecification(ctx, version.TechnicalSpecificationID)
+	if err != nil {
+		log.Warn("failed to get technical specification name", sl.Err(err))
+	} else {
+		specName = ts.Name
+	}
+
+	fileID := "аннотированный_" + specName + "_v" + strconv.Itoa(version.VersionNumber) + "_" + GetCurrentDateTimeString()
+	if err := tz.s3.SaveDocument(ctx, fileID, content, "reports", annotatedDocxExtension); err != nil {
+		log.Error("ошибка сохранения аннотированного docx в s3: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to save annotated docx: %w", err)
+	}
+
+	if err := tz.repo.SetVersionAnnotatedFile(ctx, versionID, fileID); err != nil {
+		// Документ уже построен - отдаём его, при следующем запросе он будет построен заново
+		log.Error("failed to save annotated file id: ", sl.Err(err))
+	}
+
+	log.Info("annotated docx created",
+		slog.String("fileID", fileID),
+		slog.Int("comments", len(comments)),
+		slog.Int("instances", len(invalidInstances)))
+
+	return &AnnotatedDocx{
+		FileID:   fileID,
+		FileName: fileID + annotatedDocxExtension,
+		Content:  content,
+	}, nil
+}
+
+// buildAnnotatedDocxComments формирует тексты комментариев Word по error-id span'ов в out_html.
+// Замечания, цитаты которых не были найдены в HTML, пропускаются - им не к чему привязаться
+func buildAnnotatedDocxComments(invalidInstances []OutInvalidError) map[string]string {
+	comments := make(map[string]string, len(invalidInstances))
+	for i := range invalidInstances {
+		instance := &invalidInstances[i]
+		if !instance.Located {
+			continue
+		}
+
+		var b strings.Builder
+		b.WriteString(instance.ParentError.ErrorCode)
+		if instance.ParentError.Name != "" {
+			b.WriteString(" — ")
+			b.WriteString(instance.ParentError.Name)
+		}
+		if instance.Rationale != "" {
+			b.WriteString("\n")
+			b.WriteString(instance.Rationale)
+		}
+		if instance.SuggestedFix != "" {
+			b.WriteString("\nКак исправить: ")
+			b.WriteString(instance.SuggestedFix)
+		}
+
+		comments[strconv.Itoa(int(instance.HtmlID))] = b.String()
+	}
+
+	return comments
+}
@@ -0,0 +1,47 @@
+package tzservice
+
+import (
+	"testing"
+)
+
+func TestBuildAnnotatedDocxComments(t *testing.T) {
+	instances := []OutInvalidError{
+		{
+			HtmlID:       7,
+			Rationale:    "Срок поставки не указан",
+			SuggestedFix: "Указать срок поставки в календарных днях",
+			Located:      true,
+			ParentError:  Error{ErrorCode: "E02", Name: "Неопределённые сроки"},
+		},
+		{
+			// Цитата не найдена в HTML - комментарий не к чему привязать
+			HtmlID:      8,
+			Rationale:   "Не указаны требования к надёжности",
+			ParentError: Error{ErrorCode: "E03"},
+		},
+		{
+			HtmlID:      9,
+			Located:     true,
+			ParentError: Error{ErrorCode: "E04"},
+		},
+	}
+
+	comments := buildAnnotatedDocxComments(instances)
+
+	if len(comments) != 2 {
+		t.Fatalf("ожидалось 2 комментария, получено %d: %v", len(comments), comments)
+	}
+
+	expected := "E02 — Неопределённые сроки\nСрок поставки не указан\nКак исправить: Указать срок поставки в календарных днях"
+	if comments["7"] != expected {
+		t.Errorf("неверный текст комментария:\n%q\nожидалось:\n%q", comments["7"], expected)
+	}
+
+	if _, ok := comments["8"]; ok {
+		t.Errorf("комментарий для ненайденной цитаты не должен создаваться")
+	}
+
+	if comments["9"] != "E04" {
+		t.Errorf("ожидался комментарий из одного кода ошибки, получено %q", comments["9"])
+	}
+}
@@ -0,0 +1,397 @@
+package tzservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/pkg/xlsx"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ExportSchemaVersion - версия схемы выгрузки замечаний. Меняется при любом несовместимом
+// изменении состава или смысла полей, новые поля добавляются в конец без смены версии
+const ExportSchemaVersion = 1
+
+// Форматы выгрузки ExportVersion
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// VersionExport - файл выгрузки замечаний версии
+type VersionExport struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// ExportDocument - выгрузка замечаний версии в формате json. Порядок ошибок и замечаний
+// детерминирован, времени выгрузки в документе нет - две выгрузки одной версии совпадают побайтно
+type ExportDocument struct {
+	SchemaVersion            int           `json:"schema_version"`
+	VersionID                string        `json:"version_id"`
+	TechnicalSpecificationID string        `json:"technical_specification_id"`
+	VersionNumber            int           `json:"version_number"`
+	Errors                   []ExportError `json:"errors"`
+}
+
+type ExportError struct {
+	ID          string           `json:"id"`
+	Code        string           `json:"code"`
+	GroupID     string           `json:"group_id"`
+	Name        string           `json:"name"`
+	Verdict     string           `json:"verdict"`
+	OrderNumber int              `json:"order_number"`
+	Instances   []ExportInstance `json:"instances"`
+}
+
+type ExportInstance struct {
+	ID              string         `json:"id"`
+	HtmlID          uint32         `json:"html_id"`
+	Kind            string         `json:"kind"` // invalid | missing
+	Quote           string         `json:"quote"`
+	StartLine       *int           `json:"start_line"`
+	EndLine         *int           `json:"end_line"`
+	SuggestedFix    string         `json:"suggested_fix"`
+	Priority        *string        `json:"priority"`
+	Risks           *string        `json:"risks"`
+	Sections        []string       `json:"sections"`
+	WhatIsIncorrect *string        `json:"what_is_incorrect"`
+	Located         bool           `json:"located"`
+	GroundingScore  *float64       `json:"grounding_score"`
+	Feedback        ExportFeedback `json:"feedback"`
+	Verification    ExportFeedback `json:"feedback_verification"`
+}
+
+type ExportFeedback struct {
+	Exists  bool    `json:"exists"`
+	Mark    *bool   `json:"mark"`
+	Comment *string `json:"comment"`
+	UserID  *string `json:"user_id"`
+}
+
+// exportColumns - колонки csv и xlsx. Порядок колонок - часть схемы выгрузки
+var exportColumns = []string{
+	"schema_version",
+	"version_id",
+	"error_id",
+	"error_code",
+	"group_id",
+	"error_name",
+	"verdict",
+	"instance_id",
+	"html_id",
+	"kind",
+	"quote",
+	"start_line",
+	"end_line",
+	"suggested_fix",
+	"priority",
+	"risks",
+	"sections",
+	"what_is_incorrect",
+	"located",
+	"grounding_score",
+	"feedback_exists",
+	"feedback_mark",
+	"feedback_comment",
+	"feedback_user_id",
+	"feedback_verification_exists",
+	"feedback_verification_mark",
+	"feedback_verification_comment",
+	"feedback_verification_user_id",
+}
+
+// ExportVersion выгружает все ошибки и замечания завершённой версии в json, csv или xlsx
+func (tz *Tz) ExportVersion(ctx context.Context, versionID uuid.UUID, format string) (*VersionExport, error) {
+	const op = "Tz.ExportVersion"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("versionID", versionID.String()),
+		slog.String("format", format),
+	)
+
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != ExportFormatJSON && format != ExportFormatCSV && format != ExportFormatXLSX {
+		return nil, ErrUnsupportedExportFormat
+	}
+
+	version, err := tz.getCompletedVersion(ctx, versionID)
+	if err != nil {
+		log.Error("failed to get version: ", sl.Err(err))
+		return nil, err
+	}
+
+	errorsInTz, err := tz.repo.GetErrorsByVersionID(ctx, versionID)
+	if err != nil {
+		log.Error("failed to get version errors: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get version errors: %w", err)
+	}
+
+	for i := range *errorsInTz {
+		invalidInstances, err := tz.repo.GetInvalidInstancesByErrorID(ctx, (*errorsInTz)[i].ID)
+		if err != nil {
+			log.Error("failed to get invalid instances: ", sl.Err(err))
+			return nil, fmt.Errorf("failed to get invalid instances: %w", err)
+		}
+		(*errorsInTz)[i].InvalidInstances = invalidInstances
+
+		missingInstances, err := tz.repo.GetMissingInstancesByErrorID(ctx, (*errorsInTz)[i].ID)
+		if err != nil {
+			log.Error("failed to get missing instances: ", sl.Err(err))
+			return nil, fmt.Errorf("failed to get missing instances: %w", err)
+		}
+		(*errorsInTz)[i].MissingInstances = missingInstances
+	}
+
+	doc := buildExportDocument(version.ID, version.TechnicalSpecificationID, version.VersionNumber, *errorsInTz)
+
+	fileName := fmt.Sprintf("замечания_%s_v%d.%s", versionID.String(), version.VersionNumber, format)
+
+	var export *VersionExport
+	switch format {
+	case ExportFormatJSON:
+		content, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal export: %w", err)
+		}
+		export = &VersionExport{FileName: fileName, ContentType: "application/json", Content: content}
+	case ExportFormatCSV:
+		content, err := exportToCSV(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write csv export: %w", err)
+		}
+		export = &VersionExport{FileName: fileName, ContentType: "text/csv; charset=utf-8", Content: content}
+	case ExportFormatXLSX:
+		content, err := xlsx.Write("Замечания", exportRows(doc))
+		if err != nil {
+			return nil, fmt.Errorf("failed to write xlsx export: %w", err)
+		}
+		export = &VersionExport{FileName: fileName, ContentType: xlsx.ContentType, Content: content}
+	}
+
+	log.Info("version exported",
+		slog.Int("errors", len(doc.Errors)),
+		slog.Int("size", len(export.Content)))
+
+	return export, nil
+}
+
+// buildExportDocument собирает выгрузку: ошибки по порядковому номеру, внутри ошибки -
+// сначала invalid-замечания по порядку в документе, затем missing-замечания по html_id
+func buildExportDocument(versionID, technicalSpecificationID uuid.UUID, versionNumber int, errorsInTz []Error) *ExportDocument {
+	sorted := make([]Error, len(errorsInTz))
+	copy(sorted, errorsInTz)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OrderNumber < sorted[j].OrderNumber
+	})
+
+	doc := &ExportDocument{
+		SchemaVersion:            ExportSchemaVersion,
+		VersionID:                versionID.String(),
+		TechnicalSpecificationID: technicalSpecificationID.String(),
+		VersionNumber:            versionNumber,
+		Errors:                   make([]ExportError, 0, len(sorted)),
+	}
+
+	for _, e := range sorted {
+		exportError := ExportError{
+			ID:          e.ID.String(),
+			Code:        e.ErrorCode,
+			GroupID:     e.GroupID,
+			Name:        e.Name,
+			Verdict:     e.Verdict,
+			OrderNumber: e.OrderNumber,
+			Instances:   make([]ExportInstance, 0),
+		}
+
+		if e.InvalidInstances != nil {
+			invalidInstances := make([]OutInvalidError, len(*e.InvalidInstances))
+			copy(invalidInstances, *e.InvalidInstances)
+			sort.SliceStable(invalidInstances, func(i, j int) bool {
+				if invalidInstances[i].OrderNumber != invalidInstances[j].OrderNumber {
+					return invalidInstances[i].OrderNumber < invalidInstances[j].OrderNumber
+				}
+				return invalidInstances[i].HtmlID < invalidInstances[j].HtmlID
+			})
+
+			for _, instance := range invalidInstances {
+				exportError.Instances = append(exportError.Instances, ExportInstance{
+					ID:              instance.ID.String(),
+					HtmlID:          instance.HtmlID,
+					Kind:            "invalid",
+					Quote:           instance.Quote,
+					StartLine:       instance.StartLineNumber,
+					EndLine:         instance.EndLineNumber,
+					SuggestedFix:    instance.SuggestedFix,
+					Priority:        instance.Priority,
+					Risks:           instance.Risks,
+					Sections:        stringsOrEmpty(instance.Sections),
+					WhatIsIncorrect: instance.WhatIsIncorrect,
+					Located:         instance.Located,
+					GroundingScore:  instance.GroundingScore,
+					Feedback:        newExportFeedback(instance.FeedbackExists, instance.FeedbackMark, instance.FeedbackComment, instance.FeedbackUser),
+					Verification:    newExportFeedback(instance.FeedbackVerificationExists, instance.FeedbackVerificationMark, instance.FeedbackVerificationComment, instance.FeedbackVerificationUser),
+				})
+			}
+		}
+
+		if e.MissingInstances != nil {
+			missingInstances := make([]OutMissingError, len(*e.MissingInstances))
+			copy(missingInstances, *e.MissingInstances)
+			sort.SliceStable(missingInstances, func(i, j int) bool {
+				return missingInstances[i].HtmlID < missingInstances[j].HtmlID
+			})
+
+			for _, instance := range missingInstances {
+				exportError.Instances = append(exportError.Instances, ExportInstance{
+					ID:              instance.ID.String(),
+					HtmlID:          instance.HtmlID,
+					Kind:            "missing",
+					SuggestedFix:    instance.SuggestedFix,
+					Priority:        instance.Priority,
+					Risks:           instance.Risks,
+					Sections:        stringsOrEmpty(instance.Sections),
+					WhatIsIncorrect: instance.WhatIsIncorrect,
+					Feedback:        newExportFeedback(instance.FeedbackExists, instance.FeedbackMark, instance.FeedbackComment, instance.FeedbackUser),
+					Verification:    newExportFeedback(instance.FeedbackVerificationExists, instance.FeedbackVerificationMark, instance.FeedbackVerificationComment, instance.FeedbackVerificationUser),
+				})
+			}
+		}
+
+		doc.Errors = append(doc.Errors, exportError)
+	}
+
+	return doc
+}
+
+func newExportFeedback(exists bool, mark *bool, comment *string, userID *uuid.UUID) ExportFeedback {
+	feedback := ExportFeedback{Exists: exists, Mark: mark, Comment: comment}
+	if userID != nil {
+		id := userID.String()
+		feedback.UserID = &id
+	}
+	return feedback
+}
+
+func stringsOrEmpty(values *[]string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return *values
+}
+
+// exportRows разворачивает выгрузку в таблицу: строка заголовков и по строке на замечание.
+// Ошибки без замечаний выводятся одной строкой с пустыми полями замечания. Значения
+// экранируются от подстановки формул, потому что приходят из текста документа
+func exportRows(doc *ExportDocument) [][]string {
+	rows := [][]string{exportColumns}
+	schemaVersion := strconv.Itoa(doc.SchemaVersion)
+
+	for _, e := range doc.Errors {
+		errorCells := []string{schemaVersion, doc.VersionID, e.ID, e.Code, e.GroupID, e.Name, e.Verdict}
+
+		if len(e.Instances) == 0 {
+			row := append(append([]string{}, errorCells...), make([]string, len(exportColumns)-len(errorCells))...)
+			rows = append(rows, row)
+			continue
+		}
+
+		for _, instance := range e.Instances {
+			row := append([]string{}, errorCells...)
+			row = append(row,
+				instance.ID,
+				strconv.FormatUint(uint64(instance.HtmlID), 10),
+				instance.Kind,
+				instance.Quote,
+				intPtrToString(instance.StartLine),
+				intPtrToString(instance.EndLine),
+				instance.SuggestedFix,
+				stringPtrToString(instance.Priority),
+				stringPtrToString(instance.Risks),
+				strings.Join(instance.Sections, "; "),
+				stringPtrToString(instance.WhatIsIncorrect),
+				strconv.FormatBool(instance.Located),
+				floatPtrToString(instance.GroundingScore),
+			)
+			row = append(row, feedbackCells(instance.Feedback)...)
+			row = append(row, feedbackCells(instance.Verification)...)
+			rows = append(rows, row)
+		}
+	}
+
+	for _, row := range rows[1:] {
+		for i := range row {
+			row[i] = escapeSpreadsheetCell(row[i])
+		}
+	}
+
+	return rows
+}
+
+// escapeSpreadsheetCell защищает от CSV/Excel formula injection: значение, которое табличный
+// редактор принял бы за формулу, начинается с апострофа и показывается как текст
+func escapeSpreadsheetCell(value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+
+	return value
+}
+
+func exportToCSV(doc *ExportDocument) ([]byte, error) {
+	var buf bytes.Buffer
+	// BOM нужен Excel, чтобы открыть csv в UTF-8 без искажения кириллицы
+	buf.WriteString("\uFEFF")
+
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(exportRows(doc)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func feedbackCells(feedback ExportFeedback) []string {
+	mark := ""
+	if feedback.Mark != nil {
+		mark = strconv.FormatBool(*feedback.Mark)
+	}
+	return []string{strconv.FormatBool(feedback.Exists), mark, stringPtrToString(feedback.Comment), stringPtrToString(feedback.UserID)}
+}
+
+func intPtrToString(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func stringPtrToString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func floatPtrToString(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', 4, 64)
+}
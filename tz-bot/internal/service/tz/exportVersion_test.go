@@ -0,0 +1,126 @@
+package tzservice
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"repairCopilotBot/tz-bot/internal/pkg/xlsx"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildExportDocument(t *testing.T) {
+	e01, e02 := uuid.New(), uuid.New()
+	startLine, endLine := 3, 4
+	priority := "high"
+	mark := false
+	comment := "замечание не по делу"
+	user := uuid.New()
+
+	invalidInstances := []OutInvalidError{
+		{ID: uuid.New(), HtmlID: 5, ErrorID: e02, Quote: "вторая цитата", OrderNumber: 2},
+		{
+			ID: uuid.New(), HtmlID: 1, ErrorID: e02, Quote: "первая, с \"кавычками\"", OrderNumber: 0,
+			StartLineNumber: &startLine, EndLineNumber: &endLine, Priority: &priority, Located: true,
+			Sections:       &[]string{"3.1", "3.2"},
+			FeedbackExists: true, FeedbackMark: &mark, FeedbackComment: &comment, FeedbackUser: &user,
+		},
+	}
+	missingInstances := []OutMissingError{{ID: uuid.New(), HtmlID: 7, ErrorID: e02, SuggestedFix: "добавить раздел"}}
+
+	errorsInTz := []Error{
+		{ID: e02, ErrorCode: "E02", OrderNumber: 1, InvalidInstances: &invalidInstances, MissingInstances: &missingInstances},
+		{ID: e01, ErrorCode: "E01", OrderNumber: 0},
+	}
+
+	doc := buildExportDocument(uuid.New(), uuid.New(), 2, errorsInTz)
+
+	if doc.SchemaVersion != ExportSchemaVersion || len(doc.Errors) != 2 {
+		t.Fatalf("неверная выгрузка: %+v", doc)
+	}
+	if doc.Errors[0].Code != "E01" || doc.Errors[1].Code != "E02" {
+		t.Errorf("ошибки должны идти по порядковому номеру: %s, %s", doc.Errors[0].Code, doc.Errors[1].Code)
+	}
+
+	instances := doc.Errors[1].Instances
+	if len(instances) != 3 || instances[0].HtmlID != 1 || instances[1].HtmlID != 5 || instances[2].Kind != "missing" {
+		t.Fatalf("неверный порядок замечаний: %+v", instances)
+	}
+	if instances[0].Feedback.UserID == nil || *instances[0].Feedback.UserID != user.String() {
+		t.Errorf("не выгружен автор обратной связи")
+	}
+
+	rows := exportRows(doc)
+	if len(rows) != 5 {
+		t.Fatalf("ожидалось 5 строк (заголовок, ошибка без замечаний и 3 замечания), получено %d", len(rows))
+	}
+	for i, row := range rows {
+		if len(row) != len(exportColumns) {
+			t.Errorf("строка %d: %d колонок вместо %d", i, len(row), len(exportColumns))
+		}
+	}
+
+	content, err := exportToCSV(doc)
+	if err != nil {
+		t.Fatalf("ошибка выгрузки csv: %v", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\uFEFF")))).ReadAll()
+	if err != nil {
+		t.Fatalf("выгрузка не читается как csv: %v", err)
+	}
+	if strings.Join(records[0], ",") != strings.Join(exportColumns, ",") {
+		t.Errorf("неверный заголовок csv: %v", records[0])
+	}
+	if records[2][10] != "первая, с \"кавычками\"" || records[2][11] != "3" || records[2][16] != "3.1; 3.2" || records[2][21] != "false" {
+		t.Errorf("неверная строка замечания: %v", records[2])
+	}
+
+	workbook, err := xlsx.Write("Замечания", rows)
+	if err != nil {
+		t.Fatalf("ошибка выгрузки xlsx: %v", err)
+	}
+	if _, err := zip.NewReader(bytes.NewReader(workbook), int64(len(workbook))); err != nil {
+		t.Errorf("xlsx не является zip-архивом: %v", err)
+	}
+}
+
+func TestEscapeSpreadsheetCell(t *testing.T) {
+	cases := map[string]string{
+		"":                         "",
+		"обычный текст":            "обычный текст",
+		"=HYPERLINK(\"http://x\")": "'=HYPERLINK(\"http://x\")",
+		"+7 (999) 000-00-00":       "'+7 (999) 000-00-00",
+		"-1+1":                     "'-1+1",
+		"@SUM(A1)":                 "'@SUM(A1)",
+		"\tтаб":                    "'\tтаб",
+		"\rперевод":                "'\rперевод",
+		"a=b":                      "a=b",
+	}
+
+	for value, want := range cases {
+		if got := escapeSpreadsheetCell(value); got != want {
+			t.Errorf("escapeSpreadsheetCell(%q) = %q, ожидалось %q", value, got, want)
+		}
+	}
+
+	doc := &ExportDocument{
+		SchemaVersion: ExportSchemaVersion,
+		Errors: []ExportError{{
+			Code:      "E01",
+			Instances: []ExportInstance{{Kind: "invalid", Quote: "=cmd|' /C calc'!A0"}},
+		}},
+	}
+
+	rows := exportRows(doc)
+	if rows[0][0] != exportColumns[0] {
+		t.Errorf("заголовки не должны экранироваться: %v", rows[0])
+	}
+	for _, cell := range rows[1] {
+		if strings.HasPrefix(cell, "=") {
+			t.Errorf("формула в выгрузке не экранирована: %q", cell)
+		}
+	}
+}
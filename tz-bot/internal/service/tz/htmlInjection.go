@@ -5,68 +5,101 @@ import (
 	markdown_service_client "repairCopilotBot/tz-bot/internal/pkg/markdown-service"
 )
 
+// notLocatedSystemComment - системный комментарий замечания, цитату которого не удалось найти в HTML
+const notLocatedSystemComment = "Не вставлено в тексте"
+
+// InjectInvalidErrorsToHtmlBlocks оборачивает цитаты замечаний в span с error-id внутри HTML-блоков,
+// соответствующих строкам markdown из замечания. Для каждого замечания выставляется Located;
+// возвращаются ошибки поиска по ненайденным цитатам
 func InjectInvalidErrorsToHtmlBlocks(invalidErrors *[]OutInvalidError, htmlBlocks *[]markdown_service_client.Mapping) []error {
 	errors := make([]error, 0)
 	for i := range *invalidErrors {
-		if (*invalidErrors)[i].StartLineNumber != nil && (*invalidErrors)[i].EndLineNumber != nil {
-			if *(*invalidErrors)[i].StartLineNumber == *(*invalidErrors)[i].EndLineNumber {
-				//err := injectIntoHTMLBlockByLineNumber((*invalidErrors)[i].Quote, (*invalidErrors)[i].HtmlIDStr, htmlBlocks, *(*invalidErrors)[i].StartLineNumber)
-				//if err != nil {
-				//	errors = append(errors, err)
-				//}
-			} else {
-				for line := *(*invalidErrors)[i].StartLineNumber; line <= *(*invalidErrors)[i].EndLineNumber; line++ {
-					if (*invalidErrors)[i].QuoteLines != nil {
-						//for _, quoteLine := range *(*invalidErrors)[i].QuoteLines {
-						//err := injectIntoHTMLBlockByLineNumber(quoteLine, (*invalidErrors)[i].HtmlIDStr, htmlBlocks, line)
-						//if err != nil {
-						//	errors = append(errors, err)
-						//}
-						//}
-					} else {
-						//err := injectIntoHTMLBlockByLineNumber((*invalidErrors)[i].Quote, (*invalidErrors)[i].HtmlIDStr, htmlBlocks, line)
-						//if err != nil {
-						//	errors = append(errors, err)
-						//}
-					}
-				}
+		invalidError := &(*invalidErrors)[i]
+		invalidError.Located = false
+
+		if invalidError.StartLineNumber == nil || invalidError.EndLineNumber == nil {
+			setNotLocatedSystemComment(invalidError)
+			continue
+		}
+
+		startLine, endLine := *invalidError.StartLineNumber, *invalidError.EndLineNumber
+		if endLine < startLine {
+			startLine, endLine = endLine, startLine
+		}
+
+		// Многострочная цитата (несколько абзацев или ячеек таблицы) ищется построчно:
+		// замечание считается найденным, если нашлась хотя бы одна строка
+		quotes := []string{invalidError.Quote}
+		if invalidError.QuoteLines != nil && len(*invalidError.QuoteLines) > 0 {
+			quotes = *invalidError.QuoteLines
+		}
+
+		for _, quote := range quotes {
+			err := injectIntoHTMLBlocksByLineRange(quote, invalidError.HtmlIDStr, htmlBlocks, startLine, endLine)
+			if err != nil {
+				errors = append(errors, fmt.Errorf("замечание %s: %w", invalidError.HtmlIDStr, err))
+				continue
 			}
-		} else {
-			(*invalidErrors)[i].SystemComment = "Не вставлено в тексте"
+			invalidError.Located = true
+		}
+
+		if !invalidError.Located {
+			setNotLocatedSystemComment(invalidError)
 		}
 	}
 	return errors
 }
 
-func injectIntoHTMLBlockByLineNumber(quote string, idStr string, htmlBlocks *[]markdown_service_client.Mapping, lineNumber int) error {
+// injectIntoHTMLBlocksByLineRange ищет цитату в HTML-блоках, пересекающихся со строками markdown
+// [startLine, endLine], и оборачивает первое найденное вхождение. Сначала пробуется точное
+// вхождение, затем поиск внутри блочных контейнеров и поиск текста, разбитого на несколько span
+func injectIntoHTMLBlocksByLineRange(quote string, idStr string, htmlBlocks *[]markdown_service_client.Mapping, startLine, endLine int) error {
+	blockFound := false
 	for j := range *htmlBlocks {
-		if (*htmlBlocks)[j].MarkdownStart <= lineNumber && (*htmlBlocks)[j].MarkdownEnd >= lineNumber {
-			//newHtml := (*htmlBlocks)[j].HtmlContent
-			newHtml, found := WrapSubstringSimilar((*htmlBlocks)[j].HtmlContent, quote, idStr)
-			if found {
-				(*htmlBlocks)[j].HtmlContent = newHtml
-				return nil
-			}
+		block := &(*htmlBlocks)[j]
+		if block.MarkdownEnd < startLine || block.MarkdownStart > endLine {
+			continue
+		}
+		blockFound = true
 
-			newHtml, found, err := WrapSubstringSmartHTML(newHtml, quote, idStr)
-			if err != nil {
-				fmt.Println("Error in InjectInvalidErrorsToHtmlBlocks: ", err)
-			}
-			if found {
-				(*htmlBlocks)[j].HtmlContent = newHtml
-				return nil
-			}
+		if newHtml, found := WrapSubstringSimilar(block.HtmlContent, quote, idStr); found {
+			block.HtmlContent = newHtml
+			return nil
+		}
 
-			fmt.Println("НЕ НАШЛИ СТРОКУ В HTML:")
-			fmt.Println("HTML-блок:")
-			fmt.Println((*htmlBlocks)[j].HtmlContent)
-			fmt.Println("ПОДСТРОКА:")
-			fmt.Println(quote)
+		if newHtml, found, err := WrapSubstringSmartHTML(block.HtmlContent, quote, idStr); err == nil && found {
+			block.HtmlContent = newHtml
+			return nil
+		}
 
-			//invalidError.GroupID = invalidError.GroupID + "НЕ НАЙДЕНО В ТЕКСТЕ"
-			//return fmt.Errorf("не нашли строку двумя способами")
+		if newHtml, found, err := wrapInNestedSpans(block.HtmlContent, quote, idStr); err == nil && found {
+			block.HtmlContent = newHtml
+			return nil
 		}
 	}
 
-	return fmt.Errorf("номер строки из md вышел за границы маппинга по html")
+	if !blockFound {
+		return fmt.Errorf("строки %d-%d из md вышли за границы маппинга по html", startLine, endLine)
+	}
+
+	return fmt.Errorf("цитата не найдена в html-блоках строк %d-%d", startLine, endLine)
+}
+
+// setNotLocatedSystemComment помечает замечание как ненайденное в HTML, не затирая
+// комментарий сверки цитаты с markdown - он важнее
+func setNotLocatedSystemComment(invalidError *OutInvalidError) {
+	if invalidError.SystemComment == "" {
+		invalidError.SystemComment = notLocatedSystemComment
+	}
+}
+
+// countNotLocated возвращает количество замечаний, цитаты которых не найдены в HTML
+func countNotLocated(invalidErrors *[]OutInvalidError) int {
+	count := 0
+	for i := range *invalidErrors {
+		if !(*invalidErrors)[i].Located {
+			count++
+		}
+	}
+	return count
 }
@@ -0,0 +1,83 @@
+package tzservice
+
+import (
+	markdown_service_client "repairCopilotBot/tz-bot/internal/pkg/markdown-service"
+	"strings"
+	"testing"
+)
+
+func TestInjectInvalidErrorsToHtmlBlocks(t *testing.T) {
+	htmlBlocks := []markdown_service_client.Mapping{
+		{HtmlContent: "<p>Срок поставки оборудования определяется отдельно.</p>", MarkdownStart: 1, MarkdownEnd: 1},
+		{HtmlContent: "<p>Требования к <b>надежности</b> не предъявляются.</p>", MarkdownStart: 3, MarkdownEnd: 3},
+		{HtmlContent: "<p>Гарантийный срок составляет 12 месяцев.</p>", MarkdownStart: 5, MarkdownEnd: 5},
+	}
+
+	line1, line3, line40, line41 := 1, 3, 40, 41
+
+	invalidErrors := []OutInvalidError{
+		{HtmlIDStr: "1", Quote: "Срок поставки оборудования определяется отдельно", StartLineNumber: &line1, EndLineNumber: &line1},
+		// Цитата пересекает инлайновый тег
+		{HtmlIDStr: "2", Quote: "Требования к надежности не предъявляются", StartLineNumber: &line3, EndLineNumber: &line3},
+		// Цитата есть в документе, но не в указанных строках
+		{HtmlIDStr: "3", Quote: "Гарантийный срок составляет 12 месяцев", StartLineNumber: &line1, EndLineNumber: &line1},
+		// Строки за пределами маппинга
+		{HtmlIDStr: "4", Quote: "Гарантийный срок составляет 12 месяцев", StartLineNumber: &line40, EndLineNumber: &line41},
+		{HtmlIDStr: "5", Quote: "без номеров строк"},
+	}
+
+	errs := InjectInvalidErrorsToHtmlBlocks(&invalidErrors, &htmlBlocks)
+
+	expectedLocated := []bool{true, true, false, false, false}
+	for i, located := range expectedLocated {
+		if invalidErrors[i].Located != located {
+			t.Errorf("замечание %s: located=%v, ожидалось %v", invalidErrors[i].HtmlIDStr, invalidErrors[i].Located, located)
+		}
+		if !located && invalidErrors[i].SystemComment != notLocatedSystemComment {
+			t.Errorf("замечание %s: не выставлен системный комментарий", invalidErrors[i].HtmlIDStr)
+		}
+	}
+
+	if len(errs) != 2 {
+		t.Errorf("ожидалось 2 ошибки поиска, получено %d: %v", len(errs), errs)
+	}
+
+	if !strings.Contains(htmlBlocks[0].HtmlContent, `<span error-id="1">`) {
+		t.Errorf("цитата 1 не обёрнута: %s", htmlBlocks[0].HtmlContent)
+	}
+	if !strings.Contains(htmlBlocks[1].HtmlContent, `error-id="2"`) {
+		t.Errorf("цитата 2 не обёрнута: %s", htmlBlocks[1].HtmlContent)
+	}
+	if strings.Contains(htmlBlocks[2].HtmlContent, "error-id") {
+		t.Errorf("блок вне строк замечаний не должен меняться: %s", htmlBlocks[2].HtmlContent)
+	}
+}
+
+func TestInjectInvalidErrorsToHtmlBlocksQuoteLines(t *testing.T) {
+	htmlBlocks := []markdown_service_client.Mapping{
+		{HtmlContent: "<p>Первый пункт требований.</p>", MarkdownStart: 1, MarkdownEnd: 1},
+		{HtmlContent: "<p>Второй пункт требований.</p>", MarkdownStart: 2, MarkdownEnd: 2},
+	}
+
+	startLine, endLine := 1, 2
+	invalidErrors := []OutInvalidError{{
+		HtmlIDStr:       "7",
+		Quote:           "Первый пункт требований.\nВторой пункт требований.",
+		QuoteLines:      &[]string{"Первый пункт требований", "Второй пункт требований"},
+		StartLineNumber: &startLine,
+		EndLineNumber:   &endLine,
+	}}
+
+	if errs := InjectInvalidErrorsToHtmlBlocks(&invalidErrors, &htmlBlocks); len(errs) != 0 {
+		t.Fatalf("неожиданные ошибки: %v", errs)
+	}
+
+	if !invalidErrors[0].Located {
+		t.Error("многострочная цитата должна быть найдена")
+	}
+	for i := range htmlBlocks {
+		if !strings.Contains(htmlBlocks[i].HtmlContent, `<span error-id="7">`) {
+			t.Errorf("строка цитаты не обёрнута в блоке %d: %s", i, htmlBlocks[i].HtmlContent)
+		}
+	}
+}
@@ -200,7 +200,7 @@ func buildInlineSpanningPattern(sub string) string {
 	var b strings.Builder
 	b.WriteString(`(?is)`)
 
-	inlineTag := `(?:<(?:` + inlineAlternation() + `)(?:\s[^>]*?)?>)`
+	inlineTag := `(?:</?(?:` + inlineAlternation() + `)(?:\s[^>]*?)?>)`
 
 	// Небольшой зазор инлайновых тегов перед первым символом
 	b.WriteString(`(?:` + inlineTag + `){0,3}`)
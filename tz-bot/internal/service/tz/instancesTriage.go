@@ -0,0 +1,205 @@
+package tzservice
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Приоритеты замечаний, которые возвращает LLM
+const (
+	PriorityHigh   = "high"
+	PriorityMedium = "medium"
+	PriorityLow    = "low"
+)
+
+// InstancesSort - порядок замечаний в ответе GetVersion
+type InstancesSort int
+
+const (
+	// InstancesSortOrder - порядок появления замечаний в документе
+	InstancesSortOrder InstancesSort = iota
+	// InstancesSortPriority - сначала high, затем medium, low и замечания без приоритета
+	InstancesSortPriority
+	// InstancesSortErrorCode - по коду ошибки (E01, E02, ...), внутри кода - по порядку в документе
+	InstancesSortErrorCode
+)
+
+// InstancesFilter - фильтры и сортировка замечаний версии. Пустые списки не ограничивают выборку
+type InstancesFilter struct {
+	Priorities []string
+	// Sections - номера или названия разделов документа, сравниваются по вхождению без учёта регистра
+	Sections   []string
+	ErrorCodes []string
+	Sort       InstancesSort
+}
+
+// IsEmpty сообщает, что фильтр не меняет состав и порядок замечаний
+func (f InstancesFilter) IsEmpty() bool {
+	return len(f.Priorities) == 0 && len(f.Sections) == 0 && len(f.ErrorCodes) == 0 && f.Sort == InstancesSortOrder
+}
+
+// normalizeInstancePriority приводит приоритет из ответа LLM к одному из PriorityHigh/Medium/Low.
+// Неизвестные значения не сохраняются
+func normalizeInstancePriority(priority *string) *string {
+	if priority == nil {
+		return nil
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(*priority))
+	if priorityRank(&normalized) == 0 {
+		return nil
+	}
+
+	return &normalized
+}
+
+// priorityRank - вес приоритета для сортировки, 0 - приоритет не указан
+func priorityRank(priority *string) int {
+	if priority == nil {
+		return 0
+	}
+
+	switch *priority {
+	case PriorityHigh:
+		return 3
+	case PriorityMedium:
+		return 2
+	case PriorityLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func instanceSections(sections []string) *[]string {
+	out := make([]string, 0, len(sections))
+	for _, section := range sections {
+		if section = strings.TrimSpace(section); section != "" {
+			out = append(out, section)
+		}
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return &out
+}
+
+// applyInstancesFilter оставляет в ошибках версии и в общем списке invalid_instances только
+// замечания, подходящие под фильтр, и сортирует их. При фильтрации по приоритету или разделу
+// ошибки, у которых не осталось замечаний, убираются из ответа
+func applyInstancesFilter(errorsInTz *[]Error, invalidInstances *[]OutInvalidError, filter InstancesFilter) {
+	if filter.IsEmpty() || errorsInTz == nil {
+		return
+	}
+
+	instanceFilterSet := len(filter.Priorities) > 0 || len(filter.Sections) > 0
+
+	errorOrder := make(map[string]int, len(*errorsInTz))
+	filteredErrors := make([]Error, 0, len(*errorsInTz))
+	for _, e := range *errorsInTz {
+		if len(filter.ErrorCodes) > 0 && !containsFold(filter.ErrorCodes, e.ErrorCode) {
+			continue
+		}
+
+		invalidCount, missingCount := 0, 0
+		if e.InvalidInstances != nil {
+			instances := slices.DeleteFunc(slices.Clone(*e.InvalidInstances), func(instance OutInvalidError) bool {
+				return !filter.matches(instance.Priority, instance.Sections)
+			})
+			sortInvalidInstances(instances, filter.Sort, nil)
+			e.InvalidInstances = &instances
+			invalidCount = len(instances)
+		}
+
+		if e.MissingInstances != nil {
+			instances := slices.DeleteFunc(slices.Clone(*e.MissingInstances), func(instance OutMissingError) bool {
+				return !filter.matches(instance.Priority, instance.Sections)
+			})
+			if filter.Sort == InstancesSortPriority {
+				sort.SliceStable(instances, func(i, j int) bool {
+					return priorityRank(instances[i].Priority) > priorityRank(instances[j].Priority)
+				})
+			}
+			e.MissingInstances = &instances
+			missingCount = len(instances)
+		}
+
+		if instanceFilterSet && invalidCount == 0 && missingCount == 0 {
+			continue
+		}
+
+		errorOrder[e.ID.String()] = e.OrderNumber
+		filteredErrors = append(filteredErrors, e)
+	}
+	*errorsInTz = filteredErrors
+
+	if invalidInstances == nil {
+		return
+	}
+
+	*invalidInstances = slices.DeleteFunc(*invalidInstances, func(instance OutInvalidError) bool {
+		_, errorKept := errorOrder[instance.ErrorID.String()]
+		return !errorKept || !filter.matches(instance.Priority, instance.Sections)
+	})
+	sortInvalidInstances(*invalidInstances, filter.Sort, errorOrder)
+}
+
+func (f InstancesFilter) matches(priority *string, sections *[]string) bool {
+	if len(f.Priorities) > 0 && (priority == nil || !containsFold(f.Priorities, *priority)) {
+		return false
+	}
+
+	if len(f.Sections) == 0 {
+		return true
+	}
+
+	if sections == nil {
+		return false
+	}
+
+	for _, section := range *sections {
+		section = strings.ToLower(section)
+		for _, wanted := range f.Sections {
+			if strings.Contains(section, strings.ToLower(strings.TrimSpace(wanted))) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// sortInvalidInstances сортирует замечания по выбранному порядку. errorOrder - порядковые номера
+// ошибок по id, нужны для сортировки по коду ошибки общего списка замечаний версии
+func sortInvalidInstances(instances []OutInvalidError, order InstancesSort, errorOrder map[string]int) {
+	sort.SliceStable(instances, func(i, j int) bool {
+		switch order {
+		case InstancesSortPriority:
+			rankI, rankJ := priorityRank(instances[i].Priority), priorityRank(instances[j].Priority)
+			if rankI != rankJ {
+				return rankI > rankJ
+			}
+		case InstancesSortErrorCode:
+			if errorOrder != nil {
+				orderI, orderJ := errorOrder[instances[i].ErrorID.String()], errorOrder[instances[j].ErrorID.String()]
+				if orderI != orderJ {
+					return orderI < orderJ
+				}
+			}
+		}
+
+		return instances[i].OrderNumber < instances[j].OrderNumber
+	})
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package tzservice
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestApplyInstancesFilter(t *testing.T) {
+	e01, e02 := uuid.New(), uuid.New()
+	low, medium, high := "low", "medium", "high"
+
+	e01Instances := []OutInvalidError{
+		{ID: uuid.New(), ErrorID: e01, HtmlIDStr: "1", OrderNumber: 0, Priority: &low, Sections: &[]string{"1. Общие сведения"}},
+		{ID: uuid.New(), ErrorID: e01, HtmlIDStr: "3", OrderNumber: 2, Priority: &high, Sections: &[]string{"3.2 Требования к надёжности"}},
+	}
+	e02Instances := []OutInvalidError{
+		{ID: uuid.New(), ErrorID: e02, HtmlIDStr: "2", OrderNumber: 1, Priority: &medium, Sections: &[]string{"3.2 Требования к надёжности"}},
+		// Неизвестный приоритет не сохраняется
+		{ID: uuid.New(), ErrorID: e02, HtmlIDStr: "4", OrderNumber: 3},
+	}
+
+	newVersion := func() (*[]Error, *[]OutInvalidError) {
+		errorsInTz := []Error{
+			{ID: e01, ErrorCode: "E01", OrderNumber: 0, InvalidInstances: &e01Instances},
+			{ID: e02, ErrorCode: "E02", OrderNumber: 1, InvalidInstances: &e02Instances},
+		}
+		invalidInstances := []OutInvalidError{e01Instances[0], e02Instances[0], e01Instances[1], e02Instances[1]}
+		return &errorsInTz, &invalidInstances
+	}
+
+	htmlIDs := func(instances []OutInvalidError) string {
+		ids := ""
+		for _, instance := range instances {
+			ids += instance.HtmlIDStr
+		}
+		return ids
+	}
+
+	t.Run("сортировка по приоритету", func(t *testing.T) {
+		errorsInTz, invalidInstances := newVersion()
+		applyInstancesFilter(errorsInTz, invalidInstances, InstancesFilter{Sort: InstancesSortPriority})
+
+		// Замечание без приоритета идёт последним
+		if got := htmlIDs(*invalidInstances); got != "3214" {
+			t.Errorf("неверный порядок замечаний: %s", got)
+		}
+		if len(*errorsInTz) != 2 {
+			t.Errorf("сортировка не должна убирать ошибки")
+		}
+	})
+
+	t.Run("фильтр по разделу и приоритету", func(t *testing.T) {
+		errorsInTz, invalidInstances := newVersion()
+		applyInstancesFilter(errorsInTz, invalidInstances, InstancesFilter{
+			Priorities: []string{"high", "medium"},
+			Sections:   []string{"3.2"},
+			Sort:       InstancesSortErrorCode,
+		})
+
+		if got := htmlIDs(*invalidInstances); got != "32" {
+			t.Errorf("неверный список замечаний: %s", got)
+		}
+		if got := htmlIDs(*(*errorsInTz)[1].InvalidInstances); got != "2" {
+			t.Errorf("неверные замечания ошибки E02: %s", got)
+		}
+		if len(e02Instances) != 2 {
+			t.Errorf("фильтр изменил исходные замечания ошибки")
+		}
+	})
+
+	t.Run("фильтр по коду ошибки", func(t *testing.T) {
+		errorsInTz, invalidInstances := newVersion()
+		applyInstancesFilter(errorsInTz, invalidInstances, InstancesFilter{ErrorCodes: []string{"e02"}})
+
+		if len(*errorsInTz) != 1 || (*errorsInTz)[0].ErrorCode != "E02" {
+			t.Fatalf("неверный список ошибок: %+v", *errorsInTz)
+		}
+		if got := htmlIDs(*invalidInstances); got != "24" {
+			t.Errorf("неверный список замечаний: %s", got)
+		}
+	})
+}
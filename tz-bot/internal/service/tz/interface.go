@@ -66,6 +66,9 @@ type VersionRepository interface {
 	// GetVersionsByUserID retrieves all versions with minimal data for a user
 	GetVersionsMeByUserID(ctx context.Context, userID uuid.UUID) ([]*VersionMe, error)
 
+	// GetVersionsMeByOrganizationID retrieves versions with minimal data charged to an organization quota
+	GetVersionsMeByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*VersionMe, error)
+
 	// GetAllVersions retrieves all versions with complete data and error counts
 	GetAllVersionsAdminDashboard(context.Context, uuid.UUID) ([]*VersionAdminDashboard, error)
 
@@ -84,11 +87,28 @@ type VersionRepository interface {
 	// GetLatestVersion retrieves the latest version for a technical specification
 	GetLatestVersion(ctx context.Context, technicalSpecificationID uuid.UUID) (*modelrepo.Version, error)
 
-	// UpdateVersion updates a version
+	// UpdateVersion updates a version that is still in_progress
 	UpdateVersion(ctx context.Context, req *modelrepo.UpdateVersionRequest) error
 
 	UpdateVersionProgress(ctx context.Context, id uuid.UUID, progress int) error
 
+	// UpdateVersionStatus updates only status and progress of a version
+	UpdateVersionStatus(ctx context.Context, id uuid.UUID, status string, progress int) error
+
+	// SetVersionAnnotatedFile stores the reports bucket key of the annotated docx of a version
+	SetVersionAnnotatedFile(ctx context.Context, id uuid.UUID, fileID string) error
+
+	// SetVersionMarkdownHash stores the normalized markdown hash of a version and links it to an earlier identical version
+	SetVersionMarkdownHash(ctx context.Context, id uuid.UUID, markdownHash string, recheckOfVersionID *uuid.UUID) error
+
+	// FindCompletedVersionByFileHash retrieves the latest completed version of a user or their organization
+	// uploaded from the same file and checked with the same error groups
+	FindCompletedVersionByFileHash(ctx context.Context, userID uuid.UUID, organizationID *uuid.UUID, fileHash string, ggID int) (*modelrepo.DuplicateVersion, error)
+
+	// FindCompletedVersionByMarkdownHash retrieves the latest completed version of a user or the organization of versionID
+	// with the same document text and the same error groups as versionID
+	FindCompletedVersionByMarkdownHash(ctx context.Context, userID uuid.UUID, markdownHash string, versionID uuid.UUID) (*modelrepo.DuplicateVersion, error)
+
 	// DeleteVersion deletes a version and all its errors
 	DeleteVersion(ctx context.Context, id uuid.UUID) error
 }
@@ -152,6 +172,151 @@ type ErrorFeedbackRepository interface {
 	CreateErrorFeedback(ctx context.Context, req *modelrepo.CreateErrorFeedbackRequest) (*modelrepo.ErrorFeedback, error)
 }
 
+// ProcessingJobRepository defines the interface for the durable inspection queue
+type ProcessingJobRepository interface {
+	// CreateProcessingJob enqueues an inspection of a version
+	CreateProcessingJob(ctx context.Context, req *modelrepo.CreateProcessingJobRequest) error
+
+	// ClaimProcessingJob claims the next pending job or a running job with an expired lease
+	ClaimProcessingJob(ctx context.Context, leaseExpiresAt time.Time) (*modelrepo.ProcessingJob, error)
+
+	// ExtendProcessingJobLease extends the lease of a running job
+	ExtendProcessingJobLease(ctx context.Context, id uuid.UUID, leaseExpiresAt time.Time) error
+
+	CompleteProcessingJob(ctx context.Context, id uuid.UUID) error
+
+	// RetryProcessingJob puts a job back to the queue after a failed attempt
+	RetryProcessingJob(ctx context.Context, id uuid.UUID, lastError string, runAfter time.Time) error
+
+	// ReleaseProcessingJob puts a job back to the queue without spending an attempt
+	ReleaseProcessingJob(ctx context.Context, id uuid.UUID) error
+
+	FailProcessingJob(ctx context.Context, id uuid.UUID, lastError string) error
+
+	// CancelVersionProcessing atomically moves an in_progress version to cancelled and cancels its job
+	CancelVersionProcessing(ctx context.Context, versionID uuid.UUID) error
+
+	// ResetProcessingJob requeues the job of a version with a fresh attempts counter
+	ResetProcessingJob(ctx context.Context, versionID uuid.UUID) error
+
+	// DeleteVersionFindings deletes errors and instances saved by a failed attempt
+	DeleteVersionFindings(ctx context.Context, versionID uuid.UUID) error
+}
+
+// VersionEventRepository defines the interface for inspection progress events
+type VersionEventRepository interface {
+	// CreateVersionEvent saves a progress event of a version
+	CreateVersionEvent(ctx context.Context, versionID uuid.UUID, eventType string, payload []byte) error
+
+	// GetVersionEvents retrieves events of a version with ID greater than afterID
+	GetVersionEvents(ctx context.Context, versionID uuid.UUID, afterID int64) ([]modelrepo.VersionEvent, error)
+
+	// ListenVersionEvents calls notify with the version ID of every new event until ctx is done or the connection fails
+	ListenVersionEvents(ctx context.Context, notify func(versionID uuid.UUID)) error
+}
+
+// VersionCheckpointRepository defines the interface for pipeline stage checkpoints
+type VersionCheckpointRepository interface {
+	// SaveVersionCheckpoint saves (or overwrites) the output of a pipeline stage
+	SaveVersionCheckpoint(ctx context.Context, versionID uuid.UUID, stage string, data []byte) error
+
+	// GetVersionCheckpoint retrieves the saved output of a pipeline stage
+	GetVersionCheckpoint(ctx context.Context, versionID uuid.UUID, stage string) ([]byte, error)
+
+	// GetVersionCheckpointStages retrieves stages that have a saved output
+	GetVersionCheckpointStages(ctx context.Context, versionID uuid.UUID) ([]string, error)
+
+	// DeleteVersionCheckpoints deletes the saved outputs of the given stages
+	DeleteVersionCheckpoints(ctx context.Context, versionID uuid.UUID, stages []string) error
+}
+
+// LLMCallRepository defines the interface for the log of LLM API requests
+type LLMCallRepository interface {
+	// CreateLLMCall records an LLM API request made while inspecting a version
+	CreateLLMCall(ctx context.Context, req *modelrepo.CreateLLMCallRequest) error
+
+	// GetLLMCallsByVersionID retrieves LLM API requests of a version in execution order
+	GetLLMCallsByVersionID(ctx context.Context, versionID uuid.UUID) ([]modelrepo.LLMCall, error)
+}
+
+// ErrorCatalogRepository defines the interface for the versioned error catalog
+type ErrorCatalogRepository interface {
+	// SyncErrorCatalog stores changed descriptions as new catalog versions and returns current entry ids by code
+	SyncErrorCatalog(ctx context.Context, ggID int, entries []modelrepo.ErrorCatalogEntryData) (map[string]uuid.UUID, error)
+
+	// ListErrorCatalog retrieves catalog entries matching the filter
+	ListErrorCatalog(ctx context.Context, filter modelrepo.ErrorCatalogFilter) ([]modelrepo.ErrorCatalogEntry, error)
+
+	// GetErrorCatalogEntry retrieves a catalog entry by its ID
+	GetErrorCatalogEntry(ctx context.Context, id uuid.UUID) (*modelrepo.ErrorCatalogEntry, error)
+
+	// GetErrorCatalogEntryByCode retrieves a catalog entry by code, the current one if version is nil
+	GetErrorCatalogEntryByCode(ctx context.Context, ggID int, code string, version *int) (*modelrepo.ErrorCatalogEntry, error)
+}
+
+// CheckProfileRepository defines the interface for named inspection profiles
+type CheckProfileRepository interface {
+	// ListCheckProfiles retrieves all check profiles ordered by name
+	ListCheckProfiles(ctx context.Context) ([]modelrepo.CheckProfile, error)
+
+	// GetCheckProfileByName retrieves a check profile by its name
+	GetCheckProfileByName(ctx context.Context, name string) (*modelrepo.CheckProfile, error)
+
+	// GetDefaultCheckProfile retrieves the profile used when an upload does not specify one
+	GetDefaultCheckProfile(ctx context.Context) (*modelrepo.CheckProfile, error)
+
+	// CreateCheckProfile creates a new check profile
+	CreateCheckProfile(ctx context.Context, req *modelrepo.CreateCheckProfileRequest) (*modelrepo.CheckProfile, error)
+
+	// UpdateCheckProfile updates a check profile by name
+	UpdateCheckProfile(ctx context.Context, req *modelrepo.UpdateCheckProfileRequest) (*modelrepo.CheckProfile, error)
+
+	// DeleteCheckProfile deletes a check profile by name
+	DeleteCheckProfile(ctx context.Context, name string) error
+
+	// SetDefaultCheckProfile makes the named profile the default one
+	SetDefaultCheckProfile(ctx context.Context, name string) (*modelrepo.CheckProfile, error)
+}
+
+// AccessRepository defines the lookups used to check access to versions
+type AccessRepository interface {
+	// GetVersionOwnerID retrieves the ID of the user who owns the technical specification of a version
+	GetVersionOwnerID(ctx context.Context, versionID uuid.UUID) (uuid.UUID, error)
+
+	// GetVersionOrganizationID retrieves the organization a version was charged to, nil for personal checks
+	GetVersionOrganizationID(ctx context.Context, versionID uuid.UUID) (*uuid.UUID, error)
+
+	// GetVersionChargedOrganizationID retrieves the organization the latest check of a version was charged to
+	GetVersionChargedOrganizationID(ctx context.Context, versionID uuid.UUID) (*uuid.UUID, error)
+
+	// SetVersionChargedOrganizationID records the organization a repeated check of a version was charged to
+	SetVersionChargedOrganizationID(ctx context.Context, versionID uuid.UUID, organizationID *uuid.UUID) error
+
+	// GetInstanceVersionID retrieves the version of an invalid or missing error instance
+	GetInstanceVersionID(ctx context.Context, instanceID uuid.UUID, instanceType string) (uuid.UUID, error)
+}
+
+// ShareLinkRepository defines the interface for links to view a version without an account
+type ShareLinkRepository interface {
+	// CreateShareLink creates a share link of a version
+	CreateShareLink(ctx context.Context, req *modelrepo.CreateShareLinkRequest) (*modelrepo.ShareLink, error)
+
+	// GetShareLink retrieves a share link by its ID
+	GetShareLink(ctx context.Context, id uuid.UUID) (*modelrepo.ShareLink, error)
+
+	// GetShareLinkByTokenHash retrieves a share link by the sha256 of its token
+	GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (*modelrepo.ShareLink, error)
+
+	// ListShareLinksByVersionID retrieves share links of a version, newest first
+	ListShareLinksByVersionID(ctx context.Context, versionID uuid.UUID) ([]modelrepo.ShareLink, error)
+
+	// RevokeShareLink marks a share link as revoked
+	RevokeShareLink(ctx context.Context, id uuid.UUID) (*modelrepo.ShareLink, error)
+
+	// RegisterShareLinkAccess increments the access counter of a share link
+	RegisterShareLinkAccess(ctx context.Context, id uuid.UUID) error
+}
+
 // Repository combines all repository interfaces
 type Repository interface {
 	TechnicalSpecificationRepository
@@ -162,6 +327,14 @@ type Repository interface {
 	InvalidInstanceRepository
 	MissingInstanceRepository
 	ErrorFeedbackRepository
+	ProcessingJobRepository
+	VersionCheckpointRepository
+	VersionEventRepository
+	LLMCallRepository
+	CheckProfileRepository
+	ErrorCatalogRepository
+	AccessRepository
+	ShareLinkRepository
 	//LLMCacheRepository
 
 	// GetUUIDByErrorID retrieves UUID by numeric error ID from both invalid and missing errors
@@ -25,7 +25,7 @@ func NewInvalidErrorsSet(startId uint32, report *[]tz_llm_client.GroupReport) (*
 					if (*((*report)[i]).Errors)[j].Instances != nil {
 						fmt.Println("(*report)[i].Errors[j].Instances != nil")
 						for k := range *(*((*report)[i]).Errors)[j].Instances {
-							if (*(*((*report)[i]).Errors)[j].Instances)[k].Kind != nil && *(*(*((*report)[i]).Errors)[j].Instances)[k].Kind == "Invalid" && (*(*((*report)[i]).Errors)[j].Instances)[k].Quotes != nil && (*(*((*report)[i]).Errors)[j].Instances)[k].Quotes[0] != "" {
+							if (*(*((*report)[i]).Errors)[j].Instances)[k].Kind != nil && *(*(*((*report)[i]).Errors)[j].Instances)[k].Kind == "Invalid" && len((*(*((*report)[i]).Errors)[j].Instances)[k].Quotes) > 0 && (*(*((*report)[i]).Errors)[j].Instances)[k].Quotes[0] != "" {
 
 								suggestedFix := ""
 								if (*(*((*report)[i]).Errors)[j].Instances)[k].Fix != nil {
@@ -57,16 +57,14 @@ func NewInvalidErrorsSet(startId uint32, report *[]tz_llm_client.GroupReport) (*
 									}
 								}
 
-								startLineNumber := (*(*((*report)[i]).Errors)[j].Instances)[k].Lines[0]
-								//if startLineNumber == nil {
-								//
-								//}
+								// Модель может не вернуть номера строк - тогда их определит сверка цитат с markdown
+								var startLineNumber, endLineNumber *int
+								if lines := (*(*((*report)[i]).Errors)[j].Instances)[k].Lines; len(lines) > 0 {
+									start, end := lines[0], lines[len(lines)-1]
+									startLineNumber, endLineNumber = &start, &end
+								}
 
-								lengthLines := len((*(*((*report)[i]).Errors)[j].Instances)[k].Lines)
-								endLineNumber := (*(*((*report)[i]).Errors)[j].Instances)[k].Lines[lengthLines-1]
-								//if endLineNumber == nil {
-								//
-								//}
+								llmInstance := (*(*((*report)[i]).Errors)[j].Instances)[k]
 
 								var rationale string
 
@@ -82,11 +80,15 @@ func NewInvalidErrorsSet(startId uint32, report *[]tz_llm_client.GroupReport) (*
 									Quote:                 cleanQuote,
 									SuggestedFix:          suggestedFix,
 									UntilTheEndOfSentence: EllipsisCheck((*(*((*report)[i]).Errors)[j].Instances)[k].Quotes[0]),
-									StartLineNumber:       &startLineNumber,
-									EndLineNumber:         &endLineNumber,
+									StartLineNumber:       startLineNumber,
+									EndLineNumber:         endLineNumber,
 									QuoteLines:            quoteLines,
 									OriginalQuote:         originalQuote,
 									Rationale:             rationale,
+									Priority:              normalizeInstancePriority(llmInstance.Priority),
+									Risks:                 llmInstance.Risks,
+									Sections:              instanceSections(llmInstance.Sections),
+									WhatIsIncorrect:       llmInstance.WhatIsIncorrect,
 								})
 
 								id++
@@ -0,0 +1,77 @@
+package tzservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	tz_llm_client "repairCopilotBot/tz-bot/internal/pkg/llm"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"time"
+)
+
+// LlmCacheFilter - условия сброса кэша LLM. Пустые поля не учитываются,
+// диапазон дат задаёт время сохранения ответа [From, To)
+type LlmCacheFilter struct {
+	Model *string
+	GgID  *int
+	From  *time.Time
+	To    *time.Time
+}
+
+// llmCacheOptions возвращает параметры кэша для запросов проверки с группой ошибок ggID
+func (tz *Tz) llmCacheOptions(ggID int) tz_llm_client.CacheOptions {
+	tz.mu.RLock()
+	defer tz.mu.RUnlock()
+
+	return tz_llm_client.CacheOptions{
+		Enabled: tz.useLlmCache,
+		GgID:    ggID,
+	}
+}
+
+// PurgeExpiredLlmCache удаляет из кэша LLM ответы старше TTL
+func (tz *Tz) PurgeExpiredLlmCache(ctx context.Context) (int64, error) {
+	const op = "Tz.PurgeExpiredLlmCache"
+
+	log := tz.log.With(slog.String("op", op))
+
+	deleted, err := tz.llmClient.PurgeExpiredCache(ctx)
+	if err != nil {
+		log.Error("failed to purge expired llm cache: ", sl.Err(err))
+		return 0, fmt.Errorf("failed to purge expired llm cache: %w", err)
+	}
+
+	if deleted > 0 {
+		log.Info("expired llm cache purged", slog.Int64("deleted", deleted))
+	}
+
+	return deleted, nil
+}
+
+// InvalidateLlmCache удаляет из кэша LLM ответы, подходящие под фильтр. Сброс всего кэша
+// без условий не допускается
+func (tz *Tz) InvalidateLlmCache(ctx context.Context, filter LlmCacheFilter) (int64, error) {
+	const op = "Tz.InvalidateLlmCache"
+
+	log := tz.log.With(slog.String("op", op))
+
+	if filter.Model == nil && filter.GgID == nil && filter.From == nil && filter.To == nil {
+		return 0, ErrEmptyCacheFilter
+	}
+
+	deleted, err := tz.llmClient.InvalidateCache(ctx, modelrepo.LLMCacheFilter{
+		Model: filter.Model,
+		GgID:  filter.GgID,
+		From:  filter.From,
+		To:    filter.To,
+	})
+	if err != nil {
+		log.Error("failed to invalidate llm cache: ", sl.Err(err))
+		return 0, fmt.Errorf("failed to invalidate llm cache: %w", err)
+	}
+
+	log.Info("llm cache invalidated", slog.Int64("deleted", deleted))
+
+	return deleted, nil
+}
@@ -0,0 +1,197 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	tz_llm_client "repairCopilotBot/tz-bot/internal/pkg/llm"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// LlmCostGroup - расходы на запросы к LLM одного шага и одной группы ошибок.
+// Rubs учитывает только реально выполненные запросы, ответы из кэша не оплачиваются
+type LlmCostGroup struct {
+	Step             int
+	GroupID          *int
+	GroupName        *string
+	Calls            int
+	CacheHits        int
+	FailedCalls      int
+	PromptTokens     int64
+	CompletionTokens int64
+	TotalTokens      int64
+	Rubs             float64
+	DurationMs       int64
+}
+
+type VersionCostBreakdown struct {
+	VersionID uuid.UUID
+	Total     LlmCostGroup
+	Groups    []LlmCostGroup
+	Calls     []modelrepo.LLMCall
+}
+
+// recordLlmCall сохраняет запрос к LLM в журнал расходов. Ошибки только логируются -
+// журнал не должен ломать проверку
+func (tz *Tz) recordLlmCall(ctx context.Context, versionID uuid.UUID, step int, groupID *int, groupName *string, resp *tz_llm_client.SuccessResponse, callErr error, log *slog.Logger) {
+	// Запрос прерван отменой проверки или остановкой сервиса - ответа не было, записывать нечего
+	if callErr != nil && ctx.Err() != nil {
+		return
+	}
+
+	req := &modelrepo.CreateLLMCallRequest{
+		VersionID: versionID,
+		Step:      step,
+		GroupID:   groupID,
+		GroupName: groupName,
+		Model:     tz.llmClient.Model(),
+		Attempts:  1,
+	}
+
+	if callErr != nil {
+		errorMsg := callErr.Error()
+		req.Error = &errorMsg
+		if attempts := tz_llm_client.ErrorAttempts(callErr); attempts > 0 {
+			req.Attempts = attempts
+		}
+	}
+
+	if resp != nil {
+		if resp.ModelUri != nil && *resp.ModelUri != "" {
+			req.Model = *resp.ModelUri
+		}
+		if resp.Usage != nil {
+			if resp.Usage.PromptTokens != nil {
+				req.PromptTokens = *resp.Usage.PromptTokens
+			}
+			if resp.Usage.CompletionTokens != nil {
+				req.CompletionTokens = *resp.Usage.CompletionTokens
+			}
+			if resp.Usage.TotalTokens != nil {
+				req.TotalTokens = *resp.Usage.TotalTokens
+			}
+		}
+		if resp.Cost != nil && resp.Cost.TotalRub != nil {
+			req.Rubs = *resp.Cost.TotalRub
+		}
+		// У ответа из кэша длительность исходного запроса
+		if resp.Duration != nil && !resp.CacheHit {
+			req.DurationMs = *resp.Duration
+		}
+		// Повторы клиента, а не счётчик llm-requester внутри одной попытки
+		if resp.ClientAttempts > 0 {
+			req.Attempts = resp.ClientAttempts
+		}
+		req.CacheHit = resp.CacheHit
+	}
+
+	if err := tz.repo.CreateLLMCall(context.WithoutCancel(ctx), req); err != nil {
+		log.Error("failed to record llm call: ", sl.Err(err))
+	}
+}
+
+// GetVersionCostBreakdown возвращает расходы на проверку версии в разрезе шагов и групп ошибок
+func (tz *Tz) GetVersionCostBreakdown(ctx context.Context, versionID uuid.UUID) (*VersionCostBreakdown, error) {
+	const op = "Tz.GetVersionCostBreakdown"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("versionID", versionID.String()),
+	)
+
+	if _, err := tz.repo.GetVersion(ctx, versionID); err != nil {
+		if errors.Is(err, repository.ErrVersionNotFound) {
+			return nil, ErrVersionNotFound
+		}
+		log.Error("failed to get version: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+
+	calls, err := tz.repo.GetLLMCallsByVersionID(ctx, versionID)
+	if err != nil {
+		log.Error("failed to get llm calls: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get llm calls: %w", err)
+	}
+
+	total, groups := aggregateLlmCalls(calls)
+
+	return &VersionCostBreakdown{
+		VersionID: versionID,
+		Total:     total,
+		Groups:    groups,
+		Calls:     calls,
+	}, nil
+}
+
+// aggregateLlmCalls суммирует запросы по шагу и группе ошибок. Группы упорядочены
+// по шагу, внутри шага - от самых дорогих
+func aggregateLlmCalls(calls []modelrepo.LLMCall) (LlmCostGroup, []LlmCostGroup) {
+	type groupKey struct {
+		step    int
+		groupID int
+		hasID   bool
+	}
+
+	total := LlmCostGroup{}
+	index := make(map[groupKey]int)
+	groups := make([]LlmCostGroup, 0)
+
+	for _, call := range calls {
+		key := groupKey{step: call.Step}
+		if call.GroupID != nil {
+			key.groupID = *call.GroupID
+			key.hasID = true
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, LlmCostGroup{
+				Step:    call.Step,
+				GroupID: call.GroupID,
+			})
+		}
+		if call.GroupName != nil {
+			groups[i].GroupName = call.GroupName
+		}
+
+		addLlmCall(&groups[i], call)
+		addLlmCall(&total, call)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Step != groups[j].Step {
+			return groups[i].Step < groups[j].Step
+		}
+		return groups[i].Rubs > groups[j].Rubs
+	})
+
+	return total, groups
+}
+
+func addLlmCall(group *LlmCostGroup, call modelrepo.LLMCall) {
+	group.Calls++
+	group.DurationMs += call.DurationMs
+
+	if call.Error != nil {
+		group.FailedCalls++
+		return
+	}
+
+	if call.CacheHit {
+		group.CacheHits++
+		return
+	}
+
+	group.PromptTokens += int64(call.PromptTokens)
+	group.CompletionTokens += int64(call.CompletionTokens)
+	group.TotalTokens += int64(call.TotalTokens)
+	group.Rubs += call.Rubs
+}
@@ -0,0 +1,43 @@
+package tzservice
+
+import (
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"testing"
+)
+
+func TestAggregateLlmCalls(t *testing.T) {
+	cheapGroup, expensiveGroup := 1, 2
+	failed := "статус код: 500"
+
+	calls := []modelrepo.LLMCall{
+		{Step: 1, GroupID: &cheapGroup, PromptTokens: 50, CompletionTokens: 50, TotalTokens: 100, Rubs: 1.5, DurationMs: 1000, Attempts: 1},
+		{Step: 1, GroupID: &expensiveGroup, PromptTokens: 500, CompletionTokens: 500, TotalTokens: 1000, Rubs: 10, DurationMs: 1000, Attempts: 1},
+		{Step: 1, GroupID: &cheapGroup, PromptTokens: 150, CompletionTokens: 150, TotalTokens: 300, Rubs: 3, CacheHit: true, Attempts: 1},
+		{Step: 1, GroupID: &expensiveGroup, Error: &failed, Attempts: 1},
+		{Step: 1, GroupID: &expensiveGroup, PromptTokens: 250, CompletionTokens: 250, TotalTokens: 500, Rubs: 5, DurationMs: 1000, Attempts: 1},
+		{Step: 2, PromptTokens: 350, CompletionTokens: 350, TotalTokens: 700, Rubs: 7, DurationMs: 1000, Attempts: 1},
+	}
+
+	total, groups := aggregateLlmCalls(calls)
+
+	if total.Calls != 6 || total.CacheHits != 1 || total.FailedCalls != 1 {
+		t.Errorf("неверные счётчики запросов: %+v", total)
+	}
+	// Ответ из кэша не оплачивается
+	if total.Rubs != 23.5 || total.TotalTokens != 2300 {
+		t.Errorf("неверная сумма расходов: rubs=%v tokens=%d", total.Rubs, total.TotalTokens)
+	}
+
+	if len(groups) != 3 {
+		t.Fatalf("ожидалось 3 группы, получено %d", len(groups))
+	}
+	if groups[0].Step != 1 || *groups[0].GroupID != expensiveGroup || groups[0].Rubs != 15 || groups[0].Calls != 3 {
+		t.Errorf("первой должна быть самая дорогая группа первого шага: %+v", groups[0])
+	}
+	if *groups[1].GroupID != cheapGroup || groups[1].CacheHits != 1 || groups[1].Rubs != 1.5 {
+		t.Errorf("неверная вторая группа: %+v", groups[1])
+	}
+	if groups[2].Step != 2 || groups[2].GroupID != nil || groups[2].Rubs != 7 {
+		t.Errorf("последним должен быть второй шаг: %+v", groups[2])
+	}
+}
@@ -30,13 +30,19 @@ func NewIMissingErrorsSet(startId uint32, report *[]tz_llm_client.GroupReport) (
 									rationale = "Риски: " + *(*(*((*report)[i]).Errors)[j].Instances)[k].Risks
 								}
 
+								llmInstance := (*(*((*report)[i]).Errors)[j].Instances)[k]
+
 								outInvalidErrors = append(outInvalidErrors, OutMissingError{
-									ErrorID:      (*((*report)[i]).Errors)[j].ID,
-									HtmlID:       id,
-									HtmlIDStr:    fmt.Sprintf("%d", id),
-									SuggestedFix: suggestedFix,
-									Rationale:    rationale,
-									ID:           uuid.New(),
+									ErrorID:         (*((*report)[i]).Errors)[j].ID,
+									HtmlID:          id,
+									HtmlIDStr:       fmt.Sprintf("%d", id),
+									SuggestedFix:    suggestedFix,
+									Rationale:       rationale,
+									Priority:        normalizeInstancePriority(llmInstance.Priority),
+									Risks:           llmInstance.Risks,
+									Sections:        instanceSections(llmInstance.Sections),
+									WhatIsIncorrect: llmInstance.WhatIsIncorrect,
+									ID:              uuid.New(),
 								})
 
 								id++
@@ -1,6 +1,7 @@
 package tzservice
 
 import (
+	"context"
 	"log/slog"
 	doctodocxconverterclient "repairCopilotBot/tz-bot/internal/pkg/docToDocxConverterClient"
 	tz_llm_client "repairCopilotBot/tz-bot/internal/pkg/llm"
@@ -10,6 +11,8 @@ import (
 	user_service_client "repairCopilotBot/tz-bot/internal/pkg/user-service"
 	word_parser_client "repairCopilotBot/tz-bot/internal/pkg/word-parser"
 	"repairCopilotBot/tz-bot/internal/repository/s3minio"
+
+	"github.com/google/uuid"
 )
 
 func New(
@@ -24,6 +27,7 @@ func New(
 	telegramClient *telegramclient.Client,
 	s3 *s3minio.MinioRepository,
 	repo Repository,
+	uploadLimits UploadLimits,
 ) *Tz {
 	return &Tz{
 		log:                      log,
@@ -37,7 +41,10 @@ func New(
 		telegramClient:           telegramClient,
 		s3:                       s3,
 		repo:                     repo,
+		uploadLimits:             uploadLimits,
 		ggID:                     6,
 		useLlmCache:              true,
+		activeJobs:               make(map[uuid.UUID]context.CancelCauseFunc),
+		versionEvents:            newVersionEventsHub(),
 	}
 }
@@ -0,0 +1,492 @@
+package tzservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	tz_llm_client "repairCopilotBot/tz-bot/internal/pkg/llm"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	markdown_service_client "repairCopilotBot/tz-bot/internal/pkg/markdown-service"
+	promt_builder "repairCopilotBot/tz-bot/internal/pkg/promt-builder"
+	"repairCopilotBot/tz-bot/internal/repository"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Стадии обработки версии в порядке выполнения. Для всех стадий, кроме последней,
+// результат сохраняется в version_checkpoints
+const (
+	StageHTML     = "html"
+	StageMarkdown = "markdown"
+	StagePrompts  = "prompts"
+	StageStep1    = "step1"
+	StageStep2    = "step2"
+	StageReport   = "report"
+)
+
+var PipelineStages = []string{StageHTML, StageMarkdown, StagePrompts, StageStep1, StageStep2, StageReport}
+
+type htmlCheckpoint struct {
+	Html string `json:"html"`
+}
+
+type promptsCheckpoint struct {
+	Promts             *[]promt_builder.Promt                    `json:"promts"`
+	Schema             json.RawMessage                           `json:"schema"`
+	ErrorsDescriptions map[string]promt_builder.ErrorDescription `json:"errors_descriptions"`
+}
+
+type step1Checkpoint struct {
+	GroupReports []tz_llm_client.GroupReport `json:"group_reports"`
+	Rubs         float64                     `json:"rubs"`
+	Tokens       int64                       `json:"tokens"`
+}
+
+// step1GroupCheckpoint - результат одной группы первого шага. Сохраняется сразу после ответа LLM,
+// чтобы при повторе не платить заново за группы, которые уже были обработаны
+type step1GroupCheckpoint struct {
+	GroupReport tz_llm_client.GroupReport `json:"group_report"`
+	Rubs        float64                   `json:"rubs"`
+	Tokens      int64                     `json:"tokens"`
+}
+
+type step2Checkpoint struct {
+	Report *tz_llm_client.LlmReport `json:"report"`
+	Rubs   float64                  `json:"rubs"`
+	Tokens int64                    `json:"tokens"`
+}
+
+// step1GroupStage - ключ контрольной точки группы первого шага. Группы без ID различаются по номеру промта
+func step1GroupStage(groupID *int, index int) string {
+	if groupID != nil {
+		return fmt.Sprintf("%s:group:%d", StageStep1, *groupID)
+	}
+	return fmt.Sprintf("%s:promt:%d", StageStep1, index)
+}
+
+// isStep1GroupStage проверяет, что стадия - контрольная точка группы первого шага
+func isStep1GroupStage(stage string) bool {
+	return strings.HasPrefix(stage, StageStep1+":")
+}
+
+// restoreStep1Group возвращает сохранённый результат группы первого шага или nil, если его нет
+func (tz *Tz) restoreStep1Group(ctx context.Context, versionID uuid.UUID, stage string, log *slog.Logger) *step1GroupCheckpoint {
+	data, err := tz.repo.GetVersionCheckpoint(ctx, versionID, stage)
+	if err != nil {
+		if !errors.Is(err, repository.ErrCheckpointNotFound) {
+			log.Error("ошибка чтения контрольной точки группы: ", sl.Err(err), slog.String("stage", stage))
+		}
+		return nil
+	}
+
+	var result step1GroupCheckpoint
+	if err := json.Unmarshal(data, &result); err != nil {
+		log.Warn("повреждённая контрольная точка группы, группа будет обработана заново", sl.Err(err), slog.String("stage", stage))
+		return nil
+	}
+
+	return &result
+}
+
+// saveStep1Group сохраняет результат группы первого шага. Ошибка только логируется -
+// в худшем случае группа будет обработана повторно
+func (tz *Tz) saveStep1Group(ctx context.Context, versionID uuid.UUID, stage string, result llmRequestResult, log *slog.Logger) {
+	checkpoint := step1GroupCheckpoint{GroupReport: *result.groupReport}
+	if result.cost != nil {
+		checkpoint.Rubs = *result.cost
+	}
+	if result.tokens != nil {
+		checkpoint.Tokens = *result.tokens
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		log.Error("ошибка сериализации контрольной точки группы: ", sl.Err(err))
+		return
+	}
+
+	if err := tz.repo.SaveVersionCheckpoint(ctx, versionID, stage, data); err != nil {
+		log.Error("ошибка сохранения контрольной точки группы: ", sl.Err(err), slog.String("stage", stage))
+	}
+}
+
+// runStage возвращает сохранённый результат стадии, а если его нет - выполняет стадию
+// и сохраняет результат. Ошибка сохранения не прерывает обработку: в худшем случае
+// стадия будет выполнена повторно
+func runStage[T any](ctx context.Context, tz *Tz, versionID uuid.UUID, stage string, log *slog.Logger, run func() (*T, error)) (*T, error) {
+	log = log.With(slog.String("stage", stage))
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := tz.repo.GetVersionCheckpoint(ctx, versionID, stage)
+	switch {
+	case err == nil:
+		result := new(T)
+		if err := json.Unmarshal(data, result); err == nil {
+			log.Info("результат стадии восстановлен из контрольной точки")
+			tz.publishVersionEvent(ctx, versionID, VersionEvent{Type: VersionEventStageFinished, Stage: stage, Restored: true})
+			return result, nil
+		} else {
+			log.Warn("повреждённая контрольная точка, стадия будет выполнена заново", sl.Err(err))
+		}
+	case !errors.Is(err, repository.ErrCheckpointNotFound):
+		return nil, fmt.Errorf("ошибка чтения контрольной точки %s: %w", stage, err)
+	}
+
+	tz.publishVersionEvent(ctx, versionID, VersionEvent{Type: VersionEventStageStarted, Stage: stage})
+
+	result, err := run()
+	if err != nil {
+		return nil, err
+	}
+
+	tz.publishVersionEvent(ctx, versionID, VersionEvent{Type: VersionEventStageFinished, Stage: stage})
+
+	data, err = json.Marshal(result)
+	if err != nil {
+		log.Error("ошибка сериализации контрольной точки: ", sl.Err(err))
+		return result, nil
+	}
+
+	if err := tz.repo.SaveVersionCheckpoint(ctx, versionID, stage, data); err != nil {
+		log.Error("ошибка сохранения контрольной точки: ", sl.Err(err))
+	}
+
+	return result, nil
+}
+
+func (tz *Tz) convertDocumentToHtml(ctx context.Context, file []byte, filename string, format DocumentFormat, log *slog.Logger) (*htmlCheckpoint, error) {
+	// У PDF нет структуры Word - HTML собирается из текстового слоя постранично
+	if format == FormatPDF {
+		return tz.extractPdfHtml(ctx, file, filename)
+	}
+
+	if format.needsDocxConversion() {
+		// Конвертер выбирает фильтр LibreOffice по расширению, поэтому имя файла
+		// приводится к фактическому формату
+		newFile, err := tz.docToDocXConverterClient.Convert(file, documentBaseName(filename)+format.Extension())
+		if err != nil {
+			log.Error("ошибка при конвертации "+string(format)+" в docx: ", sl.Err(err))
+			return nil, errors.New("ошибка при конвертации " + string(format) + " в docx: " + err.Error())
+		}
+
+		file = newFile
+	}
+
+	//oldVersion := false
+
+	//html, err := tz.wordConverterClient2.Convert(file, filename)
+	//if err != nil {
+	//	log.Error("ошибка при обращении к wordParserClient2: ", sl.Err(err))
+	//} else {
+	//	respHtmlWithPlaceholdersStr, respParagraphsStr := paragraphsproc.ExtractParagraphs(html)
+	//	paragraphs = &respParagraphsStr
+	//	htmlWithPlaceholder = respHtmlWithPlaceholdersStr
+	//resultExtractParagraphs := word_parser2.ExtractParagraphs(html)
+	//paragraphs = &resultExtractParagraphs.Paragraphs
+	//htmlWithPlaceholder = resultExtractParagraphs.HTMLWithPlaceholder
+	//}
+	//if paragraphs == nil || *paragraphs == "" {
+	//	err = errors.New("failed to extract paragraphs")
+	//}
+	//if err != nil {
+	//log.Error("ошибка при обращении к wordParserClient2: ", sl.Err(err))
+	log.Info("пробуем старый word_parser")
+	//oldVersion = true
+	paragraphsFromWordConverterClient, _, wordConverterClientErr := tz.wordConverterClient.Convert(file, documentBaseName(filename)+FormatDOCX.Extension())
+	if wordConverterClientErr != nil {
+		return nil, errors.New("ошибка при обращении к wordParserClient: " + wordConverterClientErr.Error())
+	}
+
+	//}
+
+	log.Info("конвертация word файла в htmlText успешна")
+
+	return &htmlCheckpoint{Html: *paragraphsFromWordConverterClient}, nil
+}
+
+func (tz *Tz) convertHtmlToMarkdown(ctx context.Context, html string, tzName string, log *slog.Logger) (*markdown_service_client.ConvertResponse, error) {
+	markdownResponse, err := tz.markdownClient.Convert(html)
+	if err != nil {
+		return nil, errors.New("ошибка конвертации HTML в markdown: " + err.Error())
+	}
+
+	log.Info("конвертация HTML в markdown успешна")
+
+	markdownResponse.Markdown = RemoveBase64Images(markdownResponse.Markdown)
+
+	// Сохраняем markdown документ в S3
+	markdownFileName := tzName + "_" + GetCurrentDateTimeString()
+	err = tz.s3.SaveDocument(ctx, markdownFileName, []byte(markdownResponse.Markdown), "mds", ".md")
+	if err != nil {
+		log.Error("ошибка сохранения markdown файла в S3: ", sl.Err(err))
+		return nil, errors.New("ошибка сохранения markdown файла в S3: " + err.Error())
+	} else {
+		log.Info("markdown файл успешно сохранён в S3", slog.String("file_id", markdownFileName))
+	}
+	log.Info(fmt.Sprintf("получены дополнительные данные: message=%s, mappings_count=%d", markdownResponse.Message, len(markdownResponse.Mappings)))
+
+	return markdownResponse, nil
+}
+
+func (tz *Tz) generateStep1Promts(markdown string, ggID int) (*promptsCheckpoint, error) {
+	promts, schema, errorsDescrptions, err := tz.promtBuilderClient.GeneratePromts(markdown, ggID)
+	if err != nil {
+		return nil, errors.New("ошибка генерации промтов: " + err.Error())
+	}
+
+	if schema == nil {
+		return nil, errors.New("схема пустая")
+	}
+
+	return &promptsCheckpoint{
+		Promts:             promts,
+		Schema:             schema,
+		ErrorsDescriptions: errorsDescrptions,
+	}, nil
+}
+
+// runStep1 параллельно отправляет промты первого шага в LLM и собирает отчёты по группам.
+// Группы с сохранённым результатом в LLM не отправляются
+func (tz *Tz) runStep1(ctx context.Context, versionID uuid.UUID, promts *[]promt_builder.Promt, schema json.RawMessage, ggID int, log *slog.Logger) (*step1Checkpoint, error) {
+	groupReports := make([]tz_llm_client.GroupReport, 0, len(*promts))
+	allRubs := float64(0)
+	allTokens := int64(0)
+
+	// Создаем канал для результатов и waitgroup для синхронизации
+	resultChan := make(chan llmRequestResult, len(*promts))
+	var wg sync.WaitGroup
+
+	progressNumberSteps := len(*promts) + 1
+	progressOneStep := 100 / progressNumberSteps
+	progressSteps := 0
+	var progressStepsMu sync.RWMutex
+
+	cacheOpts := tz.llmCacheOptions(ggID)
+
+	// Запускаем горутины для параллельной обработки запросов
+	for i, v := range *promts {
+		stage := step1GroupStage(v.GroupId, i)
+
+		if restored := tz.restoreStep1Group(ctx, versionID, stage, log); restored != nil {
+			log.Info("результат группы восстановлен из контрольной точки", slog.String("stage", stage))
+			resultChan <- llmRequestResult{
+				groupReport: &restored.GroupReport,
+				cost:        &restored.Rubs,
+				tokens:      &restored.Tokens,
+				restored:    true,
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(messagesFromPromtBuilder *[]promt_builder.Message, schema json.RawMessage, groupID *int, groupName *string, stage string) {
+			defer wg.Done()
+
+			defer func() {
+				if r := recover(); r != nil {
+					log.Error("паника в goroutine: ", slog.Any("panic", r))
+					resultChan <- llmRequestResult{err: fmt.Errorf("паника в goroutine: %v", r)}
+				}
+			}()
+
+			messages := make([]struct {
+				Role    *string `json:"role"`
+				Content *string `json:"content"`
+			},
+				0)
+
+			for _, msg := range *messagesFromPromtBuilder {
+				messages = append(messages, struct {
+					Role    *string `json:"role"`
+					Content *string `json:"content"`
+				}{
+					Role:    msg.Role,
+					Content: msg.Content,
+				})
+			}
+
+			llmResp, err := tz.llmClient.SendMessage(ctx, messages, schema, 1, cacheOpts)
+			tz.recordLlmCall(ctx, versionID, 1, groupID, groupName, llmResp, err, log)
+			if err != nil {
+				log.Error("ошибка от llm request: ", sl.Err(err))
+				resultChan <- llmRequestResult{err: err}
+				return
+			}
+
+			if llmResp.Result == nil {
+				log.Error("ошибка: в ответе от llm поле result пустое")
+				resultChan <- llmRequestResult{err: fmt.Errorf("пустое поле result в ответе от llm")}
+				return
+			}
+
+			result := llmRequestResult{
+				stage:       stage,
+				groupReport: llmResp.Result,
+				ResultRaw:   string(llmResp.ResultRaw),
+				duration:    *llmResp.Duration,
+			}
+
+			// Ответ из кэша ничего не стоит - в затраты он не входит, как и в GetVersionCostBreakdown
+			if llmResp.Cost != nil && !llmResp.CacheHit {
+				result.cost = llmResp.Cost.TotalRub
+			}
+
+			if llmResp.Usage != nil && llmResp.Usage.TotalTokens != nil && !llmResp.CacheHit {
+				tokens := int64(*llmResp.Usage.TotalTokens)
+				result.tokens = &tokens
+			}
+
+			resultChan <- result
+		}(v.Messages, schema, v.GroupId, v.GroupName, stage)
+	}
+
+	// Закрываем канал после завершения всех горутин
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	// Собираем результаты
+	expectedResults := len(*promts)
+	receivedResults := 0
+	hasErrors := false
+	var firstError error
+
+	for result := range resultChan {
+		progressStepsMu.Lock()
+		progressSteps += 1
+		progressStepsMu.Unlock()
+
+		progressStepsMu.RLock()
+		go func() {
+			UpdateVersionProgressErr := tz.repo.UpdateVersionProgress(ctx, versionID, progressSteps*progressOneStep)
+			if UpdateVersionProgressErr != nil {
+				log.Error("Error in UpdateVersionProgress: ", sl.Err(UpdateVersionProgressErr))
+			} else {
+				log.Debug("прогресс обновлён")
+			}
+		}()
+		progressStepsMu.RUnlock()
+
+		receivedResults++
+
+		if result.err != nil {
+			log.Error("ошибка в результате: ", sl.Err(result.err))
+			hasErrors = true
+			if firstError == nil {
+				firstError = result.err
+			}
+			continue
+		}
+
+		if result.groupReport != nil {
+			groupReports = append(groupReports, *result.groupReport)
+			if !result.restored {
+				tz.saveStep1Group(ctx, versionID, result.stage, result, log)
+			}
+		}
+
+		if result.cost != nil {
+			allRubs += *result.cost
+		}
+
+		if result.tokens != nil {
+			allTokens += *result.tokens
+		}
+
+		tz.publishVersionEvent(ctx, versionID, VersionEvent{
+			Type:       VersionEventLlmGroupProgress,
+			GroupIndex: receivedResults,
+			GroupTotal: expectedResults,
+		})
+		tz.publishVersionEvent(ctx, versionID, VersionEvent{
+			Type:   VersionEventCost,
+			Rubs:   allRubs,
+			Tokens: allTokens,
+		})
+
+		if receivedResults >= expectedResults {
+			break
+		}
+	}
+
+	// Проверяем, были ли критические ошибки
+	if hasErrors && firstError != nil {
+		return nil, errors.New("ошибка при обработке запросов к LLM: " + firstError.Error())
+	}
+
+	SortGroupReports(groupReports)
+
+	return &step1Checkpoint{
+		GroupReports: groupReports,
+		Rubs:         allRubs,
+		Tokens:       allTokens,
+	}, nil
+}
+
+// runStep2 сводит отчёты по группам в итоговый отчёт по разделам
+func (tz *Tz) runStep2(ctx context.Context, versionID uuid.UUID, groupReports []tz_llm_client.GroupReport, markdown string, ggID int, log *slog.Logger) (*step2Checkpoint, error) {
+	rawGroupAnalizeResult, err := json.Marshal(groupReports)
+	if err != nil {
+		return nil, errors.New("ошибка rawGroupAnalizeResult: " + err.Error())
+	}
+
+	messagesFromPromtBuilder, step2schema, GenerateStep2PromtsErr := tz.promtBuilderClient.GenerateStep2Promts(string(rawGroupAnalizeResult), markdown)
+	if GenerateStep2PromtsErr != nil {
+		log.Error("GenerateStep2Promts error: " + GenerateStep2PromtsErr.Error())
+
+		return nil, errors.New("ошибка messagesFromPromtBuilder: " + GenerateStep2PromtsErr.Error())
+	}
+
+	messages := make([]struct {
+		Role    *string `json:"role"`
+		Content *string `json:"content"`
+	},
+		0)
+
+	for _, msg := range *messagesFromPromtBuilder {
+		messages = append(messages, struct {
+			Role    *string `json:"role"`
+			Content *string `json:"content"`
+		}{
+			Role:    msg.Role,
+			Content: msg.Content,
+		})
+	}
+
+	step2LlmResponse, step2LlmError := tz.llmClient.SendMessage(ctx, messages, step2schema, 2, tz.llmCacheOptions(ggID))
+	tz.recordLlmCall(ctx, versionID, 2, nil, nil, step2LlmResponse, step2LlmError, log)
+	if step2LlmError != nil {
+		log.Error("step2Llm error: " + step2LlmError.Error())
+
+		return nil, errors.New("ошибка step2Llm send message: " + step2LlmError.Error())
+	}
+
+	if step2LlmResponse.ResultStep2 == nil {
+		return nil, errors.New("ошибка step2Llm: пустое поле result в ответе от llm")
+	}
+
+	result := &step2Checkpoint{Report: step2LlmResponse.ResultStep2}
+
+	// Ответ из кэша ничего не стоит
+	if step2LlmResponse.CacheHit {
+		return result, nil
+	}
+
+	if step2LlmResponse.Cost != nil && step2LlmResponse.Cost.TotalRub != nil {
+		result.Rubs = *step2LlmResponse.Cost.TotalRub
+	}
+
+	if step2LlmResponse.Usage != nil && step2LlmResponse.Usage.TotalTokens != nil {
+		result.Tokens = int64(*step2LlmResponse.Usage.TotalTokens)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,192 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProcessingJobOptions - параметры обработки задач из очереди проверок
+type ProcessingJobOptions struct {
+	LeaseDuration time.Duration
+	RetryDelay    time.Duration
+	MaxAttempts   int
+	JobTimeout    time.Duration
+}
+
+// enqueueVersionProcessing ставит проверку версии в очередь. Сам файл в задаче не хранится -
+// обработчик забирает его из бакета docs по ключу originalFileKey
+func (tz *Tz) enqueueVersionProcessing(ctx context.Context, versionID uuid.UUID, userID uuid.UUID, filename string, originalFileKey string) error {
+	return tz.repo.CreateProcessingJob(ctx, &modelrepo.CreateProcessingJobRequest{
+		ID:              uuid.New(),
+		VersionID:       versionID,
+		UserID:          userID,
+		Filename:        filename,
+		OriginalFileKey: originalFileKey,
+	})
+}
+
+// ProcessNextJob захватывает следующую задачу из очереди и выполняет проверку.
+// Возвращает false, если очередь пуста
+func (tz *Tz) ProcessNextJob(ctx context.Context, opts ProcessingJobOptions) (bool, error) {
+	const op = "Tz.ProcessNextJob"
+
+	job, err := tz.repo.ClaimProcessingJob(ctx, time.Now().Add(opts.LeaseDuration))
+	if err != nil {
+		if errors.Is(err, repository.ErrProcessingJobNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("jobID", job.ID.String()),
+		slog.String("versionID", job.VersionID.String()),
+		slog.String("userID", job.UserID.String()),
+		slog.Int("attempt", job.Attempts),
+	)
+
+	log.Info("processing job claimed")
+
+	// Задача могла остаться "running" после падения сервиса на последней попытке
+	if job.Attempts > opts.MaxAttempts {
+		tz.failProcessingJob(job, "превышено количество попыток обработки", log)
+		return true, nil
+	}
+
+	// Замечания не входят в контрольные точки и пересоздаются на последней стадии,
+	// поэтому остатки предыдущей попытки (или перезапуска через RetryVersion) удаляем всегда
+	if err := tz.repo.DeleteVersionFindings(ctx, job.VersionID); err != nil {
+		log.Error("failed to delete findings of previous attempt: ", sl.Err(err))
+	}
+	if job.Attempts > 1 {
+		if err := tz.repo.UpdateVersionProgress(ctx, job.VersionID, 3); err != nil {
+			log.Error("failed to reset version progress: ", sl.Err(err))
+		}
+	}
+
+	jobCtx, cancelJob := context.WithCancelCause(ctx)
+	jobCtx, cancelTimeout := context.WithTimeout(jobCtx, opts.JobTimeout)
+	tz.registerActiveJob(job.VersionID, cancelJob)
+	stopLease := tz.keepProcessingJobLease(jobCtx, job.ID, opts.LeaseDuration, cancelJob, log)
+	err = tz.runProcessingJob(jobCtx, job)
+	stopLease()
+	cancelled := errors.Is(context.Cause(jobCtx), ErrVersionCancelled) || errors.Is(err, ErrVersionCancelled)
+	tz.unregisterActiveJob(job.VersionID)
+	cancelTimeout()
+	cancelJob(nil)
+
+	// Статус версии и квоту уже обновил CancelVersion
+	if cancelled {
+		log.Info("processing job cancelled")
+		return true, nil
+	}
+
+	if err == nil {
+		if err := tz.repo.CompleteProcessingJob(context.Background(), job.ID); err != nil {
+			log.Error("failed to mark job completed: ", sl.Err(err))
+		}
+		log.Info("processing job completed")
+		return true, nil
+	}
+
+	// Сервис останавливается - возвращаем задачу в очередь, не расходуя попытку
+	if ctx.Err() != nil {
+		log.Warn("processing interrupted by shutdown, releasing job", sl.Err(err))
+		if err := tz.repo.ReleaseProcessingJob(context.Background(), job.ID); err != nil {
+			log.Error("failed to release job: ", sl.Err(err))
+		}
+		return true, nil
+	}
+
+	if job.Attempts < opts.MaxAttempts {
+		runAfter := time.Now().Add(opts.RetryDelay * time.Duration(job.Attempts))
+		log.Warn("processing attempt failed, job will be retried",
+			sl.Err(err),
+			slog.Time("runAfter", runAfter))
+		if err := tz.repo.RetryProcessingJob(context.Background(), job.ID, err.Error(), runAfter); err != nil {
+			log.Error("failed to requeue job: ", sl.Err(err))
+		}
+		return true, nil
+	}
+
+	tz.failProcessingJob(job, err.Error(), log)
+	return true, nil
+}
+
+func (tz *Tz) runProcessingJob(ctx context.Context, job *modelrepo.ProcessingJob) error {
+	file, err := tz.s3.GetDocument(ctx, "docs", job.OriginalFileKey)
+	if err != nil {
+		return fmt.Errorf("ошибка получения оригинального файла из S3: %w", err)
+	}
+
+	format, err := DetectDocumentFormat(file)
+	if err != nil {
+		return err
+	}
+
+	ggID, err := tz.versionGgID(ctx, job.VersionID)
+	if err != nil {
+		return err
+	}
+
+	return tz.ProcessTz(ctx, file, job.Filename, job.VersionID, format, documentBaseName(job.Filename), job.UserID, ggID)
+}
+
+// failProcessingJob окончательно проваливает задачу: версия переводится в статус "error",
+// проверка возвращается пользователю, в Telegram уходит уведомление
+func (tz *Tz) failProcessingJob(job *modelrepo.ProcessingJob, errorMsg string, log *slog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := tz.repo.FailProcessingJob(ctx, job.ID, errorMsg); err != nil {
+		log.Error("failed to mark job failed: ", sl.Err(err))
+	}
+
+	tz.handleProcessingError(ctx, job.VersionID, job.UserID, errorMsg, log)
+}
+
+// keepProcessingJobLease периодически продлевает аренду задачи, пока идёт обработка.
+// Если процесс упадёт, аренда истечёт и задачу подхватит другой обработчик. Если задача
+// перестала быть "running" (её отменили через другой экземпляр сервиса), обработка прерывается
+func (tz *Tz) keepProcessingJobLease(ctx context.Context, jobID uuid.UUID, leaseDuration time.Duration, cancelJob context.CancelCauseFunc, log *slog.Logger) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(leaseDuration / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := tz.repo.ExtendProcessingJobLease(ctx, jobID, time.Now().Add(leaseDuration))
+				if errors.Is(err, repository.ErrProcessingJobNotFound) {
+					log.Info("job is no longer running, stopping processing")
+					cancelJob(ErrVersionCancelled)
+					return
+				}
+				if err != nil {
+					log.Error("failed to extend job lease: ", sl.Err(err))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,241 @@
+package tzservice
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	// quoteGroundedThreshold - минимальная похожесть цитаты на текст документа,
+	// при которой цитата считается подтверждённой
+	quoteGroundedThreshold = 0.6
+	// quoteHallucinatedThreshold - ниже этой похожести цитата считается выдуманной моделью,
+	// и замечание отбрасывается. Между порогами замечание сохраняется с системным комментарием
+	quoteHallucinatedThreshold = 0.3
+)
+
+// ungroundedSystemComment - системный комментарий замечания, цитата которого не подтверждена текстом документа
+const ungroundedSystemComment = "Цитата не подтверждена текстом документа"
+
+// QuoteGroundingStats - итоги сверки цитат замечаний с markdown документа
+type QuoteGroundingStats struct {
+	Grounded       int
+	LinesCorrected int
+	Flagged        int
+	Dropped        int
+}
+
+// groundingDocument - строки markdown документа, нормализованные для сравнения с цитатами.
+// Номера строк в замечаниях LLM начинаются с 1
+type groundingDocument struct {
+	lines []string
+	words []map[string]struct{}
+}
+
+func newGroundingDocument(markdown string) *groundingDocument {
+	rawLines := strings.Split(markdown, "\n")
+	doc := &groundingDocument{
+		lines: make([]string, len(rawLines)),
+		words: make([]map[string]struct{}, len(rawLines)),
+	}
+
+	for i, line := range rawLines {
+		doc.lines[i] = normalizeGroundingText(line)
+		doc.words[i] = make(map[string]struct{})
+		for _, word := range strings.Fields(doc.lines[i]) {
+			doc.words[i][word] = struct{}{}
+		}
+	}
+
+	return doc
+}
+
+// normalizeGroundingText приводит строку markdown или цитату к виду, в котором их сравнивает
+// calculateTextSimilarity: без разметки markdown, разделителей ячеек таблиц и лишних пробелов
+func normalizeGroundingText(s string) string {
+	s = MarcdownCleaning(strings.TrimSpace(s))
+	s = strings.ReplaceAll(s, "|", " ")
+	return normalizeText(s)
+}
+
+// groundInvalidInstances сверяет цитату каждого замечания с markdown документа в пределах
+// заявленных моделью строк. Если цитата найдена в другом месте документа, номера строк
+// исправляются. Замечания с выдуманными цитатами отбрасываются, с сомнительными - помечаются
+// системным комментарием. Всем оставшимся замечаниям выставляется GroundingScore
+func groundInvalidInstances(invalidErrors *[]OutInvalidError, markdown string) (*[]OutInvalidError, QuoteGroundingStats) {
+	stats := QuoteGroundingStats{}
+	if invalidErrors == nil || len(*invalidErrors) == 0 || strings.TrimSpace(markdown) == "" {
+		return invalidErrors, stats
+	}
+
+	doc := newGroundingDocument(markdown)
+
+	grounded := make([]OutInvalidError, 0, len(*invalidErrors))
+	for i := range *invalidErrors {
+		instance := (*invalidErrors)[i]
+
+		quotes := make([]string, 0)
+		if instance.QuoteLines != nil && len(*instance.QuoteLines) > 0 {
+			for _, quoteLine := range *instance.QuoteLines {
+				quotes = append(quotes, normalizeGroundingText(quoteLine))
+			}
+		} else {
+			quotes = append(quotes, normalizeGroundingText(instance.Quote))
+		}
+
+		score := 0.0
+		if instance.StartLineNumber != nil && instance.EndLineNumber != nil {
+			score = doc.scoreInRange(quotes, *instance.StartLineNumber, *instance.EndLineNumber)
+		}
+
+		if score < quoteGroundedThreshold {
+			foundScore, startLine, endLine := doc.find(quotes)
+			if foundScore >= quoteGroundedThreshold {
+				instance.StartLineNumber = &startLine
+				instance.EndLineNumber = &endLine
+				stats.LinesCorrected++
+			}
+			score = math.Max(score, foundScore)
+		}
+
+		switch {
+		case score < quoteHallucinatedThreshold:
+			stats.Dropped++
+			continue
+		case score < quoteGroundedThreshold:
+			instance.SystemComment = ungroundedSystemComment
+			stats.Flagged++
+		default:
+			stats.Grounded++
+		}
+
+		instance.GroundingScore = &score
+		grounded = append(grounded, instance)
+	}
+
+	return &grounded, stats
+}
+
+// scoreInRange возвращает среднюю похожесть частей цитаты на текст строк [startLine, endLine]
+func (d *groundingDocument) scoreInRange(quotes []string, startLine, endLine int) float64 {
+	if endLine < startLine {
+		startLine, endLine = endLine, startLine
+	}
+
+	startLine = max(startLine, 1)
+	endLine = min(endLine, len(d.lines))
+	if startLine > endLine {
+		return 0
+	}
+
+	text := strings.Join(d.lines[startLine-1:endLine], " ")
+
+	total := 0.0
+	for _, quote := range quotes {
+		total += quoteSimilarityInText(quote, text)
+	}
+
+	return total / float64(len(quotes))
+}
+
+// find ищет каждую часть цитаты по всему документу (в строке или на стыке двух соседних строк)
+// и возвращает среднюю похожесть и диапазон строк, покрывающий найденные части
+func (d *groundingDocument) find(quotes []string) (float64, int, int) {
+	total := 0.0
+	startLine, endLine := 0, 0
+	for _, quote := range quotes {
+		score, first, last := d.findQuote(quote)
+		total += score
+		if score < quoteGroundedThreshold {
+			continue
+		}
+
+		if startLine == 0 || first < startLine {
+			startLine = first
+		}
+		if last > endLine {
+			endLine = last
+		}
+	}
+
+	if startLine == 0 {
+		return total / float64(len(quotes)), 0, 0
+	}
+
+	return total / float64(len(quotes)), startLine, endLine
+}
+
+func (d *groundingDocument) findQuote(quote string) (float64, int, int) {
+	quoteWords := strings.Fields(quote)
+	if len(quoteWords) == 0 {
+		return 0, 0, 0
+	}
+
+	// Похожесть не может превысить долю слов цитаты, встречающихся в тексте, поэтому
+	// строки, где таких слов заведомо мало, не сравниваются
+	minHits := int(math.Ceil(quoteHallucinatedThreshold * float64(len(quoteWords))))
+
+	bestScore, bestStart, bestEnd := 0.0, 0, 0
+	for i := range d.lines {
+		hits, pairHits := 0, 0
+		for _, word := range quoteWords {
+			if _, ok := d.words[i][word]; ok {
+				hits++
+				pairHits++
+			} else if i+1 < len(d.lines) {
+				if _, ok := d.words[i+1][word]; ok {
+					pairHits++
+				}
+			}
+		}
+
+		if hits >= minHits {
+			if score := quoteSimilarityInText(quote, d.lines[i]); score > bestScore {
+				bestScore, bestStart, bestEnd = score, i+1, i+1
+			}
+		}
+
+		if hits > 0 && pairHits > hits && pairHits >= minHits {
+			if score := quoteSimilarityInText(quote, d.lines[i]+" "+d.lines[i+1]); score > bestScore {
+				bestScore, bestStart, bestEnd = score, i+1, i+2
+			}
+		}
+
+		if bestScore == 1.0 {
+			break
+		}
+	}
+
+	return bestScore, bestStart, bestEnd
+}
+
+// quoteSimilarityInText - нечёткий поиск подстроки: наибольшая похожесть цитаты
+// на фрагмент текста той же длины в словах
+func quoteSimilarityInText(quote, text string) float64 {
+	if quote == "" || text == "" {
+		return 0
+	}
+
+	if strings.Contains(text, quote) {
+		return 1.0
+	}
+
+	quoteWords := strings.Fields(quote)
+	textWords := strings.Fields(text)
+	if len(textWords) <= len(quoteWords) {
+		return calculateTextSimilarity(quote, text)
+	}
+
+	best := 0.0
+	for i := 0; i+len(quoteWords) <= len(textWords); i++ {
+		similarity := calculateTextSimilarity(quote, strings.Join(textWords[i:i+len(quoteWords)], " "))
+		if similarity > best {
+			best = similarity
+			if best == 1.0 {
+				break
+			}
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,80 @@
+package tzservice
+
+import (
+	"testing"
+)
+
+const groundingMarkdown = `# Техническое задание
+
+Срок поставки оборудования определяется отдельно.
+
+**Требования к надежности** не предъявляются.
+
+| Параметр | Значение |
+| Гарантийный срок | не менее 12 месяцев |`
+
+func TestGroundInvalidInstances(t *testing.T) {
+	tableQuoteLines := []string{"Гарантийный срок", "не менее 12 месяцев"}
+	start1, end1, start2, end2 := 3, 3, 1, 1
+	start4, end4, start5, end5 := 3, 3, 3, 3
+
+	invalidErrors := []OutInvalidError{
+		{HtmlIDStr: "1", Quote: "Срок поставки оборудования определяется отдельно", StartLineNumber: &start1, EndLineNumber: &end1},
+		// Цитата есть в документе, но модель ошиблась строкой
+		{HtmlIDStr: "2", Quote: "Требования к надежности не предъявляются", StartLineNumber: &start2, EndLineNumber: &end2},
+		// Многострочная цитата из таблицы без номеров строк
+		{HtmlIDStr: "3", Quote: "Гарантийный срок | не менее 12 месяцев", QuoteLines: &tableQuoteLines},
+		// Цитата, похожая на текст документа лишь частично
+		{HtmlIDStr: "4", Quote: "Срок поставки оборудования согласуется с заказчиком", StartLineNumber: &start4, EndLineNumber: &end4},
+		// Цитаты нет в документе
+		{HtmlIDStr: "5", Quote: "Исполнитель обязан обучить персонал заказчика работе с системой", StartLineNumber: &start5, EndLineNumber: &end5},
+	}
+
+	grounded, stats := groundInvalidInstances(&invalidErrors, groundingMarkdown)
+
+	if stats.Grounded != 3 || stats.LinesCorrected != 2 || stats.Flagged != 1 || stats.Dropped != 1 {
+		t.Fatalf("неожиданные итоги сверки: %+v", stats)
+	}
+
+	if len(*grounded) != 4 {
+		t.Fatalf("ожидалось 4 замечания после сверки, получено %d", len(*grounded))
+	}
+
+	byID := make(map[string]OutInvalidError)
+	for _, instance := range *grounded {
+		byID[instance.HtmlIDStr] = instance
+	}
+
+	if _, ok := byID["5"]; ok {
+		t.Errorf("замечание с выдуманной цитатой не отброшено")
+	}
+
+	if score := byID["1"].GroundingScore; score == nil || *score != 1.0 {
+		t.Errorf("ожидалась полная похожесть цитаты 1, получено %v", score)
+	}
+
+	if instance := byID["2"]; *instance.StartLineNumber != 5 || *instance.EndLineNumber != 5 {
+		t.Errorf("строки цитаты 2 не исправлены: %d-%d", *instance.StartLineNumber, *instance.EndLineNumber)
+	}
+
+	if instance := byID["3"]; instance.StartLineNumber == nil || *instance.StartLineNumber != 8 || *instance.EndLineNumber != 8 {
+		t.Errorf("не определены строки табличной цитаты 3: %+v", instance)
+	}
+
+	if instance := byID["4"]; instance.SystemComment != ungroundedSystemComment || *instance.StartLineNumber != 3 {
+		t.Errorf("сомнительная цитата 4 не помечена: %+v", instance)
+	}
+}
+
+func TestQuoteSimilarityInText(t *testing.T) {
+	text := normalizeGroundingText("Поставщик обязан предоставить документацию на оборудование в течение 10 дней")
+
+	if similarity := quoteSimilarityInText(normalizeGroundingText("предоставить документацию на оборудование"), text); similarity != 1.0 {
+		t.Errorf("ожидалось точное вхождение, получено %f", similarity)
+	}
+
+	similarity := quoteSimilarityInText(normalizeGroundingText("предоставить техническую документацию на оборудование"), text)
+	if similarity < quoteGroundedThreshold || similarity == 1.0 {
+		t.Errorf("неожиданная похожесть неточной цитаты: %f", similarity)
+	}
+}
@@ -0,0 +1,124 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// RetryVersion перезапускает упавшую проверку версии. Стадии до fromStage берутся из
+// контрольных точек, fromStage и последующие выполняются заново. Если fromStage пустой
+// (или для более ранней стадии нет результата), обработка продолжается с первой стадии
+// без сохранённого результата.
+// Возвращает стадию, с которой фактически продолжится обработка
+func (tz *Tz) RetryVersion(ctx context.Context, versionID uuid.UUID, fromStage string) (string, error) {
+	const op = "Tz.RetryVersion"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("versionID", versionID.String()),
+		slog.String("fromStage", fromStage),
+	)
+
+	if fromStage != "" && !slices.Contains(PipelineStages, fromStage) {
+		return "", ErrInvalidStage
+	}
+
+	version, err := tz.repo.GetVersion(ctx, versionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionNotFound) {
+			return "", ErrVersionNotFound
+		}
+		log.Error("failed to get version: ", sl.Err(err))
+		return "", fmt.Errorf("failed to get version: %w", err)
+	}
+
+	if version.Status != "error" && version.Status != "cancelled" {
+		return "", ErrVersionNotRetryable
+	}
+
+	ts, err := tz.repo.GetTechnicalSpecification(ctx, version.TechnicalSpecificationID)
+	if err != nil {
+		log.Error("failed to get technical specification: ", sl.Err(err))
+		return "", fmt.Errorf("failed to get technical specification: %w", err)
+	}
+
+	savedStages, err := tz.repo.GetVersionCheckpointStages(ctx, versionID)
+	if err != nil {
+		log.Error("failed to get checkpoint stages: ", sl.Err(err))
+		return "", fmt.Errorf("failed to get checkpoint stages: %w", err)
+	}
+
+	resumeStage := firstStageWithoutCheckpoint(savedStages)
+	if fromStage != "" && slices.Index(PipelineStages, fromStage) < slices.Index(PipelineStages, resumeStage) {
+		resumeStage = fromStage
+	}
+
+	// Результаты последующих стадий зависят от перезапускаемой, поэтому удаляются вместе с ней
+	staleStages := append([]string{}, PipelineStages[slices.Index(PipelineStages, resumeStage):]...)
+
+	// Результаты групп первого шага сохраняются, только если обработка просто продолжается
+	// с первого шага. При явном перезапуске шага или более ранней стадии они устарели
+	if slices.Index(PipelineStages, resumeStage) < slices.Index(PipelineStages, StageStep1) || fromStage == StageStep1 {
+		for _, stage := range savedStages {
+			if isStep1GroupStage(stage) {
+				staleStages = append(staleStages, stage)
+			}
+		}
+	}
+	if err := tz.repo.DeleteVersionCheckpoints(ctx, versionID, staleStages); err != nil {
+		log.Error("failed to delete checkpoints: ", sl.Err(err))
+		return "", fmt.Errorf("failed to delete checkpoints: %w", err)
+	}
+
+	// При переводе в "error" проверка была возвращена пользователю - списываем её снова
+	organizationID, err := tz.incrementInspectionsForUser(ctx, ts.UserID, log)
+	if err != nil {
+		return "", err
+	}
+
+	// Владелец мог сменить организацию: при следующей отмене или ошибке проверка
+	// возвращается в ту квоту, из которой списана сейчас
+	if err := tz.repo.SetVersionChargedOrganizationID(ctx, versionID, organizationID); err != nil {
+		log.Error("failed to set version charged organization: ", sl.Err(err))
+		tz.decrementInspectionsForUser(ctx, ts.UserID, organizationID, log)
+		return "", fmt.Errorf("failed to set version charged organization: %w", err)
+	}
+
+	if err := tz.repo.UpdateVersionStatus(ctx, versionID, "in_progress", 3); err != nil {
+		log.Error("failed to update version status: ", sl.Err(err))
+		tz.decrementInspectionsForUser(ctx, ts.UserID, organizationID, log)
+		return "", fmt.Errorf("failed to update version status: %w", err)
+	}
+
+	if err := tz.repo.ResetProcessingJob(ctx, versionID); err != nil {
+		log.Error("failed to reset processing job: ", sl.Err(err))
+		tz.updateVersionWithError(ctx, versionID, "error")
+		tz.decrementInspectionsForUser(ctx, ts.UserID, organizationID, log)
+		if errors.Is(err, repository.ErrProcessingJobNotFound) {
+			return "", ErrVersionNotRetryable
+		}
+		return "", fmt.Errorf("failed to reset processing job: %w", err)
+	}
+
+	log.Info("version processing requeued", slog.String("resumeStage", resumeStage))
+
+	return resumeStage, nil
+}
+
+// firstStageWithoutCheckpoint возвращает первую по порядку стадию, для которой нет сохранённого результата
+func firstStageWithoutCheckpoint(savedStages []string) string {
+	for _, stage := range PipelineStages {
+		if !slices.Contains(savedStages, stage) {
+			return stage
+		}
+	}
+
+	return StageReport
+}
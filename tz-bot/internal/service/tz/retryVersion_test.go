@@ -0,0 +1,52 @@
+package tzservice
+
+import "testing"
+
+func TestFirstStageWithoutCheckpoint(t *testing.T) {
+	cases := []struct {
+		saved []string
+		want  string
+	}{
+		{saved: nil, want: StageHTML},
+		{saved: []string{StageHTML, StageMarkdown}, want: StagePrompts},
+		{saved: []string{StageHTML, StagePrompts, StageStep1}, want: StageMarkdown},
+		{saved: []string{StageHTML, StageMarkdown, StagePrompts, StageStep1, StageStep2}, want: StageReport},
+	}
+
+	for _, c := range cases {
+		if got := firstStageWithoutCheckpoint(c.saved); got != c.want {
+			t.Errorf("firstStageWithoutCheckpoint(%v) = %s, ожидалось %s", c.saved, got, c.want)
+		}
+	}
+}
+
+func TestStep1GroupStage(t *testing.T) {
+	groupID := 7
+
+	cases := []struct {
+		stage string
+		want  string
+	}{
+		{stage: step1GroupStage(&groupID, 0), want: "step1:group:7"},
+		{stage: step1GroupStage(nil, 3), want: "step1:promt:3"},
+	}
+
+	for _, c := range cases {
+		if c.stage != c.want {
+			t.Errorf("step1GroupStage = %s, ожидалось %s", c.stage, c.want)
+		}
+		if !isStep1GroupStage(c.stage) {
+			t.Errorf("isStep1GroupStage(%s) = false", c.stage)
+		}
+	}
+
+	if isStep1GroupStage(StageStep1) {
+		t.Error("isStep1GroupStage(step1) = true, стадия целиком не является группой")
+	}
+
+	// Контрольные точки групп не считаются стадиями конвейера
+	saved := []string{StageHTML, StageMarkdown, StagePrompts, step1GroupStage(&groupID, 0)}
+	if got := firstStageWithoutCheckpoint(saved); got != StageStep1 {
+		t.Errorf("firstStageWithoutCheckpoint(%v) = %s, ожидалось %s", saved, got, StageStep1)
+	}
+}
@@ -29,9 +29,17 @@ type Tz struct {
 	telegramClient           *telegramclient.Client
 	s3                       *s3minio.MinioRepository
 	repo                     Repository
-	ggID                     int
+	ggID                     int // группа ошибок на случай, если в check_profiles нет профиля по умолчанию
 	useLlmCache              bool
+	uploadLimits             UploadLimits
 	mu                       sync.RWMutex
+
+	// activeJobs - отмена контекстов проверок, которые сейчас выполняются в этом процессе
+	activeJobs   map[uuid.UUID]context.CancelCauseFunc
+	activeJobsMu sync.Mutex
+
+	// versionEvents раздаёт подписчикам WatchVersion уведомления о новых событиях
+	versionEvents *versionEventsHub
 }
 
 type ReportGeneratorClient interface {
@@ -48,4 +56,33 @@ var (
 	ErrConvertWordFile  = errors.New("error convert word file")
 	ErrLlmAnalyzeFile   = errors.New("error in neural network file analysis")
 	ErrGenerateDocxFile = errors.New("error in generate docx file")
+
+	ErrTechnicalSpecificationNotFound = errors.New("technical specification not found")
+	ErrAccessDenied                   = errors.New("access denied")
+	ErrDuplicateVersion               = errors.New("version with this number already exists")
+	ErrVersionNotFound                = errors.New("version not found")
+	ErrVersionNotCompleted            = errors.New("version processing is not completed")
+	ErrVersionsFromDifferentSpecs     = errors.New("versions belong to different technical specifications")
+	ErrVersionNotRetryable            = errors.New("only failed or cancelled versions can be retried")
+	ErrVersionNotCancellable          = errors.New("only versions in progress can be cancelled")
+	ErrVersionCancelled               = errors.New("version processing cancelled")
+	ErrInvalidStage                   = errors.New("invalid processing stage")
+	ErrEmptyCacheFilter               = errors.New("at least one cache filter must be set")
+	ErrAnnotatedHtmlUnavailable       = errors.New("version has no html to build annotated document")
+	ErrUnsupportedExportFormat        = errors.New("unsupported export format, expected json, csv or xlsx")
+	ErrUnsupportedFileFormat          = errors.New("unsupported file format, expected docx, doc, pdf, odt or rtf")
+	ErrScannedDocument                = errors.New("pdf has no text layer")
+	ErrUploadValidation               = errors.New("uploaded file failed validation")
+	ErrCheckProfileNotFound           = errors.New("check profile not found")
+	ErrCheckProfileExists             = errors.New("check profile with this name already exists")
+	ErrInvalidCheckProfile            = errors.New("invalid check profile")
+	ErrDefaultCheckProfile            = errors.New("default check profile cannot be deleted")
+	ErrErrorCatalogEntryNotFound      = errors.New("error catalog entry not found")
+	ErrInvalidErrorCatalogQuery       = errors.New("error catalog entry id or code is required")
+	ErrInstanceNotFound               = errors.New("error instance not found")
+	ErrShareLinkNotFound              = errors.New("share link not found")
+	ErrShareLinkExpired               = errors.New("share link expired or revoked")
+	ErrInvalidShareLink               = errors.New("invalid share link")
+	ErrInvalidVersionFileKind         = errors.New("invalid version file kind, expected original or report")
+	ErrVersionFileUnavailable         = errors.New("version file is not available yet")
 )
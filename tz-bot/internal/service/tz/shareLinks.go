@@ -0,0 +1,228 @@
+package tzservice
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Права ссылки на просмотр: view - результат проверки, export - выгрузка замечаний и
+// документа с комментариями
+const (
+	SharePermissionView   = "view"
+	SharePermissionExport = "export"
+)
+
+// maxShareLinkTTL - наибольший срок действия ссылки
+const maxShareLinkTTL = 90 * 24 * time.Hour
+
+// newShareLinkToken возвращает случайный токен ссылки для URL
+func newShareLinkToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// shareLinkTokenHash возвращает sha256 токена. В базе хранится только он
+func shareLinkTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// sharePermissions проверяет права ссылки и убирает повторы. Без прав ссылка даёт только просмотр,
+// выгрузка без просмотра не имеет смысла и тоже добавляет view
+func sharePermissions(permissions []string) ([]string, error) {
+	result := []string{SharePermissionView}
+	for _, permission := range permissions {
+		switch permission {
+		case SharePermissionView:
+		case SharePermissionExport:
+			if !slices.Contains(result, permission) {
+				result = append(result, permission)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown permission %q", ErrInvalidShareLink, permission)
+		}
+	}
+	return result, nil
+}
+
+// shareLinkActive - ссылка не отозвана и не истекла
+func shareLinkActive(link *modelrepo.ShareLink, now time.Time) bool {
+	return link.RevokedAt == nil && now.Before(link.ExpiresAt)
+}
+
+// CreateShareLink создаёт ссылку на просмотр версии и возвращает её вместе с токеном.
+// Токен больше нигде не хранится и не может быть получен повторно
+func (tz *Tz) CreateShareLink(ctx context.Context, versionID uuid.UUID, expiresAt time.Time, permissions []string, caller Caller) (*modelrepo.ShareLink, string, error) {
+	const op = "Tz.CreateShareLink"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("versionID", versionID.String()),
+		slog.String("userID", caller.UserID.String()),
+	)
+
+	if err := tz.AuthorizeVersion(ctx, versionID, caller, ""); err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	if !expiresAt.After(now) || expiresAt.Sub(now) > maxShareLinkTTL {
+		return nil, "", fmt.Errorf("%w: expires_at must be within %d days", ErrInvalidShareLink, int(maxShareLinkTTL.Hours()/24))
+	}
+
+	permissions, err := sharePermissions(permissions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := newShareLinkToken()
+	if err != nil {
+		log.Error("failed to generate share link token: ", sl.Err(err))
+		return nil, "", fmt.Errorf("failed to generate share link token: %w", err)
+	}
+
+	link, err := tz.repo.CreateShareLink(ctx, &modelrepo.CreateShareLinkRequest{
+		ID:          uuid.New(),
+		VersionID:   versionID,
+		TokenHash:   shareLinkTokenHash(token),
+		Permissions: permissions,
+		CreatedBy:   caller.UserID,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		log.Error("failed to create share link: ", sl.Err(err))
+		return nil, "", fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	log.Info("share link created", slog.String("shareLinkID", link.ID.String()))
+
+	return link, token, nil
+}
+
+// ListShareLinks возвращает ссылки на просмотр версии
+func (tz *Tz) ListShareLinks(ctx context.Context, versionID uuid.UUID, caller Caller) ([]modelrepo.ShareLink, error) {
+	const op = "Tz.ListShareLinks"
+
+	if err := tz.AuthorizeVersion(ctx, versionID, caller, ""); err != nil {
+		return nil, err
+	}
+
+	links, err := tz.repo.ListShareLinksByVersionID(ctx, versionID)
+	if err != nil {
+		tz.log.With(slog.String("op", op)).Error("failed to list share links: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+
+	return links, nil
+}
+
+// RevokeShareLink отзывает ссылку. Отозвать ссылку может владелец ТЗ или администратор
+func (tz *Tz) RevokeShareLink(ctx context.Context, shareLinkID uuid.UUID, caller Caller) (*modelrepo.ShareLink, error) {
+	const op = "Tz.RevokeShareLink"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("shareLinkID", shareLinkID.String()),
+		slog.String("userID", caller.UserID.String()),
+	)
+
+	link, err := tz.repo.GetShareLink(ctx, shareLinkID)
+	if err != nil {
+		if errors.Is(err, repository.ErrShareLinkNotFound) {
+			return nil, ErrShareLinkNotFound
+		}
+		log.Error("failed to get share link: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	if err := tz.AuthorizeVersion(ctx, link.VersionID, caller, ""); err != nil {
+		return nil, err
+	}
+
+	link, err = tz.repo.RevokeShareLink(ctx, shareLinkID)
+	if err != nil {
+		if errors.Is(err, repository.ErrShareLinkNotFound) {
+			return nil, ErrShareLinkNotFound
+		}
+		log.Error("failed to revoke share link: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to revoke share link: %w", err)
+	}
+
+	log.Info("share link revoked")
+
+	return link, nil
+}
+
+// ResolveShareLink возвращает действующую ссылку по токену и учитывает открытие ссылки
+func (tz *Tz) ResolveShareLink(ctx context.Context, token string) (*modelrepo.ShareLink, error) {
+	const op = "Tz.ResolveShareLink"
+
+	log := tz.log.With(slog.String("op", op))
+
+	link, err := tz.activeShareLink(ctx, token, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tz.repo.RegisterShareLinkAccess(ctx, link.ID); err != nil {
+		log.Error("failed to register share link access: ", sl.Err(err))
+	}
+
+	return link, nil
+}
+
+// activeShareLink находит ссылку по токену и проверяет, что она не отозвана и не истекла
+func (tz *Tz) activeShareLink(ctx context.Context, token string, log *slog.Logger) (*modelrepo.ShareLink, error) {
+	if token == "" {
+		return nil, ErrShareLinkNotFound
+	}
+
+	link, err := tz.repo.GetShareLinkByTokenHash(ctx, shareLinkTokenHash(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrShareLinkNotFound) {
+			return nil, ErrShareLinkNotFound
+		}
+		log.Error("failed to get share link: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	if !shareLinkActive(link, time.Now()) {
+		return nil, ErrShareLinkExpired
+	}
+
+	return link, nil
+}
+
+// authorizeShareLink проверяет, что ссылка действует, ведёт на эту версию и даёт право permission
+func (tz *Tz) authorizeShareLink(ctx context.Context, versionID uuid.UUID, token string, permission string, log *slog.Logger) error {
+	link, err := tz.activeShareLink(ctx, token, log)
+	if err != nil {
+		if errors.Is(err, ErrShareLinkNotFound) || errors.Is(err, ErrShareLinkExpired) {
+			return ErrAccessDenied
+		}
+		return err
+	}
+
+	if link.VersionID != versionID || permission == "" || !slices.Contains(link.Permissions, permission) {
+		log.Warn("share link does not grant access", slog.String("shareLinkID", link.ID.String()), slog.String("permission", permission))
+		return ErrAccessDenied
+	}
+
+	return nil
+}
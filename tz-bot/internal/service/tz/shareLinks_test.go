@@ -0,0 +1,51 @@
+package tzservice
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+)
+
+func TestSharePermissions(t *testing.T) {
+	got, err := sharePermissions(nil)
+	if err != nil || !slices.Equal(got, []string{SharePermissionView}) {
+		t.Errorf("empty permissions = %v, %v, want view only", got, err)
+	}
+
+	got, err = sharePermissions([]string{SharePermissionExport, SharePermissionView, SharePermissionExport})
+	if err != nil || !slices.Equal(got, []string{SharePermissionView, SharePermissionExport}) {
+		t.Errorf("export permissions = %v, %v, want view and export once", got, err)
+	}
+
+	if _, err := sharePermissions([]string{"edit"}); !errors.Is(err, ErrInvalidShareLink) {
+		t.Errorf("unknown permission error = %v, want ErrInvalidShareLink", err)
+	}
+}
+
+func TestShareLinkActive(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+
+	if !shareLinkActive(&modelrepo.ShareLink{ExpiresAt: now.Add(time.Hour)}, now) {
+		t.Errorf("link before expiry must be active")
+	}
+	if shareLinkActive(&modelrepo.ShareLink{ExpiresAt: now}, now) {
+		t.Errorf("link must expire at expires_at")
+	}
+	if shareLinkActive(&modelrepo.ShareLink{ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, now) {
+		t.Errorf("revoked link must not be active")
+	}
+}
+
+func TestShareLinkTokenHash(t *testing.T) {
+	token, err := newShareLinkToken()
+	if err != nil {
+		t.Fatalf("newShareLinkToken() error = %v", err)
+	}
+	if len(shareLinkTokenHash(token)) != 64 || shareLinkTokenHash(token) == token {
+		t.Errorf("token hash must be a hex sha256 of the token")
+	}
+}
@@ -45,6 +45,9 @@ type OutError struct {
 
 // llmRequestResult represents the result of a single LLM request
 type llmRequestResult struct {
+	// stage - ключ контрольной точки группы, restored - результат взят из неё, а не от LLM
+	stage       string
+	restored    bool
 	groupReport *tz_llm_client.GroupReport
 	ResultRaw   string
 	cost        *float64
@@ -222,7 +225,9 @@ type llmRequestResult struct {
 //	}
 type VersionMe struct {
 	ID                         uuid.UUID `db:"id"`
+	TechnicalSpecificationID   uuid.UUID `db:"technical_specification_id"`
 	TechnicalSpecificationName string    `db:"technical_specification_name"`
+	UserID                     uuid.UUID `db:"user_id"`
 	VersionNumber              int       `db:"version_number"`
 	CreatedAt                  time.Time `db:"created_at"`
 	OriginalFileID             string    `db:"original_file_id"`
@@ -249,19 +254,53 @@ func (tz *Tz) GetVersionsMe(ctx context.Context, userID uuid.UUID) ([]*VersionMe
 		return nil, fmt.Errorf("failed to get versions by user ID: %w", err)
 	}
 
+	fillVersionMeLinks(versions)
+
+	//log.Info("technical specification versions retrieved successfully", slog.Int("count", len(versions)))
+	return versions, nil
+}
+
+// GetVersionsOrganization возвращает версии ТЗ, проверки которых оплачены из квоты организации
+// пользователя. Если пользователь не состоит в организации, возвращает только его версии
+func (tz *Tz) GetVersionsOrganization(ctx context.Context, userID uuid.UUID) ([]*VersionMe, error) {
+	const op = "Tz.GetVersionsOrganization"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("userID", userID.String()),
+	)
+
+	organizationID, err := tz.userOrganizationID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user organization: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get user organization: %w", err)
+	}
+	if organizationID == nil {
+		return tz.GetVersionsMe(ctx, userID)
+	}
+
+	versions, err := tz.repo.GetVersionsMeByOrganizationID(ctx, *organizationID)
+	if err != nil {
+		log.Error("failed to get versions by organization ID: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get versions by organization ID: %w", err)
+	}
+
+	fillVersionMeLinks(versions)
+
+	return versions, nil
+}
+
+func fillVersionMeLinks(versions []*VersionMe) {
 	for i := range versions {
 		if versions[i].ReportFileID != nil && *versions[i].ReportFileID != "" {
-			reportFileLink := "/reports/" + *versions[i].ReportFileID + ".docx"
+			reportFileLink := VersionFileLink(versions[i].ID, VersionFileReport)
 			versions[i].ReportFileLink = &reportFileLink
 		}
 
 		if versions[i].OriginalFileID != "" {
-			versions[i].OriginalFileLink = "/docs/" + versions[i].OriginalFileID + ".docx"
+			versions[i].OriginalFileLink = VersionFileLink(versions[i].ID, VersionFileOriginal)
 		}
 	}
-
-	//log.Info("technical specification versions retrieved successfully", slog.Int("count", len(versions)))
-	return versions, nil
 }
 
 type VersionAdminDashboard struct {
@@ -298,8 +337,8 @@ func (tz *Tz) GetAllVersionsAdminDashboard(ctx context.Context, userID uuid.UUID
 	}
 
 	for i := range versions {
-		versions[i].OriginalFileLink = "/docs/" + versions[i].OriginalFileId + ".docx"
-		versions[i].ReportFileLink = "/reports/" + versions[i].ReportFileId + ".docx"
+		versions[i].OriginalFileLink = VersionFileLink(versions[i].ID, VersionFileOriginal)
+		versions[i].ReportFileLink = VersionFileLink(versions[i].ID, VersionFileReport)
 	}
 
 	log.Info("all versions retrieved successfully", slog.Int("count", len(versions)))
@@ -416,7 +455,7 @@ func (tz *Tz) GetDailyAnalytics(ctx context.Context, fromDate, toDate, timezone
 	return points, nil
 }
 
-func (tz *Tz) GetVersion(ctx context.Context, versionID uuid.UUID) (string, time.Time, float64, int64, time.Duration, string, string, string, *[]Error, *[]OutInvalidError, string, int64, int, string, int, error) {
+func (tz *Tz) GetVersion(ctx context.Context, versionID uuid.UUID, filter InstancesFilter) (string, time.Time, float64, int64, time.Duration, string, string, string, *[]Error, *[]OutInvalidError, string, int64, int, string, int, error) {
 	const op = "Tz.GetVersion"
 
 	log := tz.log.With(
@@ -432,8 +471,9 @@ func (tz *Tz) GetVersion(ctx context.Context, versionID uuid.UUID) (string, time
 		return "", time.Time{}, 0, 0, 0, "", "", "", nil, nil, "", 0, 0, "", 0, err
 	}
 
-	if version.Status == "in_progress" {
-		return "in_progress", time.Time{}, 0, 0, 0, "", "", "", nil, nil, "", 0, 0, "", version.Progress, err
+	// Результаты есть только у завершённой проверки, для остальных статусов возвращается статус и прогресс
+	if version.Status != "completed" {
+		return version.Status, time.Time{}, 0, 0, 0, "", "", "", nil, nil, "", 0, 0, "", version.Progress, nil
 	}
 
 	errorsInTz, err := tz.repo.GetErrorsByVersionID(ctx, versionID)
@@ -465,6 +505,8 @@ func (tz *Tz) GetVersion(ctx context.Context, versionID uuid.UUID) (string, time
 
 	SortOutInvalidErrorsByOrderNumber(&invalidInstances)
 
+	applyInstancesFilter(errorsInTz, &invalidInstances, filter)
+
 	//version, invalidErrors, missingErrors, err := tz.repo.GetVersionWithErrors(ctx, versionID)
 	//if err != nil {
 	//	log.Error("failed to get version with errors: ", sl.Err(err))
@@ -508,7 +550,7 @@ func (tz *Tz) GetVersion(ctx context.Context, versionID uuid.UUID) (string, time
 	//	slog.Int("invalid_errors_count", len(outInvalidErrors)),
 	//	slog.Int("missing_errors_count", len(outMissingErrors)))
 
-	return "completed", version.CreatedAt, *version.AllRubs, *version.AllTokens, *version.InspectionTime, version.OutHTML, version.CSS, version.CheckedFileID, errorsInTz, &invalidInstances, "", *version.OriginalFileSize, int(*version.NumberOfErrors), version.LlmReport, 0, nil
+	return version.Status, version.CreatedAt, *version.AllRubs, *version.AllTokens, *version.InspectionTime, version.OutHTML, version.CSS, version.CheckedFileID, errorsInTz, &invalidInstances, "", *version.OriginalFileSize, int(*version.NumberOfErrors), version.LlmReport, 0, nil
 }
 
 func SortOutInvalidErrorsByOrderNumber(errors *[]OutInvalidError) {
@@ -609,21 +651,6 @@ func (tz *Tz) NewVerificationFeedbackError(ctx context.Context, instanceID uuid.
 	return nil
 }
 
-// SetGGID изменяет ggID и возвращает текущее значение
-func (tz *Tz) SetGGID(newGGID int) int {
-	tz.mu.Lock()
-	defer tz.mu.Unlock()
-	tz.ggID = newGGID
-	return tz.ggID
-}
-
-// GetGGID возвращает текущий ggID
-func (tz *Tz) GetGGID() int {
-	tz.mu.RLock()
-	defer tz.mu.RUnlock()
-	return tz.ggID
-}
-
 // SetUseLlmCache изменяет useLlmCache и возвращает текущее значение
 func (tz *Tz) SetUseLlmCache(useLlmCache bool) bool {
 	tz.mu.Lock()
@@ -0,0 +1,434 @@
+package tzservice
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"repairCopilotBot/tz-bot/internal/pkg/cfb"
+	doctodocxconverterclient "repairCopilotBot/tz-bot/internal/pkg/docToDocxConverterClient"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// UploadLimits - ограничения на загружаемые документы. Нулевое значение снимает ограничение
+type UploadLimits struct {
+	// MaxFileSize - размер файла в байтах. По умолчанию 49 МБ: gRPC принимает сообщения
+	// до 50 МБ, остаток - запас на остальные поля запроса
+	MaxFileSize int64 `env:"MAX_FILE_SIZE" env-default:"51380224"`
+	MaxPages    int   `env:"MAX_PAGES" env-default:"500"`
+	MaxChars    int   `env:"MAX_CHARS" env-default:"2000000"`
+	// MaxUncompressedSize - суммарный размер файлов внутри docx/odt после распаковки
+	MaxUncompressedSize int64 `env:"MAX_UNCOMPRESSED_SIZE" env-default:"314572800"`
+	// MaxCompressionRatio - степень сжатия одного файла архива, выше которой архив считается zip-бомбой
+	MaxCompressionRatio int64 `env:"MAX_COMPRESSION_RATIO" env-default:"200"`
+	MaxArchiveEntries   int   `env:"MAX_ARCHIVE_ENTRIES" env-default:"10000"`
+}
+
+// Причины отклонения загруженного файла
+const (
+	UploadRejectEmptyFile         = "empty_file"
+	UploadRejectFileTooLarge      = "file_too_large"
+	UploadRejectUnsupportedFormat = "unsupported_format"
+	UploadRejectCorruptedFile     = "corrupted_file"
+	UploadRejectPasswordProtected = "password_protected"
+	UploadRejectMacros            = "macros"
+	UploadRejectEmbeddedObjects   = "embedded_objects"
+	UploadRejectZipBomb           = "zip_bomb"
+	UploadRejectTooManyPages      = "too_many_pages"
+	UploadRejectTooManyChars      = "too_many_chars"
+	UploadRejectScannedDocument   = "scanned_document"
+)
+
+// UploadValidationError - загруженный файл отклонён до списания проверки и сохранения в S3.
+// Message показывается пользователю как есть
+type UploadValidationError struct {
+	Reason  string
+	Message string
+	err     error
+}
+
+func (e *UploadValidationError) Error() string {
+	return "upload validation failed: " + e.Reason + ": " + e.Message
+}
+
+// Unwrap позволяет проверять причину через errors.Is: ErrUnsupportedFileFormat,
+// ErrScannedDocument или общую ErrUploadValidation
+func (e *UploadValidationError) Unwrap() error {
+	return e.err
+}
+
+func newUploadValidationError(reason, message string) *UploadValidationError {
+	err := ErrUploadValidation
+	switch reason {
+	case UploadRejectUnsupportedFormat:
+		err = ErrUnsupportedFileFormat
+	case UploadRejectScannedDocument:
+		err = ErrScannedDocument
+	}
+	return &UploadValidationError{Reason: reason, Message: message, err: err}
+}
+
+var (
+	errUploadEmpty             = newUploadValidationError(UploadRejectEmptyFile, "Файл пустой")
+	errUploadUnsupportedFormat = newUploadValidationError(UploadRejectUnsupportedFormat, "Неподдерживаемый формат файла. Загрузите документ в формате DOCX, DOC, PDF, ODT или RTF")
+	errUploadCorrupted         = newUploadValidationError(UploadRejectCorruptedFile, "Файл повреждён и не может быть прочитан")
+	errUploadPasswordProtected = newUploadValidationError(UploadRejectPasswordProtected, "Документ защищён паролем. Снимите защиту и загрузите файл повторно")
+	errUploadMacros            = newUploadValidationError(UploadRejectMacros, "Документ содержит макросы или скрипты. Сохраните его без макросов (например, в формате DOCX) и загрузите повторно")
+	errUploadEmbeddedObjects   = newUploadValidationError(UploadRejectEmbeddedObjects, "Документ содержит встроенные объекты (OLE). Удалите их или сохраните документ в формате DOCX и загрузите повторно")
+	errUploadZipBomb           = newUploadValidationError(UploadRejectZipBomb, "Файл содержит подозрительно сильно сжатые данные и не может быть обработан")
+	errUploadScanned           = newUploadValidationError(UploadRejectScannedDocument, "PDF не содержит текстового слоя (скан или изображения). Загрузите документ в формате DOCX или PDF с текстом")
+)
+
+// documentStats - объём документа, известный до его конвертации. 0 - значение неизвестно
+type documentStats struct {
+	Pages int
+	Chars int
+}
+
+// Флаги FIB документа Word 97-2003
+const (
+	fibIdent       = 0xA5EC
+	fibEncrypted   = 0x0100
+	fibFlagsOffset = 0x0A
+	// fibCcpTextOffset - число символов основного текста документа (FibRgLw97.ccpText)
+	fibCcpTextOffset = 0x4C
+)
+
+// validateUpload определяет формат документа и проверяет его контейнер, ограничения по размеру,
+// страницам и символам, отсутствие пароля, макросов и признаков zip-бомбы. PDF проверяется
+// дополнительно после извлечения текста - см. validatePdfText
+func (l UploadLimits) validateUpload(file []byte) (DocumentFormat, documentStats, error) {
+	if len(file) == 0 {
+		return "", documentStats{}, errUploadEmpty
+	}
+
+	if l.MaxFileSize > 0 && int64(len(file)) > l.MaxFileSize {
+		return "", documentStats{}, newUploadValidationError(UploadRejectFileTooLarge,
+			fmt.Sprintf("Файл больше допустимого размера %d МБ", l.MaxFileSize>>20))
+	}
+
+	format, err := DetectDocumentFormat(file)
+	if err != nil {
+		return "", documentStats{}, errUploadUnsupportedFormat
+	}
+
+	var stats documentStats
+	switch format {
+	case FormatDOCX, FormatODT:
+		stats, err = l.inspectZipDocument(file, format)
+	case FormatDOC:
+		stats, err = inspectOleDocument(file)
+	case FormatPDF:
+		err = inspectPdfDocument(file)
+	case FormatRTF:
+		err = inspectRtfDocument(file)
+	default:
+		err = errUploadUnsupportedFormat
+	}
+	if err != nil {
+		return "", documentStats{}, err
+	}
+
+	if err := l.checkStats(stats); err != nil {
+		return "", documentStats{}, err
+	}
+
+	return format, stats, nil
+}
+
+func (l UploadLimits) checkStats(stats documentStats) error {
+	if l.MaxPages > 0 && stats.Pages > l.MaxPages {
+		return newUploadValidationError(UploadRejectTooManyPages,
+			fmt.Sprintf("В документе %d страниц, допускается не больше %d", stats.Pages, l.MaxPages))
+	}
+	if l.MaxChars > 0 && stats.Chars > l.MaxChars {
+		return newUploadValidationError(UploadRejectTooManyChars,
+			fmt.Sprintf("В документе %d символов, допускается не больше %d", stats.Chars, l.MaxChars))
+	}
+	return nil
+}
+
+// validatePdfText проверяет результат извлечения текста из PDF: пароль, наличие
+// текстового слоя и ограничения по страницам и символам
+func (l UploadLimits) validatePdfText(pdfText *doctodocxconverterclient.PdfText) error {
+	if pdfText.PasswordProtected {
+		return errUploadPasswordProtected
+	}
+	if !pdfText.HasTextLayer {
+		return errUploadScanned
+	}
+
+	stats := documentStats{Pages: pdfText.PageCount}
+	for _, page := range pdfText.Pages {
+		stats.Chars += utf8.RuneCountInString(page)
+	}
+
+	return l.checkStats(stats)
+}
+
+// inspectZipDocument проверяет docx или odt: размеры и степень сжатия файлов архива,
+// макросы, шифрование (odt) и считает символы основного текста
+func (l UploadLimits) inspectZipDocument(file []byte, format DocumentFormat) (documentStats, error) {
+	archive, err := zip.NewReader(bytes.NewReader(file), int64(len(file)))
+	if err != nil {
+		return documentStats{}, errUploadCorrupted
+	}
+
+	if l.MaxArchiveEntries > 0 && len(archive.File) > l.MaxArchiveEntries {
+		return documentStats{}, errUploadZipBomb
+	}
+
+	var total uint64
+	for _, f := range archive.File {
+		total += f.UncompressedSize64
+		if l.MaxUncompressedSize > 0 && total > uint64(l.MaxUncompressedSize) {
+			return documentStats{}, errUploadZipBomb
+		}
+		// Маленькие файлы (стили, пустые части) сжимаются очень сильно, это нормально
+		if l.MaxCompressionRatio > 0 && f.UncompressedSize64 > 1<<20 &&
+			f.UncompressedSize64 > f.CompressedSize64*uint64(l.MaxCompressionRatio) {
+			return documentStats{}, errUploadZipBomb
+		}
+	}
+
+	if format == FormatODT {
+		return l.inspectOdt(archive)
+	}
+	return l.inspectDocx(archive)
+}
+
+var docxPagesRe = regexp.MustCompile(`<Pages>(\d+)</Pages>`)
+
+func (l UploadLimits) inspectDocx(archive *zip.Reader) (documentStats, error) {
+	stats := documentStats{}
+	var document *zip.File
+
+	for _, f := range archive.File {
+		name := strings.ToLower(f.Name)
+		switch {
+		case name == "word/document.xml":
+			document = f
+		case strings.HasSuffix(name, "vbaproject.bin"):
+			return documentStats{}, errUploadMacros
+		case name == "[content_types].xml":
+			contentTypes, err := l.readZipFile(f)
+			if err != nil {
+				return documentStats{}, err
+			}
+			if bytes.Contains(bytes.ToLower(contentTypes), []byte("macroenabled")) {
+				return documentStats{}, errUploadMacros
+			}
+		case name == "docprops/app.xml":
+			// Число страниц записывает Word при сохранении. Если его нет, страницы не проверяются
+			app, err := l.readZipFile(f)
+			if err != nil {
+				return documentStats{}, err
+			}
+			if m := docxPagesRe.FindSubmatch(app); m != nil {
+				stats.Pages, _ = strconv.Atoi(string(m[1]))
+			}
+		}
+	}
+
+	if document == nil {
+		return documentStats{}, errUploadCorrupted
+	}
+
+	chars, err := l.countXmlTextChars(document, "t")
+	if err != nil {
+		return documentStats{}, err
+	}
+	stats.Chars = chars
+
+	return stats, nil
+}
+
+var (
+	odtPageCountRe = regexp.MustCompile(`meta:page-count="(\d+)"`)
+	odtCharCountRe = regexp.MustCompile(`meta:character-count="(\d+)"`)
+)
+
+func (l UploadLimits) inspectOdt(archive *zip.Reader) (documentStats, error) {
+	stats := documentStats{}
+
+	for _, f := range archive.File {
+		name := f.Name
+		switch {
+		case (strings.HasPrefix(name, "Basic/") || strings.HasPrefix(name, "Scripts/")) && !strings.HasSuffix(name, "/"):
+			return documentStats{}, errUploadMacros
+		case name == "META-INF/manifest.xml":
+			manifest, err := l.readZipFile(f)
+			if err != nil {
+				return documentStats{}, err
+			}
+			if bytes.Contains(manifest, []byte("encryption-data")) {
+				return documentStats{}, errUploadPasswordProtected
+			}
+		case name == "meta.xml":
+			meta, err := l.readZipFile(f)
+			if err != nil {
+				return documentStats{}, err
+			}
+			if m := odtPageCountRe.FindSubmatch(meta); m != nil {
+				stats.Pages, _ = strconv.Atoi(string(m[1]))
+			}
+			if m := odtCharCountRe.FindSubmatch(meta); m != nil {
+				stats.Chars, _ = strconv.Atoi(string(m[1]))
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// readZipFile читает файл архива, не распаковывая больше MaxUncompressedSize байт:
+// заявленный в архиве размер может не совпадать с реальным
+func (l UploadLimits) readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, errUploadCorrupted
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(l.limitReader(rc))
+	if err != nil {
+		if errors.Is(err, errUploadZipBomb) {
+			return nil, errUploadZipBomb
+		}
+		return nil, errUploadCorrupted
+	}
+
+	return data, nil
+}
+
+// countXmlTextChars считает символы внутри элементов с локальным именем textElement
+// (w:t в docx) потоково, не загружая документ в память целиком
+func (l UploadLimits) countXmlTextChars(f *zip.File, textElement string) (int, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return 0, errUploadCorrupted
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(l.limitReader(rc))
+	chars, depth := 0, 0
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return chars, nil
+		}
+		if err != nil {
+			if errors.Is(err, errUploadZipBomb) {
+				return 0, errUploadZipBomb
+			}
+			return 0, errUploadCorrupted
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == textElement {
+				depth++
+			}
+		case xml.EndElement:
+			if t.Name.Local == textElement && depth > 0 {
+				depth--
+			}
+		case xml.CharData:
+			if depth > 0 {
+				chars += utf8.RuneCount(t)
+			}
+		}
+	}
+}
+
+func (l UploadLimits) limitReader(r io.Reader) io.Reader {
+	if l.MaxUncompressedSize <= 0 {
+		return r
+	}
+	return &zipBombReader{r: r, left: l.MaxUncompressedSize}
+}
+
+// zipBombReader возвращает errUploadZipBomb, если из архива распаковано больше допустимого
+type zipBombReader struct {
+	r    io.Reader
+	left int64
+}
+
+func (z *zipBombReader) Read(p []byte) (int, error) {
+	if z.left <= 0 {
+		return 0, errUploadZipBomb
+	}
+	if int64(len(p)) > z.left {
+		p = p[:z.left]
+	}
+	n, err := z.r.Read(p)
+	z.left -= int64(n)
+	return n, err
+}
+
+// inspectOleDocument проверяет OLE-контейнер: это должен быть документ Word без шифрования
+// и макросов. Зашифрованные паролем docx Office тоже сохраняет в OLE-контейнер
+func inspectOleDocument(file []byte) (documentStats, error) {
+	container, err := cfb.Open(file)
+	if err != nil {
+		return documentStats{}, errUploadCorrupted
+	}
+
+	if _, ok := container.Find("EncryptedPackage"); ok {
+		return documentStats{}, errUploadPasswordProtected
+	}
+
+	for _, e := range container.Entries() {
+		if strings.EqualFold(e.Name, "Macros") || strings.EqualFold(e.Name, "_VBA_PROJECT_CUR") || strings.EqualFold(e.Name, "VBA") {
+			return documentStats{}, errUploadMacros
+		}
+	}
+
+	wordDocument, ok := container.Find("WordDocument")
+	if !ok {
+		// OLE-контейнер без потока WordDocument - это xls, ppt, msg и т.п.
+		return documentStats{}, errUploadUnsupportedFormat
+	}
+
+	fib, err := container.ReadStream(wordDocument, fibCcpTextOffset+4)
+	if err != nil || len(fib) < fibCcpTextOffset+4 || binary.LittleEndian.Uint16(fib) != fibIdent {
+		return documentStats{}, errUploadCorrupted
+	}
+
+	if flags := binary.LittleEndian.Uint16(fib[fibFlagsOffset:]); flags&fibEncrypted != 0 {
+		return documentStats{}, errUploadPasswordProtected
+	}
+
+	return documentStats{Chars: int(binary.LittleEndian.Uint32(fib[fibCcpTextOffset:]))}, nil
+}
+
+// rtfEmbeddedObjectRe - управляющие слова встроенного OLE-объекта. Имя управляющего слова RTF
+// заканчивается на первом символе, отличном от латинской буквы, поэтому \objectx не совпадёт
+var rtfEmbeddedObjectRe = regexp.MustCompile(`\\obj(ect|data)([^a-zA-Z]|$)`)
+
+// inspectRtfDocument отклоняет RTF со встроенными объектами: макросов и шифрования в RTF нет,
+// но в \object можно вложить произвольный OLE-контейнер, в том числе с исполняемым содержимым
+func inspectRtfDocument(file []byte) error {
+	if rtfEmbeddedObjectRe.Match(file) {
+		return errUploadEmbeddedObjects
+	}
+	return nil
+}
+
+// pdfActiveContentRe - JavaScript и запуск внешних программ из PDF
+var pdfActiveContentRe = regexp.MustCompile(`/(JavaScript|JS|Launch)\s*[/(<\[\d]`)
+
+// inspectPdfDocument отклоняет PDF с активным содержимым. Пароль на открытие определяется
+// при извлечении текста: шифрование только прав доступа текст читать не мешает
+func inspectPdfDocument(file []byte) error {
+	if pdfActiveContentRe.Match(file) {
+		return errUploadMacros
+	}
+	return nil
+}
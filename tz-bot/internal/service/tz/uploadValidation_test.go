@@ -0,0 +1,271 @@
+package tzservice
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	doctodocxconverterclient "repairCopilotBot/tz-bot/internal/pkg/docToDocxConverterClient"
+)
+
+var testUploadLimits = UploadLimits{
+	MaxFileSize:         1 << 20,
+	MaxPages:            10,
+	MaxChars:            1000,
+	MaxUncompressedSize: 8 << 20,
+	MaxCompressionRatio: 100,
+	MaxArchiveEntries:   50,
+}
+
+type cfbStream struct {
+	name string
+	data []byte
+}
+
+// buildCFB собирает минимальный OLE-контейнер версии 3: сектор FAT, сектор каталога
+// (корень и до трёх потоков) и данные потоков. Потоки должны быть пустыми или не меньше 4096 байт,
+// чтобы не попадать в мини-поток
+func buildCFB(t *testing.T, streams ...cfbStream) []byte {
+	t.Helper()
+
+	const sectorSize = 512
+	if len(streams) > 3 {
+		t.Fatalf("too many streams")
+	}
+
+	fat := []uint32{0xFFFFFFFD, 0xFFFFFFFE}
+	dir := make([]byte, sectorSize)
+	var data []byte
+
+	writeEntry := func(i int, name string, entryType byte, start uint32, size int) {
+		raw := dir[i*128 : (i+1)*128]
+		name16 := utf16.Encode([]rune(name))
+		for j, c := range name16 {
+			binary.LittleEndian.PutUint16(raw[j*2:], c)
+		}
+		binary.LittleEndian.PutUint16(raw[0x40:], uint16((len(name16)+1)*2))
+		raw[0x42] = entryType
+		binary.LittleEndian.PutUint32(raw[0x44:], 0xFFFFFFFF)
+		binary.LittleEndian.PutUint32(raw[0x48:], 0xFFFFFFFF)
+		binary.LittleEndian.PutUint32(raw[0x4C:], 0xFFFFFFFF)
+		binary.LittleEndian.PutUint32(raw[0x74:], start)
+		binary.LittleEndian.PutUint32(raw[0x78:], uint32(size))
+	}
+
+	writeEntry(0, "Root Entry", 5, 0xFFFFFFFE, 0)
+	for i, stream := range streams {
+		if len(stream.data) == 0 {
+			writeEntry(i+1, stream.name, 2, 0xFFFFFFFE, 0)
+			continue
+		}
+		if len(stream.data) < 4096 {
+			t.Fatalf("stream %s is smaller than mini stream cutoff", stream.name)
+		}
+
+		start := uint32(len(fat))
+		sectors := (len(stream.data) + sectorSize - 1) / sectorSize
+		for s := 0; s < sectors; s++ {
+			if s == sectors-1 {
+				fat = append(fat, 0xFFFFFFFE)
+			} else {
+				fat = append(fat, uint32(len(fat)+1))
+			}
+		}
+		padded := make([]byte, sectors*sectorSize)
+		copy(padded, stream.data)
+		data = append(data, padded...)
+		writeEntry(i+1, stream.name, 2, start, len(stream.data))
+	}
+
+	header := make([]byte, sectorSize)
+	copy(header, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1})
+	binary.LittleEndian.PutUint16(header[0x18:], 0x3E)
+	binary.LittleEndian.PutUint16(header[0x1A:], 3)
+	binary.LittleEndian.PutUint16(header[0x1C:], 0xFFFE)
+	binary.LittleEndian.PutUint16(header[0x1E:], 9)
+	binary.LittleEndian.PutUint16(header[0x20:], 6)
+	binary.LittleEndian.PutUint32(header[0x2C:], 1)
+	binary.LittleEndian.PutUint32(header[0x30:], 1)
+	binary.LittleEndian.PutUint32(header[0x38:], 4096)
+	binary.LittleEndian.PutUint32(header[0x3C:], 0xFFFFFFFE)
+	binary.LittleEndian.PutUint32(header[0x44:], 0xFFFFFFFE)
+	for i := 0; i < 109; i++ {
+		binary.LittleEndian.PutUint32(header[0x4C+i*4:], 0xFFFFFFFF)
+	}
+	binary.LittleEndian.PutUint32(header[0x4C:], 0)
+
+	fatSector := make([]byte, sectorSize)
+	for i := 0; i < sectorSize/4; i++ {
+		value := uint32(0xFFFFFFFF)
+		if i < len(fat) {
+			value = fat[i]
+		}
+		binary.LittleEndian.PutUint32(fatSector[i*4:], value)
+	}
+
+	out := append(header, fatSector...)
+	out = append(out, dir...)
+	return append(out, data...)
+}
+
+func wordDocumentStream(flags uint16, chars uint32) []byte {
+	fib := make([]byte, 4096)
+	binary.LittleEndian.PutUint16(fib, fibIdent)
+	binary.LittleEndian.PutUint16(fib[fibFlagsOffset:], flags)
+	binary.LittleEndian.PutUint32(fib[fibCcpTextOffset:], chars)
+	return fib
+}
+
+func docxWithText(text string, extra map[string]string) map[string]string {
+	files := map[string]string{
+		"[Content_Types].xml": `<Types><Default Extension="xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`,
+		"word/document.xml":   `<w:document xmlns:w="w"><w:body><w:p><w:r><w:t>` + text + `</w:t></w:r></w:p></w:body></w:document>`,
+	}
+	for name, content := range extra {
+		files[name] = content
+	}
+	return files
+}
+
+func TestValidateUpload(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       []byte
+		wantFormat DocumentFormat
+		wantChars  int
+		wantReason string
+	}{
+		{name: "docx", file: zipWithFiles(t, docxWithText("Техническое задание", nil)), wantFormat: FormatDOCX, wantChars: 19},
+		{name: "empty", file: nil, wantReason: UploadRejectEmptyFile},
+		{name: "too large", file: append([]byte("%PDF-1.7"), make([]byte, 1<<20)...), wantReason: UploadRejectFileTooLarge},
+		{name: "unknown format", file: []byte("просто текст"), wantReason: UploadRejectUnsupportedFormat},
+		{name: "docx without document", file: zipWithFiles(t, map[string]string{"word/document.xml.bak": ""}), wantReason: UploadRejectUnsupportedFormat},
+		{name: "docx with vba project", file: zipWithFiles(t, docxWithText("текст", map[string]string{"word/vbaProject.bin": "vba"})), wantReason: UploadRejectMacros},
+		{
+			name: "docm content type",
+			file: zipWithFiles(t, map[string]string{
+				"[Content_Types].xml": `<Types><Override ContentType="application/vnd.ms-word.document.macroEnabled.main+xml"/></Types>`,
+				"word/document.xml":   `<w:document/>`,
+			}),
+			wantReason: UploadRejectMacros,
+		},
+		{name: "docx too many chars", file: zipWithFiles(t, docxWithText(strings.Repeat("а", 1001), nil)), wantReason: UploadRejectTooManyChars},
+		{name: "docx too many pages", file: zipWithFiles(t, docxWithText("текст", map[string]string{"docProps/app.xml": "<Properties><Pages>11</Pages></Properties>"})), wantReason: UploadRejectTooManyPages},
+		{name: "docx zip bomb ratio", file: zipWithFiles(t, docxWithText("текст", map[string]string{"word/media/image1.bin": strings.Repeat("0", 4<<20)})), wantReason: UploadRejectZipBomb},
+		{name: "docx zip bomb size", file: zipWithFiles(t, docxWithText(strings.Repeat("а", 5<<20), nil)), wantReason: UploadRejectZipBomb},
+		{
+			name: "odt",
+			file: zipWithFiles(t, map[string]string{
+				"mimetype": odtMimetype,
+				"meta.xml": `<office:meta><meta:document-statistic meta:page-count="3" meta:character-count="120"/></office:meta>`,
+			}),
+			wantFormat: FormatODT,
+			wantChars:  120,
+		},
+		{
+			name: "odt encrypted",
+			file: zipWithFiles(t, map[string]string{
+				"mimetype":              odtMimetype,
+				"META-INF/manifest.xml": `<manifest:file-entry manifest:full-path="content.xml"><manifest:encryption-data/></manifest:file-entry>`,
+			}),
+			wantReason: UploadRejectPasswordProtected,
+		},
+		{name: "odt with basic macros", file: zipWithFiles(t, map[string]string{"mimetype": odtMimetype, "Basic/Standard/Module1.xml": "Sub Main"}), wantReason: UploadRejectMacros},
+		{name: "doc", file: buildCFB(t, cfbStream{"WordDocument", wordDocumentStream(0, 250)}), wantFormat: FormatDOC, wantChars: 250},
+		{name: "doc encrypted", file: buildCFB(t, cfbStream{"WordDocument", wordDocumentStream(fibEncrypted, 250)}), wantReason: UploadRejectPasswordProtected},
+		{name: "doc with macros", file: buildCFB(t, cfbStream{"WordDocument", wordDocumentStream(0, 250)}, cfbStream{"Macros", nil}), wantReason: UploadRejectMacros},
+		{name: "doc too many chars", file: buildCFB(t, cfbStream{"WordDocument", wordDocumentStream(0, 5000)}), wantReason: UploadRejectTooManyChars},
+		{name: "encrypted docx", file: buildCFB(t, cfbStream{"EncryptionInfo", nil}, cfbStream{"EncryptedPackage", nil}), wantReason: UploadRejectPasswordProtected},
+		{name: "xls", file: buildCFB(t, cfbStream{"Workbook", nil}), wantReason: UploadRejectUnsupportedFormat},
+		{name: "broken ole", file: append([]byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}, make([]byte, 600)...), wantReason: UploadRejectCorruptedFile},
+		{name: "pdf", file: []byte("%PDF-1.7\n1 0 obj << /Type /Catalog >> endobj"), wantFormat: FormatPDF},
+		{name: "pdf with javascript", file: []byte("%PDF-1.7\n1 0 obj << /S /JavaScript /JS (app.alert(1)) >> endobj"), wantReason: UploadRejectMacros},
+		{name: "rtf", file: []byte(`{\rtf1\ansi текст}`), wantFormat: FormatRTF},
+		{name: "rtf with embedded object", file: []byte(`{\rtf1\ansi текст{\object\objemb{\*\objclass Package}}}`), wantReason: UploadRejectEmbeddedObjects},
+		{name: "rtf with object data", file: []byte(`{\rtf1{\*\objdata 0105000002000000}}`), wantReason: UploadRejectEmbeddedObjects},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, stats, err := testUploadLimits.validateUpload(tt.file)
+
+			if tt.wantReason != "" {
+				var validationErr *UploadValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("expected UploadValidationError %q, got format %q, err %v", tt.wantReason, format, err)
+				}
+				if validationErr.Reason != tt.wantReason {
+					t.Fatalf("expected reason %q, got %q (%s)", tt.wantReason, validationErr.Reason, validationErr.Message)
+				}
+				if validationErr.Message == "" {
+					t.Errorf("validation error without message")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if format != tt.wantFormat {
+				t.Errorf("expected format %q, got %q", tt.wantFormat, format)
+			}
+			if stats.Chars != tt.wantChars {
+				t.Errorf("expected %d chars, got %d", tt.wantChars, stats.Chars)
+			}
+		})
+	}
+}
+
+func TestUploadValidationErrorIs(t *testing.T) {
+	if !errors.Is(errUploadUnsupportedFormat, ErrUnsupportedFileFormat) {
+		t.Errorf("unsupported format must match ErrUnsupportedFileFormat")
+	}
+	if !errors.Is(errUploadScanned, ErrScannedDocument) {
+		t.Errorf("scanned document must match ErrScannedDocument")
+	}
+	if !errors.Is(errUploadMacros, ErrUploadValidation) {
+		t.Errorf("macros must match ErrUploadValidation")
+	}
+}
+
+func TestValidatePdfText(t *testing.T) {
+	page := strings.Repeat("текст ", 10)
+
+	tests := []struct {
+		name       string
+		pdfText    doctodocxconverterclient.PdfText
+		wantReason string
+	}{
+		{name: "ok", pdfText: doctodocxconverterclient.PdfText{Pages: []string{page, page}, PageCount: 2, HasTextLayer: true}},
+		{name: "password", pdfText: doctodocxconverterclient.PdfText{PasswordProtected: true}, wantReason: UploadRejectPasswordProtected},
+		{name: "scanned", pdfText: doctodocxconverterclient.PdfText{Pages: []string{"", ""}, PageCount: 2}, wantReason: UploadRejectScannedDocument},
+		{name: "too many pages", pdfText: doctodocxconverterclient.PdfText{Pages: make([]string, 11), PageCount: 11, HasTextLayer: true}, wantReason: UploadRejectTooManyPages},
+		{name: "too many chars", pdfText: doctodocxconverterclient.PdfText{Pages: []string{strings.Repeat("а", 1001)}, PageCount: 1, HasTextLayer: true}, wantReason: UploadRejectTooManyChars},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testUploadLimits.validatePdfText(&tt.pdfText)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var validationErr *UploadValidationError
+			if !errors.As(err, &validationErr) || validationErr.Reason != tt.wantReason {
+				t.Fatalf("expected reason %q, got %v", tt.wantReason, err)
+			}
+		})
+	}
+}
+
+func TestUploadLimitsZeroMeansUnlimited(t *testing.T) {
+	file := zipWithFiles(t, docxWithText(strings.Repeat("а", 5000), map[string]string{"docProps/app.xml": "<Pages>900</Pages>"}))
+	if _, _, err := (UploadLimits{}).validateUpload(file); err != nil {
+		t.Fatalf("zero limits must not reject documents: %v", err)
+	}
+}
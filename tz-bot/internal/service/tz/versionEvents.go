@@ -0,0 +1,258 @@
+package tzservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы событий хода проверки версии
+const (
+	VersionEventStageStarted     = "stage_started"
+	VersionEventStageFinished    = "stage_finished"
+	VersionEventLlmGroupProgress = "llm_group_progress"
+	VersionEventCost             = "cost"
+	VersionEventFinalStatus      = "final_status"
+)
+
+// watchVersionFallbackInterval - как часто WatchVersion перечитывает события без уведомления.
+// Нужен на случай потерянного NOTIFY, пока слушатель переподключается к базе
+const watchVersionFallbackInterval = 30 * time.Second
+
+// versionEventsListenRetryDelay - пауза перед переподключением слушателя событий после ошибки
+const versionEventsListenRetryDelay = 5 * time.Second
+
+// VersionEvent - событие хода проверки. Заполнены только поля, относящиеся к типу события
+type VersionEvent struct {
+	ID        int64     `json:"-"`
+	VersionID uuid.UUID `json:"-"`
+	Type      string    `json:"-"`
+	CreatedAt time.Time `json:"-"`
+
+	// stage_started, stage_finished
+	Stage    string `json:"stage,omitempty"`
+	Restored bool   `json:"restored,omitempty"`
+
+	// llm_group_progress: обработано GroupIndex групп из GroupTotal
+	GroupIndex int `json:"group_index,omitempty"`
+	GroupTotal int `json:"group_total,omitempty"`
+
+	// cost: затраты с начала проверки
+	Rubs   float64 `json:"rubs,omitempty"`
+	Tokens int64   `json:"tokens,omitempty"`
+
+	// final_status: completed, error, cancelled
+	Status string `json:"status,omitempty"`
+}
+
+func (e *VersionEvent) IsFinal() bool {
+	return e.Type == VersionEventFinalStatus
+}
+
+// publishVersionEvent сохраняет событие проверки. Ошибка только логируется -
+// из-за неё проверка прерываться не должна
+func (tz *Tz) publishVersionEvent(ctx context.Context, versionID uuid.UUID, event VersionEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		tz.log.Error("failed to marshal version event: ", sl.Err(err))
+		return
+	}
+
+	if err := tz.repo.CreateVersionEvent(ctx, versionID, event.Type, payload); err != nil {
+		tz.log.Error("failed to save version event: ",
+			sl.Err(err),
+			slog.String("versionID", versionID.String()),
+			slog.String("eventType", event.Type))
+	}
+}
+
+// WatchVersion передаёт в send события проверки версии, начиная с события после afterEventID,
+// и ждёт новых, пока проверка не завершится или не будет отменён ctx
+func (tz *Tz) WatchVersion(ctx context.Context, versionID uuid.UUID, afterEventID int64, send func(*VersionEvent) error) error {
+	const op = "Tz.WatchVersion"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("versionID", versionID.String()),
+	)
+
+	// Подписываемся до первого чтения, чтобы не пропустить событие между чтением и ожиданием
+	notified, unsubscribe := tz.versionEvents.subscribe(versionID)
+	defer unsubscribe()
+
+	ticker := time.NewTicker(watchVersionFallbackInterval)
+	defer ticker.Stop()
+
+	for {
+		events, err := tz.repo.GetVersionEvents(ctx, versionID, afterEventID)
+		if err != nil {
+			log.Error("failed to get version events: ", sl.Err(err))
+			return fmt.Errorf("failed to get version events: %w", err)
+		}
+
+		lastIsFinal := false
+		for i := range events {
+			event, err := convertVersionEvent(&events[i])
+			if err != nil {
+				log.Error("failed to unmarshal version event: ", sl.Err(err))
+				return fmt.Errorf("failed to unmarshal version event: %w", err)
+			}
+
+			if err := send(event); err != nil {
+				return err
+			}
+			afterEventID = event.ID
+			lastIsFinal = event.IsFinal()
+		}
+
+		// После RetryVersion за final_status следуют события новой попытки, поэтому
+		// завершаемся, только если версия действительно в конечном статусе
+		if lastIsFinal || len(events) == 0 {
+			version, err := tz.repo.GetVersion(ctx, versionID)
+			if err != nil {
+				if errors.Is(err, repository.ErrVersionNotFound) {
+					return ErrVersionNotFound
+				}
+				log.Error("failed to get version: ", sl.Err(err))
+				return fmt.Errorf("failed to get version: %w", err)
+			}
+
+			if isFinalVersionStatus(version.Status) {
+				if lastIsFinal {
+					return nil
+				}
+
+				// Версии, проверенные до появления событий, не получат final_status - отдаём статус из самой версии
+				return send(&VersionEvent{
+					VersionID: versionID,
+					Type:      VersionEventFinalStatus,
+					Status:    version.Status,
+					CreatedAt: version.UpdatedAt,
+				})
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notified:
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListenVersionEvents - единственный на процесс слушатель уведомлений о новых событиях.
+// Будит подписчиков WatchVersion нужной версии, так что база не опрашивается на каждую
+// открытую вкладку. Работает до отмены ctx, при потере соединения переподключается
+func (tz *Tz) ListenVersionEvents(ctx context.Context) {
+	const op = "Tz.ListenVersionEvents"
+
+	log := tz.log.With(slog.String("op", op))
+
+	for {
+		err := tz.repo.ListenVersionEvents(ctx, tz.versionEvents.notify)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Error("version events listener stopped, reconnecting", sl.Err(err))
+
+		// Пока слушателя не было, уведомления могли потеряться - пусть подписчики перечитают события
+		tz.versionEvents.notifyAll()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(versionEventsListenRetryDelay):
+		}
+	}
+}
+
+// versionEventsHub раздаёт уведомления о новых событиях подписчикам внутри процесса.
+// Уведомление только будит подписчика, сами события он читает из базы
+type versionEventsHub struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan struct{}]struct{}
+}
+
+func newVersionEventsHub() *versionEventsHub {
+	return &versionEventsHub{subscribers: make(map[uuid.UUID]map[chan struct{}]struct{})}
+}
+
+// subscribe возвращает канал уведомлений о событиях версии и функцию отписки
+func (h *versionEventsHub) subscribe(versionID uuid.UUID) (<-chan struct{}, func()) {
+	// Буфер в одно уведомление: несколько событий подряд будят подписчика один раз
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subscribers[versionID] == nil {
+		h.subscribers[versionID] = make(map[chan struct{}]struct{})
+	}
+	h.subscribers[versionID][ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.subscribers[versionID], ch)
+		if len(h.subscribers[versionID]) == 0 {
+			delete(h.subscribers, versionID)
+		}
+	}
+}
+
+func (h *versionEventsHub) notify(versionID uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[versionID] {
+		wake(ch)
+	}
+}
+
+func (h *versionEventsHub) notifyAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, subscribers := range h.subscribers {
+		for ch := range subscribers {
+			wake(ch)
+		}
+	}
+}
+
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func isFinalVersionStatus(status string) bool {
+	return status == "completed" || status == "error" || status == "cancelled"
+}
+
+func convertVersionEvent(event *modelrepo.VersionEvent) (*VersionEvent, error) {
+	var result VersionEvent
+	if err := json.Unmarshal(event.Payload, &result); err != nil {
+		return nil, err
+	}
+
+	result.ID = event.ID
+	result.VersionID = event.VersionID
+	result.Type = event.EventType
+	result.CreatedAt = event.CreatedAt
+
+	return &result, nil
+}
@@ -0,0 +1,101 @@
+package tzservice
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	modelrepo "repairCopilotBot/tz-bot/internal/repository/models"
+
+	"github.com/google/uuid"
+)
+
+func TestConvertVersionEvent(t *testing.T) {
+	payload, err := json.Marshal(VersionEvent{
+		Type:       VersionEventLlmGroupProgress,
+		GroupIndex: 3,
+		GroupTotal: 7,
+	})
+	if err != nil {
+		t.Fatalf("ошибка сериализации события: %v", err)
+	}
+
+	// Служебные поля хранятся в колонках, а не в payload
+	if string(payload) != `{"group_index":3,"group_total":7}` {
+		t.Errorf("неожиданный payload: %s", payload)
+	}
+
+	versionID := uuid.New()
+	createdAt := time.Now()
+	event, err := convertVersionEvent(&modelrepo.VersionEvent{
+		ID:        42,
+		VersionID: versionID,
+		EventType: VersionEventLlmGroupProgress,
+		Payload:   payload,
+		CreatedAt: createdAt,
+	})
+	if err != nil {
+		t.Fatalf("ошибка преобразования события: %v", err)
+	}
+
+	if event.ID != 42 || event.VersionID != versionID || event.Type != VersionEventLlmGroupProgress || !event.CreatedAt.Equal(createdAt) {
+		t.Errorf("неверно заполнены служебные поля: %+v", event)
+	}
+	if event.GroupIndex != 3 || event.GroupTotal != 7 {
+		t.Errorf("неверно восстановлен прогресс: %+v", event)
+	}
+	if event.IsFinal() {
+		t.Errorf("событие прогресса не должно быть финальным")
+	}
+}
+
+func TestVersionEventsHub(t *testing.T) {
+	hub := newVersionEventsHub()
+
+	versionID := uuid.New()
+	otherVersionID := uuid.New()
+
+	first, unsubscribeFirst := hub.subscribe(versionID)
+	second, unsubscribeSecond := hub.subscribe(versionID)
+	other, unsubscribeOther := hub.subscribe(otherVersionID)
+	defer unsubscribeSecond()
+	defer unsubscribeOther()
+
+	// Несколько событий подряд сливаются в одно уведомление и не блокируют отправителя
+	hub.notify(versionID)
+	hub.notify(versionID)
+
+	for name, ch := range map[string]<-chan struct{}{"first": first, "second": second} {
+		select {
+		case <-ch:
+		default:
+			t.Errorf("подписчик %s не получил уведомление", name)
+		}
+		select {
+		case <-ch:
+			t.Errorf("подписчик %s получил лишнее уведомление", name)
+		default:
+		}
+	}
+
+	select {
+	case <-other:
+		t.Error("подписчик другой версии не должен получать уведомление")
+	default:
+	}
+
+	unsubscribeFirst()
+	hub.notify(versionID)
+	select {
+	case <-first:
+		t.Error("отписавшийся подписчик не должен получать уведомления")
+	default:
+	}
+
+	hub.notifyAll()
+	select {
+	case <-other:
+	default:
+		t.Error("notifyAll должен будить подписчиков всех версий")
+	}
+}
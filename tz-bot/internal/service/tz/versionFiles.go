@@ -0,0 +1,110 @@
+package tzservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/tz-bot/internal/pkg/logger/sl"
+	"repairCopilotBot/tz-bot/internal/repository"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Файлы версии, которые можно скачать через GetVersionFile
+const (
+	VersionFileOriginal = "original"
+	VersionFileReport   = "report"
+)
+
+// reportFileExtension - расширение отчёта в бакете reports
+const reportFileExtension = ".docx"
+
+// VersionFile - исходный документ или отчёт версии, прочитанный из S3
+type VersionFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// VersionFileLink возвращает ссылку на скачивание файла версии через api-gateway.
+// Бакеты docs и reports наружу не публикуются: файл отдаётся только после проверки доступа
+func VersionFileLink(versionID uuid.UUID, kind string) string {
+	return "/api/tz/" + versionID.String() + "/files/" + kind
+}
+
+// VersionFilePermission - право ссылки на просмотр, нужное для скачивания файла.
+// Отчёт - та же выгрузка, что и export; исходный документ по ссылке не отдаётся
+func VersionFilePermission(kind string) string {
+	if kind == VersionFileReport {
+		return SharePermissionExport
+	}
+	return ""
+}
+
+// GetVersionFile читает из S3 исходный документ или отчёт версии. Доступ к версии
+// проверяется вызывающей стороной через AuthorizeVersion
+func (tz *Tz) GetVersionFile(ctx context.Context, versionID uuid.UUID, kind string) (*VersionFile, error) {
+	const op = "Tz.GetVersionFile"
+
+	log := tz.log.With(
+		slog.String("op", op),
+		slog.String("versionID", versionID.String()),
+		slog.String("kind", kind),
+	)
+
+	if kind != VersionFileOriginal && kind != VersionFileReport {
+		return nil, ErrInvalidVersionFileKind
+	}
+
+	version, err := tz.repo.GetVersion(ctx, versionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionNotFound) {
+			return nil, ErrVersionNotFound
+		}
+		log.Error("failed to get version: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+
+	var bucket, key, extension string
+	switch kind {
+	case VersionFileOriginal:
+		bucket, key, extension = "docs", version.OriginalFileID, version.OriginalFileExtension
+	case VersionFileReport:
+		bucket, key, extension = "reports", version.CheckedFileID, reportFileExtension
+	}
+	if key == "" {
+		return nil, ErrVersionFileUnavailable
+	}
+
+	content, err := tz.s3.GetDocument(ctx, bucket, key+extension)
+	if err != nil {
+		log.Error("failed to get version file from s3: ", sl.Err(err))
+		return nil, fmt.Errorf("failed to get version file: %w", err)
+	}
+
+	return &VersionFile{
+		FileName:    key + extension,
+		ContentType: fileContentType(extension),
+		Content:     content,
+	}, nil
+}
+
+// fileContentType возвращает MIME-тип файла по расширению поддерживаемого формата
+func fileContentType(extension string) string {
+	switch DocumentFormat(strings.TrimPrefix(extension, ".")) {
+	case FormatDOCX:
+		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	case FormatDOC:
+		return "application/msword"
+	case FormatPDF:
+		return "application/pdf"
+	case FormatODT:
+		return odtMimetype
+	case FormatRTF:
+		return "application/rtf"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS processing_jobs
+(
+    id                UUID PRIMARY KEY,
+    version_id        UUID                     NOT NULL REFERENCES versions (id) ON DELETE CASCADE,
+    user_id           UUID                     NOT NULL,
+    filename          TEXT                     NOT NULL,
+    original_file_key TEXT                     NOT NULL, -- ключ оригинального файла в бакете docs
+    status            VARCHAR(20)              NOT NULL DEFAULT 'pending', -- pending, running, completed, failed
+    attempts          INTEGER                  NOT NULL DEFAULT 0,
+    last_error        TEXT,
+    run_after         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    lease_expires_at  TIMESTAMP WITH TIME ZONE,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_processing_jobs_version_id ON processing_jobs (version_id);
+CREATE INDEX IF NOT EXISTS idx_processing_jobs_status_run_after ON processing_jobs (status, run_after);
+CREATE INDEX IF NOT EXISTS idx_processing_jobs_lease_expires_at ON processing_jobs (lease_expires_at) WHERE status = 'running';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS processing_jobs;
+-- +goose StatementEnd
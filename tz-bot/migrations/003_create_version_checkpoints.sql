@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS version_checkpoints
+(
+    version_id UUID                     NOT NULL REFERENCES versions (id) ON DELETE CASCADE,
+    stage      VARCHAR(32)              NOT NULL, -- html, markdown, prompts, step1, step2
+    data       JSONB                    NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (version_id, stage)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS version_checkpoints;
+-- +goose StatementEnd
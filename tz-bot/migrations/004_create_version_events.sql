@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS version_events
+(
+    id         BIGSERIAL PRIMARY KEY,
+    version_id UUID                     NOT NULL REFERENCES versions (id) ON DELETE CASCADE,
+    event_type VARCHAR(32)              NOT NULL, -- stage_started, stage_finished, llm_group_progress, cost, final_status
+    payload    JSONB                    NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_version_events_version_id_id ON version_events (version_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS version_events;
+-- +goose StatementEnd
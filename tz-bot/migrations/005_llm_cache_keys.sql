@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ключ кэша - модель, хэш схемы ответа и хэш сообщений: одинаковые сообщения
+-- с другой моделью или схемой не должны отдавать старый ответ
+ALTER TABLE llm_cache DROP CONSTRAINT IF EXISTS llm_cache_messages_hash_key;
+ALTER TABLE llm_cache ADD COLUMN IF NOT EXISTS model VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE llm_cache ADD COLUMN IF NOT EXISTS schema_hash VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE llm_cache ADD COLUMN IF NOT EXISTS gg_id INTEGER;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_llm_cache_key ON llm_cache (model, schema_hash, messages_hash);
+CREATE INDEX IF NOT EXISTS idx_llm_cache_gg_id ON llm_cache (gg_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_llm_cache_gg_id;
+DROP INDEX IF EXISTS idx_llm_cache_key;
+
+-- Старый уникальный ключ только по хэшу сообщений
+DELETE FROM llm_cache a USING llm_cache b
+WHERE a.messages_hash = b.messages_hash AND a.created_at < b.created_at;
+
+ALTER TABLE llm_cache DROP COLUMN IF EXISTS gg_id;
+ALTER TABLE llm_cache DROP COLUMN IF EXISTS schema_hash;
+ALTER TABLE llm_cache DROP COLUMN IF EXISTS model;
+ALTER TABLE llm_cache ADD CONSTRAINT llm_cache_messages_hash_key UNIQUE (messages_hash);
+-- +goose StatementEnd
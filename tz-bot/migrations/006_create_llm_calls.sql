@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS llm_calls
+(
+    id                BIGSERIAL PRIMARY KEY,
+    version_id        UUID                     NOT NULL REFERENCES versions (id) ON DELETE CASCADE,
+    step              SMALLINT                 NOT NULL, -- 1 - проверка по группам ошибок, 2 - итоговый отчёт
+    group_id          INTEGER,
+    group_name        TEXT,
+    model             VARCHAR(255)             NOT NULL,
+    prompt_tokens     INTEGER                  NOT NULL DEFAULT 0,
+    completion_tokens INTEGER                  NOT NULL DEFAULT 0,
+    total_tokens      INTEGER                  NOT NULL DEFAULT 0,
+    rubs              DOUBLE PRECISION         NOT NULL DEFAULT 0,
+    duration_ms       BIGINT                   NOT NULL DEFAULT 0,
+    cache_hit         BOOLEAN                  NOT NULL DEFAULT FALSE,
+    attempts          INTEGER                  NOT NULL DEFAULT 1,
+    error             TEXT,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_llm_calls_version_id ON llm_calls (version_id);
+CREATE INDEX IF NOT EXISTS idx_llm_calls_group_id ON llm_calls (group_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS llm_calls;
+-- +goose StatementEnd
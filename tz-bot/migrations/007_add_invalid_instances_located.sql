@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- located - цитата найдена в HTML документа и обёрнута в span с error-id = html_id
+ALTER TABLE invalid_instances ADD COLUMN IF NOT EXISTS located BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invalid_instances DROP COLUMN IF EXISTS located;
+-- +goose StatementEnd
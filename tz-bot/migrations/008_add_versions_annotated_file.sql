@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- annotated_file_id - ключ в бакете reports (без расширения) docx-документа с замечаниями в виде комментариев Word
+ALTER TABLE versions ADD COLUMN IF NOT EXISTS annotated_file_id VARCHAR(255);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE versions DROP COLUMN IF EXISTS annotated_file_id;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- grounding_score - похожесть цитаты замечания на текст markdown документа (0..1), NULL для проверок до появления сверки цитат
+ALTER TABLE invalid_instances ADD COLUMN IF NOT EXISTS grounding_score DOUBLE PRECISION;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invalid_instances DROP COLUMN IF EXISTS grounding_score;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Поля замечания из ответа LLM: priority (low | medium | high), risks, sections - разделы документа, what_is_incorrect
+ALTER TABLE invalid_instances
+    ADD COLUMN IF NOT EXISTS priority VARCHAR(16),
+    ADD COLUMN IF NOT EXISTS risks TEXT,
+    ADD COLUMN IF NOT EXISTS sections TEXT[],
+    ADD COLUMN IF NOT EXISTS what_is_incorrect TEXT;
+
+ALTER TABLE missing_instances
+    ADD COLUMN IF NOT EXISTS priority VARCHAR(16),
+    ADD COLUMN IF NOT EXISTS risks TEXT,
+    ADD COLUMN IF NOT EXISTS sections TEXT[],
+    ADD COLUMN IF NOT EXISTS what_is_incorrect TEXT;
+
+-- Для старых проверок риски известны только из rationale вида "Риски: ..."
+UPDATE invalid_instances SET risks = substring(rationale FROM 8) WHERE risks IS NULL AND rationale LIKE 'Риски: %';
+UPDATE missing_instances SET risks = substring(rationale FROM 8) WHERE risks IS NULL AND rationale LIKE 'Риски: %';
+
+CREATE INDEX IF NOT EXISTS idx_invalid_instances_priority ON invalid_instances (priority);
+CREATE INDEX IF NOT EXISTS idx_missing_instances_priority ON missing_instances (priority);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_missing_instances_priority;
+DROP INDEX IF EXISTS idx_invalid_instances_priority;
+
+ALTER TABLE missing_instances
+    DROP COLUMN IF EXISTS what_is_incorrect,
+    DROP COLUMN IF EXISTS sections,
+    DROP COLUMN IF EXISTS risks,
+    DROP COLUMN IF EXISTS priority;
+
+ALTER TABLE invalid_instances
+    DROP COLUMN IF EXISTS what_is_incorrect,
+    DROP COLUMN IF EXISTS sections,
+    DROP COLUMN IF EXISTS risks,
+    DROP COLUMN IF EXISTS priority;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- file_hash - sha256 загруженного файла, markdown_hash - sha256 нормализованного markdown документа.
+-- recheck_of_version_id - завершённая версия с тем же содержимым, если проверка была запущена повторно
+ALTER TABLE versions
+    ADD COLUMN IF NOT EXISTS file_hash VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS markdown_hash VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS recheck_of_version_id UUID REFERENCES versions (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_versions_file_hash ON versions (file_hash) WHERE file_hash IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_versions_markdown_hash ON versions (markdown_hash) WHERE markdown_hash IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_versions_markdown_hash;
+DROP INDEX IF EXISTS idx_versions_file_hash;
+
+ALTER TABLE versions
+    DROP COLUMN IF EXISTS recheck_of_version_id,
+    DROP COLUMN IF EXISTS markdown_hash,
+    DROP COLUMN IF EXISTS file_hash;
+-- +goose StatementEnd
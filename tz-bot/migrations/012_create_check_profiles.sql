@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Профили проверки: набор групп ошибок (gg_id промт-билдера) под тип закупки.
+-- Профиль по умолчанию используется, если при загрузке профиль не указан
+CREATE TABLE IF NOT EXISTS check_profiles
+(
+    id          UUID PRIMARY KEY,
+    name        VARCHAR(64)              NOT NULL UNIQUE, -- код профиля: construction, it_procurement
+    title       TEXT                     NOT NULL,
+    description TEXT,
+    gg_id       INTEGER                  NOT NULL,
+    is_default  BOOLEAN                  NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_check_profiles_default ON check_profiles (is_default) WHERE is_default;
+
+-- Прежнее глобальное значение ggID становится профилем по умолчанию
+INSERT INTO check_profiles (id, name, title, gg_id, is_default)
+VALUES ('6f1c1b8e-3d0a-4c59-9d7e-000000000006', 'default', 'Стандартная проверка', 6, TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+-- Профиль и gg_id, с которыми запущена проверка версии. gg_id хранится отдельно:
+-- профиль могут изменить или удалить после проверки
+ALTER TABLE versions
+    ADD COLUMN IF NOT EXISTS check_profile_id UUID REFERENCES check_profiles (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS gg_id INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE versions
+    DROP COLUMN IF EXISTS gg_id,
+    DROP COLUMN IF EXISTS check_profile_id;
+
+DROP TABLE IF EXISTS check_profiles;
+-- +goose StatementEnd
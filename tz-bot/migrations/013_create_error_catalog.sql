@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Каталог ошибок: коды, названия, описания и детекторы из промт-билдера. При изменении
+-- описания создаётся новая версия записи, старая остаётся для ранее проверенных документов
+CREATE TABLE IF NOT EXISTS error_catalog
+(
+    id            UUID PRIMARY KEY,
+    gg_id         INTEGER                  NOT NULL,
+    code          TEXT                     NOT NULL,
+    version       INTEGER                  NOT NULL,
+    name          TEXT                     NOT NULL,
+    description   TEXT                     NOT NULL,
+    detector      TEXT                     NOT NULL,
+    content_hash  VARCHAR(64)              NOT NULL, -- sha256 названия, описания и детектора
+    is_current    BOOLEAN                  NOT NULL DEFAULT TRUE,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    superseded_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (gg_id, code, version)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_error_catalog_current ON error_catalog (gg_id, code) WHERE is_current;
+
+-- Версия записи каталога, с которой сформирована ошибка
+ALTER TABLE errors
+    ADD COLUMN IF NOT EXISTS catalog_entry_id UUID REFERENCES error_catalog (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_errors_catalog_entry_id ON errors (catalog_entry_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_errors_catalog_entry_id;
+
+ALTER TABLE errors
+    DROP COLUMN IF EXISTS catalog_entry_id;
+
+DROP TABLE IF EXISTS error_catalog;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ссылки для просмотра результата проверки без входа в систему. Токен хранится только в виде
+-- sha256: сама ссылка показывается один раз при создании
+CREATE TABLE IF NOT EXISTS share_links
+(
+    id               UUID PRIMARY KEY,
+    version_id       UUID                     NOT NULL REFERENCES versions (id) ON DELETE CASCADE,
+    token_hash       VARCHAR(64)              NOT NULL UNIQUE,
+    permissions      TEXT[]                   NOT NULL, -- view, export
+    created_by       UUID                     NOT NULL,
+    expires_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at       TIMESTAMP WITH TIME ZONE,
+    access_count     INTEGER                  NOT NULL DEFAULT 0,
+    last_accessed_at TIMESTAMP WITH TIME ZONE,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_links_version_id ON share_links (version_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS share_links;
+-- +goose StatementEnd
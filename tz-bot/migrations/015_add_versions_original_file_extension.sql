@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Оригинал хранится в S3 с расширением исходного формата (.docx, .pdf, .odt, .rtf),
+-- поэтому ссылку на него нельзя строить с жёстко заданным .docx
+ALTER TABLE versions
+    ADD COLUMN IF NOT EXISTS original_file_extension VARCHAR(8) NOT NULL DEFAULT '.docx';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE versions
+    DROP COLUMN IF EXISTS original_file_extension;
+-- +goose StatementEnd
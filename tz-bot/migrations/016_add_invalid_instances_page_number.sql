@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Номер страницы исходного PDF, на которой находится цитата замечания
+ALTER TABLE invalid_instances
+    ADD COLUMN IF NOT EXISTS page_number INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invalid_instances
+    DROP COLUMN IF EXISTS page_number;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Организация, из квоты которой оплачена проверка. Версия видна участникам этой организации,
+-- а не текущим коллегам владельца: вступление в организацию не открывает ей прежние проверки.
+-- У версий, созданных до появления колонки, организации нет - они доступны только владельцу
+ALTER TABLE versions
+    ADD COLUMN IF NOT EXISTS organization_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_versions_organization_id ON versions (organization_id)
+    WHERE organization_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_versions_organization_id;
+
+ALTER TABLE versions
+    DROP COLUMN IF EXISTS organization_id;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Квота, из которой списана последняя проверка версии: организация или, если NULL, личный лимит
+-- владельца. При отмене или ошибке проверка возвращается именно туда, даже если владелец успел
+-- сменить организацию. В отличие от organization_id меняется при повторном запуске проверки
+ALTER TABLE versions
+    ADD COLUMN IF NOT EXISTS charged_organization_id UUID;
+
+UPDATE versions SET charged_organization_id = organization_id WHERE charged_organization_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE versions
+    DROP COLUMN IF EXISTS charged_organization_id;
+-- +goose StatementEnd
@@ -22,18 +22,88 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Пользователь, от имени которого выполняется запрос. По нему tz-bot проверяет доступ к версии:
+// владельцу ТЗ, администратору или посетителю по ссылке на просмотр
+type Caller struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// user | admin | shared
+	Role string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// Токен ссылки на просмотр для роли shared
+	ShareToken    string `protobuf:"bytes,3,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Caller) Reset() {
+	*x = Caller{}
+	mi := &file_tz_v1_tz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Caller) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Caller) ProtoMessage() {}
+
+func (x *Caller) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Caller.ProtoReflect.Descriptor instead.
+func (*Caller) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Caller) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Caller) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Caller) GetShareToken() string {
+	if x != nil {
+		return x.ShareToken
+	}
+	return ""
+}
+
 type CheckTzRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	File          []byte                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	RequestId     string                 `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	File      []byte                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Filename  string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	RequestId string                 `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// force - проверить файл заново, даже если он уже проверялся
+	Force bool `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
+	// check_profile - код профиля проверки, по умолчанию - профиль по умолчанию
+	CheckProfile *string `protobuf:"bytes,5,opt,name=check_profile,json=checkProfile,proto3,oneof" json:"check_profile,omitempty"`
+	// gg_id - группа ошибок промт-билдера вместо gg_id профиля
+	GgId          *int32 `protobuf:"varint,6,opt,name=gg_id,json=ggId,proto3,oneof" json:"gg_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CheckTzRequest) Reset() {
 	*x = CheckTzRequest{}
-	mi := &file_tz_v1_tz_proto_msgTypes[0]
+	mi := &file_tz_v1_tz_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -45,7 +115,7 @@ func (x *CheckTzRequest) String() string {
 func (*CheckTzRequest) ProtoMessage() {}
 
 func (x *CheckTzRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[0]
+	mi := &file_tz_v1_tz_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,7 +128,7 @@ func (x *CheckTzRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckTzRequest.ProtoReflect.Descriptor instead.
 func (*CheckTzRequest) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{0}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *CheckTzRequest) GetFile() []byte {
@@ -82,18 +152,46 @@ func (x *CheckTzRequest) GetRequestId() string {
 	return ""
 }
 
+func (x *CheckTzRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *CheckTzRequest) GetCheckProfile() string {
+	if x != nil && x.CheckProfile != nil {
+		return *x.CheckProfile
+	}
+	return ""
+}
+
+func (x *CheckTzRequest) GetGgId() int32 {
+	if x != nil && x.GgId != nil {
+		return *x.GgId
+	}
+	return 0
+}
+
 type CheckTzResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// duplicate - файл уже проверялся, id - завершённая версия, новая проверка не запускалась
+	Duplicate bool `protobuf:"varint,4,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	// recheck_of_version_id - ранее проверенная версия с тем же файлом при force = true
+	RecheckOfVersionId *string `protobuf:"bytes,5,opt,name=recheck_of_version_id,json=recheckOfVersionId,proto3,oneof" json:"recheck_of_version_id,omitempty"`
+	// Профиль и группа ошибок, с которыми проверяется версия
+	CheckProfile  string `protobuf:"bytes,6,opt,name=check_profile,json=checkProfile,proto3" json:"check_profile,omitempty"`
+	GgId          int32  `protobuf:"varint,7,opt,name=gg_id,json=ggId,proto3" json:"gg_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CheckTzResponse) Reset() {
 	*x = CheckTzResponse{}
-	mi := &file_tz_v1_tz_proto_msgTypes[1]
+	mi := &file_tz_v1_tz_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -105,7 +203,7 @@ func (x *CheckTzResponse) String() string {
 func (*CheckTzResponse) ProtoMessage() {}
 
 func (x *CheckTzResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[1]
+	mi := &file_tz_v1_tz_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,7 +216,7 @@ func (x *CheckTzResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckTzResponse.ProtoReflect.Descriptor instead.
 func (*CheckTzResponse) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{1}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CheckTzResponse) GetId() string {
@@ -142,6 +240,234 @@ func (x *CheckTzResponse) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *CheckTzResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *CheckTzResponse) GetRecheckOfVersionId() string {
+	if x != nil && x.RecheckOfVersionId != nil {
+		return *x.RecheckOfVersionId
+	}
+	return ""
+}
+
+func (x *CheckTzResponse) GetCheckProfile() string {
+	if x != nil {
+		return x.CheckProfile
+	}
+	return ""
+}
+
+func (x *CheckTzResponse) GetGgId() int32 {
+	if x != nil {
+		return x.GgId
+	}
+	return 0
+}
+
+type CheckTzVersionRequest struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	TechnicalSpecificationId string                 `protobuf:"bytes,1,opt,name=technical_specification_id,json=technicalSpecificationId,proto3" json:"technical_specification_id,omitempty"`
+	File                     []byte                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Filename                 string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	UserId                   string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Force                    bool                   `protobuf:"varint,5,opt,name=force,proto3" json:"force,omitempty"`
+	CheckProfile             *string                `protobuf:"bytes,6,opt,name=check_profile,json=checkProfile,proto3,oneof" json:"check_profile,omitempty"`
+	GgId                     *int32                 `protobuf:"varint,7,opt,name=gg_id,json=ggId,proto3,oneof" json:"gg_id,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CheckTzVersionRequest) Reset() {
+	*x = CheckTzVersionRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckTzVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckTzVersionRequest) ProtoMessage() {}
+
+func (x *CheckTzVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckTzVersionRequest.ProtoReflect.Descriptor instead.
+func (*CheckTzVersionRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckTzVersionRequest) GetTechnicalSpecificationId() string {
+	if x != nil {
+		return x.TechnicalSpecificationId
+	}
+	return ""
+}
+
+func (x *CheckTzVersionRequest) GetFile() []byte {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *CheckTzVersionRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *CheckTzVersionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckTzVersionRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *CheckTzVersionRequest) GetCheckProfile() string {
+	if x != nil && x.CheckProfile != nil {
+		return *x.CheckProfile
+	}
+	return ""
+}
+
+func (x *CheckTzVersionRequest) GetGgId() int32 {
+	if x != nil && x.GgId != nil {
+		return *x.GgId
+	}
+	return 0
+}
+
+type CheckTzVersionResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Id                       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt                *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TechnicalSpecificationId string                 `protobuf:"bytes,4,opt,name=technical_specification_id,json=technicalSpecificationId,proto3" json:"technical_specification_id,omitempty"`
+	VersionNumber            int32                  `protobuf:"varint,5,opt,name=version_number,json=versionNumber,proto3" json:"version_number,omitempty"`
+	Duplicate                bool                   `protobuf:"varint,6,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	RecheckOfVersionId       *string                `protobuf:"bytes,7,opt,name=recheck_of_version_id,json=recheckOfVersionId,proto3,oneof" json:"recheck_of_version_id,omitempty"`
+	CheckProfile             string                 `protobuf:"bytes,8,opt,name=check_profile,json=checkProfile,proto3" json:"check_profile,omitempty"`
+	GgId                     int32                  `protobuf:"varint,9,opt,name=gg_id,json=ggId,proto3" json:"gg_id,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CheckTzVersionResponse) Reset() {
+	*x = CheckTzVersionResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckTzVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckTzVersionResponse) ProtoMessage() {}
+
+func (x *CheckTzVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckTzVersionResponse.ProtoReflect.Descriptor instead.
+func (*CheckTzVersionResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckTzVersionResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CheckTzVersionResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CheckTzVersionResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CheckTzVersionResponse) GetTechnicalSpecificationId() string {
+	if x != nil {
+		return x.TechnicalSpecificationId
+	}
+	return ""
+}
+
+func (x *CheckTzVersionResponse) GetVersionNumber() int32 {
+	if x != nil {
+		return x.VersionNumber
+	}
+	return 0
+}
+
+func (x *CheckTzVersionResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *CheckTzVersionResponse) GetRecheckOfVersionId() string {
+	if x != nil && x.RecheckOfVersionId != nil {
+		return *x.RecheckOfVersionId
+	}
+	return ""
+}
+
+func (x *CheckTzVersionResponse) GetCheckProfile() string {
+	if x != nil {
+		return x.CheckProfile
+	}
+	return ""
+}
+
+func (x *CheckTzVersionResponse) GetGgId() int32 {
+	if x != nil {
+		return x.GgId
+	}
+	return 0
+}
+
 type Error struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -160,13 +486,16 @@ type Error struct {
 	InvalidInstances    []*InvalidInstance     `protobuf:"bytes,14,rep,name=invalid_instances,json=invalidInstances,proto3" json:"invalid_instances,omitempty"`
 	MissingInstances    []*MissingInstance     `protobuf:"bytes,15,rep,name=missing_instances,json=missingInstances,proto3" json:"missing_instances,omitempty"`
 	OrderNumber         int32                  `protobuf:"varint,16,opt,name=order_number,json=orderNumber,proto3" json:"order_number,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// Запись каталога ошибок, с описанием из которой сформирована ошибка
+	CatalogEntryId *string `protobuf:"bytes,17,opt,name=catalog_entry_id,json=catalogEntryId,proto3,oneof" json:"catalog_entry_id,omitempty"`
+	CatalogVersion *int32  `protobuf:"varint,18,opt,name=catalog_version,json=catalogVersion,proto3,oneof" json:"catalog_version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_tz_v1_tz_proto_msgTypes[2]
+	mi := &file_tz_v1_tz_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -178,7 +507,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[2]
+	mi := &file_tz_v1_tz_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -191,7 +520,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{2}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Error) GetId() string {
@@ -306,6 +635,20 @@ func (x *Error) GetOrderNumber() int32 {
 	return 0
 }
 
+func (x *Error) GetCatalogEntryId() string {
+	if x != nil && x.CatalogEntryId != nil {
+		return *x.CatalogEntryId
+	}
+	return ""
+}
+
+func (x *Error) GetCatalogVersion() int32 {
+	if x != nil && x.CatalogVersion != nil {
+		return *x.CatalogVersion
+	}
+	return 0
+}
+
 type InvalidInstance struct {
 	state                       protoimpl.MessageState `protogen:"open.v1"`
 	Id                          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -330,13 +673,24 @@ type InvalidInstance struct {
 	FeedbackVerificationMark    *bool                  `protobuf:"varint,21,opt,name=feedback_verification_mark,json=feedbackVerificationMark,proto3,oneof" json:"feedback_verification_mark,omitempty"`
 	FeedbackVerificationComment *string                `protobuf:"bytes,22,opt,name=feedback_verification_comment,json=feedbackVerificationComment,proto3,oneof" json:"feedback_verification_comment,omitempty"`
 	FeedbackVerificationUser    *string                `protobuf:"bytes,23,opt,name=feedback_verification_user,json=feedbackVerificationUser,proto3,oneof" json:"feedback_verification_user,omitempty"`
-	unknownFields               protoimpl.UnknownFields
-	sizeCache                   protoimpl.SizeCache
+	// Цитата найдена в HTML документа и обёрнута в span с error-id = html_id
+	Located bool `protobuf:"varint,24,opt,name=located,proto3" json:"located,omitempty"`
+	// Похожесть цитаты на текст markdown документа (0..1), отсутствует у проверок до сверки цитат
+	GroundingScore *float64 `protobuf:"fixed64,25,opt,name=grounding_score,json=groundingScore,proto3,oneof" json:"grounding_score,omitempty"`
+	// low | medium | high
+	Priority        *string  `protobuf:"bytes,26,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	Risks           *string  `protobuf:"bytes,27,opt,name=risks,proto3,oneof" json:"risks,omitempty"`
+	Sections        []string `protobuf:"bytes,28,rep,name=sections,proto3" json:"sections,omitempty"`
+	WhatIsIncorrect *string  `protobuf:"bytes,29,opt,name=what_is_incorrect,json=whatIsIncorrect,proto3,oneof" json:"what_is_incorrect,omitempty"`
+	// Страница исходного PDF, на которой находится цитата; только для PDF
+	PageNumber    *int32 `protobuf:"varint,30,opt,name=page_number,json=pageNumber,proto3,oneof" json:"page_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InvalidInstance) Reset() {
 	*x = InvalidInstance{}
-	mi := &file_tz_v1_tz_proto_msgTypes[3]
+	mi := &file_tz_v1_tz_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -348,7 +702,7 @@ func (x *InvalidInstance) String() string {
 func (*InvalidInstance) ProtoMessage() {}
 
 func (x *InvalidInstance) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[3]
+	mi := &file_tz_v1_tz_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -361,7 +715,7 @@ func (x *InvalidInstance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvalidInstance.ProtoReflect.Descriptor instead.
 func (*InvalidInstance) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{3}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *InvalidInstance) GetId() string {
@@ -518,28 +872,82 @@ func (x *InvalidInstance) GetFeedbackVerificationUser() string {
 	return ""
 }
 
-type MissingInstance struct {
-	state                       protoimpl.MessageState `protogen:"open.v1"`
-	Id                          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	HtmlId                      uint32                 `protobuf:"varint,2,opt,name=html_id,json=htmlId,proto3" json:"html_id,omitempty"`
-	ErrorId                     string                 `protobuf:"bytes,3,opt,name=error_id,json=errorId,proto3" json:"error_id,omitempty"`
-	SuggestedFix                string                 `protobuf:"bytes,4,opt,name=suggested_fix,json=suggestedFix,proto3" json:"suggested_fix,omitempty"`
-	Rationale                   string                 `protobuf:"bytes,5,opt,name=rationale,proto3" json:"rationale,omitempty"`
-	FeedbackExists              bool                   `protobuf:"varint,16,opt,name=feedback_exists,json=feedbackExists,proto3" json:"feedback_exists,omitempty"`
-	FeedbackMark                *bool                  `protobuf:"varint,17,opt,name=feedback_mark,json=feedbackMark,proto3,oneof" json:"feedback_mark,omitempty"`
+func (x *InvalidInstance) GetLocated() bool {
+	if x != nil {
+		return x.Located
+	}
+	return false
+}
+
+func (x *InvalidInstance) GetGroundingScore() float64 {
+	if x != nil && x.GroundingScore != nil {
+		return *x.GroundingScore
+	}
+	return 0
+}
+
+func (x *InvalidInstance) GetPriority() string {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return ""
+}
+
+func (x *InvalidInstance) GetRisks() string {
+	if x != nil && x.Risks != nil {
+		return *x.Risks
+	}
+	return ""
+}
+
+func (x *InvalidInstance) GetSections() []string {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+func (x *InvalidInstance) GetWhatIsIncorrect() string {
+	if x != nil && x.WhatIsIncorrect != nil {
+		return *x.WhatIsIncorrect
+	}
+	return ""
+}
+
+func (x *InvalidInstance) GetPageNumber() int32 {
+	if x != nil && x.PageNumber != nil {
+		return *x.PageNumber
+	}
+	return 0
+}
+
+type MissingInstance struct {
+	state                       protoimpl.MessageState `protogen:"open.v1"`
+	Id                          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	HtmlId                      uint32                 `protobuf:"varint,2,opt,name=html_id,json=htmlId,proto3" json:"html_id,omitempty"`
+	ErrorId                     string                 `protobuf:"bytes,3,opt,name=error_id,json=errorId,proto3" json:"error_id,omitempty"`
+	SuggestedFix                string                 `protobuf:"bytes,4,opt,name=suggested_fix,json=suggestedFix,proto3" json:"suggested_fix,omitempty"`
+	Rationale                   string                 `protobuf:"bytes,5,opt,name=rationale,proto3" json:"rationale,omitempty"`
+	FeedbackExists              bool                   `protobuf:"varint,16,opt,name=feedback_exists,json=feedbackExists,proto3" json:"feedback_exists,omitempty"`
+	FeedbackMark                *bool                  `protobuf:"varint,17,opt,name=feedback_mark,json=feedbackMark,proto3,oneof" json:"feedback_mark,omitempty"`
 	FeedbackComment             *string                `protobuf:"bytes,18,opt,name=feedback_comment,json=feedbackComment,proto3,oneof" json:"feedback_comment,omitempty"`
 	FeedbackUser                *string                `protobuf:"bytes,19,opt,name=feedback_user,json=feedbackUser,proto3,oneof" json:"feedback_user,omitempty"`
 	FeedbackVerificationExists  bool                   `protobuf:"varint,20,opt,name=feedback_verification_exists,json=feedbackVerificationExists,proto3" json:"feedback_verification_exists,omitempty"`
 	FeedbackVerificationMark    *bool                  `protobuf:"varint,21,opt,name=feedback_verification_mark,json=feedbackVerificationMark,proto3,oneof" json:"feedback_verification_mark,omitempty"`
 	FeedbackVerificationComment *string                `protobuf:"bytes,22,opt,name=feedback_verification_comment,json=feedbackVerificationComment,proto3,oneof" json:"feedback_verification_comment,omitempty"`
 	FeedbackVerificationUser    *string                `protobuf:"bytes,23,opt,name=feedback_verification_user,json=feedbackVerificationUser,proto3,oneof" json:"feedback_verification_user,omitempty"`
-	unknownFields               protoimpl.UnknownFields
-	sizeCache                   protoimpl.SizeCache
+	// low | medium | high
+	Priority        *string  `protobuf:"bytes,24,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	Risks           *string  `protobuf:"bytes,25,opt,name=risks,proto3,oneof" json:"risks,omitempty"`
+	Sections        []string `protobuf:"bytes,26,rep,name=sections,proto3" json:"sections,omitempty"`
+	WhatIsIncorrect *string  `protobuf:"bytes,27,opt,name=what_is_incorrect,json=whatIsIncorrect,proto3,oneof" json:"what_is_incorrect,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *MissingInstance) Reset() {
 	*x = MissingInstance{}
-	mi := &file_tz_v1_tz_proto_msgTypes[4]
+	mi := &file_tz_v1_tz_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -551,7 +959,7 @@ func (x *MissingInstance) String() string {
 func (*MissingInstance) ProtoMessage() {}
 
 func (x *MissingInstance) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[4]
+	mi := &file_tz_v1_tz_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,7 +972,7 @@ func (x *MissingInstance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MissingInstance.ProtoReflect.Descriptor instead.
 func (*MissingInstance) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{4}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *MissingInstance) GetId() string {
@@ -658,16 +1066,45 @@ func (x *MissingInstance) GetFeedbackVerificationUser() string {
 	return ""
 }
 
+func (x *MissingInstance) GetPriority() string {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return ""
+}
+
+func (x *MissingInstance) GetRisks() string {
+	if x != nil && x.Risks != nil {
+		return *x.Risks
+	}
+	return ""
+}
+
+func (x *MissingInstance) GetSections() []string {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+func (x *MissingInstance) GetWhatIsIncorrect() string {
+	if x != nil && x.WhatIsIncorrect != nil {
+		return *x.WhatIsIncorrect
+	}
+	return ""
+}
+
 type GetVersionsMeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Organization  bool                   `protobuf:"varint,2,opt,name=organization,proto3" json:"organization,omitempty"` // версии всех участников организации пользователя
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetVersionsMeRequest) Reset() {
 	*x = GetVersionsMeRequest{}
-	mi := &file_tz_v1_tz_proto_msgTypes[5]
+	mi := &file_tz_v1_tz_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -679,7 +1116,7 @@ func (x *GetVersionsMeRequest) String() string {
 func (*GetVersionsMeRequest) ProtoMessage() {}
 
 func (x *GetVersionsMeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[5]
+	mi := &file_tz_v1_tz_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,7 +1129,7 @@ func (x *GetVersionsMeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionsMeRequest.ProtoReflect.Descriptor instead.
 func (*GetVersionsMeRequest) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{5}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetVersionsMeRequest) GetUserId() string {
@@ -702,6 +1139,13 @@ func (x *GetVersionsMeRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GetVersionsMeRequest) GetOrganization() bool {
+	if x != nil {
+		return x.Organization
+	}
+	return false
+}
+
 type GetVersionsMeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Versions      []*VersionMe           `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
@@ -711,7 +1155,7 @@ type GetVersionsMeResponse struct {
 
 func (x *GetVersionsMeResponse) Reset() {
 	*x = GetVersionsMeResponse{}
-	mi := &file_tz_v1_tz_proto_msgTypes[6]
+	mi := &file_tz_v1_tz_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -723,7 +1167,7 @@ func (x *GetVersionsMeResponse) String() string {
 func (*GetVersionsMeResponse) ProtoMessage() {}
 
 func (x *GetVersionsMeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[6]
+	mi := &file_tz_v1_tz_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -736,7 +1180,7 @@ func (x *GetVersionsMeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionsMeResponse.ProtoReflect.Descriptor instead.
 func (*GetVersionsMeResponse) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{6}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetVersionsMeResponse) GetVersions() []*VersionMe {
@@ -756,13 +1200,15 @@ type VersionMe struct {
 	ReportFileLink             *string                `protobuf:"bytes,6,opt,name=report_file_link,json=reportFileLink,proto3,oneof" json:"report_file_link,omitempty"`
 	Status                     string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
 	Progress                   int32                  `protobuf:"varint,8,opt,name=progress,proto3" json:"progress,omitempty"`
+	TechnicalSpecificationId   string                 `protobuf:"bytes,9,opt,name=technical_specification_id,json=technicalSpecificationId,proto3" json:"technical_specification_id,omitempty"`
+	UserId                     string                 `protobuf:"bytes,10,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // владелец ТЗ
 	unknownFields              protoimpl.UnknownFields
 	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *VersionMe) Reset() {
 	*x = VersionMe{}
-	mi := &file_tz_v1_tz_proto_msgTypes[7]
+	mi := &file_tz_v1_tz_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -774,7 +1220,7 @@ func (x *VersionMe) String() string {
 func (*VersionMe) ProtoMessage() {}
 
 func (x *VersionMe) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[7]
+	mi := &file_tz_v1_tz_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -787,7 +1233,7 @@ func (x *VersionMe) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VersionMe.ProtoReflect.Descriptor instead.
 func (*VersionMe) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{7}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *VersionMe) GetVersionId() string {
@@ -846,16 +1292,38 @@ func (x *VersionMe) GetProgress() int32 {
 	return 0
 }
 
+func (x *VersionMe) GetTechnicalSpecificationId() string {
+	if x != nil {
+		return x.TechnicalSpecificationId
+	}
+	return ""
+}
+
+func (x *VersionMe) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type GetVersionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	VersionId     string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VersionId string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	// Фильтры замечаний, пустой список не ограничивает выборку
+	Priorities []string `protobuf:"bytes,2,rep,name=priorities,proto3" json:"priorities,omitempty"`
+	// Номера или названия разделов документа, сравниваются по вхождению без учёта регистра
+	Sections   []string `protobuf:"bytes,3,rep,name=sections,proto3" json:"sections,omitempty"`
+	ErrorCodes []string `protobuf:"bytes,4,rep,name=error_codes,json=errorCodes,proto3" json:"error_codes,omitempty"`
+	// Порядок замечаний: order (по умолчанию, порядок в документе) | priority | error_code
+	Sort          string  `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	Caller        *Caller `protobuf:"bytes,6,opt,name=caller,proto3" json:"caller,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetVersionRequest) Reset() {
 	*x = GetVersionRequest{}
-	mi := &file_tz_v1_tz_proto_msgTypes[8]
+	mi := &file_tz_v1_tz_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -867,7 +1335,7 @@ func (x *GetVersionRequest) String() string {
 func (*GetVersionRequest) ProtoMessage() {}
 
 func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[8]
+	mi := &file_tz_v1_tz_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -880,7 +1348,7 @@ func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
 func (*GetVersionRequest) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{8}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetVersionRequest) GetVersionId() string {
@@ -890,10 +1358,46 @@ func (x *GetVersionRequest) GetVersionId() string {
 	return ""
 }
 
+func (x *GetVersionRequest) GetPriorities() []string {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+func (x *GetVersionRequest) GetSections() []string {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+func (x *GetVersionRequest) GetErrorCodes() []string {
+	if x != nil {
+		return x.ErrorCodes
+	}
+	return nil
+}
+
+func (x *GetVersionRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *GetVersionRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
 type GetVersionResponse struct {
-	state                            protoimpl.MessageState `protogen:"open.v1"`
-	HtmlText                         *string                `protobuf:"bytes,1,opt,name=html_text,json=htmlText,proto3,oneof" json:"html_text,omitempty"`
-	Css                              *string                `protobuf:"bytes,2,opt,name=css,proto3,oneof" json:"css,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	HtmlText *string                `protobuf:"bytes,1,opt,name=html_text,json=htmlText,proto3,oneof" json:"html_text,omitempty"`
+	Css      *string                `protobuf:"bytes,2,opt,name=css,proto3,oneof" json:"css,omitempty"`
+	// Ссылки на скачивание отчёта и исходного документа через api-gateway с проверкой доступа
 	DocId                            *string                `protobuf:"bytes,3,opt,name=docId,proto3,oneof" json:"docId,omitempty"`
 	FileId                           *string                `protobuf:"bytes,4,opt,name=fileId,proto3,oneof" json:"fileId,omitempty"`
 	Errors                           []*Error               `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
@@ -915,7 +1419,7 @@ type GetVersionResponse struct {
 
 func (x *GetVersionResponse) Reset() {
 	*x = GetVersionResponse{}
-	mi := &file_tz_v1_tz_proto_msgTypes[9]
+	mi := &file_tz_v1_tz_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -927,7 +1431,7 @@ func (x *GetVersionResponse) String() string {
 func (*GetVersionResponse) ProtoMessage() {}
 
 func (x *GetVersionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[9]
+	mi := &file_tz_v1_tz_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -940,7 +1444,7 @@ func (x *GetVersionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionResponse.ProtoReflect.Descriptor instead.
 func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{9}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetVersionResponse) GetHtmlText() string {
@@ -1071,7 +1575,7 @@ type GetAllVersionsAdminDashboardRequest struct {
 
 func (x *GetAllVersionsAdminDashboardRequest) Reset() {
 	*x = GetAllVersionsAdminDashboardRequest{}
-	mi := &file_tz_v1_tz_proto_msgTypes[10]
+	mi := &file_tz_v1_tz_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1083,7 +1587,7 @@ func (x *GetAllVersionsAdminDashboardRequest) String() string {
 func (*GetAllVersionsAdminDashboardRequest) ProtoMessage() {}
 
 func (x *GetAllVersionsAdminDashboardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[10]
+	mi := &file_tz_v1_tz_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1096,7 +1600,7 @@ func (x *GetAllVersionsAdminDashboardRequest) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use GetAllVersionsAdminDashboardRequest.ProtoReflect.Descriptor instead.
 func (*GetAllVersionsAdminDashboardRequest) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{10}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetAllVersionsAdminDashboardRequest) GetUserId() string {
@@ -1115,7 +1619,7 @@ type GetAllVersionsAdminDashboardResponse struct {
 
 func (x *GetAllVersionsAdminDashboardResponse) Reset() {
 	*x = GetAllVersionsAdminDashboardResponse{}
-	mi := &file_tz_v1_tz_proto_msgTypes[11]
+	mi := &file_tz_v1_tz_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1127,7 +1631,7 @@ func (x *GetAllVersionsAdminDashboardResponse) String() string {
 func (*GetAllVersionsAdminDashboardResponse) ProtoMessage() {}
 
 func (x *GetAllVersionsAdminDashboardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[11]
+	mi := &file_tz_v1_tz_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1140,7 +1644,7 @@ func (x *GetAllVersionsAdminDashboardResponse) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use GetAllVersionsAdminDashboardResponse.ProtoReflect.Descriptor instead.
 func (*GetAllVersionsAdminDashboardResponse) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{11}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetAllVersionsAdminDashboardResponse) GetVersions() []*VersionAdminDashboard {
@@ -1171,7 +1675,7 @@ type VersionAdminDashboard struct {
 
 func (x *VersionAdminDashboard) Reset() {
 	*x = VersionAdminDashboard{}
-	mi := &file_tz_v1_tz_proto_msgTypes[12]
+	mi := &file_tz_v1_tz_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1183,7 +1687,7 @@ func (x *VersionAdminDashboard) String() string {
 func (*VersionAdminDashboard) ProtoMessage() {}
 
 func (x *VersionAdminDashboard) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[12]
+	mi := &file_tz_v1_tz_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1196,7 +1700,7 @@ func (x *VersionAdminDashboard) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VersionAdminDashboard.ProtoReflect.Descriptor instead.
 func (*VersionAdminDashboard) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{12}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *VersionAdminDashboard) GetVersionId() string {
@@ -1298,7 +1802,7 @@ type GetVersionStatisticsRequest struct {
 
 func (x *GetVersionStatisticsRequest) Reset() {
 	*x = GetVersionStatisticsRequest{}
-	mi := &file_tz_v1_tz_proto_msgTypes[13]
+	mi := &file_tz_v1_tz_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1310,7 +1814,7 @@ func (x *GetVersionStatisticsRequest) String() string {
 func (*GetVersionStatisticsRequest) ProtoMessage() {}
 
 func (x *GetVersionStatisticsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[13]
+	mi := &file_tz_v1_tz_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1323,7 +1827,7 @@ func (x *GetVersionStatisticsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionStatisticsRequest.ProtoReflect.Descriptor instead.
 func (*GetVersionStatisticsRequest) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{13}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{16}
 }
 
 type GetVersionStatisticsResponse struct {
@@ -1335,7 +1839,7 @@ type GetVersionStatisticsResponse struct {
 
 func (x *GetVersionStatisticsResponse) Reset() {
 	*x = GetVersionStatisticsResponse{}
-	mi := &file_tz_v1_tz_proto_msgTypes[14]
+	mi := &file_tz_v1_tz_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1347,7 +1851,7 @@ func (x *GetVersionStatisticsResponse) String() string {
 func (*GetVersionStatisticsResponse) ProtoMessage() {}
 
 func (x *GetVersionStatisticsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[14]
+	mi := &file_tz_v1_tz_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1360,7 +1864,7 @@ func (x *GetVersionStatisticsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionStatisticsResponse.ProtoReflect.Descriptor instead.
 func (*GetVersionStatisticsResponse) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{14}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetVersionStatisticsResponse) GetStatistics() *VersionStatistics {
@@ -1382,7 +1886,7 @@ type VersionStatistics struct {
 
 func (x *VersionStatistics) Reset() {
 	*x = VersionStatistics{}
-	mi := &file_tz_v1_tz_proto_msgTypes[15]
+	mi := &file_tz_v1_tz_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1394,7 +1898,7 @@ func (x *VersionStatistics) String() string {
 func (*VersionStatistics) ProtoMessage() {}
 
 func (x *VersionStatistics) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[15]
+	mi := &file_tz_v1_tz_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1407,7 +1911,7 @@ func (x *VersionStatistics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VersionStatistics.ProtoReflect.Descriptor instead.
 func (*VersionStatistics) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{15}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *VersionStatistics) GetTotalVersions() int64 {
@@ -1446,13 +1950,14 @@ type NewFeedbackErrorRequest struct {
 	FeedbackComment *string                `protobuf:"bytes,4,opt,name=feedback_comment,json=feedbackComment,proto3,oneof" json:"feedback_comment,omitempty"`
 	UserId          string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	IsVerification  bool                   `protobuf:"varint,6,opt,name=is_verification,json=isVerification,proto3" json:"is_verification,omitempty"`
+	Caller          *Caller                `protobuf:"bytes,7,opt,name=caller,proto3" json:"caller,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *NewFeedbackErrorRequest) Reset() {
 	*x = NewFeedbackErrorRequest{}
-	mi := &file_tz_v1_tz_proto_msgTypes[16]
+	mi := &file_tz_v1_tz_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1464,7 +1969,7 @@ func (x *NewFeedbackErrorRequest) String() string {
 func (*NewFeedbackErrorRequest) ProtoMessage() {}
 
 func (x *NewFeedbackErrorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[16]
+	mi := &file_tz_v1_tz_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1477,7 +1982,7 @@ func (x *NewFeedbackErrorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewFeedbackErrorRequest.ProtoReflect.Descriptor instead.
 func (*NewFeedbackErrorRequest) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{16}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *NewFeedbackErrorRequest) GetInstanceId() string {
@@ -1522,6 +2027,13 @@ func (x *NewFeedbackErrorRequest) GetIsVerification() bool {
 	return false
 }
 
+func (x *NewFeedbackErrorRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
 type NewFeedbackErrorResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1530,7 +2042,7 @@ type NewFeedbackErrorResponse struct {
 
 func (x *NewFeedbackErrorResponse) Reset() {
 	*x = NewFeedbackErrorResponse{}
-	mi := &file_tz_v1_tz_proto_msgTypes[17]
+	mi := &file_tz_v1_tz_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1542,7 +2054,7 @@ func (x *NewFeedbackErrorResponse) String() string {
 func (*NewFeedbackErrorResponse) ProtoMessage() {}
 
 func (x *NewFeedbackErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[17]
+	mi := &file_tz_v1_tz_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1555,7 +2067,7 @@ func (x *NewFeedbackErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewFeedbackErrorResponse.ProtoReflect.Descriptor instead.
 func (*NewFeedbackErrorResponse) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{17}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{20}
 }
 
 type GetVersionsDateRangeRequest struct {
@@ -1566,7 +2078,7 @@ type GetVersionsDateRangeRequest struct {
 
 func (x *GetVersionsDateRangeRequest) Reset() {
 	*x = GetVersionsDateRangeRequest{}
-	mi := &file_tz_v1_tz_proto_msgTypes[18]
+	mi := &file_tz_v1_tz_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1578,7 +2090,7 @@ func (x *GetVersionsDateRangeRequest) String() string {
 func (*GetVersionsDateRangeRequest) ProtoMessage() {}
 
 func (x *GetVersionsDateRangeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[18]
+	mi := &file_tz_v1_tz_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1591,7 +2103,7 @@ func (x *GetVersionsDateRangeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionsDateRangeRequest.ProtoReflect.Descriptor instead.
 func (*GetVersionsDateRangeRequest) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{18}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{21}
 }
 
 type GetVersionsDateRangeResponse struct {
@@ -1604,7 +2116,7 @@ type GetVersionsDateRangeResponse struct {
 
 func (x *GetVersionsDateRangeResponse) Reset() {
 	*x = GetVersionsDateRangeResponse{}
-	mi := &file_tz_v1_tz_proto_msgTypes[19]
+	mi := &file_tz_v1_tz_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1616,7 +2128,7 @@ func (x *GetVersionsDateRangeResponse) String() string {
 func (*GetVersionsDateRangeResponse) ProtoMessage() {}
 
 func (x *GetVersionsDateRangeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[19]
+	mi := &file_tz_v1_tz_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1629,7 +2141,7 @@ func (x *GetVersionsDateRangeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionsDateRangeResponse.ProtoReflect.Descriptor instead.
 func (*GetVersionsDateRangeResponse) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{19}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetVersionsDateRangeResponse) GetMinDate() string {
@@ -1659,7 +2171,7 @@ type GetDailyAnalyticsRequest struct {
 
 func (x *GetDailyAnalyticsRequest) Reset() {
 	*x = GetDailyAnalyticsRequest{}
-	mi := &file_tz_v1_tz_proto_msgTypes[20]
+	mi := &file_tz_v1_tz_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1671,7 +2183,7 @@ func (x *GetDailyAnalyticsRequest) String() string {
 func (*GetDailyAnalyticsRequest) ProtoMessage() {}
 
 func (x *GetDailyAnalyticsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[20]
+	mi := &file_tz_v1_tz_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1684,7 +2196,7 @@ func (x *GetDailyAnalyticsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDailyAnalyticsRequest.ProtoReflect.Descriptor instead.
 func (*GetDailyAnalyticsRequest) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{20}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetDailyAnalyticsRequest) GetFromDate() string {
@@ -1731,7 +2243,7 @@ type GetDailyAnalyticsResponse struct {
 
 func (x *GetDailyAnalyticsResponse) Reset() {
 	*x = GetDailyAnalyticsResponse{}
-	mi := &file_tz_v1_tz_proto_msgTypes[21]
+	mi := &file_tz_v1_tz_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1743,7 +2255,7 @@ func (x *GetDailyAnalyticsResponse) String() string {
 func (*GetDailyAnalyticsResponse) ProtoMessage() {}
 
 func (x *GetDailyAnalyticsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[21]
+	mi := &file_tz_v1_tz_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1756,7 +2268,7 @@ func (x *GetDailyAnalyticsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDailyAnalyticsResponse.ProtoReflect.Descriptor instead.
 func (*GetDailyAnalyticsResponse) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{21}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetDailyAnalyticsResponse) GetSeries() []*DailyAnalyticsPoint {
@@ -1779,7 +2291,7 @@ type DailyAnalyticsPoint struct {
 
 func (x *DailyAnalyticsPoint) Reset() {
 	*x = DailyAnalyticsPoint{}
-	mi := &file_tz_v1_tz_proto_msgTypes[22]
+	mi := &file_tz_v1_tz_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1791,7 +2303,7 @@ func (x *DailyAnalyticsPoint) String() string {
 func (*DailyAnalyticsPoint) ProtoMessage() {}
 
 func (x *DailyAnalyticsPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[22]
+	mi := &file_tz_v1_tz_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1804,7 +2316,7 @@ func (x *DailyAnalyticsPoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DailyAnalyticsPoint.ProtoReflect.Descriptor instead.
 func (*DailyAnalyticsPoint) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{22}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *DailyAnalyticsPoint) GetDate() string {
@@ -1851,7 +2363,7 @@ type GetFeedbacksRequest struct {
 
 func (x *GetFeedbacksRequest) Reset() {
 	*x = GetFeedbacksRequest{}
-	mi := &file_tz_v1_tz_proto_msgTypes[23]
+	mi := &file_tz_v1_tz_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1863,7 +2375,7 @@ func (x *GetFeedbacksRequest) String() string {
 func (*GetFeedbacksRequest) ProtoMessage() {}
 
 func (x *GetFeedbacksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[23]
+	mi := &file_tz_v1_tz_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1876,7 +2388,7 @@ func (x *GetFeedbacksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFeedbacksRequest.ProtoReflect.Descriptor instead.
 func (*GetFeedbacksRequest) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{23}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetFeedbacksRequest) GetUserId() string {
@@ -1895,7 +2407,7 @@ type GetFeedbacksResponse struct {
 
 func (x *GetFeedbacksResponse) Reset() {
 	*x = GetFeedbacksResponse{}
-	mi := &file_tz_v1_tz_proto_msgTypes[24]
+	mi := &file_tz_v1_tz_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1907,7 +2419,7 @@ func (x *GetFeedbacksResponse) String() string {
 func (*GetFeedbacksResponse) ProtoMessage() {}
 
 func (x *GetFeedbacksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[24]
+	mi := &file_tz_v1_tz_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1920,7 +2432,7 @@ func (x *GetFeedbacksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFeedbacksResponse.ProtoReflect.Descriptor instead.
 func (*GetFeedbacksResponse) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{24}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetFeedbacksResponse) GetFeedbacks() []*FeedbackInstance {
@@ -1948,7 +2460,7 @@ type FeedbackInstance struct {
 
 func (x *FeedbackInstance) Reset() {
 	*x = FeedbackInstance{}
-	mi := &file_tz_v1_tz_proto_msgTypes[25]
+	mi := &file_tz_v1_tz_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1960,7 +2472,7 @@ func (x *FeedbackInstance) String() string {
 func (*FeedbackInstance) ProtoMessage() {}
 
 func (x *FeedbackInstance) ProtoReflect() protoreflect.Message {
-	mi := &file_tz_v1_tz_proto_msgTypes[25]
+	mi := &file_tz_v1_tz_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1973,7 +2485,7 @@ func (x *FeedbackInstance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FeedbackInstance.ProtoReflect.Descriptor instead.
 func (*FeedbackInstance) Descriptor() ([]byte, []int) {
-	return file_tz_v1_tz_proto_rawDescGZIP(), []int{25}
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *FeedbackInstance) GetInstanceId() string {
@@ -2046,32 +2558,3186 @@ func (x *FeedbackInstance) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-var File_tz_v1_tz_proto protoreflect.FileDescriptor
+type CompareVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionAId    string                 `protobuf:"bytes,1,opt,name=version_a_id,json=versionAId,proto3" json:"version_a_id,omitempty"`
+	VersionBId    string                 `protobuf:"bytes,2,opt,name=version_b_id,json=versionBId,proto3" json:"version_b_id,omitempty"`
+	Caller        *Caller                `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_tz_v1_tz_proto_rawDesc = "" +
-	"\n" +
-	"\x0etz/v1/tz.proto\x12\x05tz.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"_\n" +
-	"\x0eCheckTzRequest\x12\x12\n" +
-	"\x04file\x18\x01 \x01(\fR\x04file\x12\x1a\n" +
-	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1d\n" +
-	"\n" +
-	"request_id\x18\x03 \x01(\tR\trequestId\"p\n" +
-	"\x0fCheckTzResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
-	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xff\x05\n" +
-	"\x05Error\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
-	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12\x1d\n" +
-	"\n" +
-	"error_code\x18\x03 \x01(\tR\terrorCode\x12\x12\n" +
-	"\x04name\x18\x04 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1a\n" +
-	"\bdetector\x18\x06 \x01(\tR\bdetector\x120\n" +
-	"\x11preliminary_notes\x18\a \x01(\tH\x00R\x10preliminaryNotes\x88\x01\x01\x12.\n" +
-	"\x10overall_critique\x18\b \x01(\tH\x01R\x0foverallCritique\x88\x01\x01\x12\x18\n" +
-	"\averdict\x18\t \x01(\tR\averdict\x12.\n" +
+func (x *CompareVersionsRequest) Reset() {
+	*x = CompareVersionsRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareVersionsRequest) ProtoMessage() {}
+
+func (x *CompareVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareVersionsRequest.ProtoReflect.Descriptor instead.
+func (*CompareVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CompareVersionsRequest) GetVersionAId() string {
+	if x != nil {
+		return x.VersionAId
+	}
+	return ""
+}
+
+func (x *CompareVersionsRequest) GetVersionBId() string {
+	if x != nil {
+		return x.VersionBId
+	}
+	return ""
+}
+
+func (x *CompareVersionsRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+type InstanceComparison struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorCode     string                 `protobuf:"bytes,1,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	InstanceA     *InvalidInstance       `protobuf:"bytes,2,opt,name=instance_a,json=instanceA,proto3,oneof" json:"instance_a,omitempty"`
+	InstanceB     *InvalidInstance       `protobuf:"bytes,3,opt,name=instance_b,json=instanceB,proto3,oneof" json:"instance_b,omitempty"`
+	Similarity    float64                `protobuf:"fixed64,4,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceComparison) Reset() {
+	*x = InstanceComparison{}
+	mi := &file_tz_v1_tz_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceComparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceComparison) ProtoMessage() {}
+
+func (x *InstanceComparison) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceComparison.ProtoReflect.Descriptor instead.
+func (*InstanceComparison) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *InstanceComparison) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *InstanceComparison) GetInstanceA() *InvalidInstance {
+	if x != nil {
+		return x.InstanceA
+	}
+	return nil
+}
+
+func (x *InstanceComparison) GetInstanceB() *InvalidInstance {
+	if x != nil {
+		return x.InstanceB
+	}
+	return nil
+}
+
+func (x *InstanceComparison) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+type CompareVersionsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VersionAId     string                 `protobuf:"bytes,1,opt,name=version_a_id,json=versionAId,proto3" json:"version_a_id,omitempty"`
+	VersionANumber int32                  `protobuf:"varint,2,opt,name=version_a_number,json=versionANumber,proto3" json:"version_a_number,omitempty"`
+	VersionBId     string                 `protobuf:"bytes,3,opt,name=version_b_id,json=versionBId,proto3" json:"version_b_id,omitempty"`
+	VersionBNumber int32                  `protobuf:"varint,4,opt,name=version_b_number,json=versionBNumber,proto3" json:"version_b_number,omitempty"`
+	Fixed          []*InstanceComparison  `protobuf:"bytes,5,rep,name=fixed,proto3" json:"fixed,omitempty"`
+	Persisting     []*InstanceComparison  `protobuf:"bytes,6,rep,name=persisting,proto3" json:"persisting,omitempty"`
+	New            []*InstanceComparison  `protobuf:"bytes,7,rep,name=new,proto3" json:"new,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CompareVersionsResponse) Reset() {
+	*x = CompareVersionsResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareVersionsResponse) ProtoMessage() {}
+
+func (x *CompareVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareVersionsResponse.ProtoReflect.Descriptor instead.
+func (*CompareVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CompareVersionsResponse) GetVersionAId() string {
+	if x != nil {
+		return x.VersionAId
+	}
+	return ""
+}
+
+func (x *CompareVersionsResponse) GetVersionANumber() int32 {
+	if x != nil {
+		return x.VersionANumber
+	}
+	return 0
+}
+
+func (x *CompareVersionsResponse) GetVersionBId() string {
+	if x != nil {
+		return x.VersionBId
+	}
+	return ""
+}
+
+func (x *CompareVersionsResponse) GetVersionBNumber() int32 {
+	if x != nil {
+		return x.VersionBNumber
+	}
+	return 0
+}
+
+func (x *CompareVersionsResponse) GetFixed() []*InstanceComparison {
+	if x != nil {
+		return x.Fixed
+	}
+	return nil
+}
+
+func (x *CompareVersionsResponse) GetPersisting() []*InstanceComparison {
+	if x != nil {
+		return x.Persisting
+	}
+	return nil
+}
+
+func (x *CompareVersionsResponse) GetNew() []*InstanceComparison {
+	if x != nil {
+		return x.New
+	}
+	return nil
+}
+
+type RetryVersionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VersionId string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	// html, markdown, prompts, step1, step2, report; пусто - с первой незавершённой стадии
+	FromStage     string  `protobuf:"bytes,2,opt,name=from_stage,json=fromStage,proto3" json:"from_stage,omitempty"`
+	Caller        *Caller `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryVersionRequest) Reset() {
+	*x = RetryVersionRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryVersionRequest) ProtoMessage() {}
+
+func (x *RetryVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryVersionRequest.ProtoReflect.Descriptor instead.
+func (*RetryVersionRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RetryVersionRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *RetryVersionRequest) GetFromStage() string {
+	if x != nil {
+		return x.FromStage
+	}
+	return ""
+}
+
+func (x *RetryVersionRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+type RetryVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionId     string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	ResumeStage   string                 `protobuf:"bytes,2,opt,name=resume_stage,json=resumeStage,proto3" json:"resume_stage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryVersionResponse) Reset() {
+	*x = RetryVersionResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryVersionResponse) ProtoMessage() {}
+
+func (x *RetryVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryVersionResponse.ProtoReflect.Descriptor instead.
+func (*RetryVersionResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RetryVersionResponse) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *RetryVersionResponse) GetResumeStage() string {
+	if x != nil {
+		return x.ResumeStage
+	}
+	return ""
+}
+
+type CancelVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionId     string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelVersionRequest) Reset() {
+	*x = CancelVersionRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelVersionRequest) ProtoMessage() {}
+
+func (x *CancelVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelVersionRequest.ProtoReflect.Descriptor instead.
+func (*CancelVersionRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CancelVersionRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *CancelVersionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CancelVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionId     string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelVersionResponse) Reset() {
+	*x = CancelVersionResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelVersionResponse) ProtoMessage() {}
+
+func (x *CancelVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelVersionResponse.ProtoReflect.Descriptor instead.
+func (*CancelVersionResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CancelVersionResponse) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *CancelVersionResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type WatchVersionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VersionId string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	// События с id <= after_event_id уже получены клиентом (Last-Event-ID при переподключении)
+	AfterEventId  int64   `protobuf:"varint,2,opt,name=after_event_id,json=afterEventId,proto3" json:"after_event_id,omitempty"`
+	Caller        *Caller `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchVersionRequest) Reset() {
+	*x = WatchVersionRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchVersionRequest) ProtoMessage() {}
+
+func (x *WatchVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchVersionRequest.ProtoReflect.Descriptor instead.
+func (*WatchVersionRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *WatchVersionRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *WatchVersionRequest) GetAfterEventId() int64 {
+	if x != nil {
+		return x.AfterEventId
+	}
+	return 0
+}
+
+func (x *WatchVersionRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+type StageStartedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stage         string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StageStartedEvent) Reset() {
+	*x = StageStartedEvent{}
+	mi := &file_tz_v1_tz_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StageStartedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StageStartedEvent) ProtoMessage() {}
+
+func (x *StageStartedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StageStartedEvent.ProtoReflect.Descriptor instead.
+func (*StageStartedEvent) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StageStartedEvent) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+type StageFinishedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Stage string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	// Результат стадии восстановлен из контрольной точки
+	Restored      bool `protobuf:"varint,2,opt,name=restored,proto3" json:"restored,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StageFinishedEvent) Reset() {
+	*x = StageFinishedEvent{}
+	mi := &file_tz_v1_tz_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StageFinishedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StageFinishedEvent) ProtoMessage() {}
+
+func (x *StageFinishedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StageFinishedEvent.ProtoReflect.Descriptor instead.
+func (*StageFinishedEvent) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StageFinishedEvent) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *StageFinishedEvent) GetRestored() bool {
+	if x != nil {
+		return x.Restored
+	}
+	return false
+}
+
+type LlmGroupProgressEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupIndex    int32                  `protobuf:"varint,1,opt,name=group_index,json=groupIndex,proto3" json:"group_index,omitempty"`
+	GroupTotal    int32                  `protobuf:"varint,2,opt,name=group_total,json=groupTotal,proto3" json:"group_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LlmGroupProgressEvent) Reset() {
+	*x = LlmGroupProgressEvent{}
+	mi := &file_tz_v1_tz_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LlmGroupProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LlmGroupProgressEvent) ProtoMessage() {}
+
+func (x *LlmGroupProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LlmGroupProgressEvent.ProtoReflect.Descriptor instead.
+func (*LlmGroupProgressEvent) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *LlmGroupProgressEvent) GetGroupIndex() int32 {
+	if x != nil {
+		return x.GroupIndex
+	}
+	return 0
+}
+
+func (x *LlmGroupProgressEvent) GetGroupTotal() int32 {
+	if x != nil {
+		return x.GroupTotal
+	}
+	return 0
+}
+
+type CostEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rubs          float64                `protobuf:"fixed64,1,opt,name=rubs,proto3" json:"rubs,omitempty"`
+	Tokens        int64                  `protobuf:"varint,2,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CostEvent) Reset() {
+	*x = CostEvent{}
+	mi := &file_tz_v1_tz_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CostEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostEvent) ProtoMessage() {}
+
+func (x *CostEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostEvent.ProtoReflect.Descriptor instead.
+func (*CostEvent) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CostEvent) GetRubs() float64 {
+	if x != nil {
+		return x.Rubs
+	}
+	return 0
+}
+
+func (x *CostEvent) GetTokens() int64 {
+	if x != nil {
+		return x.Tokens
+	}
+	return 0
+}
+
+type FinalStatusEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinalStatusEvent) Reset() {
+	*x = FinalStatusEvent{}
+	mi := &file_tz_v1_tz_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinalStatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalStatusEvent) ProtoMessage() {}
+
+func (x *FinalStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalStatusEvent.ProtoReflect.Descriptor instead.
+func (*FinalStatusEvent) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *FinalStatusEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type VersionEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	VersionId string                 `protobuf:"bytes,2,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*VersionEvent_StageStarted
+	//	*VersionEvent_StageFinished
+	//	*VersionEvent_LlmGroupProgress
+	//	*VersionEvent_Cost
+	//	*VersionEvent_FinalStatus
+	Event         isVersionEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionEvent) Reset() {
+	*x = VersionEvent{}
+	mi := &file_tz_v1_tz_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionEvent) ProtoMessage() {}
+
+func (x *VersionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionEvent.ProtoReflect.Descriptor instead.
+func (*VersionEvent) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *VersionEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *VersionEvent) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *VersionEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *VersionEvent) GetEvent() isVersionEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *VersionEvent) GetStageStarted() *StageStartedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*VersionEvent_StageStarted); ok {
+			return x.StageStarted
+		}
+	}
+	return nil
+}
+
+func (x *VersionEvent) GetStageFinished() *StageFinishedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*VersionEvent_StageFinished); ok {
+			return x.StageFinished
+		}
+	}
+	return nil
+}
+
+func (x *VersionEvent) GetLlmGroupProgress() *LlmGroupProgressEvent {
+	if x != nil {
+		if x, ok := x.Event.(*VersionEvent_LlmGroupProgress); ok {
+			return x.LlmGroupProgress
+		}
+	}
+	return nil
+}
+
+func (x *VersionEvent) GetCost() *CostEvent {
+	if x != nil {
+		if x, ok := x.Event.(*VersionEvent_Cost); ok {
+			return x.Cost
+		}
+	}
+	return nil
+}
+
+func (x *VersionEvent) GetFinalStatus() *FinalStatusEvent {
+	if x != nil {
+		if x, ok := x.Event.(*VersionEvent_FinalStatus); ok {
+			return x.FinalStatus
+		}
+	}
+	return nil
+}
+
+type isVersionEvent_Event interface {
+	isVersionEvent_Event()
+}
+
+type VersionEvent_StageStarted struct {
+	StageStarted *StageStartedEvent `protobuf:"bytes,4,opt,name=stage_started,json=stageStarted,proto3,oneof"`
+}
+
+type VersionEvent_StageFinished struct {
+	StageFinished *StageFinishedEvent `protobuf:"bytes,5,opt,name=stage_finished,json=stageFinished,proto3,oneof"`
+}
+
+type VersionEvent_LlmGroupProgress struct {
+	LlmGroupProgress *LlmGroupProgressEvent `protobuf:"bytes,6,opt,name=llm_group_progress,json=llmGroupProgress,proto3,oneof"`
+}
+
+type VersionEvent_Cost struct {
+	Cost *CostEvent `protobuf:"bytes,7,opt,name=cost,proto3,oneof"`
+}
+
+type VersionEvent_FinalStatus struct {
+	FinalStatus *FinalStatusEvent `protobuf:"bytes,8,opt,name=final_status,json=finalStatus,proto3,oneof"`
+}
+
+func (*VersionEvent_StageStarted) isVersionEvent_Event() {}
+
+func (*VersionEvent_StageFinished) isVersionEvent_Event() {}
+
+func (*VersionEvent_LlmGroupProgress) isVersionEvent_Event() {}
+
+func (*VersionEvent_Cost) isVersionEvent_Event() {}
+
+func (*VersionEvent_FinalStatus) isVersionEvent_Event() {}
+
+// Сброс кэша LLM. Условия объединяются по И, хотя бы одно должно быть задано
+type InvalidateLlmCacheRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Model *string                `protobuf:"bytes,1,opt,name=model,proto3,oneof" json:"model,omitempty"`
+	GgId  *int32                 `protobuf:"varint,2,opt,name=gg_id,json=ggId,proto3,oneof" json:"gg_id,omitempty"`
+	// Время сохранения ответа: [from, to)
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3,oneof" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3,oneof" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateLlmCacheRequest) Reset() {
+	*x = InvalidateLlmCacheRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateLlmCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateLlmCacheRequest) ProtoMessage() {}
+
+func (x *InvalidateLlmCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateLlmCacheRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateLlmCacheRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *InvalidateLlmCacheRequest) GetModel() string {
+	if x != nil && x.Model != nil {
+		return *x.Model
+	}
+	return ""
+}
+
+func (x *InvalidateLlmCacheRequest) GetGgId() int32 {
+	if x != nil && x.GgId != nil {
+		return *x.GgId
+	}
+	return 0
+}
+
+func (x *InvalidateLlmCacheRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *InvalidateLlmCacheRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type InvalidateLlmCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateLlmCacheResponse) Reset() {
+	*x = InvalidateLlmCacheResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateLlmCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateLlmCacheResponse) ProtoMessage() {}
+
+func (x *InvalidateLlmCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateLlmCacheResponse.ProtoReflect.Descriptor instead.
+func (*InvalidateLlmCacheResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *InvalidateLlmCacheResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type GetVersionCostBreakdownRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VersionId string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	// Вернуть также каждый запрос к LLM по отдельности
+	IncludeCalls  bool    `protobuf:"varint,2,opt,name=include_calls,json=includeCalls,proto3" json:"include_calls,omitempty"`
+	Caller        *Caller `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionCostBreakdownRequest) Reset() {
+	*x = GetVersionCostBreakdownRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionCostBreakdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionCostBreakdownRequest) ProtoMessage() {}
+
+func (x *GetVersionCostBreakdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionCostBreakdownRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionCostBreakdownRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetVersionCostBreakdownRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *GetVersionCostBreakdownRequest) GetIncludeCalls() bool {
+	if x != nil {
+		return x.IncludeCalls
+	}
+	return false
+}
+
+func (x *GetVersionCostBreakdownRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+// Расходы одного шага и одной группы ошибок. rubs и токены - только по реально
+// выполненным запросам, ответы из кэша не оплачиваются
+type LlmCostGroup struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Step             int32                  `protobuf:"varint,1,opt,name=step,proto3" json:"step,omitempty"`
+	GroupId          *int32                 `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3,oneof" json:"group_id,omitempty"`
+	GroupName        *string                `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3,oneof" json:"group_name,omitempty"`
+	Calls            int32                  `protobuf:"varint,4,opt,name=calls,proto3" json:"calls,omitempty"`
+	CacheHits        int32                  `protobuf:"varint,5,opt,name=cache_hits,json=cacheHits,proto3" json:"cache_hits,omitempty"`
+	FailedCalls      int32                  `protobuf:"varint,6,opt,name=failed_calls,json=failedCalls,proto3" json:"failed_calls,omitempty"`
+	PromptTokens     int64                  `protobuf:"varint,7,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens int64                  `protobuf:"varint,8,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	TotalTokens      int64                  `protobuf:"varint,9,opt,name=total_tokens,json=totalTokens,proto3" json:"total_tokens,omitempty"`
+	Rubs             float64                `protobuf:"fixed64,10,opt,name=rubs,proto3" json:"rubs,omitempty"`
+	DurationMs       int64                  `protobuf:"varint,11,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LlmCostGroup) Reset() {
+	*x = LlmCostGroup{}
+	mi := &file_tz_v1_tz_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LlmCostGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LlmCostGroup) ProtoMessage() {}
+
+func (x *LlmCostGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LlmCostGroup.ProtoReflect.Descriptor instead.
+func (*LlmCostGroup) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *LlmCostGroup) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *LlmCostGroup) GetGroupId() int32 {
+	if x != nil && x.GroupId != nil {
+		return *x.GroupId
+	}
+	return 0
+}
+
+func (x *LlmCostGroup) GetGroupName() string {
+	if x != nil && x.GroupName != nil {
+		return *x.GroupName
+	}
+	return ""
+}
+
+func (x *LlmCostGroup) GetCalls() int32 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *LlmCostGroup) GetCacheHits() int32 {
+	if x != nil {
+		return x.CacheHits
+	}
+	return 0
+}
+
+func (x *LlmCostGroup) GetFailedCalls() int32 {
+	if x != nil {
+		return x.FailedCalls
+	}
+	return 0
+}
+
+func (x *LlmCostGroup) GetPromptTokens() int64 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *LlmCostGroup) GetCompletionTokens() int64 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *LlmCostGroup) GetTotalTokens() int64 {
+	if x != nil {
+		return x.TotalTokens
+	}
+	return 0
+}
+
+func (x *LlmCostGroup) GetRubs() float64 {
+	if x != nil {
+		return x.Rubs
+	}
+	return 0
+}
+
+func (x *LlmCostGroup) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type LlmCall struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Step             int32                  `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	GroupId          *int32                 `protobuf:"varint,3,opt,name=group_id,json=groupId,proto3,oneof" json:"group_id,omitempty"`
+	GroupName        *string                `protobuf:"bytes,4,opt,name=group_name,json=groupName,proto3,oneof" json:"group_name,omitempty"`
+	Model            string                 `protobuf:"bytes,5,opt,name=model,proto3" json:"model,omitempty"`
+	PromptTokens     int32                  `protobuf:"varint,6,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens int32                  `protobuf:"varint,7,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	TotalTokens      int32                  `protobuf:"varint,8,opt,name=total_tokens,json=totalTokens,proto3" json:"total_tokens,omitempty"`
+	Rubs             float64                `protobuf:"fixed64,9,opt,name=rubs,proto3" json:"rubs,omitempty"`
+	DurationMs       int64                  `protobuf:"varint,10,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	CacheHit         bool                   `protobuf:"varint,11,opt,name=cache_hit,json=cacheHit,proto3" json:"cache_hit,omitempty"`
+	Attempts         int32                  `protobuf:"varint,12,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Error            *string                `protobuf:"bytes,13,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LlmCall) Reset() {
+	*x = LlmCall{}
+	mi := &file_tz_v1_tz_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LlmCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LlmCall) ProtoMessage() {}
+
+func (x *LlmCall) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LlmCall.ProtoReflect.Descriptor instead.
+func (*LlmCall) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *LlmCall) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LlmCall) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *LlmCall) GetGroupId() int32 {
+	if x != nil && x.GroupId != nil {
+		return *x.GroupId
+	}
+	return 0
+}
+
+func (x *LlmCall) GetGroupName() string {
+	if x != nil && x.GroupName != nil {
+		return *x.GroupName
+	}
+	return ""
+}
+
+func (x *LlmCall) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *LlmCall) GetPromptTokens() int32 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *LlmCall) GetCompletionTokens() int32 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *LlmCall) GetTotalTokens() int32 {
+	if x != nil {
+		return x.TotalTokens
+	}
+	return 0
+}
+
+func (x *LlmCall) GetRubs() float64 {
+	if x != nil {
+		return x.Rubs
+	}
+	return 0
+}
+
+func (x *LlmCall) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *LlmCall) GetCacheHit() bool {
+	if x != nil {
+		return x.CacheHit
+	}
+	return false
+}
+
+func (x *LlmCall) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *LlmCall) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *LlmCall) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetVersionCostBreakdownResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionId     string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	Total         *LlmCostGroup          `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	Groups        []*LlmCostGroup        `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+	Calls         []*LlmCall             `protobuf:"bytes,4,rep,name=calls,proto3" json:"calls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionCostBreakdownResponse) Reset() {
+	*x = GetVersionCostBreakdownResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionCostBreakdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionCostBreakdownResponse) ProtoMessage() {}
+
+func (x *GetVersionCostBreakdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionCostBreakdownResponse.ProtoReflect.Descriptor instead.
+func (*GetVersionCostBreakdownResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetVersionCostBreakdownResponse) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *GetVersionCostBreakdownResponse) GetTotal() *LlmCostGroup {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *GetVersionCostBreakdownResponse) GetGroups() []*LlmCostGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *GetVersionCostBreakdownResponse) GetCalls() []*LlmCall {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+type ExportAnnotatedDocxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionId     string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	Caller        *Caller                `protobuf:"bytes,2,opt,name=caller,proto3" json:"caller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAnnotatedDocxRequest) Reset() {
+	*x = ExportAnnotatedDocxRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAnnotatedDocxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAnnotatedDocxRequest) ProtoMessage() {}
+
+func (x *ExportAnnotatedDocxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAnnotatedDocxRequest.ProtoReflect.Descriptor instead.
+func (*ExportAnnotatedDocxRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ExportAnnotatedDocxRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *ExportAnnotatedDocxRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+// Исходный документ версии, где каждое замечание - комментарий Word к цитате
+type ExportAnnotatedDocxResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FileName string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// Ссылка на скачивание через api-gateway, как docId в GetVersionResponse
+	FileLink      string `protobuf:"bytes,2,opt,name=file_link,json=fileLink,proto3" json:"file_link,omitempty"`
+	Content       []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAnnotatedDocxResponse) Reset() {
+	*x = ExportAnnotatedDocxResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAnnotatedDocxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAnnotatedDocxResponse) ProtoMessage() {}
+
+func (x *ExportAnnotatedDocxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAnnotatedDocxResponse.ProtoReflect.Descriptor instead.
+func (*ExportAnnotatedDocxResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ExportAnnotatedDocxResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportAnnotatedDocxResponse) GetFileLink() string {
+	if x != nil {
+		return x.FileLink
+	}
+	return ""
+}
+
+func (x *ExportAnnotatedDocxResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// Скачивание исходного документа или отчёта версии после проверки доступа
+type GetVersionFileRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VersionId string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	// original | report
+	Kind          string  `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Caller        *Caller `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionFileRequest) Reset() {
+	*x = GetVersionFileRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionFileRequest) ProtoMessage() {}
+
+func (x *GetVersionFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionFileRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionFileRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetVersionFileRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *GetVersionFileRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetVersionFileRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+type GetVersionFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content       []byte                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionFileResponse) Reset() {
+	*x = GetVersionFileResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionFileResponse) ProtoMessage() {}
+
+func (x *GetVersionFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionFileResponse.ProtoReflect.Descriptor instead.
+func (*GetVersionFileResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetVersionFileResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *GetVersionFileResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetVersionFileResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type ExportVersionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VersionId string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	// json | csv | xlsx
+	Format        string  `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Caller        *Caller `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportVersionRequest) Reset() {
+	*x = ExportVersionRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportVersionRequest) ProtoMessage() {}
+
+func (x *ExportVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportVersionRequest.ProtoReflect.Descriptor instead.
+func (*ExportVersionRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ExportVersionRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *ExportVersionRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportVersionRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+// Выгрузка всех ошибок и замечаний версии. Состав полей определяется schema_version
+type ExportVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content       []byte                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	SchemaVersion int32                  `protobuf:"varint,4,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportVersionResponse) Reset() {
+	*x = ExportVersionResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportVersionResponse) ProtoMessage() {}
+
+func (x *ExportVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportVersionResponse.ProtoReflect.Descriptor instead.
+func (*ExportVersionResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ExportVersionResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportVersionResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportVersionResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ExportVersionResponse) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+// Профиль проверки - набор групп ошибок под тип закупки (строительство, ИТ и т.п.)
+type CheckProfile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Код профиля, передаётся в check_profile при загрузке
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	GgId          int32                  `protobuf:"varint,5,opt,name=gg_id,json=ggId,proto3" json:"gg_id,omitempty"`
+	IsDefault     bool                   `protobuf:"varint,6,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckProfile) Reset() {
+	*x = CheckProfile{}
+	mi := &file_tz_v1_tz_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckProfile) ProtoMessage() {}
+
+func (x *CheckProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckProfile.ProtoReflect.Descriptor instead.
+func (*CheckProfile) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *CheckProfile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CheckProfile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CheckProfile) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CheckProfile) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CheckProfile) GetGgId() int32 {
+	if x != nil {
+		return x.GgId
+	}
+	return 0
+}
+
+func (x *CheckProfile) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *CheckProfile) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CheckProfile) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListCheckProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCheckProfilesRequest) Reset() {
+	*x = ListCheckProfilesRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCheckProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCheckProfilesRequest) ProtoMessage() {}
+
+func (x *ListCheckProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCheckProfilesRequest.ProtoReflect.Descriptor instead.
+func (*ListCheckProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{56}
+}
+
+type ListCheckProfilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*CheckProfile        `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCheckProfilesResponse) Reset() {
+	*x = ListCheckProfilesResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCheckProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCheckProfilesResponse) ProtoMessage() {}
+
+func (x *ListCheckProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCheckProfilesResponse.ProtoReflect.Descriptor instead.
+func (*ListCheckProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListCheckProfilesResponse) GetProfiles() []*CheckProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+type CreateCheckProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	GgId          int32                  `protobuf:"varint,4,opt,name=gg_id,json=ggId,proto3" json:"gg_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCheckProfileRequest) Reset() {
+	*x = CreateCheckProfileRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCheckProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCheckProfileRequest) ProtoMessage() {}
+
+func (x *CreateCheckProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCheckProfileRequest.ProtoReflect.Descriptor instead.
+func (*CreateCheckProfileRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CreateCheckProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCheckProfileRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateCheckProfileRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateCheckProfileRequest) GetGgId() int32 {
+	if x != nil {
+		return x.GgId
+	}
+	return 0
+}
+
+// Незаданные поля не меняются
+type UpdateCheckProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	GgId          *int32                 `protobuf:"varint,4,opt,name=gg_id,json=ggId,proto3,oneof" json:"gg_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCheckProfileRequest) Reset() {
+	*x = UpdateCheckProfileRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCheckProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCheckProfileRequest) ProtoMessage() {}
+
+func (x *UpdateCheckProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCheckProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCheckProfileRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *UpdateCheckProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCheckProfileRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateCheckProfileRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateCheckProfileRequest) GetGgId() int32 {
+	if x != nil && x.GgId != nil {
+		return *x.GgId
+	}
+	return 0
+}
+
+type CheckProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *CheckProfile          `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckProfileResponse) Reset() {
+	*x = CheckProfileResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckProfileResponse) ProtoMessage() {}
+
+func (x *CheckProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckProfileResponse.ProtoReflect.Descriptor instead.
+func (*CheckProfileResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CheckProfileResponse) GetProfile() *CheckProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type DeleteCheckProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCheckProfileRequest) Reset() {
+	*x = DeleteCheckProfileRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCheckProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCheckProfileRequest) ProtoMessage() {}
+
+func (x *DeleteCheckProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCheckProfileRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCheckProfileRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DeleteCheckProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteCheckProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCheckProfileResponse) Reset() {
+	*x = DeleteCheckProfileResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCheckProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCheckProfileResponse) ProtoMessage() {}
+
+func (x *DeleteCheckProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCheckProfileResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCheckProfileResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{62}
+}
+
+type SetDefaultCheckProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDefaultCheckProfileRequest) Reset() {
+	*x = SetDefaultCheckProfileRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDefaultCheckProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDefaultCheckProfileRequest) ProtoMessage() {}
+
+func (x *SetDefaultCheckProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDefaultCheckProfileRequest.ProtoReflect.Descriptor instead.
+func (*SetDefaultCheckProfileRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SetDefaultCheckProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Версия описания ошибки из промт-билдера. При изменении названия, описания или детектора
+// создаётся новая версия, предыдущая получает superseded_at
+type ErrorCatalogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GgId          int32                  `protobuf:"varint,2,opt,name=gg_id,json=ggId,proto3" json:"gg_id,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Version       int32                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Detector      string                 `protobuf:"bytes,7,opt,name=detector,proto3" json:"detector,omitempty"`
+	IsCurrent     bool                   `protobuf:"varint,8,opt,name=is_current,json=isCurrent,proto3" json:"is_current,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SupersededAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=superseded_at,json=supersededAt,proto3,oneof" json:"superseded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorCatalogEntry) Reset() {
+	*x = ErrorCatalogEntry{}
+	mi := &file_tz_v1_tz_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorCatalogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorCatalogEntry) ProtoMessage() {}
+
+func (x *ErrorCatalogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorCatalogEntry.ProtoReflect.Descriptor instead.
+func (*ErrorCatalogEntry) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ErrorCatalogEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ErrorCatalogEntry) GetGgId() int32 {
+	if x != nil {
+		return x.GgId
+	}
+	return 0
+}
+
+func (x *ErrorCatalogEntry) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ErrorCatalogEntry) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ErrorCatalogEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ErrorCatalogEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ErrorCatalogEntry) GetDetector() string {
+	if x != nil {
+		return x.Detector
+	}
+	return ""
+}
+
+func (x *ErrorCatalogEntry) GetIsCurrent() bool {
+	if x != nil {
+		return x.IsCurrent
+	}
+	return false
+}
+
+func (x *ErrorCatalogEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ErrorCatalogEntry) GetSupersededAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SupersededAt
+	}
+	return nil
+}
+
+type ListErrorCatalogRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	GgId  *int32                 `protobuf:"varint,1,opt,name=gg_id,json=ggId,proto3,oneof" json:"gg_id,omitempty"`
+	Code  *string                `protobuf:"bytes,2,opt,name=code,proto3,oneof" json:"code,omitempty"`
+	// include_history - вернуть и устаревшие версии
+	IncludeHistory bool `protobuf:"varint,3,opt,name=include_history,json=includeHistory,proto3" json:"include_history,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListErrorCatalogRequest) Reset() {
+	*x = ListErrorCatalogRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListErrorCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListErrorCatalogRequest) ProtoMessage() {}
+
+func (x *ListErrorCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListErrorCatalogRequest.ProtoReflect.Descriptor instead.
+func (*ListErrorCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListErrorCatalogRequest) GetGgId() int32 {
+	if x != nil && x.GgId != nil {
+		return *x.GgId
+	}
+	return 0
+}
+
+func (x *ListErrorCatalogRequest) GetCode() string {
+	if x != nil && x.Code != nil {
+		return *x.Code
+	}
+	return ""
+}
+
+func (x *ListErrorCatalogRequest) GetIncludeHistory() bool {
+	if x != nil {
+		return x.IncludeHistory
+	}
+	return false
+}
+
+type ListErrorCatalogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*ErrorCatalogEntry   `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListErrorCatalogResponse) Reset() {
+	*x = ListErrorCatalogResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListErrorCatalogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListErrorCatalogResponse) ProtoMessage() {}
+
+func (x *ListErrorCatalogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListErrorCatalogResponse.ProtoReflect.Descriptor instead.
+func (*ListErrorCatalogResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListErrorCatalogResponse) GetEntries() []*ErrorCatalogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// Запись ищется по id или по коду. Без gg_id используется группа профиля по умолчанию,
+// без version - актуальная версия
+type GetErrorCatalogEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	GgId          *int32                 `protobuf:"varint,2,opt,name=gg_id,json=ggId,proto3,oneof" json:"gg_id,omitempty"`
+	Code          *string                `protobuf:"bytes,3,opt,name=code,proto3,oneof" json:"code,omitempty"`
+	Version       *int32                 `protobuf:"varint,4,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetErrorCatalogEntryRequest) Reset() {
+	*x = GetErrorCatalogEntryRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetErrorCatalogEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetErrorCatalogEntryRequest) ProtoMessage() {}
+
+func (x *GetErrorCatalogEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetErrorCatalogEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetErrorCatalogEntryRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetErrorCatalogEntryRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *GetErrorCatalogEntryRequest) GetGgId() int32 {
+	if x != nil && x.GgId != nil {
+		return *x.GgId
+	}
+	return 0
+}
+
+func (x *GetErrorCatalogEntryRequest) GetCode() string {
+	if x != nil && x.Code != nil {
+		return *x.Code
+	}
+	return ""
+}
+
+func (x *GetErrorCatalogEntryRequest) GetVersion() int32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type GetErrorCatalogEntryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *ErrorCatalogEntry     `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetErrorCatalogEntryResponse) Reset() {
+	*x = GetErrorCatalogEntryResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetErrorCatalogEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetErrorCatalogEntryResponse) ProtoMessage() {}
+
+func (x *GetErrorCatalogEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetErrorCatalogEntryResponse.ProtoReflect.Descriptor instead.
+func (*GetErrorCatalogEntryResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetErrorCatalogEntryResponse) GetEntry() *ErrorCatalogEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+// Ссылка на просмотр результата проверки без учётной записи. Токен не хранится
+// и возвращается только при создании
+type ShareLink struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VersionId string                 `protobuf:"bytes,2,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	// view | export
+	Permissions    []string               `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RevokedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=revoked_at,json=revokedAt,proto3,oneof" json:"revoked_at,omitempty"`
+	AccessCount    int32                  `protobuf:"varint,7,opt,name=access_count,json=accessCount,proto3" json:"access_count,omitempty"`
+	LastAccessedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_accessed_at,json=lastAccessedAt,proto3,oneof" json:"last_accessed_at,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
+	mi := &file_tz_v1_tz_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLink) ProtoMessage() {}
+
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareLink.ProtoReflect.Descriptor instead.
+func (*ShareLink) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ShareLink) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShareLink) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *ShareLink) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *ShareLink) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ShareLink) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetAccessCount() int32 {
+	if x != nil {
+		return x.AccessCount
+	}
+	return 0
+}
+
+func (x *ShareLink) GetLastAccessedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAccessedAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateShareLinkRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VersionId string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Без прав ссылка даёт только просмотр
+	Permissions   []string `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Caller        *Caller  `protobuf:"bytes,4,opt,name=caller,proto3" json:"caller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkRequest) Reset() {
+	*x = CreateShareLinkRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkRequest) ProtoMessage() {}
+
+func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CreateShareLinkRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateShareLinkRequest) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *CreateShareLinkRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+type CreateShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareLink     *ShareLink             `protobuf:"bytes,1,opt,name=share_link,json=shareLink,proto3" json:"share_link,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkResponse) Reset() {
+	*x = CreateShareLinkResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkResponse) ProtoMessage() {}
+
+func (x *CreateShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CreateShareLinkResponse) GetShareLink() *ShareLink {
+	if x != nil {
+		return x.ShareLink
+	}
+	return nil
+}
+
+func (x *CreateShareLinkResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListShareLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionId     string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	Caller        *Caller                `protobuf:"bytes,2,opt,name=caller,proto3" json:"caller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksRequest) Reset() {
+	*x = ListShareLinksRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksRequest) ProtoMessage() {}
+
+func (x *ListShareLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListShareLinksRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListShareLinksRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *ListShareLinksRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+type ListShareLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareLinks    []*ShareLink           `protobuf:"bytes,1,rep,name=share_links,json=shareLinks,proto3" json:"share_links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksResponse) Reset() {
+	*x = ListShareLinksResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksResponse) ProtoMessage() {}
+
+func (x *ListShareLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListShareLinksResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListShareLinksResponse) GetShareLinks() []*ShareLink {
+	if x != nil {
+		return x.ShareLinks
+	}
+	return nil
+}
+
+type RevokeShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareLinkId   string                 `protobuf:"bytes,1,opt,name=share_link_id,json=shareLinkId,proto3" json:"share_link_id,omitempty"`
+	Caller        *Caller                `protobuf:"bytes,2,opt,name=caller,proto3" json:"caller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkRequest) Reset() {
+	*x = RevokeShareLinkRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkRequest) ProtoMessage() {}
+
+func (x *RevokeShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RevokeShareLinkRequest) GetShareLinkId() string {
+	if x != nil {
+		return x.ShareLinkId
+	}
+	return ""
+}
+
+func (x *RevokeShareLinkRequest) GetCaller() *Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+type RevokeShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareLink     *ShareLink             `protobuf:"bytes,1,opt,name=share_link,json=shareLink,proto3" json:"share_link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkResponse) Reset() {
+	*x = RevokeShareLinkResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkResponse) ProtoMessage() {}
+
+func (x *RevokeShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RevokeShareLinkResponse) GetShareLink() *ShareLink {
+	if x != nil {
+		return x.ShareLink
+	}
+	return nil
+}
+
+// Проверяет токен и учитывает открытие ссылки. Отозванная или истёкшая ссылка - FailedPrecondition
+type ResolveShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveShareLinkRequest) Reset() {
+	*x = ResolveShareLinkRequest{}
+	mi := &file_tz_v1_tz_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveShareLinkRequest) ProtoMessage() {}
+
+func (x *ResolveShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*ResolveShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ResolveShareLinkRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ResolveShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareLink     *ShareLink             `protobuf:"bytes,1,opt,name=share_link,json=shareLink,proto3" json:"share_link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveShareLinkResponse) Reset() {
+	*x = ResolveShareLinkResponse{}
+	mi := &file_tz_v1_tz_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveShareLinkResponse) ProtoMessage() {}
+
+func (x *ResolveShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tz_v1_tz_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*ResolveShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_tz_v1_tz_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ResolveShareLinkResponse) GetShareLink() *ShareLink {
+	if x != nil {
+		return x.ShareLink
+	}
+	return nil
+}
+
+var File_tz_v1_tz_proto protoreflect.FileDescriptor
+
+const file_tz_v1_tz_proto_rawDesc = "" +
+	"\n" +
+	"\x0etz/v1/tz.proto\x12\x05tz.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"V\n" +
+	"\x06Caller\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x1f\n" +
+	"\vshare_token\x18\x03 \x01(\tR\n" +
+	"shareToken\"\xd5\x01\n" +
+	"\x0eCheckTzRequest\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\fR\x04file\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x03 \x01(\tR\trequestId\x12\x14\n" +
+	"\x05force\x18\x04 \x01(\bR\x05force\x12(\n" +
+	"\rcheck_profile\x18\x05 \x01(\tH\x00R\fcheckProfile\x88\x01\x01\x12\x18\n" +
+	"\x05gg_id\x18\x06 \x01(\x05H\x01R\x04ggId\x88\x01\x01B\x10\n" +
+	"\x0e_check_profileB\b\n" +
+	"\x06_gg_id\"\x9a\x02\n" +
+	"\x0fCheckTzResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1c\n" +
+	"\tduplicate\x18\x04 \x01(\bR\tduplicate\x126\n" +
+	"\x15recheck_of_version_id\x18\x05 \x01(\tH\x00R\x12recheckOfVersionId\x88\x01\x01\x12#\n" +
+	"\rcheck_profile\x18\x06 \x01(\tR\fcheckProfile\x12\x13\n" +
+	"\x05gg_id\x18\a \x01(\x05R\x04ggIdB\x18\n" +
+	"\x16_recheck_of_version_id\"\x94\x02\n" +
+	"\x15CheckTzVersionRequest\x12<\n" +
+	"\x1atechnical_specification_id\x18\x01 \x01(\tR\x18technicalSpecificationId\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\fR\x04file\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05force\x18\x05 \x01(\bR\x05force\x12(\n" +
+	"\rcheck_profile\x18\x06 \x01(\tH\x00R\fcheckProfile\x88\x01\x01\x12\x18\n" +
+	"\x05gg_id\x18\a \x01(\x05H\x01R\x04ggId\x88\x01\x01B\x10\n" +
+	"\x0e_check_profileB\b\n" +
+	"\x06_gg_id\"\x86\x03\n" +
+	"\x16CheckTzVersionResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\x1atechnical_specification_id\x18\x04 \x01(\tR\x18technicalSpecificationId\x12%\n" +
+	"\x0eversion_number\x18\x05 \x01(\x05R\rversionNumber\x12\x1c\n" +
+	"\tduplicate\x18\x06 \x01(\bR\tduplicate\x126\n" +
+	"\x15recheck_of_version_id\x18\a \x01(\tH\x00R\x12recheckOfVersionId\x88\x01\x01\x12#\n" +
+	"\rcheck_profile\x18\b \x01(\tR\fcheckProfile\x12\x13\n" +
+	"\x05gg_id\x18\t \x01(\x05R\x04ggIdB\x18\n" +
+	"\x16_recheck_of_version_id\"\x85\a\n" +
+	"\x05Error\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bdetector\x18\x06 \x01(\tR\bdetector\x120\n" +
+	"\x11preliminary_notes\x18\a \x01(\tH\x00R\x10preliminaryNotes\x88\x01\x01\x12.\n" +
+	"\x10overall_critique\x18\b \x01(\tH\x01R\x0foverallCritique\x88\x01\x01\x12\x18\n" +
+	"\averdict\x18\t \x01(\tR\averdict\x12.\n" +
 	"\x10process_analysis\x18\n" +
 	" \x01(\tH\x02R\x0fprocessAnalysis\x88\x01\x01\x12.\n" +
 	"\x10process_critique\x18\v \x01(\tH\x03R\x0fprocessCritique\x88\x01\x01\x126\n" +
@@ -2079,12 +5745,16 @@ const file_tz_v1_tz_proto_rawDesc = "" +
 	"\x11process_retrieval\x18\r \x03(\tR\x10processRetrieval\x12C\n" +
 	"\x11invalid_instances\x18\x0e \x03(\v2\x16.tz.v1.InvalidInstanceR\x10invalidInstances\x12C\n" +
 	"\x11missing_instances\x18\x0f \x03(\v2\x16.tz.v1.MissingInstanceR\x10missingInstances\x12!\n" +
-	"\forder_number\x18\x10 \x01(\x05R\vorderNumberB\x14\n" +
+	"\forder_number\x18\x10 \x01(\x05R\vorderNumber\x12-\n" +
+	"\x10catalog_entry_id\x18\x11 \x01(\tH\x05R\x0ecatalogEntryId\x88\x01\x01\x12,\n" +
+	"\x0fcatalog_version\x18\x12 \x01(\x05H\x06R\x0ecatalogVersion\x88\x01\x01B\x14\n" +
 	"\x12_preliminary_notesB\x13\n" +
 	"\x11_overall_critiqueB\x13\n" +
 	"\x11_process_analysisB\x13\n" +
 	"\x11_process_critiqueB\x17\n" +
-	"\x15_process_verification\"\xa0\t\n" +
+	"\x15_process_verificationB\x13\n" +
+	"\x11_catalog_entry_idB\x12\n" +
+	"\x10_catalog_version\"\xe8\v\n" +
 	"\x0fInvalidInstance\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\ahtml_id\x18\x02 \x01(\rR\x06htmlId\x12\x19\n" +
@@ -2109,7 +5779,16 @@ const file_tz_v1_tz_proto_rawDesc = "" +
 	"\x1cfeedback_verification_exists\x18\x14 \x01(\bR\x1afeedbackVerificationExists\x12A\n" +
 	"\x1afeedback_verification_mark\x18\x15 \x01(\bH\x06R\x18feedbackVerificationMark\x88\x01\x01\x12G\n" +
 	"\x1dfeedback_verification_comment\x18\x16 \x01(\tH\aR\x1bfeedbackVerificationComment\x88\x01\x01\x12A\n" +
-	"\x1afeedback_verification_user\x18\x17 \x01(\tH\bR\x18feedbackVerificationUser\x88\x01\x01B\x14\n" +
+	"\x1afeedback_verification_user\x18\x17 \x01(\tH\bR\x18feedbackVerificationUser\x88\x01\x01\x12\x18\n" +
+	"\alocated\x18\x18 \x01(\bR\alocated\x12,\n" +
+	"\x0fgrounding_score\x18\x19 \x01(\x01H\tR\x0egroundingScore\x88\x01\x01\x12\x1f\n" +
+	"\bpriority\x18\x1a \x01(\tH\n" +
+	"R\bpriority\x88\x01\x01\x12\x19\n" +
+	"\x05risks\x18\x1b \x01(\tH\vR\x05risks\x88\x01\x01\x12\x1a\n" +
+	"\bsections\x18\x1c \x03(\tR\bsections\x12/\n" +
+	"\x11what_is_incorrect\x18\x1d \x01(\tH\fR\x0fwhatIsIncorrect\x88\x01\x01\x12$\n" +
+	"\vpage_number\x18\x1e \x01(\x05H\rR\n" +
+	"pageNumber\x88\x01\x01B\x14\n" +
 	"\x12_start_line_numberB\x12\n" +
 	"\x10_end_line_numberB\x0f\n" +
 	"\r_parent_errorB\x10\n" +
@@ -2118,7 +5797,12 @@ const file_tz_v1_tz_proto_rawDesc = "" +
 	"\x0e_feedback_userB\x1d\n" +
 	"\x1b_feedback_verification_markB \n" +
 	"\x1e_feedback_verification_commentB\x1d\n" +
-	"\x1b_feedback_verification_user\"\xef\x05\n" +
+	"\x1b_feedback_verification_userB\x12\n" +
+	"\x10_grounding_scoreB\v\n" +
+	"\t_priorityB\b\n" +
+	"\x06_risksB\x14\n" +
+	"\x12_what_is_incorrectB\x0e\n" +
+	"\f_page_number\"\xa5\a\n" +
 	"\x0fMissingInstance\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\ahtml_id\x18\x02 \x01(\rR\x06htmlId\x12\x19\n" +
@@ -2132,17 +5816,25 @@ const file_tz_v1_tz_proto_rawDesc = "" +
 	"\x1cfeedback_verification_exists\x18\x14 \x01(\bR\x1afeedbackVerificationExists\x12A\n" +
 	"\x1afeedback_verification_mark\x18\x15 \x01(\bH\x03R\x18feedbackVerificationMark\x88\x01\x01\x12G\n" +
 	"\x1dfeedback_verification_comment\x18\x16 \x01(\tH\x04R\x1bfeedbackVerificationComment\x88\x01\x01\x12A\n" +
-	"\x1afeedback_verification_user\x18\x17 \x01(\tH\x05R\x18feedbackVerificationUser\x88\x01\x01B\x10\n" +
+	"\x1afeedback_verification_user\x18\x17 \x01(\tH\x05R\x18feedbackVerificationUser\x88\x01\x01\x12\x1f\n" +
+	"\bpriority\x18\x18 \x01(\tH\x06R\bpriority\x88\x01\x01\x12\x19\n" +
+	"\x05risks\x18\x19 \x01(\tH\aR\x05risks\x88\x01\x01\x12\x1a\n" +
+	"\bsections\x18\x1a \x03(\tR\bsections\x12/\n" +
+	"\x11what_is_incorrect\x18\x1b \x01(\tH\bR\x0fwhatIsIncorrect\x88\x01\x01B\x10\n" +
 	"\x0e_feedback_markB\x13\n" +
 	"\x11_feedback_commentB\x10\n" +
 	"\x0e_feedback_userB\x1d\n" +
 	"\x1b_feedback_verification_markB \n" +
 	"\x1e_feedback_verification_commentB\x1d\n" +
-	"\x1b_feedback_verification_user\"/\n" +
+	"\x1b_feedback_verification_userB\v\n" +
+	"\t_priorityB\b\n" +
+	"\x06_risksB\x14\n" +
+	"\x12_what_is_incorrect\"S\n" +
 	"\x14GetVersionsMeRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"E\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\"\n" +
+	"\forganization\x18\x02 \x01(\bR\forganization\"E\n" +
 	"\x15GetVersionsMeResponse\x12,\n" +
-	"\bversions\x18\x01 \x03(\v2\x10.tz.v1.VersionMeR\bversions\"\xf4\x02\n" +
+	"\bversions\x18\x01 \x03(\v2\x10.tz.v1.VersionMeR\bversions\"\xcb\x03\n" +
 	"\tVersionMe\x12\x1d\n" +
 	"\n" +
 	"version_id\x18\x01 \x01(\tR\tversionId\x12@\n" +
@@ -2153,11 +5845,22 @@ const file_tz_v1_tz_proto_rawDesc = "" +
 	"\x12original_file_link\x18\x05 \x01(\tR\x10originalFileLink\x12-\n" +
 	"\x10report_file_link\x18\x06 \x01(\tH\x00R\x0ereportFileLink\x88\x01\x01\x12\x16\n" +
 	"\x06status\x18\a \x01(\tR\x06status\x12\x1a\n" +
-	"\bprogress\x18\b \x01(\x05R\bprogressB\x13\n" +
-	"\x11_report_file_link\"2\n" +
+	"\bprogress\x18\b \x01(\x05R\bprogress\x12<\n" +
+	"\x1atechnical_specification_id\x18\t \x01(\tR\x18technicalSpecificationId\x12\x17\n" +
+	"\auser_id\x18\n" +
+	" \x01(\tR\x06userIdB\x13\n" +
+	"\x11_report_file_link\"\xca\x01\n" +
 	"\x11GetVersionRequest\x12\x1d\n" +
 	"\n" +
-	"version_id\x18\x01 \x01(\tR\tversionId\"\xc3\a\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12\x1e\n" +
+	"\n" +
+	"priorities\x18\x02 \x03(\tR\n" +
+	"priorities\x12\x1a\n" +
+	"\bsections\x18\x03 \x03(\tR\bsections\x12\x1f\n" +
+	"\verror_codes\x18\x04 \x03(\tR\n" +
+	"errorCodes\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12%\n" +
+	"\x06caller\x18\x06 \x01(\v2\r.tz.v1.CallerR\x06caller\"\xc3\a\n" +
 	"\x12GetVersionResponse\x12 \n" +
 	"\thtml_text\x18\x01 \x01(\tH\x00R\bhtmlText\x88\x01\x01\x12\x15\n" +
 	"\x03css\x18\x02 \x01(\tH\x01R\x03css\x88\x01\x01\x12\x19\n" +
@@ -2230,7 +5933,7 @@ const file_tz_v1_tz_proto_rawDesc = "" +
 	"#average_inspection_time_nanoseconds\x18\x04 \x01(\x03H\x02R averageInspectionTimeNanoseconds\x88\x01\x01B\x0f\n" +
 	"\r_total_tokensB\r\n" +
 	"\v_total_rubsB&\n" +
-	"$_average_inspection_time_nanoseconds\"\xa2\x02\n" +
+	"$_average_inspection_time_nanoseconds\"\xc9\x02\n" +
 	"\x17NewFeedbackErrorRequest\x12\x1f\n" +
 	"\vinstance_id\x18\x01 \x01(\tR\n" +
 	"instanceId\x12#\n" +
@@ -2238,7 +5941,8 @@ const file_tz_v1_tz_proto_rawDesc = "" +
 	"\rfeedback_mark\x18\x03 \x01(\bH\x00R\ffeedbackMark\x88\x01\x01\x12.\n" +
 	"\x10feedback_comment\x18\x04 \x01(\tH\x01R\x0ffeedbackComment\x88\x01\x01\x12\x17\n" +
 	"\auser_id\x18\x05 \x01(\tR\x06userId\x12'\n" +
-	"\x0fis_verification\x18\x06 \x01(\bR\x0eisVerificationB\x10\n" +
+	"\x0fis_verification\x18\x06 \x01(\bR\x0eisVerification\x12%\n" +
+	"\x06caller\x18\a \x01(\v2\r.tz.v1.CallerR\x06callerB\x10\n" +
 	"\x0e_feedback_markB\x13\n" +
 	"\x11_feedback_comment\"\x1a\n" +
 	"\x18NewFeedbackErrorResponse\"\x1d\n" +
@@ -2288,9 +5992,297 @@ const file_tz_v1_tz_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tcreatedAt\x88\x01\x01B\r\n" +
-	"\v_created_at2\x85\x06\n" +
+	"\v_created_at\"\x83\x01\n" +
+	"\x16CompareVersionsRequest\x12 \n" +
+	"\fversion_a_id\x18\x01 \x01(\tR\n" +
+	"versionAId\x12 \n" +
+	"\fversion_b_id\x18\x02 \x01(\tR\n" +
+	"versionBId\x12%\n" +
+	"\x06caller\x18\x03 \x01(\v2\r.tz.v1.CallerR\x06caller\"\xe9\x01\n" +
+	"\x12InstanceComparison\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x01 \x01(\tR\terrorCode\x12:\n" +
+	"\n" +
+	"instance_a\x18\x02 \x01(\v2\x16.tz.v1.InvalidInstanceH\x00R\tinstanceA\x88\x01\x01\x12:\n" +
+	"\n" +
+	"instance_b\x18\x03 \x01(\v2\x16.tz.v1.InvalidInstanceH\x01R\tinstanceB\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x04 \x01(\x01R\n" +
+	"similarityB\r\n" +
+	"\v_instance_aB\r\n" +
+	"\v_instance_b\"\xca\x02\n" +
+	"\x17CompareVersionsResponse\x12 \n" +
+	"\fversion_a_id\x18\x01 \x01(\tR\n" +
+	"versionAId\x12(\n" +
+	"\x10version_a_number\x18\x02 \x01(\x05R\x0eversionANumber\x12 \n" +
+	"\fversion_b_id\x18\x03 \x01(\tR\n" +
+	"versionBId\x12(\n" +
+	"\x10version_b_number\x18\x04 \x01(\x05R\x0eversionBNumber\x12/\n" +
+	"\x05fixed\x18\x05 \x03(\v2\x19.tz.v1.InstanceComparisonR\x05fixed\x129\n" +
+	"\n" +
+	"persisting\x18\x06 \x03(\v2\x19.tz.v1.InstanceComparisonR\n" +
+	"persisting\x12+\n" +
+	"\x03new\x18\a \x03(\v2\x19.tz.v1.InstanceComparisonR\x03new\"z\n" +
+	"\x13RetryVersionRequest\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12\x1d\n" +
+	"\n" +
+	"from_stage\x18\x02 \x01(\tR\tfromStage\x12%\n" +
+	"\x06caller\x18\x03 \x01(\v2\r.tz.v1.CallerR\x06caller\"X\n" +
+	"\x14RetryVersionResponse\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12!\n" +
+	"\fresume_stage\x18\x02 \x01(\tR\vresumeStage\"N\n" +
+	"\x14CancelVersionRequest\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"N\n" +
+	"\x15CancelVersionResponse\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x81\x01\n" +
+	"\x13WatchVersionRequest\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12$\n" +
+	"\x0eafter_event_id\x18\x02 \x01(\x03R\fafterEventId\x12%\n" +
+	"\x06caller\x18\x03 \x01(\v2\r.tz.v1.CallerR\x06caller\")\n" +
+	"\x11StageStartedEvent\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\"F\n" +
+	"\x12StageFinishedEvent\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x1a\n" +
+	"\brestored\x18\x02 \x01(\bR\brestored\"Y\n" +
+	"\x15LlmGroupProgressEvent\x12\x1f\n" +
+	"\vgroup_index\x18\x01 \x01(\x05R\n" +
+	"groupIndex\x12\x1f\n" +
+	"\vgroup_total\x18\x02 \x01(\x05R\n" +
+	"groupTotal\"7\n" +
+	"\tCostEvent\x12\x12\n" +
+	"\x04rubs\x18\x01 \x01(\x01R\x04rubs\x12\x16\n" +
+	"\x06tokens\x18\x02 \x01(\x03R\x06tokens\"*\n" +
+	"\x10FinalStatusEvent\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\xba\x03\n" +
+	"\fVersionEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x02 \x01(\tR\tversionId\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12?\n" +
+	"\rstage_started\x18\x04 \x01(\v2\x18.tz.v1.StageStartedEventH\x00R\fstageStarted\x12B\n" +
+	"\x0estage_finished\x18\x05 \x01(\v2\x19.tz.v1.StageFinishedEventH\x00R\rstageFinished\x12L\n" +
+	"\x12llm_group_progress\x18\x06 \x01(\v2\x1c.tz.v1.LlmGroupProgressEventH\x00R\x10llmGroupProgress\x12&\n" +
+	"\x04cost\x18\a \x01(\v2\x10.tz.v1.CostEventH\x00R\x04cost\x12<\n" +
+	"\ffinal_status\x18\b \x01(\v2\x17.tz.v1.FinalStatusEventH\x00R\vfinalStatusB\a\n" +
+	"\x05event\"\xda\x01\n" +
+	"\x19InvalidateLlmCacheRequest\x12\x19\n" +
+	"\x05model\x18\x01 \x01(\tH\x00R\x05model\x88\x01\x01\x12\x18\n" +
+	"\x05gg_id\x18\x02 \x01(\x05H\x01R\x04ggId\x88\x01\x01\x123\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x04from\x88\x01\x01\x12/\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\x02to\x88\x01\x01B\b\n" +
+	"\x06_modelB\b\n" +
+	"\x06_gg_idB\a\n" +
+	"\x05_fromB\x05\n" +
+	"\x03_to\"6\n" +
+	"\x1aInvalidateLlmCacheResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"\x8b\x01\n" +
+	"\x1eGetVersionCostBreakdownRequest\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12#\n" +
+	"\rinclude_calls\x18\x02 \x01(\bR\fincludeCalls\x12%\n" +
+	"\x06caller\x18\x03 \x01(\v2\r.tz.v1.CallerR\x06caller\"\x84\x03\n" +
+	"\fLlmCostGroup\x12\x12\n" +
+	"\x04step\x18\x01 \x01(\x05R\x04step\x12\x1e\n" +
+	"\bgroup_id\x18\x02 \x01(\x05H\x00R\agroupId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"group_name\x18\x03 \x01(\tH\x01R\tgroupName\x88\x01\x01\x12\x14\n" +
+	"\x05calls\x18\x04 \x01(\x05R\x05calls\x12\x1d\n" +
+	"\n" +
+	"cache_hits\x18\x05 \x01(\x05R\tcacheHits\x12!\n" +
+	"\ffailed_calls\x18\x06 \x01(\x05R\vfailedCalls\x12#\n" +
+	"\rprompt_tokens\x18\a \x01(\x03R\fpromptTokens\x12+\n" +
+	"\x11completion_tokens\x18\b \x01(\x03R\x10completionTokens\x12!\n" +
+	"\ftotal_tokens\x18\t \x01(\x03R\vtotalTokens\x12\x12\n" +
+	"\x04rubs\x18\n" +
+	" \x01(\x01R\x04rubs\x12\x1f\n" +
+	"\vduration_ms\x18\v \x01(\x03R\n" +
+	"durationMsB\v\n" +
+	"\t_group_idB\r\n" +
+	"\v_group_name\"\xe6\x03\n" +
+	"\aLlmCall\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\x05R\x04step\x12\x1e\n" +
+	"\bgroup_id\x18\x03 \x01(\x05H\x00R\agroupId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"group_name\x18\x04 \x01(\tH\x01R\tgroupName\x88\x01\x01\x12\x14\n" +
+	"\x05model\x18\x05 \x01(\tR\x05model\x12#\n" +
+	"\rprompt_tokens\x18\x06 \x01(\x05R\fpromptTokens\x12+\n" +
+	"\x11completion_tokens\x18\a \x01(\x05R\x10completionTokens\x12!\n" +
+	"\ftotal_tokens\x18\b \x01(\x05R\vtotalTokens\x12\x12\n" +
+	"\x04rubs\x18\t \x01(\x01R\x04rubs\x12\x1f\n" +
+	"\vduration_ms\x18\n" +
+	" \x01(\x03R\n" +
+	"durationMs\x12\x1b\n" +
+	"\tcache_hit\x18\v \x01(\bR\bcacheHit\x12\x1a\n" +
+	"\battempts\x18\f \x01(\x05R\battempts\x12\x19\n" +
+	"\x05error\x18\r \x01(\tH\x02R\x05error\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\v\n" +
+	"\t_group_idB\r\n" +
+	"\v_group_nameB\b\n" +
+	"\x06_error\"\xbe\x01\n" +
+	"\x1fGetVersionCostBreakdownResponse\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12)\n" +
+	"\x05total\x18\x02 \x01(\v2\x13.tz.v1.LlmCostGroupR\x05total\x12+\n" +
+	"\x06groups\x18\x03 \x03(\v2\x13.tz.v1.LlmCostGroupR\x06groups\x12$\n" +
+	"\x05calls\x18\x04 \x03(\v2\x0e.tz.v1.LlmCallR\x05calls\"b\n" +
+	"\x1aExportAnnotatedDocxRequest\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12%\n" +
+	"\x06caller\x18\x02 \x01(\v2\r.tz.v1.CallerR\x06caller\"q\n" +
+	"\x1bExportAnnotatedDocxResponse\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_link\x18\x02 \x01(\tR\bfileLink\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"q\n" +
+	"\x15GetVersionFileRequest\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12%\n" +
+	"\x06caller\x18\x03 \x01(\v2\r.tz.v1.CallerR\x06caller\"r\n" +
+	"\x16GetVersionFileResponse\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"t\n" +
+	"\x14ExportVersionRequest\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12%\n" +
+	"\x06caller\x18\x03 \x01(\v2\r.tz.v1.CallerR\x06caller\"\x98\x01\n" +
+	"\x15ExportVersionResponse\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\x12%\n" +
+	"\x0eschema_version\x18\x04 \x01(\x05R\rschemaVersion\"\xa9\x02\n" +
+	"\fCheckProfile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x13\n" +
+	"\x05gg_id\x18\x05 \x01(\x05R\x04ggId\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x06 \x01(\bR\tisDefault\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
+	"\f_description\"\x1a\n" +
+	"\x18ListCheckProfilesRequest\"L\n" +
+	"\x19ListCheckProfilesResponse\x12/\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x13.tz.v1.CheckProfileR\bprofiles\"\x91\x01\n" +
+	"\x19CreateCheckProfileRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x13\n" +
+	"\x05gg_id\x18\x04 \x01(\x05R\x04ggIdB\x0e\n" +
+	"\f_description\"\xaf\x01\n" +
+	"\x19UpdateCheckProfileRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x18\n" +
+	"\x05gg_id\x18\x04 \x01(\x05H\x02R\x04ggId\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_gg_id\"E\n" +
+	"\x14CheckProfileResponse\x12-\n" +
+	"\aprofile\x18\x01 \x01(\v2\x13.tz.v1.CheckProfileR\aprofile\"/\n" +
+	"\x19DeleteCheckProfileRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1c\n" +
+	"\x1aDeleteCheckProfileResponse\"3\n" +
+	"\x1dSetDefaultCheckProfileRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xea\x02\n" +
+	"\x11ErrorCatalogEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x13\n" +
+	"\x05gg_id\x18\x02 \x01(\x05R\x04ggId\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bdetector\x18\a \x01(\tR\bdetector\x12\x1d\n" +
+	"\n" +
+	"is_current\x18\b \x01(\bR\tisCurrent\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12D\n" +
+	"\rsuperseded_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampH\x00R\fsupersededAt\x88\x01\x01B\x10\n" +
+	"\x0e_superseded_at\"\x88\x01\n" +
+	"\x17ListErrorCatalogRequest\x12\x18\n" +
+	"\x05gg_id\x18\x01 \x01(\x05H\x00R\x04ggId\x88\x01\x01\x12\x17\n" +
+	"\x04code\x18\x02 \x01(\tH\x01R\x04code\x88\x01\x01\x12'\n" +
+	"\x0finclude_history\x18\x03 \x01(\bR\x0eincludeHistoryB\b\n" +
+	"\x06_gg_idB\a\n" +
+	"\x05_code\"N\n" +
+	"\x18ListErrorCatalogResponse\x122\n" +
+	"\aentries\x18\x01 \x03(\v2\x18.tz.v1.ErrorCatalogEntryR\aentries\"\xaa\x01\n" +
+	"\x1bGetErrorCatalogEntryRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01\x12\x18\n" +
+	"\x05gg_id\x18\x02 \x01(\x05H\x01R\x04ggId\x88\x01\x01\x12\x17\n" +
+	"\x04code\x18\x03 \x01(\tH\x02R\x04code\x88\x01\x01\x12\x1d\n" +
+	"\aversion\x18\x04 \x01(\x05H\x03R\aversion\x88\x01\x01B\x05\n" +
+	"\x03_idB\b\n" +
+	"\x06_gg_idB\a\n" +
+	"\x05_codeB\n" +
+	"\n" +
+	"\b_version\"N\n" +
+	"\x1cGetErrorCatalogEntryResponse\x12.\n" +
+	"\x05entry\x18\x01 \x01(\v2\x18.tz.v1.ErrorCatalogEntryR\x05entry\"\xc3\x03\n" +
+	"\tShareLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x02 \x01(\tR\tversionId\x12 \n" +
+	"\vpermissions\x18\x03 \x03(\tR\vpermissions\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12>\n" +
+	"\n" +
+	"revoked_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\trevokedAt\x88\x01\x01\x12!\n" +
+	"\faccess_count\x18\a \x01(\x05R\vaccessCount\x12I\n" +
+	"\x10last_accessed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x0elastAccessedAt\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\r\n" +
+	"\v_revoked_atB\x13\n" +
+	"\x11_last_accessed_at\"\xbb\x01\n" +
+	"\x16CreateShareLinkRequest\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12 \n" +
+	"\vpermissions\x18\x03 \x03(\tR\vpermissions\x12%\n" +
+	"\x06caller\x18\x04 \x01(\v2\r.tz.v1.CallerR\x06caller\"`\n" +
+	"\x17CreateShareLinkResponse\x12/\n" +
+	"\n" +
+	"share_link\x18\x01 \x01(\v2\x10.tz.v1.ShareLinkR\tshareLink\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"]\n" +
+	"\x15ListShareLinksRequest\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12%\n" +
+	"\x06caller\x18\x02 \x01(\v2\r.tz.v1.CallerR\x06caller\"K\n" +
+	"\x16ListShareLinksResponse\x121\n" +
+	"\vshare_links\x18\x01 \x03(\v2\x10.tz.v1.ShareLinkR\n" +
+	"shareLinks\"c\n" +
+	"\x16RevokeShareLinkRequest\x12\"\n" +
+	"\rshare_link_id\x18\x01 \x01(\tR\vshareLinkId\x12%\n" +
+	"\x06caller\x18\x02 \x01(\v2\r.tz.v1.CallerR\x06caller\"J\n" +
+	"\x17RevokeShareLinkResponse\x12/\n" +
+	"\n" +
+	"share_link\x18\x01 \x01(\v2\x10.tz.v1.ShareLinkR\tshareLink\"/\n" +
+	"\x17ResolveShareLinkRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"K\n" +
+	"\x18ResolveShareLinkResponse\x12/\n" +
+	"\n" +
+	"share_link\x18\x01 \x01(\v2\x10.tz.v1.ShareLinkR\tshareLink2\xf4\x13\n" +
 	"\tTzService\x128\n" +
-	"\aCheckTz\x12\x15.tz.v1.CheckTzRequest\x1a\x16.tz.v1.CheckTzResponse\x12J\n" +
+	"\aCheckTz\x12\x15.tz.v1.CheckTzRequest\x1a\x16.tz.v1.CheckTzResponse\x12M\n" +
+	"\x0eCheckTzVersion\x12\x1c.tz.v1.CheckTzVersionRequest\x1a\x1d.tz.v1.CheckTzVersionResponse\x12J\n" +
 	"\rGetVersionsMe\x12\x1b.tz.v1.GetVersionsMeRequest\x1a\x1c.tz.v1.GetVersionsMeResponse\x12w\n" +
 	"\x1cGetAllVersionsAdminDashboard\x12*.tz.v1.GetAllVersionsAdminDashboardRequest\x1a+.tz.v1.GetAllVersionsAdminDashboardResponse\x12_\n" +
 	"\x14GetVersionStatistics\x12\".tz.v1.GetVersionStatisticsRequest\x1a#.tz.v1.GetVersionStatisticsResponse\x12A\n" +
@@ -2299,7 +6291,27 @@ const file_tz_v1_tz_proto_rawDesc = "" +
 	"\x10NewFeedbackError\x12\x1e.tz.v1.NewFeedbackErrorRequest\x1a\x1f.tz.v1.NewFeedbackErrorResponse\x12_\n" +
 	"\x14GetVersionsDateRange\x12\".tz.v1.GetVersionsDateRangeRequest\x1a#.tz.v1.GetVersionsDateRangeResponse\x12V\n" +
 	"\x11GetDailyAnalytics\x12\x1f.tz.v1.GetDailyAnalyticsRequest\x1a .tz.v1.GetDailyAnalyticsResponse\x12G\n" +
-	"\fGetFeedbacks\x12\x1a.tz.v1.GetFeedbacksRequest\x1a\x1b.tz.v1.GetFeedbacksResponseB*Z(repairCopilotBot/tz-bot/proto/tz/v1;tzv1b\x06proto3"
+	"\fGetFeedbacks\x12\x1a.tz.v1.GetFeedbacksRequest\x1a\x1b.tz.v1.GetFeedbacksResponse\x12P\n" +
+	"\x0fCompareVersions\x12\x1d.tz.v1.CompareVersionsRequest\x1a\x1e.tz.v1.CompareVersionsResponse\x12G\n" +
+	"\fRetryVersion\x12\x1a.tz.v1.RetryVersionRequest\x1a\x1b.tz.v1.RetryVersionResponse\x12J\n" +
+	"\rCancelVersion\x12\x1b.tz.v1.CancelVersionRequest\x1a\x1c.tz.v1.CancelVersionResponse\x12A\n" +
+	"\fWatchVersion\x12\x1a.tz.v1.WatchVersionRequest\x1a\x13.tz.v1.VersionEvent0\x01\x12Y\n" +
+	"\x12InvalidateLlmCache\x12 .tz.v1.InvalidateLlmCacheRequest\x1a!.tz.v1.InvalidateLlmCacheResponse\x12h\n" +
+	"\x17GetVersionCostBreakdown\x12%.tz.v1.GetVersionCostBreakdownRequest\x1a&.tz.v1.GetVersionCostBreakdownResponse\x12\\\n" +
+	"\x13ExportAnnotatedDocx\x12!.tz.v1.ExportAnnotatedDocxRequest\x1a\".tz.v1.ExportAnnotatedDocxResponse\x12M\n" +
+	"\x0eGetVersionFile\x12\x1c.tz.v1.GetVersionFileRequest\x1a\x1d.tz.v1.GetVersionFileResponse\x12J\n" +
+	"\rExportVersion\x12\x1b.tz.v1.ExportVersionRequest\x1a\x1c.tz.v1.ExportVersionResponse\x12V\n" +
+	"\x11ListCheckProfiles\x12\x1f.tz.v1.ListCheckProfilesRequest\x1a .tz.v1.ListCheckProfilesResponse\x12S\n" +
+	"\x12CreateCheckProfile\x12 .tz.v1.CreateCheckProfileRequest\x1a\x1b.tz.v1.CheckProfileResponse\x12S\n" +
+	"\x12UpdateCheckProfile\x12 .tz.v1.UpdateCheckProfileRequest\x1a\x1b.tz.v1.CheckProfileResponse\x12Y\n" +
+	"\x12DeleteCheckProfile\x12 .tz.v1.DeleteCheckProfileRequest\x1a!.tz.v1.DeleteCheckProfileResponse\x12[\n" +
+	"\x16SetDefaultCheckProfile\x12$.tz.v1.SetDefaultCheckProfileRequest\x1a\x1b.tz.v1.CheckProfileResponse\x12S\n" +
+	"\x10ListErrorCatalog\x12\x1e.tz.v1.ListErrorCatalogRequest\x1a\x1f.tz.v1.ListErrorCatalogResponse\x12_\n" +
+	"\x14GetErrorCatalogEntry\x12\".tz.v1.GetErrorCatalogEntryRequest\x1a#.tz.v1.GetErrorCatalogEntryResponse\x12P\n" +
+	"\x0fCreateShareLink\x12\x1d.tz.v1.CreateShareLinkRequest\x1a\x1e.tz.v1.CreateShareLinkResponse\x12M\n" +
+	"\x0eListShareLinks\x12\x1c.tz.v1.ListShareLinksRequest\x1a\x1d.tz.v1.ListShareLinksResponse\x12P\n" +
+	"\x0fRevokeShareLink\x12\x1d.tz.v1.RevokeShareLinkRequest\x1a\x1e.tz.v1.RevokeShareLinkResponse\x12S\n" +
+	"\x10ResolveShareLink\x12\x1e.tz.v1.ResolveShareLinkRequest\x1a\x1f.tz.v1.ResolveShareLinkResponseB*Z(repairCopilotBot/tz-bot/proto/tz/v1;tzv1b\x06proto3"
 
 var (
 	file_tz_v1_tz_proto_rawDescOnce sync.Once
@@ -2313,78 +6325,219 @@ func file_tz_v1_tz_proto_rawDescGZIP() []byte {
 	return file_tz_v1_tz_proto_rawDescData
 }
 
-var file_tz_v1_tz_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_tz_v1_tz_proto_msgTypes = make([]protoimpl.MessageInfo, 79)
 var file_tz_v1_tz_proto_goTypes = []any{
-	(*CheckTzRequest)(nil),                       // 0: tz.v1.CheckTzRequest
-	(*CheckTzResponse)(nil),                      // 1: tz.v1.CheckTzResponse
-	(*Error)(nil),                                // 2: tz.v1.Error
-	(*InvalidInstance)(nil),                      // 3: tz.v1.InvalidInstance
-	(*MissingInstance)(nil),                      // 4: tz.v1.MissingInstance
-	(*GetVersionsMeRequest)(nil),                 // 5: tz.v1.GetVersionsMeRequest
-	(*GetVersionsMeResponse)(nil),                // 6: tz.v1.GetVersionsMeResponse
-	(*VersionMe)(nil),                            // 7: tz.v1.VersionMe
-	(*GetVersionRequest)(nil),                    // 8: tz.v1.GetVersionRequest
-	(*GetVersionResponse)(nil),                   // 9: tz.v1.GetVersionResponse
-	(*GetAllVersionsAdminDashboardRequest)(nil),  // 10: tz.v1.GetAllVersionsAdminDashboardRequest
-	(*GetAllVersionsAdminDashboardResponse)(nil), // 11: tz.v1.GetAllVersionsAdminDashboardResponse
-	(*VersionAdminDashboard)(nil),                // 12: tz.v1.VersionAdminDashboard
-	(*GetVersionStatisticsRequest)(nil),          // 13: tz.v1.GetVersionStatisticsRequest
-	(*GetVersionStatisticsResponse)(nil),         // 14: tz.v1.GetVersionStatisticsResponse
-	(*VersionStatistics)(nil),                    // 15: tz.v1.VersionStatistics
-	(*NewFeedbackErrorRequest)(nil),              // 16: tz.v1.NewFeedbackErrorRequest
-	(*NewFeedbackErrorResponse)(nil),             // 17: tz.v1.NewFeedbackErrorResponse
-	(*GetVersionsDateRangeRequest)(nil),          // 18: tz.v1.GetVersionsDateRangeRequest
-	(*GetVersionsDateRangeResponse)(nil),         // 19: tz.v1.GetVersionsDateRangeResponse
-	(*GetDailyAnalyticsRequest)(nil),             // 20: tz.v1.GetDailyAnalyticsRequest
-	(*GetDailyAnalyticsResponse)(nil),            // 21: tz.v1.GetDailyAnalyticsResponse
-	(*DailyAnalyticsPoint)(nil),                  // 22: tz.v1.DailyAnalyticsPoint
-	(*GetFeedbacksRequest)(nil),                  // 23: tz.v1.GetFeedbacksRequest
-	(*GetFeedbacksResponse)(nil),                 // 24: tz.v1.GetFeedbacksResponse
-	(*FeedbackInstance)(nil),                     // 25: tz.v1.FeedbackInstance
-	nil,                                          // 26: tz.v1.GetVersionResponse.ErrorsMapEntry
-	(*timestamppb.Timestamp)(nil),                // 27: google.protobuf.Timestamp
+	(*Caller)(nil),                               // 0: tz.v1.Caller
+	(*CheckTzRequest)(nil),                       // 1: tz.v1.CheckTzRequest
+	(*CheckTzResponse)(nil),                      // 2: tz.v1.CheckTzResponse
+	(*CheckTzVersionRequest)(nil),                // 3: tz.v1.CheckTzVersionRequest
+	(*CheckTzVersionResponse)(nil),               // 4: tz.v1.CheckTzVersionResponse
+	(*Error)(nil),                                // 5: tz.v1.Error
+	(*InvalidInstance)(nil),                      // 6: tz.v1.InvalidInstance
+	(*MissingInstance)(nil),                      // 7: tz.v1.MissingInstance
+	(*GetVersionsMeRequest)(nil),                 // 8: tz.v1.GetVersionsMeRequest
+	(*GetVersionsMeResponse)(nil),                // 9: tz.v1.GetVersionsMeResponse
+	(*VersionMe)(nil),                            // 10: tz.v1.VersionMe
+	(*GetVersionRequest)(nil),                    // 11: tz.v1.GetVersionRequest
+	(*GetVersionResponse)(nil),                   // 12: tz.v1.GetVersionResponse
+	(*GetAllVersionsAdminDashboardRequest)(nil),  // 13: tz.v1.GetAllVersionsAdminDashboardRequest
+	(*GetAllVersionsAdminDashboardResponse)(nil), // 14: tz.v1.GetAllVersionsAdminDashboardResponse
+	(*VersionAdminDashboard)(nil),                // 15: tz.v1.VersionAdminDashboard
+	(*GetVersionStatisticsRequest)(nil),          // 16: tz.v1.GetVersionStatisticsRequest
+	(*GetVersionStatisticsResponse)(nil),         // 17: tz.v1.GetVersionStatisticsResponse
+	(*VersionStatistics)(nil),                    // 18: tz.v1.VersionStatistics
+	(*NewFeedbackErrorRequest)(nil),              // 19: tz.v1.NewFeedbackErrorRequest
+	(*NewFeedbackErrorResponse)(nil),             // 20: tz.v1.NewFeedbackErrorResponse
+	(*GetVersionsDateRangeRequest)(nil),          // 21: tz.v1.GetVersionsDateRangeRequest
+	(*GetVersionsDateRangeResponse)(nil),         // 22: tz.v1.GetVersionsDateRangeResponse
+	(*GetDailyAnalyticsRequest)(nil),             // 23: tz.v1.GetDailyAnalyticsRequest
+	(*GetDailyAnalyticsResponse)(nil),            // 24: tz.v1.GetDailyAnalyticsResponse
+	(*DailyAnalyticsPoint)(nil),                  // 25: tz.v1.DailyAnalyticsPoint
+	(*GetFeedbacksRequest)(nil),                  // 26: tz.v1.GetFeedbacksRequest
+	(*GetFeedbacksResponse)(nil),                 // 27: tz.v1.GetFeedbacksResponse
+	(*FeedbackInstance)(nil),                     // 28: tz.v1.FeedbackInstance
+	(*CompareVersionsRequest)(nil),               // 29: tz.v1.CompareVersionsRequest
+	(*InstanceComparison)(nil),                   // 30: tz.v1.InstanceComparison
+	(*CompareVersionsResponse)(nil),              // 31: tz.v1.CompareVersionsResponse
+	(*RetryVersionRequest)(nil),                  // 32: tz.v1.RetryVersionRequest
+	(*RetryVersionResponse)(nil),                 // 33: tz.v1.RetryVersionResponse
+	(*CancelVersionRequest)(nil),                 // 34: tz.v1.CancelVersionRequest
+	(*CancelVersionResponse)(nil),                // 35: tz.v1.CancelVersionResponse
+	(*WatchVersionRequest)(nil),                  // 36: tz.v1.WatchVersionRequest
+	(*StageStartedEvent)(nil),                    // 37: tz.v1.StageStartedEvent
+	(*StageFinishedEvent)(nil),                   // 38: tz.v1.StageFinishedEvent
+	(*LlmGroupProgressEvent)(nil),                // 39: tz.v1.LlmGroupProgressEvent
+	(*CostEvent)(nil),                            // 40: tz.v1.CostEvent
+	(*FinalStatusEvent)(nil),                     // 41: tz.v1.FinalStatusEvent
+	(*VersionEvent)(nil),                         // 42: tz.v1.VersionEvent
+	(*InvalidateLlmCacheRequest)(nil),            // 43: tz.v1.InvalidateLlmCacheRequest
+	(*InvalidateLlmCacheResponse)(nil),           // 44: tz.v1.InvalidateLlmCacheResponse
+	(*GetVersionCostBreakdownRequest)(nil),       // 45: tz.v1.GetVersionCostBreakdownRequest
+	(*LlmCostGroup)(nil),                         // 46: tz.v1.LlmCostGroup
+	(*LlmCall)(nil),                              // 47: tz.v1.LlmCall
+	(*GetVersionCostBreakdownResponse)(nil),      // 48: tz.v1.GetVersionCostBreakdownResponse
+	(*ExportAnnotatedDocxRequest)(nil),           // 49: tz.v1.ExportAnnotatedDocxRequest
+	(*ExportAnnotatedDocxResponse)(nil),          // 50: tz.v1.ExportAnnotatedDocxResponse
+	(*GetVersionFileRequest)(nil),                // 51: tz.v1.GetVersionFileRequest
+	(*GetVersionFileResponse)(nil),               // 52: tz.v1.GetVersionFileResponse
+	(*ExportVersionRequest)(nil),                 // 53: tz.v1.ExportVersionRequest
+	(*ExportVersionResponse)(nil),                // 54: tz.v1.ExportVersionResponse
+	(*CheckProfile)(nil),                         // 55: tz.v1.CheckProfile
+	(*ListCheckProfilesRequest)(nil),             // 56: tz.v1.ListCheckProfilesRequest
+	(*ListCheckProfilesResponse)(nil),            // 57: tz.v1.ListCheckProfilesResponse
+	(*CreateCheckProfileRequest)(nil),            // 58: tz.v1.CreateCheckProfileRequest
+	(*UpdateCheckProfileRequest)(nil),            // 59: tz.v1.UpdateCheckProfileRequest
+	(*CheckProfileResponse)(nil),                 // 60: tz.v1.CheckProfileResponse
+	(*DeleteCheckProfileRequest)(nil),            // 61: tz.v1.DeleteCheckProfileRequest
+	(*DeleteCheckProfileResponse)(nil),           // 62: tz.v1.DeleteCheckProfileResponse
+	(*SetDefaultCheckProfileRequest)(nil),        // 63: tz.v1.SetDefaultCheckProfileRequest
+	(*ErrorCatalogEntry)(nil),                    // 64: tz.v1.ErrorCatalogEntry
+	(*ListErrorCatalogRequest)(nil),              // 65: tz.v1.ListErrorCatalogRequest
+	(*ListErrorCatalogResponse)(nil),             // 66: tz.v1.ListErrorCatalogResponse
+	(*GetErrorCatalogEntryRequest)(nil),          // 67: tz.v1.GetErrorCatalogEntryRequest
+	(*GetErrorCatalogEntryResponse)(nil),         // 68: tz.v1.GetErrorCatalogEntryResponse
+	(*ShareLink)(nil),                            // 69: tz.v1.ShareLink
+	(*CreateShareLinkRequest)(nil),               // 70: tz.v1.CreateShareLinkRequest
+	(*CreateShareLinkResponse)(nil),              // 71: tz.v1.CreateShareLinkResponse
+	(*ListShareLinksRequest)(nil),                // 72: tz.v1.ListShareLinksRequest
+	(*ListShareLinksResponse)(nil),               // 73: tz.v1.ListShareLinksResponse
+	(*RevokeShareLinkRequest)(nil),               // 74: tz.v1.RevokeShareLinkRequest
+	(*RevokeShareLinkResponse)(nil),              // 75: tz.v1.RevokeShareLinkResponse
+	(*ResolveShareLinkRequest)(nil),              // 76: tz.v1.ResolveShareLinkRequest
+	(*ResolveShareLinkResponse)(nil),             // 77: tz.v1.ResolveShareLinkResponse
+	nil,                                          // 78: tz.v1.GetVersionResponse.ErrorsMapEntry
+	(*timestamppb.Timestamp)(nil),                // 79: google.protobuf.Timestamp
 }
 var file_tz_v1_tz_proto_depIdxs = []int32{
-	27, // 0: tz.v1.CheckTzResponse.created_at:type_name -> google.protobuf.Timestamp
-	3,  // 1: tz.v1.Error.invalid_instances:type_name -> tz.v1.InvalidInstance
-	4,  // 2: tz.v1.Error.missing_instances:type_name -> tz.v1.MissingInstance
-	2,  // 3: tz.v1.InvalidInstance.parent_error:type_name -> tz.v1.Error
-	7,  // 4: tz.v1.GetVersionsMeResponse.versions:type_name -> tz.v1.VersionMe
-	27, // 5: tz.v1.VersionMe.created_at:type_name -> google.protobuf.Timestamp
-	2,  // 6: tz.v1.GetVersionResponse.errors:type_name -> tz.v1.Error
-	3,  // 7: tz.v1.GetVersionResponse.invalid_instances:type_name -> tz.v1.InvalidInstance
-	27, // 8: tz.v1.GetVersionResponse.created_at:type_name -> google.protobuf.Timestamp
-	26, // 9: tz.v1.GetVersionResponse.errorsMap:type_name -> tz.v1.GetVersionResponse.ErrorsMapEntry
-	12, // 10: tz.v1.GetAllVersionsAdminDashboardResponse.versions:type_name -> tz.v1.VersionAdminDashboard
-	27, // 11: tz.v1.VersionAdminDashboard.created_at:type_name -> google.protobuf.Timestamp
-	15, // 12: tz.v1.GetVersionStatisticsResponse.statistics:type_name -> tz.v1.VersionStatistics
-	22, // 13: tz.v1.GetDailyAnalyticsResponse.series:type_name -> tz.v1.DailyAnalyticsPoint
-	25, // 14: tz.v1.GetFeedbacksResponse.feedbacks:type_name -> tz.v1.FeedbackInstance
-	27, // 15: tz.v1.FeedbackInstance.created_at:type_name -> google.protobuf.Timestamp
-	2,  // 16: tz.v1.GetVersionResponse.ErrorsMapEntry.value:type_name -> tz.v1.Error
-	0,  // 17: tz.v1.TzService.CheckTz:input_type -> tz.v1.CheckTzRequest
-	5,  // 18: tz.v1.TzService.GetVersionsMe:input_type -> tz.v1.GetVersionsMeRequest
-	10, // 19: tz.v1.TzService.GetAllVersionsAdminDashboard:input_type -> tz.v1.GetAllVersionsAdminDashboardRequest
-	13, // 20: tz.v1.TzService.GetVersionStatistics:input_type -> tz.v1.GetVersionStatisticsRequest
-	8,  // 21: tz.v1.TzService.GetVersion:input_type -> tz.v1.GetVersionRequest
-	16, // 22: tz.v1.TzService.NewFeedbackError:input_type -> tz.v1.NewFeedbackErrorRequest
-	18, // 23: tz.v1.TzService.GetVersionsDateRange:input_type -> tz.v1.GetVersionsDateRangeRequest
-	20, // 24: tz.v1.TzService.GetDailyAnalytics:input_type -> tz.v1.GetDailyAnalyticsRequest
-	23, // 25: tz.v1.TzService.GetFeedbacks:input_type -> tz.v1.GetFeedbacksRequest
-	1,  // 26: tz.v1.TzService.CheckTz:output_type -> tz.v1.CheckTzResponse
-	6,  // 27: tz.v1.TzService.GetVersionsMe:output_type -> tz.v1.GetVersionsMeResponse
-	11, // 28: tz.v1.TzService.GetAllVersionsAdminDashboard:output_type -> tz.v1.GetAllVersionsAdminDashboardResponse
-	14, // 29: tz.v1.TzService.GetVersionStatistics:output_type -> tz.v1.GetVersionStatisticsResponse
-	9,  // 30: tz.v1.TzService.GetVersion:output_type -> tz.v1.GetVersionResponse
-	17, // 31: tz.v1.TzService.NewFeedbackError:output_type -> tz.v1.NewFeedbackErrorResponse
-	19, // 32: tz.v1.TzService.GetVersionsDateRange:output_type -> tz.v1.GetVersionsDateRangeResponse
-	21, // 33: tz.v1.TzService.GetDailyAnalytics:output_type -> tz.v1.GetDailyAnalyticsResponse
-	24, // 34: tz.v1.TzService.GetFeedbacks:output_type -> tz.v1.GetFeedbacksResponse
-	26, // [26:35] is the sub-list for method output_type
-	17, // [17:26] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	79, // 0: tz.v1.CheckTzResponse.created_at:type_name -> google.protobuf.Timestamp
+	79, // 1: tz.v1.CheckTzVersionResponse.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 2: tz.v1.Error.invalid_instances:type_name -> tz.v1.InvalidInstance
+	7,  // 3: tz.v1.Error.missing_instances:type_name -> tz.v1.MissingInstance
+	5,  // 4: tz.v1.InvalidInstance.parent_error:type_name -> tz.v1.Error
+	10, // 5: tz.v1.GetVersionsMeResponse.versions:type_name -> tz.v1.VersionMe
+	79, // 6: tz.v1.VersionMe.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: tz.v1.GetVersionRequest.caller:type_name -> tz.v1.Caller
+	5,  // 8: tz.v1.GetVersionResponse.errors:type_name -> tz.v1.Error
+	6,  // 9: tz.v1.GetVersionResponse.invalid_instances:type_name -> tz.v1.InvalidInstance
+	79, // 10: tz.v1.GetVersionResponse.created_at:type_name -> google.protobuf.Timestamp
+	78, // 11: tz.v1.GetVersionResponse.errorsMap:type_name -> tz.v1.GetVersionResponse.ErrorsMapEntry
+	15, // 12: tz.v1.GetAllVersionsAdminDashboardResponse.versions:type_name -> tz.v1.VersionAdminDashboard
+	79, // 13: tz.v1.VersionAdminDashboard.created_at:type_name -> google.protobuf.Timestamp
+	18, // 14: tz.v1.GetVersionStatisticsResponse.statistics:type_name -> tz.v1.VersionStatistics
+	0,  // 15: tz.v1.NewFeedbackErrorRequest.caller:type_name -> tz.v1.Caller
+	25, // 16: tz.v1.GetDailyAnalyticsResponse.series:type_name -> tz.v1.DailyAnalyticsPoint
+	28, // 17: tz.v1.GetFeedbacksResponse.feedbacks:type_name -> tz.v1.FeedbackInstance
+	79, // 18: tz.v1.FeedbackInstance.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 19: tz.v1.CompareVersionsRequest.caller:type_name -> tz.v1.Caller
+	6,  // 20: tz.v1.InstanceComparison.instance_a:type_name -> tz.v1.InvalidInstance
+	6,  // 21: tz.v1.InstanceComparison.instance_b:type_name -> tz.v1.InvalidInstance
+	30, // 22: tz.v1.CompareVersionsResponse.fixed:type_name -> tz.v1.InstanceComparison
+	30, // 23: tz.v1.CompareVersionsResponse.persisting:type_name -> tz.v1.InstanceComparison
+	30, // 24: tz.v1.CompareVersionsResponse.new:type_name -> tz.v1.InstanceComparison
+	0,  // 25: tz.v1.RetryVersionRequest.caller:type_name -> tz.v1.Caller
+	0,  // 26: tz.v1.WatchVersionRequest.caller:type_name -> tz.v1.Caller
+	79, // 27: tz.v1.VersionEvent.created_at:type_name -> google.protobuf.Timestamp
+	37, // 28: tz.v1.VersionEvent.stage_started:type_name -> tz.v1.StageStartedEvent
+	38, // 29: tz.v1.VersionEvent.stage_finished:type_name -> tz.v1.StageFinishedEvent
+	39, // 30: tz.v1.VersionEvent.llm_group_progress:type_name -> tz.v1.LlmGroupProgressEvent
+	40, // 31: tz.v1.VersionEvent.cost:type_name -> tz.v1.CostEvent
+	41, // 32: tz.v1.VersionEvent.final_status:type_name -> tz.v1.FinalStatusEvent
+	79, // 33: tz.v1.InvalidateLlmCacheRequest.from:type_name -> google.protobuf.Timestamp
+	79, // 34: tz.v1.InvalidateLlmCacheRequest.to:type_name -> google.protobuf.Timestamp
+	0,  // 35: tz.v1.GetVersionCostBreakdownRequest.caller:type_name -> tz.v1.Caller
+	79, // 36: tz.v1.LlmCall.created_at:type_name -> google.protobuf.Timestamp
+	46, // 37: tz.v1.GetVersionCostBreakdownResponse.total:type_name -> tz.v1.LlmCostGroup
+	46, // 38: tz.v1.GetVersionCostBreakdownResponse.groups:type_name -> tz.v1.LlmCostGroup
+	47, // 39: tz.v1.GetVersionCostBreakdownResponse.calls:type_name -> tz.v1.LlmCall
+	0,  // 40: tz.v1.ExportAnnotatedDocxRequest.caller:type_name -> tz.v1.Caller
+	0,  // 41: tz.v1.GetVersionFileRequest.caller:type_name -> tz.v1.Caller
+	0,  // 42: tz.v1.ExportVersionRequest.caller:type_name -> tz.v1.Caller
+	79, // 43: tz.v1.CheckProfile.created_at:type_name -> google.protobuf.Timestamp
+	79, // 44: tz.v1.CheckProfile.updated_at:type_name -> google.protobuf.Timestamp
+	55, // 45: tz.v1.ListCheckProfilesResponse.profiles:type_name -> tz.v1.CheckProfile
+	55, // 46: tz.v1.CheckProfileResponse.profile:type_name -> tz.v1.CheckProfile
+	79, // 47: tz.v1.ErrorCatalogEntry.created_at:type_name -> google.protobuf.Timestamp
+	79, // 48: tz.v1.ErrorCatalogEntry.superseded_at:type_name -> google.protobuf.Timestamp
+	64, // 49: tz.v1.ListErrorCatalogResponse.entries:type_name -> tz.v1.ErrorCatalogEntry
+	64, // 50: tz.v1.GetErrorCatalogEntryResponse.entry:type_name -> tz.v1.ErrorCatalogEntry
+	79, // 51: tz.v1.ShareLink.expires_at:type_name -> google.protobuf.Timestamp
+	79, // 52: tz.v1.ShareLink.revoked_at:type_name -> google.protobuf.Timestamp
+	79, // 53: tz.v1.ShareLink.last_accessed_at:type_name -> google.protobuf.Timestamp
+	79, // 54: tz.v1.ShareLink.created_at:type_name -> google.protobuf.Timestamp
+	79, // 55: tz.v1.CreateShareLinkRequest.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 56: tz.v1.CreateShareLinkRequest.caller:type_name -> tz.v1.Caller
+	69, // 57: tz.v1.CreateShareLinkResponse.share_link:type_name -> tz.v1.ShareLink
+	0,  // 58: tz.v1.ListShareLinksRequest.caller:type_name -> tz.v1.Caller
+	69, // 59: tz.v1.ListShareLinksResponse.share_links:type_name -> tz.v1.ShareLink
+	0,  // 60: tz.v1.RevokeShareLinkRequest.caller:type_name -> tz.v1.Caller
+	69, // 61: tz.v1.RevokeShareLinkResponse.share_link:type_name -> tz.v1.ShareLink
+	69, // 62: tz.v1.ResolveShareLinkResponse.share_link:type_name -> tz.v1.ShareLink
+	5,  // 63: tz.v1.GetVersionResponse.ErrorsMapEntry.value:type_name -> tz.v1.Error
+	1,  // 64: tz.v1.TzService.CheckTz:input_type -> tz.v1.CheckTzRequest
+	3,  // 65: tz.v1.TzService.CheckTzVersion:input_type -> tz.v1.CheckTzVersionRequest
+	8,  // 66: tz.v1.TzService.GetVersionsMe:input_type -> tz.v1.GetVersionsMeRequest
+	13, // 67: tz.v1.TzService.GetAllVersionsAdminDashboard:input_type -> tz.v1.GetAllVersionsAdminDashboardRequest
+	16, // 68: tz.v1.TzService.GetVersionStatistics:input_type -> tz.v1.GetVersionStatisticsRequest
+	11, // 69: tz.v1.TzService.GetVersion:input_type -> tz.v1.GetVersionRequest
+	19, // 70: tz.v1.TzService.NewFeedbackError:input_type -> tz.v1.NewFeedbackErrorRequest
+	21, // 71: tz.v1.TzService.GetVersionsDateRange:input_type -> tz.v1.GetVersionsDateRangeRequest
+	23, // 72: tz.v1.TzService.GetDailyAnalytics:input_type -> tz.v1.GetDailyAnalyticsRequest
+	26, // 73: tz.v1.TzService.GetFeedbacks:input_type -> tz.v1.GetFeedbacksRequest
+	29, // 74: tz.v1.TzService.CompareVersions:input_type -> tz.v1.CompareVersionsRequest
+	32, // 75: tz.v1.TzService.RetryVersion:input_type -> tz.v1.RetryVersionRequest
+	34, // 76: tz.v1.TzService.CancelVersion:input_type -> tz.v1.CancelVersionRequest
+	36, // 77: tz.v1.TzService.WatchVersion:input_type -> tz.v1.WatchVersionRequest
+	43, // 78: tz.v1.TzService.InvalidateLlmCache:input_type -> tz.v1.InvalidateLlmCacheRequest
+	45, // 79: tz.v1.TzService.GetVersionCostBreakdown:input_type -> tz.v1.GetVersionCostBreakdownRequest
+	49, // 80: tz.v1.TzService.ExportAnnotatedDocx:input_type -> tz.v1.ExportAnnotatedDocxRequest
+	51, // 81: tz.v1.TzService.GetVersionFile:input_type -> tz.v1.GetVersionFileRequest
+	53, // 82: tz.v1.TzService.ExportVersion:input_type -> tz.v1.ExportVersionRequest
+	56, // 83: tz.v1.TzService.ListCheckProfiles:input_type -> tz.v1.ListCheckProfilesRequest
+	58, // 84: tz.v1.TzService.CreateCheckProfile:input_type -> tz.v1.CreateCheckProfileRequest
+	59, // 85: tz.v1.TzService.UpdateCheckProfile:input_type -> tz.v1.UpdateCheckProfileRequest
+	61, // 86: tz.v1.TzService.DeleteCheckProfile:input_type -> tz.v1.DeleteCheckProfileRequest
+	63, // 87: tz.v1.TzService.SetDefaultCheckProfile:input_type -> tz.v1.SetDefaultCheckProfileRequest
+	65, // 88: tz.v1.TzService.ListErrorCatalog:input_type -> tz.v1.ListErrorCatalogRequest
+	67, // 89: tz.v1.TzService.GetErrorCatalogEntry:input_type -> tz.v1.GetErrorCatalogEntryRequest
+	70, // 90: tz.v1.TzService.CreateShareLink:input_type -> tz.v1.CreateShareLinkRequest
+	72, // 91: tz.v1.TzService.ListShareLinks:input_type -> tz.v1.ListShareLinksRequest
+	74, // 92: tz.v1.TzService.RevokeShareLink:input_type -> tz.v1.RevokeShareLinkRequest
+	76, // 93: tz.v1.TzService.ResolveShareLink:input_type -> tz.v1.ResolveShareLinkRequest
+	2,  // 94: tz.v1.TzService.CheckTz:output_type -> tz.v1.CheckTzResponse
+	4,  // 95: tz.v1.TzService.CheckTzVersion:output_type -> tz.v1.CheckTzVersionResponse
+	9,  // 96: tz.v1.TzService.GetVersionsMe:output_type -> tz.v1.GetVersionsMeResponse
+	14, // 97: tz.v1.TzService.GetAllVersionsAdminDashboard:output_type -> tz.v1.GetAllVersionsAdminDashboardResponse
+	17, // 98: tz.v1.TzService.GetVersionStatistics:output_type -> tz.v1.GetVersionStatisticsResponse
+	12, // 99: tz.v1.TzService.GetVersion:output_type -> tz.v1.GetVersionResponse
+	20, // 100: tz.v1.TzService.NewFeedbackError:output_type -> tz.v1.NewFeedbackErrorResponse
+	22, // 101: tz.v1.TzService.GetVersionsDateRange:output_type -> tz.v1.GetVersionsDateRangeResponse
+	24, // 102: tz.v1.TzService.GetDailyAnalytics:output_type -> tz.v1.GetDailyAnalyticsResponse
+	27, // 103: tz.v1.TzService.GetFeedbacks:output_type -> tz.v1.GetFeedbacksResponse
+	31, // 104: tz.v1.TzService.CompareVersions:output_type -> tz.v1.CompareVersionsResponse
+	33, // 105: tz.v1.TzService.RetryVersion:output_type -> tz.v1.RetryVersionResponse
+	35, // 106: tz.v1.TzService.CancelVersion:output_type -> tz.v1.CancelVersionResponse
+	42, // 107: tz.v1.TzService.WatchVersion:output_type -> tz.v1.VersionEvent
+	44, // 108: tz.v1.TzService.InvalidateLlmCache:output_type -> tz.v1.InvalidateLlmCacheResponse
+	48, // 109: tz.v1.TzService.GetVersionCostBreakdown:output_type -> tz.v1.GetVersionCostBreakdownResponse
+	50, // 110: tz.v1.TzService.ExportAnnotatedDocx:output_type -> tz.v1.ExportAnnotatedDocxResponse
+	52, // 111: tz.v1.TzService.GetVersionFile:output_type -> tz.v1.GetVersionFileResponse
+	54, // 112: tz.v1.TzService.ExportVersion:output_type -> tz.v1.ExportVersionResponse
+	57, // 113: tz.v1.TzService.ListCheckProfiles:output_type -> tz.v1.ListCheckProfilesResponse
+	60, // 114: tz.v1.TzService.CreateCheckProfile:output_type -> tz.v1.CheckProfileResponse
+	60, // 115: tz.v1.TzService.UpdateCheckProfile:output_type -> tz.v1.CheckProfileResponse
+	62, // 116: tz.v1.TzService.DeleteCheckProfile:output_type -> tz.v1.DeleteCheckProfileResponse
+	60, // 117: tz.v1.TzService.SetDefaultCheckProfile:output_type -> tz.v1.CheckProfileResponse
+	66, // 118: tz.v1.TzService.ListErrorCatalog:output_type -> tz.v1.ListErrorCatalogResponse
+	68, // 119: tz.v1.TzService.GetErrorCatalogEntry:output_type -> tz.v1.GetErrorCatalogEntryResponse
+	71, // 120: tz.v1.TzService.CreateShareLink:output_type -> tz.v1.CreateShareLinkResponse
+	73, // 121: tz.v1.TzService.ListShareLinks:output_type -> tz.v1.ListShareLinksResponse
+	75, // 122: tz.v1.TzService.RevokeShareLink:output_type -> tz.v1.RevokeShareLinkResponse
+	77, // 123: tz.v1.TzService.ResolveShareLink:output_type -> tz.v1.ResolveShareLinkResponse
+	94, // [94:124] is the sub-list for method output_type
+	64, // [64:94] is the sub-list for method input_type
+	64, // [64:64] is the sub-list for extension type_name
+	64, // [64:64] is the sub-list for extension extendee
+	0,  // [0:64] is the sub-list for field type_name
 }
 
 func init() { file_tz_v1_tz_proto_init() }
@@ -2392,25 +6545,47 @@ func file_tz_v1_tz_proto_init() {
 	if File_tz_v1_tz_proto != nil {
 		return
 	}
+	file_tz_v1_tz_proto_msgTypes[1].OneofWrappers = []any{}
 	file_tz_v1_tz_proto_msgTypes[2].OneofWrappers = []any{}
 	file_tz_v1_tz_proto_msgTypes[3].OneofWrappers = []any{}
 	file_tz_v1_tz_proto_msgTypes[4].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[5].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[6].OneofWrappers = []any{}
 	file_tz_v1_tz_proto_msgTypes[7].OneofWrappers = []any{}
-	file_tz_v1_tz_proto_msgTypes[9].OneofWrappers = []any{}
 	file_tz_v1_tz_proto_msgTypes[10].OneofWrappers = []any{}
-	file_tz_v1_tz_proto_msgTypes[15].OneofWrappers = []any{}
-	file_tz_v1_tz_proto_msgTypes[16].OneofWrappers = []any{}
-	file_tz_v1_tz_proto_msgTypes[20].OneofWrappers = []any{}
-	file_tz_v1_tz_proto_msgTypes[22].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[12].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[13].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[18].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[19].OneofWrappers = []any{}
 	file_tz_v1_tz_proto_msgTypes[23].OneofWrappers = []any{}
 	file_tz_v1_tz_proto_msgTypes[25].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[26].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[28].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[30].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[42].OneofWrappers = []any{
+		(*VersionEvent_StageStarted)(nil),
+		(*VersionEvent_StageFinished)(nil),
+		(*VersionEvent_LlmGroupProgress)(nil),
+		(*VersionEvent_Cost)(nil),
+		(*VersionEvent_FinalStatus)(nil),
+	}
+	file_tz_v1_tz_proto_msgTypes[43].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[46].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[47].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[55].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[58].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[59].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[64].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[65].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[67].OneofWrappers = []any{}
+	file_tz_v1_tz_proto_msgTypes[69].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tz_v1_tz_proto_rawDesc), len(file_tz_v1_tz_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   27,
+			NumMessages:   79,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
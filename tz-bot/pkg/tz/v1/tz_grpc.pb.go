@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	TzService_CheckTz_FullMethodName                      = "/tz.v1.TzService/CheckTz"
+	TzService_CheckTzVersion_FullMethodName               = "/tz.v1.TzService/CheckTzVersion"
 	TzService_GetVersionsMe_FullMethodName                = "/tz.v1.TzService/GetVersionsMe"
 	TzService_GetAllVersionsAdminDashboard_FullMethodName = "/tz.v1.TzService/GetAllVersionsAdminDashboard"
 	TzService_GetVersionStatistics_FullMethodName         = "/tz.v1.TzService/GetVersionStatistics"
@@ -28,6 +29,26 @@ const (
 	TzService_GetVersionsDateRange_FullMethodName         = "/tz.v1.TzService/GetVersionsDateRange"
 	TzService_GetDailyAnalytics_FullMethodName            = "/tz.v1.TzService/GetDailyAnalytics"
 	TzService_GetFeedbacks_FullMethodName                 = "/tz.v1.TzService/GetFeedbacks"
+	TzService_CompareVersions_FullMethodName              = "/tz.v1.TzService/CompareVersions"
+	TzService_RetryVersion_FullMethodName                 = "/tz.v1.TzService/RetryVersion"
+	TzService_CancelVersion_FullMethodName                = "/tz.v1.TzService/CancelVersion"
+	TzService_WatchVersion_FullMethodName                 = "/tz.v1.TzService/WatchVersion"
+	TzService_InvalidateLlmCache_FullMethodName           = "/tz.v1.TzService/InvalidateLlmCache"
+	TzService_GetVersionCostBreakdown_FullMethodName      = "/tz.v1.TzService/GetVersionCostBreakdown"
+	TzService_ExportAnnotatedDocx_FullMethodName          = "/tz.v1.TzService/ExportAnnotatedDocx"
+	TzService_GetVersionFile_FullMethodName               = "/tz.v1.TzService/GetVersionFile"
+	TzService_ExportVersion_FullMethodName                = "/tz.v1.TzService/ExportVersion"
+	TzService_ListCheckProfiles_FullMethodName            = "/tz.v1.TzService/ListCheckProfiles"
+	TzService_CreateCheckProfile_FullMethodName           = "/tz.v1.TzService/CreateCheckProfile"
+	TzService_UpdateCheckProfile_FullMethodName           = "/tz.v1.TzService/UpdateCheckProfile"
+	TzService_DeleteCheckProfile_FullMethodName           = "/tz.v1.TzService/DeleteCheckProfile"
+	TzService_SetDefaultCheckProfile_FullMethodName       = "/tz.v1.TzService/SetDefaultCheckProfile"
+	TzService_ListErrorCatalog_FullMethodName             = "/tz.v1.TzService/ListErrorCatalog"
+	TzService_GetErrorCatalogEntry_FullMethodName         = "/tz.v1.TzService/GetErrorCatalogEntry"
+	TzService_CreateShareLink_FullMethodName              = "/tz.v1.TzService/CreateShareLink"
+	TzService_ListShareLinks_FullMethodName               = "/tz.v1.TzService/ListShareLinks"
+	TzService_RevokeShareLink_FullMethodName              = "/tz.v1.TzService/RevokeShareLink"
+	TzService_ResolveShareLink_FullMethodName             = "/tz.v1.TzService/ResolveShareLink"
 )
 
 // TzServiceClient is the client API for TzService service.
@@ -35,6 +56,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TzServiceClient interface {
 	CheckTz(ctx context.Context, in *CheckTzRequest, opts ...grpc.CallOption) (*CheckTzResponse, error)
+	CheckTzVersion(ctx context.Context, in *CheckTzVersionRequest, opts ...grpc.CallOption) (*CheckTzVersionResponse, error)
 	GetVersionsMe(ctx context.Context, in *GetVersionsMeRequest, opts ...grpc.CallOption) (*GetVersionsMeResponse, error)
 	GetAllVersionsAdminDashboard(ctx context.Context, in *GetAllVersionsAdminDashboardRequest, opts ...grpc.CallOption) (*GetAllVersionsAdminDashboardResponse, error)
 	GetVersionStatistics(ctx context.Context, in *GetVersionStatisticsRequest, opts ...grpc.CallOption) (*GetVersionStatisticsResponse, error)
@@ -43,6 +65,26 @@ type TzServiceClient interface {
 	GetVersionsDateRange(ctx context.Context, in *GetVersionsDateRangeRequest, opts ...grpc.CallOption) (*GetVersionsDateRangeResponse, error)
 	GetDailyAnalytics(ctx context.Context, in *GetDailyAnalyticsRequest, opts ...grpc.CallOption) (*GetDailyAnalyticsResponse, error)
 	GetFeedbacks(ctx context.Context, in *GetFeedbacksRequest, opts ...grpc.CallOption) (*GetFeedbacksResponse, error)
+	CompareVersions(ctx context.Context, in *CompareVersionsRequest, opts ...grpc.CallOption) (*CompareVersionsResponse, error)
+	RetryVersion(ctx context.Context, in *RetryVersionRequest, opts ...grpc.CallOption) (*RetryVersionResponse, error)
+	CancelVersion(ctx context.Context, in *CancelVersionRequest, opts ...grpc.CallOption) (*CancelVersionResponse, error)
+	WatchVersion(ctx context.Context, in *WatchVersionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VersionEvent], error)
+	InvalidateLlmCache(ctx context.Context, in *InvalidateLlmCacheRequest, opts ...grpc.CallOption) (*InvalidateLlmCacheResponse, error)
+	GetVersionCostBreakdown(ctx context.Context, in *GetVersionCostBreakdownRequest, opts ...grpc.CallOption) (*GetVersionCostBreakdownResponse, error)
+	ExportAnnotatedDocx(ctx context.Context, in *ExportAnnotatedDocxRequest, opts ...grpc.CallOption) (*ExportAnnotatedDocxResponse, error)
+	GetVersionFile(ctx context.Context, in *GetVersionFileRequest, opts ...grpc.CallOption) (*GetVersionFileResponse, error)
+	ExportVersion(ctx context.Context, in *ExportVersionRequest, opts ...grpc.CallOption) (*ExportVersionResponse, error)
+	ListCheckProfiles(ctx context.Context, in *ListCheckProfilesRequest, opts ...grpc.CallOption) (*ListCheckProfilesResponse, error)
+	CreateCheckProfile(ctx context.Context, in *CreateCheckProfileRequest, opts ...grpc.CallOption) (*CheckProfileResponse, error)
+	UpdateCheckProfile(ctx context.Context, in *UpdateCheckProfileRequest, opts ...grpc.CallOption) (*CheckProfileResponse, error)
+	DeleteCheckProfile(ctx context.Context, in *DeleteCheckProfileRequest, opts ...grpc.CallOption) (*DeleteCheckProfileResponse, error)
+	SetDefaultCheckProfile(ctx context.Context, in *SetDefaultCheckProfileRequest, opts ...grpc.CallOption) (*CheckProfileResponse, error)
+	ListErrorCatalog(ctx context.Context, in *ListErrorCatalogRequest, opts ...grpc.CallOption) (*ListErrorCatalogResponse, error)
+	GetErrorCatalogEntry(ctx context.Context, in *GetErrorCatalogEntryRequest, opts ...grpc.CallOption) (*GetErrorCatalogEntryResponse, error)
+	CreateShareLink(ctx context.Context, in *CreateShareLinkRequest, opts ...grpc.CallOption) (*CreateShareLinkResponse, error)
+	ListShareLinks(ctx context.Context, in *ListShareLinksRequest, opts ...grpc.CallOption) (*ListShareLinksResponse, error)
+	RevokeShareLink(ctx context.Context, in *RevokeShareLinkRequest, opts ...grpc.CallOption) (*RevokeShareLinkResponse, error)
+	ResolveShareLink(ctx context.Context, in *ResolveShareLinkRequest, opts ...grpc.CallOption) (*ResolveShareLinkResponse, error)
 }
 
 type tzServiceClient struct {
@@ -63,6 +105,16 @@ func (c *tzServiceClient) CheckTz(ctx context.Context, in *CheckTzRequest, opts
 	return out, nil
 }
 
+func (c *tzServiceClient) CheckTzVersion(ctx context.Context, in *CheckTzVersionRequest, opts ...grpc.CallOption) (*CheckTzVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckTzVersionResponse)
+	err := c.cc.Invoke(ctx, TzService_CheckTzVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tzServiceClient) GetVersionsMe(ctx context.Context, in *GetVersionsMeRequest, opts ...grpc.CallOption) (*GetVersionsMeResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetVersionsMeResponse)
@@ -143,11 +195,221 @@ func (c *tzServiceClient) GetFeedbacks(ctx context.Context, in *GetFeedbacksRequ
 	return out, nil
 }
 
+func (c *tzServiceClient) CompareVersions(ctx context.Context, in *CompareVersionsRequest, opts ...grpc.CallOption) (*CompareVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareVersionsResponse)
+	err := c.cc.Invoke(ctx, TzService_CompareVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) RetryVersion(ctx context.Context, in *RetryVersionRequest, opts ...grpc.CallOption) (*RetryVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetryVersionResponse)
+	err := c.cc.Invoke(ctx, TzService_RetryVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) CancelVersion(ctx context.Context, in *CancelVersionRequest, opts ...grpc.CallOption) (*CancelVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelVersionResponse)
+	err := c.cc.Invoke(ctx, TzService_CancelVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) WatchVersion(ctx context.Context, in *WatchVersionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VersionEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TzService_ServiceDesc.Streams[0], TzService_WatchVersion_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchVersionRequest, VersionEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TzService_WatchVersionClient = grpc.ServerStreamingClient[VersionEvent]
+
+func (c *tzServiceClient) InvalidateLlmCache(ctx context.Context, in *InvalidateLlmCacheRequest, opts ...grpc.CallOption) (*InvalidateLlmCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvalidateLlmCacheResponse)
+	err := c.cc.Invoke(ctx, TzService_InvalidateLlmCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) GetVersionCostBreakdown(ctx context.Context, in *GetVersionCostBreakdownRequest, opts ...grpc.CallOption) (*GetVersionCostBreakdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionCostBreakdownResponse)
+	err := c.cc.Invoke(ctx, TzService_GetVersionCostBreakdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) ExportAnnotatedDocx(ctx context.Context, in *ExportAnnotatedDocxRequest, opts ...grpc.CallOption) (*ExportAnnotatedDocxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportAnnotatedDocxResponse)
+	err := c.cc.Invoke(ctx, TzService_ExportAnnotatedDocx_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) GetVersionFile(ctx context.Context, in *GetVersionFileRequest, opts ...grpc.CallOption) (*GetVersionFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionFileResponse)
+	err := c.cc.Invoke(ctx, TzService_GetVersionFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) ExportVersion(ctx context.Context, in *ExportVersionRequest, opts ...grpc.CallOption) (*ExportVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportVersionResponse)
+	err := c.cc.Invoke(ctx, TzService_ExportVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) ListCheckProfiles(ctx context.Context, in *ListCheckProfilesRequest, opts ...grpc.CallOption) (*ListCheckProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCheckProfilesResponse)
+	err := c.cc.Invoke(ctx, TzService_ListCheckProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) CreateCheckProfile(ctx context.Context, in *CreateCheckProfileRequest, opts ...grpc.CallOption) (*CheckProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckProfileResponse)
+	err := c.cc.Invoke(ctx, TzService_CreateCheckProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) UpdateCheckProfile(ctx context.Context, in *UpdateCheckProfileRequest, opts ...grpc.CallOption) (*CheckProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckProfileResponse)
+	err := c.cc.Invoke(ctx, TzService_UpdateCheckProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) DeleteCheckProfile(ctx context.Context, in *DeleteCheckProfileRequest, opts ...grpc.CallOption) (*DeleteCheckProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCheckProfileResponse)
+	err := c.cc.Invoke(ctx, TzService_DeleteCheckProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) SetDefaultCheckProfile(ctx context.Context, in *SetDefaultCheckProfileRequest, opts ...grpc.CallOption) (*CheckProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckProfileResponse)
+	err := c.cc.Invoke(ctx, TzService_SetDefaultCheckProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) ListErrorCatalog(ctx context.Context, in *ListErrorCatalogRequest, opts ...grpc.CallOption) (*ListErrorCatalogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListErrorCatalogResponse)
+	err := c.cc.Invoke(ctx, TzService_ListErrorCatalog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) GetErrorCatalogEntry(ctx context.Context, in *GetErrorCatalogEntryRequest, opts ...grpc.CallOption) (*GetErrorCatalogEntryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetErrorCatalogEntryResponse)
+	err := c.cc.Invoke(ctx, TzService_GetErrorCatalogEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) CreateShareLink(ctx context.Context, in *CreateShareLinkRequest, opts ...grpc.CallOption) (*CreateShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateShareLinkResponse)
+	err := c.cc.Invoke(ctx, TzService_CreateShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) ListShareLinks(ctx context.Context, in *ListShareLinksRequest, opts ...grpc.CallOption) (*ListShareLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShareLinksResponse)
+	err := c.cc.Invoke(ctx, TzService_ListShareLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) RevokeShareLink(ctx context.Context, in *RevokeShareLinkRequest, opts ...grpc.CallOption) (*RevokeShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeShareLinkResponse)
+	err := c.cc.Invoke(ctx, TzService_RevokeShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tzServiceClient) ResolveShareLink(ctx context.Context, in *ResolveShareLinkRequest, opts ...grpc.CallOption) (*ResolveShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveShareLinkResponse)
+	err := c.cc.Invoke(ctx, TzService_ResolveShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TzServiceServer is the server API for TzService service.
 // All implementations must embed UnimplementedTzServiceServer
 // for forward compatibility.
 type TzServiceServer interface {
 	CheckTz(context.Context, *CheckTzRequest) (*CheckTzResponse, error)
+	CheckTzVersion(context.Context, *CheckTzVersionRequest) (*CheckTzVersionResponse, error)
 	GetVersionsMe(context.Context, *GetVersionsMeRequest) (*GetVersionsMeResponse, error)
 	GetAllVersionsAdminDashboard(context.Context, *GetAllVersionsAdminDashboardRequest) (*GetAllVersionsAdminDashboardResponse, error)
 	GetVersionStatistics(context.Context, *GetVersionStatisticsRequest) (*GetVersionStatisticsResponse, error)
@@ -156,6 +418,26 @@ type TzServiceServer interface {
 	GetVersionsDateRange(context.Context, *GetVersionsDateRangeRequest) (*GetVersionsDateRangeResponse, error)
 	GetDailyAnalytics(context.Context, *GetDailyAnalyticsRequest) (*GetDailyAnalyticsResponse, error)
 	GetFeedbacks(context.Context, *GetFeedbacksRequest) (*GetFeedbacksResponse, error)
+	CompareVersions(context.Context, *CompareVersionsRequest) (*CompareVersionsResponse, error)
+	RetryVersion(context.Context, *RetryVersionRequest) (*RetryVersionResponse, error)
+	CancelVersion(context.Context, *CancelVersionRequest) (*CancelVersionResponse, error)
+	WatchVersion(*WatchVersionRequest, grpc.ServerStreamingServer[VersionEvent]) error
+	InvalidateLlmCache(context.Context, *InvalidateLlmCacheRequest) (*InvalidateLlmCacheResponse, error)
+	GetVersionCostBreakdown(context.Context, *GetVersionCostBreakdownRequest) (*GetVersionCostBreakdownResponse, error)
+	ExportAnnotatedDocx(context.Context, *ExportAnnotatedDocxRequest) (*ExportAnnotatedDocxResponse, error)
+	GetVersionFile(context.Context, *GetVersionFileRequest) (*GetVersionFileResponse, error)
+	ExportVersion(context.Context, *ExportVersionRequest) (*ExportVersionResponse, error)
+	ListCheckProfiles(context.Context, *ListCheckProfilesRequest) (*ListCheckProfilesResponse, error)
+	CreateCheckProfile(context.Context, *CreateCheckProfileRequest) (*CheckProfileResponse, error)
+	UpdateCheckProfile(context.Context, *UpdateCheckProfileRequest) (*CheckProfileResponse, error)
+	DeleteCheckProfile(context.Context, *DeleteCheckProfileRequest) (*DeleteCheckProfileResponse, error)
+	SetDefaultCheckProfile(context.Context, *SetDefaultCheckProfileRequest) (*CheckProfileResponse, error)
+	ListErrorCatalog(context.Context, *ListErrorCatalogRequest) (*ListErrorCatalogResponse, error)
+	GetErrorCatalogEntry(context.Context, *GetErrorCatalogEntryRequest) (*GetErrorCatalogEntryResponse, error)
+	CreateShareLink(context.Context, *CreateShareLinkRequest) (*CreateShareLinkResponse, error)
+	ListShareLinks(context.Context, *ListShareLinksRequest) (*ListShareLinksResponse, error)
+	RevokeShareLink(context.Context, *RevokeShareLinkRequest) (*RevokeShareLinkResponse, error)
+	ResolveShareLink(context.Context, *ResolveShareLinkRequest) (*ResolveShareLinkResponse, error)
 	mustEmbedUnimplementedTzServiceServer()
 }
 
@@ -169,6 +451,9 @@ type UnimplementedTzServiceServer struct{}
 func (UnimplementedTzServiceServer) CheckTz(context.Context, *CheckTzRequest) (*CheckTzResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckTz not implemented")
 }
+func (UnimplementedTzServiceServer) CheckTzVersion(context.Context, *CheckTzVersionRequest) (*CheckTzVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckTzVersion not implemented")
+}
 func (UnimplementedTzServiceServer) GetVersionsMe(context.Context, *GetVersionsMeRequest) (*GetVersionsMeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVersionsMe not implemented")
 }
@@ -193,6 +478,66 @@ func (UnimplementedTzServiceServer) GetDailyAnalytics(context.Context, *GetDaily
 func (UnimplementedTzServiceServer) GetFeedbacks(context.Context, *GetFeedbacksRequest) (*GetFeedbacksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFeedbacks not implemented")
 }
+func (UnimplementedTzServiceServer) CompareVersions(context.Context, *CompareVersionsRequest) (*CompareVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareVersions not implemented")
+}
+func (UnimplementedTzServiceServer) RetryVersion(context.Context, *RetryVersionRequest) (*RetryVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryVersion not implemented")
+}
+func (UnimplementedTzServiceServer) CancelVersion(context.Context, *CancelVersionRequest) (*CancelVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelVersion not implemented")
+}
+func (UnimplementedTzServiceServer) WatchVersion(*WatchVersionRequest, grpc.ServerStreamingServer[VersionEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchVersion not implemented")
+}
+func (UnimplementedTzServiceServer) InvalidateLlmCache(context.Context, *InvalidateLlmCacheRequest) (*InvalidateLlmCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvalidateLlmCache not implemented")
+}
+func (UnimplementedTzServiceServer) GetVersionCostBreakdown(context.Context, *GetVersionCostBreakdownRequest) (*GetVersionCostBreakdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersionCostBreakdown not implemented")
+}
+func (UnimplementedTzServiceServer) ExportAnnotatedDocx(context.Context, *ExportAnnotatedDocxRequest) (*ExportAnnotatedDocxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAnnotatedDocx not implemented")
+}
+func (UnimplementedTzServiceServer) GetVersionFile(context.Context, *GetVersionFileRequest) (*GetVersionFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersionFile not implemented")
+}
+func (UnimplementedTzServiceServer) ExportVersion(context.Context, *ExportVersionRequest) (*ExportVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportVersion not implemented")
+}
+func (UnimplementedTzServiceServer) ListCheckProfiles(context.Context, *ListCheckProfilesRequest) (*ListCheckProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCheckProfiles not implemented")
+}
+func (UnimplementedTzServiceServer) CreateCheckProfile(context.Context, *CreateCheckProfileRequest) (*CheckProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCheckProfile not implemented")
+}
+func (UnimplementedTzServiceServer) UpdateCheckProfile(context.Context, *UpdateCheckProfileRequest) (*CheckProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCheckProfile not implemented")
+}
+func (UnimplementedTzServiceServer) DeleteCheckProfile(context.Context, *DeleteCheckProfileRequest) (*DeleteCheckProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCheckProfile not implemented")
+}
+func (UnimplementedTzServiceServer) SetDefaultCheckProfile(context.Context, *SetDefaultCheckProfileRequest) (*CheckProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDefaultCheckProfile not implemented")
+}
+func (UnimplementedTzServiceServer) ListErrorCatalog(context.Context, *ListErrorCatalogRequest) (*ListErrorCatalogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListErrorCatalog not implemented")
+}
+func (UnimplementedTzServiceServer) GetErrorCatalogEntry(context.Context, *GetErrorCatalogEntryRequest) (*GetErrorCatalogEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetErrorCatalogEntry not implemented")
+}
+func (UnimplementedTzServiceServer) CreateShareLink(context.Context, *CreateShareLinkRequest) (*CreateShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShareLink not implemented")
+}
+func (UnimplementedTzServiceServer) ListShareLinks(context.Context, *ListShareLinksRequest) (*ListShareLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShareLinks not implemented")
+}
+func (UnimplementedTzServiceServer) RevokeShareLink(context.Context, *RevokeShareLinkRequest) (*RevokeShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShareLink not implemented")
+}
+func (UnimplementedTzServiceServer) ResolveShareLink(context.Context, *ResolveShareLinkRequest) (*ResolveShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveShareLink not implemented")
+}
 func (UnimplementedTzServiceServer) mustEmbedUnimplementedTzServiceServer() {}
 func (UnimplementedTzServiceServer) testEmbeddedByValue()                   {}
 
@@ -232,6 +577,24 @@ func _TzService_CheckTz_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TzService_CheckTzVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckTzVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).CheckTzVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_CheckTzVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).CheckTzVersion(ctx, req.(*CheckTzVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TzService_GetVersionsMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetVersionsMeRequest)
 	if err := dec(in); err != nil {
@@ -376,6 +739,359 @@ func _TzService_GetFeedbacks_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TzService_CompareVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).CompareVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_CompareVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).CompareVersions(ctx, req.(*CompareVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_RetryVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).RetryVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_RetryVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).RetryVersion(ctx, req.(*RetryVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_CancelVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).CancelVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_CancelVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).CancelVersion(ctx, req.(*CancelVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_WatchVersion_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchVersionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TzServiceServer).WatchVersion(m, &grpc.GenericServerStream[WatchVersionRequest, VersionEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TzService_WatchVersionServer = grpc.ServerStreamingServer[VersionEvent]
+
+func _TzService_InvalidateLlmCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateLlmCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).InvalidateLlmCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_InvalidateLlmCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).InvalidateLlmCache(ctx, req.(*InvalidateLlmCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_GetVersionCostBreakdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionCostBreakdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).GetVersionCostBreakdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_GetVersionCostBreakdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).GetVersionCostBreakdown(ctx, req.(*GetVersionCostBreakdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_ExportAnnotatedDocx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportAnnotatedDocxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).ExportAnnotatedDocx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_ExportAnnotatedDocx_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).ExportAnnotatedDocx(ctx, req.(*ExportAnnotatedDocxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_GetVersionFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).GetVersionFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_GetVersionFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).GetVersionFile(ctx, req.(*GetVersionFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_ExportVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).ExportVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_ExportVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).ExportVersion(ctx, req.(*ExportVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_ListCheckProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCheckProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).ListCheckProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_ListCheckProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).ListCheckProfiles(ctx, req.(*ListCheckProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_CreateCheckProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCheckProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).CreateCheckProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_CreateCheckProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).CreateCheckProfile(ctx, req.(*CreateCheckProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_UpdateCheckProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCheckProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).UpdateCheckProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_UpdateCheckProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).UpdateCheckProfile(ctx, req.(*UpdateCheckProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_DeleteCheckProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCheckProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).DeleteCheckProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_DeleteCheckProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).DeleteCheckProfile(ctx, req.(*DeleteCheckProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_SetDefaultCheckProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDefaultCheckProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).SetDefaultCheckProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_SetDefaultCheckProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).SetDefaultCheckProfile(ctx, req.(*SetDefaultCheckProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_ListErrorCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListErrorCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).ListErrorCatalog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_ListErrorCatalog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).ListErrorCatalog(ctx, req.(*ListErrorCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_GetErrorCatalogEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetErrorCatalogEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).GetErrorCatalogEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_GetErrorCatalogEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).GetErrorCatalogEntry(ctx, req.(*GetErrorCatalogEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_CreateShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).CreateShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_CreateShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).CreateShareLink(ctx, req.(*CreateShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_ListShareLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShareLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).ListShareLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_ListShareLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).ListShareLinks(ctx, req.(*ListShareLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_RevokeShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).RevokeShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_RevokeShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).RevokeShareLink(ctx, req.(*RevokeShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TzService_ResolveShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TzServiceServer).ResolveShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TzService_ResolveShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TzServiceServer).ResolveShareLink(ctx, req.(*ResolveShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TzService_ServiceDesc is the grpc.ServiceDesc for TzService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -387,6 +1103,10 @@ var TzService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckTz",
 			Handler:    _TzService_CheckTz_Handler,
 		},
+		{
+			MethodName: "CheckTzVersion",
+			Handler:    _TzService_CheckTzVersion_Handler,
+		},
 		{
 			MethodName: "GetVersionsMe",
 			Handler:    _TzService_GetVersionsMe_Handler,
@@ -419,7 +1139,89 @@ var TzService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFeedbacks",
 			Handler:    _TzService_GetFeedbacks_Handler,
 		},
+		{
+			MethodName: "CompareVersions",
+			Handler:    _TzService_CompareVersions_Handler,
+		},
+		{
+			MethodName: "RetryVersion",
+			Handler:    _TzService_RetryVersion_Handler,
+		},
+		{
+			MethodName: "CancelVersion",
+			Handler:    _TzService_CancelVersion_Handler,
+		},
+		{
+			MethodName: "InvalidateLlmCache",
+			Handler:    _TzService_InvalidateLlmCache_Handler,
+		},
+		{
+			MethodName: "GetVersionCostBreakdown",
+			Handler:    _TzService_GetVersionCostBreakdown_Handler,
+		},
+		{
+			MethodName: "ExportAnnotatedDocx",
+			Handler:    _TzService_ExportAnnotatedDocx_Handler,
+		},
+		{
+			MethodName: "GetVersionFile",
+			Handler:    _TzService_GetVersionFile_Handler,
+		},
+		{
+			MethodName: "ExportVersion",
+			Handler:    _TzService_ExportVersion_Handler,
+		},
+		{
+			MethodName: "ListCheckProfiles",
+			Handler:    _TzService_ListCheckProfiles_Handler,
+		},
+		{
+			MethodName: "CreateCheckProfile",
+			Handler:    _TzService_CreateCheckProfile_Handler,
+		},
+		{
+			MethodName: "UpdateCheckProfile",
+			Handler:    _TzService_UpdateCheckProfile_Handler,
+		},
+		{
+			MethodName: "DeleteCheckProfile",
+			Handler:    _TzService_DeleteCheckProfile_Handler,
+		},
+		{
+			MethodName: "SetDefaultCheckProfile",
+			Handler:    _TzService_SetDefaultCheckProfile_Handler,
+		},
+		{
+			MethodName: "ListErrorCatalog",
+			Handler:    _TzService_ListErrorCatalog_Handler,
+		},
+		{
+			MethodName: "GetErrorCatalogEntry",
+			Handler:    _TzService_GetErrorCatalogEntry_Handler,
+		},
+		{
+			MethodName: "CreateShareLink",
+			Handler:    _TzService_CreateShareLink_Handler,
+		},
+		{
+			MethodName: "ListShareLinks",
+			Handler:    _TzService_ListShareLinks_Handler,
+		},
+		{
+			MethodName: "RevokeShareLink",
+			Handler:    _TzService_RevokeShareLink_Handler,
+		},
+		{
+			MethodName: "ResolveShareLink",
+			Handler:    _TzService_ResolveShareLink_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchVersion",
+			Handler:       _TzService_WatchVersion_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "tz/v1/tz.proto",
 }
@@ -470,3 +470,110 @@ func (c *UserClient) Recovery(ctx context.Context, email string) (*pb.RecoveryRe
 
 	return resp, nil
 }
+
+// OrganizationError ошибка операций с организацией, Code позволяет вызывающей стороне выбрать HTTP статус
+type OrganizationError struct {
+	Code    codes.Code
+	Message string
+}
+
+func (e OrganizationError) Error() string {
+	return e.Message
+}
+
+func organizationError(err error, msg string) error {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied, codes.FailedPrecondition:
+			return OrganizationError{Code: st.Code(), Message: st.Message()}
+		case codes.Internal:
+			return fmt.Errorf("internal server error")
+		default:
+			return fmt.Errorf("%s: %s", msg, st.Message())
+		}
+	}
+	return err
+}
+
+// CreateOrganization создаёт организацию с общей квотой проверок и назначает её администратора
+func (c *UserClient) CreateOrganization(ctx context.Context, name string, inspectionsPerDay, inspectionsPerMonth uint32, adminUserID string) (*pb.Organization, error) {
+	resp, err := c.client.CreateOrganization(ctx, &pb.CreateOrganizationRequest{
+		Name:                name,
+		InspectionsPerDay:   inspectionsPerDay,
+		InspectionsPerMonth: inspectionsPerMonth,
+		AdminUserId:         adminUserID,
+	})
+	if err != nil {
+		return nil, organizationError(err, "failed to create organization")
+	}
+
+	return resp.Organization, nil
+}
+
+// GetOrganization получает организацию с участниками
+func (c *UserClient) GetOrganization(ctx context.Context, organizationID string) (*pb.Organization, error) {
+	resp, err := c.client.GetOrganization(ctx, &pb.GetOrganizationRequest{
+		OrganizationId: organizationID,
+	})
+	if err != nil {
+		return nil, organizationError(err, "failed to get organization")
+	}
+
+	return resp.Organization, nil
+}
+
+// GetUserOrganization получает организацию пользователя, OrganizationError с codes.NotFound - пользователь не состоит в организации
+func (c *UserClient) GetUserOrganization(ctx context.Context, userID string) (*pb.Organization, error) {
+	resp, err := c.client.GetUserOrganization(ctx, &pb.GetUserOrganizationRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, organizationError(err, "failed to get user organization")
+	}
+
+	return resp.Organization, nil
+}
+
+// AddOrganizationMember добавляет пользователя в организацию или меняет его роль
+func (c *UserClient) AddOrganizationMember(ctx context.Context, organizationID, actorUserID, userID, role string) (*pb.Organization, error) {
+	resp, err := c.client.AddOrganizationMember(ctx, &pb.AddOrganizationMemberRequest{
+		OrganizationId: organizationID,
+		ActorUserId:    actorUserID,
+		UserId:         userID,
+		Role:           role,
+	})
+	if err != nil {
+		return nil, organizationError(err, "failed to add organization member")
+	}
+
+	return resp.Organization, nil
+}
+
+// RemoveOrganizationMember исключает пользователя из организации
+func (c *UserClient) RemoveOrganizationMember(ctx context.Context, organizationID, actorUserID, userID string) (*pb.Organization, error) {
+	resp, err := c.client.RemoveOrganizationMember(ctx, &pb.RemoveOrganizationMemberRequest{
+		OrganizationId: organizationID,
+		ActorUserId:    actorUserID,
+		UserId:         userID,
+	})
+	if err != nil {
+		return nil, organizationError(err, "failed to remove organization member")
+	}
+
+	return resp.Organization, nil
+}
+
+// UpdateOrganizationQuota изменяет дневную и месячную квоту организации
+func (c *UserClient) UpdateOrganizationQuota(ctx context.Context, organizationID, actorUserID string, inspectionsPerDay, inspectionsPerMonth uint32) (*pb.Organization, error) {
+	resp, err := c.client.UpdateOrganizationQuota(ctx, &pb.UpdateOrganizationQuotaRequest{
+		OrganizationId:      organizationID,
+		ActorUserId:         actorUserID,
+		InspectionsPerDay:   inspectionsPerDay,
+		InspectionsPerMonth: inspectionsPerMonth,
+	})
+	if err != nil {
+		return nil, organizationError(err, "failed to update organization quota")
+	}
+
+	return resp.Organization, nil
+}
@@ -7,6 +7,7 @@ import (
 	"repairCopilotBot/user-service/internal/cron"
 	"repairCopilotBot/user-service/internal/migrator"
 	"repairCopilotBot/user-service/internal/repository/postgres"
+	postgresOrganization "repairCopilotBot/user-service/internal/repository/postgres/organization"
 	postgresUser "repairCopilotBot/user-service/internal/repository/postgres/user"
 	userservice "repairCopilotBot/user-service/internal/service/user"
 
@@ -40,6 +41,11 @@ func New(
 		panic(err)
 	}
 
+	organizations, err := postgresOrganization.New(postgresConn)
+	if err != nil {
+		panic(err)
+	}
+
 	migratorRunner := migrator.NewMigrator(stdlib.OpenDB(*postgresConn.Config().ConnConfig.Copy()), postgresConfig.MigrationsDir)
 
 	err = migratorRunner.Up()
@@ -48,12 +54,12 @@ func New(
 		panic(fmt.Errorf("cannot run migrator - %w", err).Error())
 	}
 
-	usrService := userservice.New(log, postgres, postgres, mailToken, mailDomen)
+	usrService := userservice.New(log, postgres, postgres, organizations, mailToken, mailDomen)
 
 	grpcApp := grpcapp.NewUserGRPCServer(log, usrService, grpcConfig)
 
 	// Создаём планировщик крон-джоб
-	scheduler := cron.New(log, postgres)
+	scheduler := cron.New(log, postgres, organizations)
 
 	return &App{
 		GRPCServer: grpcApp,
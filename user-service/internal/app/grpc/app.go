@@ -8,6 +8,7 @@ import (
 	"net"
 	"time"
 
+	"repairCopilotBot/user-service/internal/domain/models"
 	service "repairCopilotBot/user-service/internal/service/user"
 	pb "repairCopilotBot/user-service/pkg/user/v1"
 
@@ -332,7 +333,7 @@ func (s *serverAPI) IncrementInspectionsForTodayByUserId(ctx context.Context, re
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
 
-	err := s.userService.IncrementInspectionsForToday(ctx, req.UserId)
+	organizationID, err := s.userService.IncrementInspectionsForToday(ctx, req.UserId)
 	if err != nil {
 		if errors.Is(err, service.ErrInspectionLimitExceeded) {
 			return nil, status.Error(codes.ResourceExhausted, "daily inspection limit exceeded")
@@ -341,7 +342,12 @@ func (s *serverAPI) IncrementInspectionsForTodayByUserId(ctx context.Context, re
 		return nil, status.Error(codes.Internal, "failed to increment inspections for today")
 	}
 
-	return &pb.IncrementInspectionsForTodayByUserIdResponse{}, nil
+	resp := &pb.IncrementInspectionsForTodayByUserIdResponse{}
+	if organizationID != "" {
+		resp.OrganizationId = &organizationID
+	}
+
+	return resp, nil
 }
 
 func (s *serverAPI) DecrementInspectionsForTodayByUserId(ctx context.Context, req *pb.DecrementInspectionsForTodayByUserIdRequest) (*pb.DecrementInspectionsForTodayByUserIdResponse, error) {
@@ -349,7 +355,13 @@ func (s *serverAPI) DecrementInspectionsForTodayByUserId(ctx context.Context, re
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
 
-	err := s.userService.DecrementInspectionsForToday(ctx, req.UserId)
+	if req.OrganizationId != nil {
+		if _, err := uuid.Parse(req.GetOrganizationId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid organization_id")
+		}
+	}
+
+	err := s.userService.DecrementInspectionsForToday(ctx, req.UserId, req.GetOrganizationId())
 	if err != nil {
 		s.log.Error("failed to decrement inspections for today", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "failed to decrement inspections for today")
@@ -363,7 +375,7 @@ func (s *serverAPI) CheckInspectionLimit(ctx context.Context, req *pb.CheckInspe
 		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
 
-	inspectionsLeft, err := s.userService.CheckInspectionLimit(ctx, req.UserId)
+	inspectionsLeft, organizationID, err := s.userService.CheckInspectionLimit(ctx, req.UserId)
 	if err != nil {
 		if errors.Is(err, service.ErrInspectionLimitExceeded) {
 			return nil, status.Error(codes.ResourceExhausted, "лимит исчерпан")
@@ -372,9 +384,14 @@ func (s *serverAPI) CheckInspectionLimit(ctx context.Context, req *pb.CheckInspe
 		return nil, status.Error(codes.Internal, "failed to check inspection limit")
 	}
 
-	return &pb.CheckInspectionLimitResponse{
+	resp := &pb.CheckInspectionLimitResponse{
 		InspectionsLeft: uint32(inspectionsLeft),
-	}, nil
+	}
+	if organizationID != "" {
+		resp.OrganizationId = &organizationID
+	}
+
+	return resp, nil
 }
 
 func (s *serverAPI) ChangeUserRole(ctx context.Context, req *pb.ChangeUserRoleRequest) (*pb.ChangeUserRoleResponse, error) {
@@ -404,7 +421,7 @@ func (s *serverAPI) Recovery(ctx context.Context, req *pb.RecoveryRequest) (*pb.
 		return nil, status.Error(codes.InvalidArgument, "email is required")
 	}
 
-	err := s.userService.Recovery(ctx, req.Email)
+	userID, err := s.userService.Recovery(ctx, req.Email)
 	if err != nil {
 		s.log.Error("failed to recover account", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, "failed to recover account")
@@ -413,9 +430,169 @@ func (s *serverAPI) Recovery(ctx context.Context, req *pb.RecoveryRequest) (*pb.
 	return &pb.RecoveryResponse{
 		Success: true,
 		Message: "Recovery email sent successfully. Please check your email for new credentials.",
+		UserId:  userID.String(),
 	}, nil
 }
 
+func (s *serverAPI) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.OrganizationResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	adminUserID, err := uuid.Parse(req.AdminUserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid admin_user_id")
+	}
+
+	org, err := s.userService.CreateOrganization(ctx, req.Name, int(req.InspectionsPerDay), int(req.InspectionsPerMonth), adminUserID)
+	if err != nil {
+		return nil, organizationStatus(err, "failed to create organization")
+	}
+
+	return &pb.OrganizationResponse{Organization: convertOrganization(org)}, nil
+}
+
+func (s *serverAPI) GetOrganization(ctx context.Context, req *pb.GetOrganizationRequest) (*pb.OrganizationResponse, error) {
+	orgID, err := uuid.Parse(req.OrganizationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization_id")
+	}
+
+	org, err := s.userService.GetOrganization(ctx, orgID)
+	if err != nil {
+		return nil, organizationStatus(err, "failed to get organization")
+	}
+
+	return &pb.OrganizationResponse{Organization: convertOrganization(org)}, nil
+}
+
+func (s *serverAPI) GetUserOrganization(ctx context.Context, req *pb.GetUserOrganizationRequest) (*pb.OrganizationResponse, error) {
+	if _, err := uuid.Parse(req.UserId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	org, err := s.userService.GetUserOrganization(ctx, req.UserId)
+	if err != nil {
+		return nil, organizationStatus(err, "failed to get user organization")
+	}
+
+	return &pb.OrganizationResponse{Organization: convertOrganization(org)}, nil
+}
+
+func (s *serverAPI) AddOrganizationMember(ctx context.Context, req *pb.AddOrganizationMemberRequest) (*pb.OrganizationResponse, error) {
+	orgID, actorUserID, userID, err := parseOrganizationMemberIDs(req.OrganizationId, req.ActorUserId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	role := req.Role
+	if role == "" {
+		role = models.OrganizationRoleMember
+	}
+	if role != models.OrganizationRoleAdmin && role != models.OrganizationRoleMember {
+		return nil, status.Error(codes.InvalidArgument, "role must be admin or member")
+	}
+
+	org, err := s.userService.AddOrganizationMember(ctx, orgID, actorUserID, userID, role)
+	if err != nil {
+		return nil, organizationStatus(err, "failed to add organization member")
+	}
+
+	return &pb.OrganizationResponse{Organization: convertOrganization(org)}, nil
+}
+
+func (s *serverAPI) RemoveOrganizationMember(ctx context.Context, req *pb.RemoveOrganizationMemberRequest) (*pb.OrganizationResponse, error) {
+	orgID, actorUserID, userID, err := parseOrganizationMemberIDs(req.OrganizationId, req.ActorUserId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.userService.RemoveOrganizationMember(ctx, orgID, actorUserID, userID)
+	if err != nil {
+		return nil, organizationStatus(err, "failed to remove organization member")
+	}
+
+	return &pb.OrganizationResponse{Organization: convertOrganization(org)}, nil
+}
+
+func (s *serverAPI) UpdateOrganizationQuota(ctx context.Context, req *pb.UpdateOrganizationQuotaRequest) (*pb.OrganizationResponse, error) {
+	orgID, err := uuid.Parse(req.OrganizationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization_id")
+	}
+	actorUserID, err := uuid.Parse(req.ActorUserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid actor_user_id")
+	}
+
+	org, err := s.userService.UpdateOrganizationQuota(ctx, orgID, actorUserID, int(req.InspectionsPerDay), int(req.InspectionsPerMonth))
+	if err != nil {
+		return nil, organizationStatus(err, "failed to update organization quota")
+	}
+
+	return &pb.OrganizationResponse{Organization: convertOrganization(org)}, nil
+}
+
+func parseOrganizationMemberIDs(organizationID, actorUserID, userID string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, status.Error(codes.InvalidArgument, "invalid organization_id")
+	}
+	actorID, err := uuid.Parse(actorUserID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, status.Error(codes.InvalidArgument, "invalid actor_user_id")
+	}
+	memberID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	return orgID, actorID, memberID, nil
+}
+
+// organizationStatus переводит ошибки сервиса организаций в gRPC статусы
+func organizationStatus(err error, msg string) error {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound):
+		return status.Error(codes.NotFound, "organization not found")
+	case errors.Is(err, service.ErrNotOrganizationMember):
+		return status.Error(codes.NotFound, "user is not a member of the organization")
+	case errors.Is(err, service.ErrOrganizationUserNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, service.ErrOrganizationMemberExists):
+		return status.Error(codes.AlreadyExists, "user already belongs to another organization")
+	case errors.Is(err, service.ErrOrganizationForbidden):
+		return status.Error(codes.PermissionDenied, "organization admin rights required")
+	case errors.Is(err, service.ErrLastOrganizationAdmin):
+		return status.Error(codes.FailedPrecondition, "organization must have at least one admin")
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+func convertOrganization(org *models.Organization) *pb.Organization {
+	members := make([]*pb.OrganizationMember, 0, len(org.Members))
+	for _, m := range org.Members {
+		members = append(members, &pb.OrganizationMember{
+			UserId:    m.UserID.String(),
+			Role:      m.Role,
+			FirstName: m.FirstName,
+			LastName:  m.LastName,
+			JoinedAt:  timestamppb.New(m.JoinedAt),
+		})
+	}
+
+	return &pb.Organization{
+		Id:                  org.ID.String(),
+		Name:                org.Name,
+		InspectionsPerDay:   uint32(org.InspectionsPerDay),
+		InspectionsPerMonth: uint32(org.InspectionsPerMonth),
+		InspectionsForToday: uint32(org.InspectionsForToday),
+		InspectionsForMonth: uint32(org.InspectionsForMonth),
+		CreatedAt:           timestamppb.New(org.CreatedAt),
+		Members:             members,
+	}
+}
+
 //func (s *serverAPI) mustEmbedUnimplementedUserServiceServer() {
 //	s.log.Error("GetLoginById not implemented")
 //}
@@ -13,15 +13,22 @@ type InspectionResetter interface {
 	ResetDailyInspectionsForAllUsers(ctx context.Context) error
 }
 
+// OrganizationQuotaResetter интерфейс для сброса счётчиков квот организаций
+type OrganizationQuotaResetter interface {
+	ResetDailyInspectionsForAllOrganizations(ctx context.Context) error
+	ResetMonthlyInspectionsForAllOrganizations(ctx context.Context) error
+}
+
 // Scheduler управляет крон-джобами
 type Scheduler struct {
 	cron   *cron.Cron
 	log    *slog.Logger
 	resetter InspectionResetter
+	orgResetter OrganizationQuotaResetter
 }
 
 // New создаёт новый планировщик
-func New(log *slog.Logger, resetter InspectionResetter) *Scheduler {
+func New(log *slog.Logger, resetter InspectionResetter, orgResetter OrganizationQuotaResetter) *Scheduler {
 	// Создаём cron с московским часовым поясом
 	moscowLocation, err := time.LoadLocation("Europe/Moscow")
 	if err != nil {
@@ -35,6 +42,7 @@ func New(log *slog.Logger, resetter InspectionResetter) *Scheduler {
 		cron:   c,
 		log:    log,
 		resetter: resetter,
+		orgResetter: orgResetter,
 	}
 }
 
@@ -47,6 +55,11 @@ func (s *Scheduler) Start() error {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
+		// Квоты организаций сбрасываются независимо от результата сброса личных счётчиков
+		if err := s.orgResetter.ResetDailyInspectionsForAllOrganizations(ctx); err != nil {
+			s.log.Error("failed to reset organizations daily inspections", "error", err)
+		}
+
 		if err := s.resetter.ResetDailyInspectionsForAllUsers(ctx); err != nil {
 			s.log.Error("failed to reset daily inspections", "error", err)
 			return
@@ -59,6 +72,25 @@ func (s *Scheduler) Start() error {
 		return err
 	}
 
+	// Месячные квоты организаций сбрасываются первого числа в полночь по МСК
+	_, err = s.cron.AddFunc("0 0 1 * *", func() {
+		s.log.Info("Starting monthly organizations inspections reset job")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := s.orgResetter.ResetMonthlyInspectionsForAllOrganizations(ctx); err != nil {
+			s.log.Error("failed to reset organizations monthly inspections", "error", err)
+			return
+		}
+
+		s.log.Info("Monthly organizations inspections reset completed successfully")
+	})
+
+	if err != nil {
+		return err
+	}
+
 	s.cron.Start()
 	s.log.Info("Cron scheduler started", "timezone", "Europe/Moscow")
 
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OrganizationRoleAdmin  = "admin"
+	OrganizationRoleMember = "member"
+)
+
+// OrganizationInspectionsUnlimited - остаток проверок организации без дневного и месячного ограничения
+const OrganizationInspectionsUnlimited = math.MaxInt32
+
+// Organization организация с общей дневной и месячной квотой проверок.
+// InspectionsPerDay или InspectionsPerMonth = 0 означает отсутствие соответствующего ограничения
+type Organization struct {
+	ID                  uuid.UUID
+	Name                string
+	InspectionsPerDay   int
+	InspectionsPerMonth int
+	InspectionsForToday int
+	InspectionsForMonth int
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	Members             []OrganizationMember
+}
+
+type OrganizationMember struct {
+	UserID    uuid.UUID
+	Role      string
+	FirstName string
+	LastName  string
+	JoinedAt  time.Time
+}
@@ -0,0 +1,298 @@
+package postgresOrganization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"repairCopilotBot/user-service/internal/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	repo "repairCopilotBot/user-service/internal/repository"
+)
+
+type Storage struct {
+	db *pgxpool.Pool
+}
+
+func New(pool *pgxpool.Pool) (*Storage, error) {
+	return &Storage{db: pool}, nil
+}
+
+// CreateOrganization создаёт организацию и добавляет в неё первого администратора в одной транзакции
+func (s *Storage) CreateOrganization(ctx context.Context, org *models.Organization, adminUserID uuid.UUID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO organizations(id, name, inspections_per_day, inspections_per_month, inspections_for_today, inspections_for_month, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, 0, 0, $5, $5)`,
+		org.ID, org.Name, org.InspectionsPerDay, org.InspectionsPerMonth, org.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	err = addMember(ctx, tx, org.ID, adminUserID, models.OrganizationRoleAdmin, org.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Organization возвращает организацию вместе с участниками
+func (s *Storage) Organization(ctx context.Context, orgID uuid.UUID) (*models.Organization, error) {
+	query := `SELECT id, name, inspections_per_day, inspections_per_month, inspections_for_today, inspections_for_month, created_at, updated_at
+	          FROM organizations WHERE id = $1`
+
+	var org models.Organization
+	err := s.db.QueryRow(ctx, query, orgID).Scan(&org.ID, &org.Name, &org.InspectionsPerDay, &org.InspectionsPerMonth, &org.InspectionsForToday, &org.InspectionsForMonth, &org.CreatedAt, &org.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repo.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	rows, err := s.db.Query(
+		ctx,
+		`SELECT m.user_id, m.role, u.first_name, u.last_name, m.joined_at
+		 FROM organization_members m
+		 JOIN users u ON u.id = m.user_id
+		 WHERE m.organization_id = $1
+		 ORDER BY m.joined_at`,
+		orgID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member models.OrganizationMember
+		err := rows.Scan(&member.UserID, &member.Role, &member.FirstName, &member.LastName, &member.JoinedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		org.Members = append(org.Members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return &org, nil
+}
+
+// OrganizationIDByUserID возвращает ID организации, в которой состоит пользователь
+func (s *Storage) OrganizationIDByUserID(ctx context.Context, userID string) (uuid.UUID, error) {
+	query := `SELECT organization_id FROM organization_members WHERE user_id = $1`
+
+	var orgID uuid.UUID
+	err := s.db.QueryRow(ctx, query, userID).Scan(&orgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, repo.ErrOrganizationMemberNotFound
+		}
+		return uuid.Nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return orgID, nil
+}
+
+// MemberRole возвращает роль пользователя в организации
+func (s *Storage) MemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (string, error) {
+	query := `SELECT role FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+
+	var role string
+	err := s.db.QueryRow(ctx, query, orgID, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", repo.ErrOrganizationMemberNotFound
+		}
+		return "", fmt.Errorf("database error: %w", err)
+	}
+
+	return role, nil
+}
+
+// CountAdmins возвращает количество администраторов организации
+func (s *Storage) CountAdmins(ctx context.Context, orgID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM organization_members WHERE organization_id = $1 AND role = $2`
+
+	var count int
+	err := s.db.QueryRow(ctx, query, orgID, models.OrganizationRoleAdmin).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+
+	return count, nil
+}
+
+// AddMember добавляет пользователя в организацию, для существующего участника меняет роль
+func (s *Storage) AddMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role string) error {
+	return addMember(ctx, s.db, orgID, userID, role, time.Now())
+}
+
+// RemoveMember исключает пользователя из организации
+func (s *Storage) RemoveMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) error {
+	query := `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+
+	result, err := s.db.Exec(ctx, query, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrOrganizationMemberNotFound
+	}
+
+	return nil
+}
+
+// UpdateQuota изменяет дневную и месячную квоту организации
+func (s *Storage) UpdateQuota(ctx context.Context, orgID uuid.UUID, inspectionsPerDay int, inspectionsPerMonth int) error {
+	query := `UPDATE organizations SET inspections_per_day = $1, inspections_per_month = $2, updated_at = NOW() WHERE id = $3`
+
+	result, err := s.db.Exec(ctx, query, inspectionsPerDay, inspectionsPerMonth, orgID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+// ChargeInspection атомарно списывает одну проверку из дневной и месячной квоты организации
+func (s *Storage) ChargeInspection(ctx context.Context, orgID uuid.UUID) error {
+	query := `UPDATE organizations
+	          SET inspections_for_today = inspections_for_today + 1,
+	              inspections_for_month = inspections_for_month + 1
+	          WHERE id = $1
+	            AND (inspections_per_day = 0 OR inspections_for_today < inspections_per_day)
+	            AND (inspections_per_month = 0 OR inspections_for_month < inspections_per_month)`
+
+	result, err := s.db.Exec(ctx, query, orgID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		if _, err := s.InspectionsLeft(ctx, orgID); err != nil {
+			return err
+		}
+		return repo.ErrOrganizationQuotaExceeded
+	}
+
+	return nil
+}
+
+// RefundInspection возвращает одну проверку в квоту организации
+func (s *Storage) RefundInspection(ctx context.Context, orgID uuid.UUID) error {
+	query := `UPDATE organizations
+	          SET inspections_for_today = GREATEST(inspections_for_today - 1, 0),
+	              inspections_for_month = GREATEST(inspections_for_month - 1, 0)
+	          WHERE id = $1`
+
+	result, err := s.db.Exec(ctx, query, orgID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+// InspectionsLeft возвращает количество проверок, доступных организации сегодня с учётом месячной квоты.
+// Если ни дневного, ни месячного ограничения нет, возвращает models.OrganizationInspectionsUnlimited
+func (s *Storage) InspectionsLeft(ctx context.Context, orgID uuid.UUID) (int, error) {
+	query := `SELECT
+	              CASE WHEN inspections_per_day = 0 THEN NULL ELSE GREATEST(inspections_per_day - inspections_for_today, 0) END,
+	              CASE WHEN inspections_per_month = 0 THEN NULL ELSE GREATEST(inspections_per_month - inspections_for_month, 0) END
+	          FROM organizations WHERE id = $1`
+
+	var dayLeft, monthLeft *int
+	err := s.db.QueryRow(ctx, query, orgID).Scan(&dayLeft, &monthLeft)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, repo.ErrOrganizationNotFound
+		}
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+
+	left := models.OrganizationInspectionsUnlimited
+	if dayLeft != nil {
+		left = *dayLeft
+	}
+	if monthLeft != nil && *monthLeft < left {
+		left = *monthLeft
+	}
+
+	return left, nil
+}
+
+// ResetDailyInspectionsForAllOrganizations сбрасывает дневные счётчики проверок всех организаций
+func (s *Storage) ResetDailyInspectionsForAllOrganizations(ctx context.Context) error {
+	_, err := s.db.Exec(ctx, `UPDATE organizations SET inspections_for_today = 0`)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	return nil
+}
+
+// ResetMonthlyInspectionsForAllOrganizations сбрасывает месячные счётчики проверок всех организаций
+func (s *Storage) ResetMonthlyInspectionsForAllOrganizations(ctx context.Context) error {
+	_, err := s.db.Exec(ctx, `UPDATE organizations SET inspections_for_month = 0`)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	return nil
+}
+
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+func addMember(ctx context.Context, db execer, orgID uuid.UUID, userID uuid.UUID, role string, joinedAt time.Time) error {
+	// Пользователь может состоять только в одной организации: при конфликте роль меняется,
+	// только если он уже участник этой же организации
+	query := `INSERT INTO organization_members(organization_id, user_id, role, joined_at)
+	          VALUES ($1, $2, $3, $4)
+	          ON CONFLICT (user_id) DO UPDATE SET role = EXCLUDED.role
+	          WHERE organization_members.organization_id = EXCLUDED.organization_id`
+
+	result, err := db.Exec(ctx, query, orgID, userID, role, joinedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			if pgErr.ConstraintName == "organization_members_user_id_fkey" {
+				return repo.ErrUserNotFound
+			}
+			return repo.ErrOrganizationNotFound
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repo.ErrOrganizationMemberExists
+	}
+
+	return nil
+}
@@ -6,3 +6,10 @@ var (
 	ErrUserExists   = errors.New("user already exists")
 	ErrUserNotFound = errors.New("user not found")
 )
+
+var (
+	ErrOrganizationNotFound       = errors.New("organization not found")
+	ErrOrganizationMemberNotFound = errors.New("organization member not found")
+	ErrOrganizationMemberExists   = errors.New("user already belongs to another organization")
+	ErrOrganizationQuotaExceeded  = errors.New("organization inspection quota exceeded")
+)
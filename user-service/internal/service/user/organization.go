@@ -0,0 +1,317 @@
+package userservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"repairCopilotBot/user-service/internal/domain/models"
+	"repairCopilotBot/user-service/internal/pkg/logger/sl"
+	"repairCopilotBot/user-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOrganizationNotFound     = errors.New("organization not found")
+	ErrNotOrganizationMember    = errors.New("user is not a member of the organization")
+	ErrOrganizationMemberExists = errors.New("user already belongs to another organization")
+	ErrOrganizationForbidden    = errors.New("organization admin rights required")
+	ErrLastOrganizationAdmin    = errors.New("organization must have at least one admin")
+	ErrOrganizationUserNotFound = errors.New("user not found")
+)
+
+type OrganizationProvider interface {
+	CreateOrganization(ctx context.Context, org *models.Organization, adminUserID uuid.UUID) error
+	Organization(ctx context.Context, orgID uuid.UUID) (*models.Organization, error)
+	OrganizationIDByUserID(ctx context.Context, userID string) (uuid.UUID, error)
+	MemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (string, error)
+	CountAdmins(ctx context.Context, orgID uuid.UUID) (int, error)
+	AddMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role string) error
+	RemoveMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) error
+	UpdateQuota(ctx context.Context, orgID uuid.UUID, inspectionsPerDay int, inspectionsPerMonth int) error
+	ChargeInspection(ctx context.Context, orgID uuid.UUID) error
+	RefundInspection(ctx context.Context, orgID uuid.UUID) error
+	InspectionsLeft(ctx context.Context, orgID uuid.UUID) (int, error)
+}
+
+// CreateOrganization создаёт организацию с общей квотой и назначает adminUserID её администратором
+func (u *User) CreateOrganization(ctx context.Context, name string, inspectionsPerDay int, inspectionsPerMonth int, adminUserID uuid.UUID) (*models.Organization, error) {
+	const op = "User.CreateOrganization"
+
+	log := u.log.With(
+		slog.String("op", op),
+		slog.String("name", name),
+		slog.String("adminUserID", adminUserID.String()),
+	)
+
+	log.Info("creating organization")
+
+	now := time.Now()
+	org := &models.Organization{
+		ID:                  uuid.New(),
+		Name:                name,
+		InspectionsPerDay:   inspectionsPerDay,
+		InspectionsPerMonth: inspectionsPerMonth,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+
+	err := u.orgProvider.CreateOrganization(ctx, org, adminUserID)
+	if err != nil {
+		log.Error("failed to create organization", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, organizationError(err))
+	}
+
+	log.Info("organization created", slog.String("organizationID", org.ID.String()))
+
+	return u.GetOrganization(ctx, org.ID)
+}
+
+// GetOrganization возвращает организацию с участниками
+func (u *User) GetOrganization(ctx context.Context, orgID uuid.UUID) (*models.Organization, error) {
+	const op = "User.GetOrganization"
+
+	org, err := u.orgProvider.Organization(ctx, orgID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrOrganizationNotFound) {
+			u.log.Error("failed to get organization", slog.String("op", op), sl.Err(err))
+		}
+		return nil, fmt.Errorf("%s: %w", op, organizationError(err))
+	}
+
+	return org, nil
+}
+
+// GetUserOrganization возвращает организацию, в которой состоит пользователь
+func (u *User) GetUserOrganization(ctx context.Context, userID string) (*models.Organization, error) {
+	const op = "User.GetUserOrganization"
+
+	orgID, err := u.orgProvider.OrganizationIDByUserID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrOrganizationMemberNotFound) {
+			u.log.Error("failed to get user organization", slog.String("op", op), sl.Err(err))
+		}
+		return nil, fmt.Errorf("%s: %w", op, organizationError(err))
+	}
+
+	return u.GetOrganization(ctx, orgID)
+}
+
+// AddOrganizationMember добавляет пользователя в организацию или меняет роль существующего участника.
+// Нового участника добавляет только администратор системы: иначе администратор организации мог бы
+// без согласия пользователя перевести его проверки на квоту организации и открыть коллегам его версии
+func (u *User) AddOrganizationMember(ctx context.Context, orgID uuid.UUID, actorUserID uuid.UUID, userID uuid.UUID, role string) (*models.Organization, error) {
+	const op = "User.AddOrganizationMember"
+
+	log := u.log.With(
+		slog.String("op", op),
+		slog.String("organizationID", orgID.String()),
+		slog.String("actorUserID", actorUserID.String()),
+		slog.String("userID", userID.String()),
+		slog.String("role", role),
+	)
+
+	log.Info("adding organization member")
+
+	member, err := u.isOrganizationMember(ctx, orgID, userID)
+	if err != nil {
+		log.Error("failed to get organization member", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, organizationError(err))
+	}
+
+	if member {
+		err = u.authorizeOrganizationAdmin(ctx, orgID, actorUserID)
+	} else {
+		err = u.authorizeSystemAdmin(ctx, actorUserID)
+	}
+	if err != nil {
+		log.Warn("organization member change denied", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if role == models.OrganizationRoleMember {
+		if err := u.ensureNotLastAdmin(ctx, orgID, userID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = u.orgProvider.AddMember(ctx, orgID, userID, role)
+	if err != nil {
+		log.Error("failed to add organization member", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, organizationError(err))
+	}
+
+	log.Info("organization member added")
+
+	return u.GetOrganization(ctx, orgID)
+}
+
+// RemoveOrganizationMember исключает пользователя из организации. Участник может выйти сам,
+// исключать других может только администратор
+func (u *User) RemoveOrganizationMember(ctx context.Context, orgID uuid.UUID, actorUserID uuid.UUID, userID uuid.UUID) (*models.Organization, error) {
+	const op = "User.RemoveOrganizationMember"
+
+	log := u.log.With(
+		slog.String("op", op),
+		slog.String("organizationID", orgID.String()),
+		slog.String("actorUserID", actorUserID.String()),
+		slog.String("userID", userID.String()),
+	)
+
+	log.Info("removing organization member")
+
+	if actorUserID != userID {
+		if err := u.authorizeOrganizationAdmin(ctx, orgID, actorUserID); err != nil {
+			log.Warn("organization member removal denied", sl.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := u.ensureNotLastAdmin(ctx, orgID, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err := u.orgProvider.RemoveMember(ctx, orgID, userID)
+	if err != nil {
+		log.Error("failed to remove organization member", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, organizationError(err))
+	}
+
+	log.Info("organization member removed")
+
+	return u.GetOrganization(ctx, orgID)
+}
+
+// UpdateOrganizationQuota изменяет дневную и месячную квоту организации. Квоту задаёт только
+// администратор системы: администратор организации не может сам себе её поднять
+func (u *User) UpdateOrganizationQuota(ctx context.Context, orgID uuid.UUID, actorUserID uuid.UUID, inspectionsPerDay int, inspectionsPerMonth int) (*models.Organization, error) {
+	const op = "User.UpdateOrganizationQuota"
+
+	log := u.log.With(
+		slog.String("op", op),
+		slog.String("organizationID", orgID.String()),
+		slog.String("actorUserID", actorUserID.String()),
+		slog.Int("inspectionsPerDay", inspectionsPerDay),
+		slog.Int("inspectionsPerMonth", inspectionsPerMonth),
+	)
+
+	log.Info("updating organization quota")
+
+	if err := u.authorizeSystemAdmin(ctx, actorUserID); err != nil {
+		log.Warn("organization quota change denied", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err := u.orgProvider.UpdateQuota(ctx, orgID, inspectionsPerDay, inspectionsPerMonth)
+	if err != nil {
+		log.Error("failed to update organization quota", sl.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, organizationError(err))
+	}
+
+	log.Info("organization quota updated")
+
+	return u.GetOrganization(ctx, orgID)
+}
+
+// userOrganization возвращает ID организации пользователя, ok = false если пользователь в ней не состоит
+func (u *User) userOrganization(ctx context.Context, userID string) (uuid.UUID, bool, error) {
+	orgID, err := u.orgProvider.OrganizationIDByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrganizationMemberNotFound) {
+			return uuid.Nil, false, nil
+		}
+		return uuid.Nil, false, err
+	}
+
+	return orgID, true, nil
+}
+
+// isOrganizationMember проверяет, что пользователь уже состоит в организации orgID
+func (u *User) isOrganizationMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (bool, error) {
+	_, err := u.orgProvider.MemberRole(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrganizationMemberNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// authorizeOrganizationAdmin проверяет, что actor - администратор организации или администратор системы
+func (u *User) authorizeOrganizationAdmin(ctx context.Context, orgID uuid.UUID, actorUserID uuid.UUID) error {
+	if _, err := u.orgProvider.Organization(ctx, orgID); err != nil {
+		return organizationError(err)
+	}
+
+	role, err := u.orgProvider.MemberRole(ctx, orgID, actorUserID)
+	if err == nil && role == models.OrganizationRoleAdmin {
+		return nil
+	}
+	if err != nil && !errors.Is(err, repository.ErrOrganizationMemberNotFound) {
+		return err
+	}
+
+	return u.authorizeSystemAdmin(ctx, actorUserID)
+}
+
+// authorizeSystemAdmin проверяет, что actor - администратор системы
+func (u *User) authorizeSystemAdmin(ctx context.Context, actorUserID uuid.UUID) error {
+	actor, err := u.usrProvider.User(ctx, actorUserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrOrganizationForbidden
+		}
+		return err
+	}
+	if actor.IsAdmin1 || actor.IsAdmin2 {
+		return nil
+	}
+
+	return ErrOrganizationForbidden
+}
+
+// ensureNotLastAdmin запрещает исключение или понижение последнего администратора организации
+func (u *User) ensureNotLastAdmin(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) error {
+	role, err := u.orgProvider.MemberRole(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrganizationMemberNotFound) {
+			return nil
+		}
+		return err
+	}
+	if role != models.OrganizationRoleAdmin {
+		return nil
+	}
+
+	admins, err := u.orgProvider.CountAdmins(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return ErrLastOrganizationAdmin
+	}
+
+	return nil
+}
+
+// organizationError переводит ошибки репозитория в ошибки сервиса
+func organizationError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrOrganizationNotFound):
+		return ErrOrganizationNotFound
+	case errors.Is(err, repository.ErrOrganizationMemberNotFound):
+		return ErrNotOrganizationMember
+	case errors.Is(err, repository.ErrOrganizationMemberExists):
+		return ErrOrganizationMemberExists
+	case errors.Is(err, repository.ErrUserNotFound):
+		return ErrOrganizationUserNotFound
+	case errors.Is(err, repository.ErrOrganizationQuotaExceeded):
+		return ErrInspectionLimitExceeded
+	default:
+		return err
+	}
+}
@@ -21,6 +21,7 @@ type User struct {
 	log         *slog.Logger
 	usrSaver    UserSaver
 	usrProvider UserProvider
+	orgProvider OrganizationProvider
 	mailToken   string
 	mailDomen   string
 }
@@ -82,12 +83,14 @@ func New(
 	log *slog.Logger,
 	userSaver UserSaver,
 	userProvider UserProvider,
+	orgProvider OrganizationProvider,
 	mailToken string,
 	mailDomen string,
 ) *User {
 	return &User{
 		usrSaver:    userSaver,
 		usrProvider: userProvider,
+		orgProvider: orgProvider,
 		mailToken:   mailToken,
 		mailDomen:   mailDomen,
 		log:         log,
@@ -533,7 +536,9 @@ var (
 	ErrInspectionLimitExceeded = errors.New("daily inspection limit exceeded")
 )
 
-func (u *User) IncrementInspectionsForToday(ctx context.Context, userID string) error {
+// IncrementInspectionsForToday списывает проверку и возвращает ID организации, из квоты которой
+// она списана. Для пользователя вне организации возвращается пустая строка
+func (u *User) IncrementInspectionsForToday(ctx context.Context, userID string) (string, error) {
 	const op = "User.IncrementInspectionsForToday"
 
 	log := u.log.With(
@@ -543,64 +548,116 @@ func (u *User) IncrementInspectionsForToday(ctx context.Context, userID string)
 
 	log.Info("incrementing inspections for today")
 
+	orgID, inOrg, err := u.userOrganization(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user organization", sl.Err(err))
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Проверки участника организации списываются из общей квоты, личный счётчик остаётся статистикой
+	if inOrg {
+		err = u.orgProvider.ChargeInspection(ctx, orgID)
+		if err != nil {
+			if errors.Is(err, repository.ErrOrganizationQuotaExceeded) {
+				log.Warn("organization inspection quota exceeded", slog.String("organizationID", orgID.String()))
+				return "", fmt.Errorf("%s: %w", op, ErrInspectionLimitExceeded)
+			}
+			log.Error("failed to charge organization inspection", sl.Err(err))
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = u.usrProvider.IncrementInspectionsForToday(ctx, userID)
+		if err != nil {
+			log.Error("failed to increment inspections for today", sl.Err(err))
+		}
+
+		log.Info("organization inspection charged successfully", slog.String("organizationID", orgID.String()))
+		return orgID.String(), nil
+	}
+
 	inspectionsLeft, err := u.usrProvider.GetInspectionsLeftForToday(ctx, userID)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
 			u.log.Warn("user not found", sl.Err(err))
-			return fmt.Errorf("%s: user not found", op)
+			return "", fmt.Errorf("%s: user not found", op)
 		}
 		u.log.Error("failed to get inspections left for today", sl.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
 	if inspectionsLeft == 0 {
 		u.log.Warn("daily inspection limit exceeded")
-		return fmt.Errorf("%s: %w", op, ErrInspectionLimitExceeded)
+		return "", fmt.Errorf("%s: %w", op, ErrInspectionLimitExceeded)
 	}
 
 	//user, err := u.usrProvider.User(ctx, uuid.MustParse(userID))
 	//if err != nil {
 	//	if errors.Is(err, repository.ErrUserNotFound) {
 	//		u.log.Warn("user not found", sl.Err(err))
-	//		return fmt.Errorf("%s: user not found", op)
+	//		return "", fmt.Errorf("%s: user not found", op)
 	//	}
 	//	u.log.Error("failed to get user info", sl.Err(err))
-	//	return fmt.Errorf("%s: %w", op, err)
+	//	return "", fmt.Errorf("%s: %w", op, err)
 	//}
 
 	//if user.InspectionsForToday >= user.InspectionsPerDay {
 	//	u.log.Warn("daily inspection limit exceeded",
 	//		slog.Int("current", user.InspectionsForToday),
 	//		slog.Int("limit", user.InspectionsPerDay))
-	//	return fmt.Errorf("%s: %w", op, ErrInspectionLimitExceeded)
+	//	return "", fmt.Errorf("%s: %w", op, ErrInspectionLimitExceeded)
 	//}
 
 	err = u.usrProvider.IncrementInspectionsForToday(ctx, userID)
 	if err != nil {
 		log.Error("failed to increment inspections for today", sl.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
 	err = u.usrProvider.DecrementInspectionsLeftForToday(ctx, userID)
 	if err != nil {
 		log.Error("failed to decrement inspections left for today", sl.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Info("inspections for today incremented successfully")
-	return nil
+	return "", nil
 }
 
-func (u *User) DecrementInspectionsForToday(ctx context.Context, userID string) error {
+// DecrementInspectionsForToday возвращает проверку в квоту, из которой она была списана:
+// организации organizationID или, если он пуст, в личный лимит пользователя. Текущее членство
+// пользователя не учитывается - он мог сменить организацию после списания
+func (u *User) DecrementInspectionsForToday(ctx context.Context, userID string, organizationID string) error {
 	const op = "User.DecrementInspectionsForToday"
 
 	log := u.log.With(
 		slog.String("op", op),
 		slog.String("userID", userID),
+		slog.String("organizationID", organizationID),
 	)
 
 	log.Info("decrementing inspections for today")
 
+	if organizationID != "" {
+		orgID, err := uuid.Parse(organizationID)
+		if err != nil {
+			return fmt.Errorf("%s: invalid organization id: %w", op, err)
+		}
+
+		err = u.orgProvider.RefundInspection(ctx, orgID)
+		if err != nil {
+			log.Error("failed to refund organization inspection", sl.Err(err))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = u.usrProvider.DecrementInspectionsForToday(ctx, userID)
+		if err != nil {
+			log.Error("failed to decrement inspections for today", sl.Err(err))
+		}
+
+		log.Info("organization inspection refunded successfully")
+		return nil
+	}
+
 	err := u.usrProvider.DecrementInspectionsForToday(ctx, userID)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
@@ -620,7 +677,9 @@ func (u *User) DecrementInspectionsForToday(ctx context.Context, userID string)
 	return nil
 }
 
-func (u *User) CheckInspectionLimit(ctx context.Context, userID string) (int, error) {
+// CheckInspectionLimit возвращает количество оставшихся проверок и ID организации,
+// если проверки пользователя списываются из её квоты
+func (u *User) CheckInspectionLimit(ctx context.Context, userID string) (int, string, error) {
 	const op = "User.CheckInspectionLimit"
 
 	log := u.log.With(
@@ -630,23 +689,40 @@ func (u *User) CheckInspectionLimit(ctx context.Context, userID string) (int, er
 
 	log.Info("checking inspection limit")
 
-	inspectionsLeft, err := u.usrProvider.GetInspectionsLeftForToday(ctx, userID)
+	orgID, inOrg, err := u.userOrganization(ctx, userID)
 	if err != nil {
-		if errors.Is(err, repository.ErrUserNotFound) {
-			u.log.Warn("user not found", sl.Err(err))
-			return 0, fmt.Errorf("%s: user not found", op)
+		log.Error("failed to get user organization", sl.Err(err))
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	var inspectionsLeft int
+	var organizationID string
+	if inOrg {
+		organizationID = orgID.String()
+		inspectionsLeft, err = u.orgProvider.InspectionsLeft(ctx, orgID)
+		if err != nil {
+			log.Error("failed to get organization inspections left", sl.Err(err))
+			return 0, "", fmt.Errorf("%s: %w", op, err)
+		}
+	} else {
+		inspectionsLeft, err = u.usrProvider.GetInspectionsLeftForToday(ctx, userID)
+		if err != nil {
+			if errors.Is(err, repository.ErrUserNotFound) {
+				u.log.Warn("user not found", sl.Err(err))
+				return 0, "", fmt.Errorf("%s: user not found", op)
+			}
+			u.log.Error("failed to get inspections left for today", sl.Err(err))
+			return 0, "", fmt.Errorf("%s: %w", op, err)
 		}
-		u.log.Error("failed to get inspections left for today", sl.Err(err))
-		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	if inspectionsLeft <= 0 {
-		u.log.Warn("inspection limit exhausted", slog.Int("inspectionsLeft", inspectionsLeft))
-		return 0, fmt.Errorf("%s: %w", op, ErrInspectionLimitExceeded)
+		u.log.Warn("inspection limit exhausted", slog.Int("inspectionsLeft", inspectionsLeft), slog.String("organizationID", organizationID))
+		return 0, organizationID, fmt.Errorf("%s: %w", op, ErrInspectionLimitExceeded)
 	}
 
 	log.Info("inspection limit checked successfully", slog.Int("inspectionsLeft", inspectionsLeft))
-	return inspectionsLeft, nil
+	return inspectionsLeft, organizationID, nil
 }
 
 func (u *User) ChangeUserRole(ctx context.Context, userID string, isAdmin bool) error {
@@ -740,8 +816,8 @@ func (u *User) sendRecoveryEmail(email, login, password string) error {
 	//return nil
 }
 
-// Recovery восстанавливает логин и пароль пользователя по email
-func (u *User) Recovery(ctx context.Context, email string) error {
+// Recovery восстанавливает логин и пароль пользователя по email и возвращает его ID
+func (u *User) Recovery(ctx context.Context, email string) (uuid.UUID, error) {
 	const op = "User.Recovery"
 
 	log := u.log.With(
@@ -756,10 +832,10 @@ func (u *User) Recovery(ctx context.Context, email string) error {
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
 			u.log.Warn("user not found by email", sl.Err(err))
-			return fmt.Errorf("%s: user not found", op)
+			return uuid.Nil, fmt.Errorf("%s: user not found", op)
 		}
 		u.log.Error("failed to get user by email", sl.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	// Получаем логин пользователя по id
@@ -767,10 +843,10 @@ func (u *User) Recovery(ctx context.Context, email string) error {
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
 			u.log.Warn("user not found by email", sl.Err(err))
-			return fmt.Errorf("%s: user not found", op)
+			return uuid.Nil, fmt.Errorf("%s: user not found", op)
 		}
 		u.log.Error("failed to get login by userID", sl.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	// Генерируем новый пароль
@@ -780,14 +856,14 @@ func (u *User) Recovery(ctx context.Context, email string) error {
 	passHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
 		log.Error("failed to generate password hash", sl.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	// Обновляем логин и пароль в базе данных
 	err = u.usrProvider.UpdateLoginAndPassword(ctx, userID, login, passHash)
 	if err != nil {
 		log.Error("failed to update login and password", sl.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	err = u.usrProvider.UpdateConfirmStatusByUserId(ctx, userID, true)
@@ -807,5 +883,5 @@ func (u *User) Recovery(ctx context.Context, email string) error {
 
 	log.Info("account recovery completed successfully")
 
-	return nil
+	return userID, nil
 }
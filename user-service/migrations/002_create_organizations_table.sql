@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- создаем таблицу организаций с общей квотой проверок.
+-- 0 в inspections_per_day и inspections_per_month - соответствующего ограничения нет
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    inspections_per_day INTEGER NOT NULL DEFAULT 10,
+    inspections_per_month INTEGER NOT NULL DEFAULT 0,
+    inspections_for_today INTEGER NOT NULL DEFAULT 0,
+    inspections_for_month INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+    );
+
+-- участники организации, пользователь может состоять только в одной организации
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL DEFAULT 'member' CHECK (role IN ('admin', 'member')),
+    joined_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (organization_id, user_id)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_organization_id ON organization_members(organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_organization_members_organization_id;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
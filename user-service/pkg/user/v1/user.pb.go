@@ -1586,9 +1586,10 @@ func (x *IncrementInspectionsForTodayByUserIdRequest) GetUserId() string {
 }
 
 type IncrementInspectionsForTodayByUserIdResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId *string                `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3,oneof" json:"organization_id,omitempty"` // Организация, из квоты которой списана проверка
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *IncrementInspectionsForTodayByUserIdResponse) Reset() {
@@ -1621,11 +1622,19 @@ func (*IncrementInspectionsForTodayByUserIdResponse) Descriptor() ([]byte, []int
 	return file_user_v1_user_proto_rawDescGZIP(), []int{26}
 }
 
+func (x *IncrementInspectionsForTodayByUserIdResponse) GetOrganizationId() string {
+	if x != nil && x.OrganizationId != nil {
+		return *x.OrganizationId
+	}
+	return ""
+}
+
 type DecrementInspectionsForTodayByUserIdRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	OrganizationId *string                `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3,oneof" json:"organization_id,omitempty"` // Организация, из квоты которой была списана проверка. Не задана - возвращается личный лимит
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *DecrementInspectionsForTodayByUserIdRequest) Reset() {
@@ -1665,6 +1674,13 @@ func (x *DecrementInspectionsForTodayByUserIdRequest) GetUserId() string {
 	return ""
 }
 
+func (x *DecrementInspectionsForTodayByUserIdRequest) GetOrganizationId() string {
+	if x != nil && x.OrganizationId != nil {
+		return *x.OrganizationId
+	}
+	return ""
+}
+
 type DecrementInspectionsForTodayByUserIdResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1747,7 +1763,8 @@ func (x *CheckInspectionLimitRequest) GetUserId() string {
 
 type CheckInspectionLimitResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
-	InspectionsLeft uint32                 `protobuf:"varint,1,opt,name=inspections_left,json=inspectionsLeft,proto3" json:"inspections_left,omitempty"` // Количество оставшихся проверок на сегодня
+	InspectionsLeft uint32                 `protobuf:"varint,1,opt,name=inspections_left,json=inspectionsLeft,proto3" json:"inspections_left,omitempty"`   // Количество оставшихся проверок на сегодня
+	OrganizationId  *string                `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3,oneof" json:"organization_id,omitempty"` // Организация, из квоты которой списываются проверки
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -1789,6 +1806,13 @@ func (x *CheckInspectionLimitResponse) GetInspectionsLeft() uint32 {
 	return 0
 }
 
+func (x *CheckInspectionLimitResponse) GetOrganizationId() string {
+	if x != nil && x.OrganizationId != nil {
+		return *x.OrganizationId
+	}
+	return ""
+}
+
 // ChangeUserRoleRequest запрос для смены роли пользователя
 type ChangeUserRoleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1943,8 +1967,9 @@ func (x *RecoveryRequest) GetEmail() string {
 // RecoveryResponse ответ на восстановление
 type RecoveryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Статус успешности операции
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // Сообщение о результате
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`            // Статус успешности операции
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`             // Сообщение о результате
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Пользователь, чей пароль был сброшен
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1993,6 +2018,591 @@ func (x *RecoveryResponse) GetMessage() string {
 	return ""
 }
 
+func (x *RecoveryResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Organization организация с общей квотой проверок. Проверки участников списываются из квоты
+// организации вместо личного inspections_per_day
+type Organization struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	InspectionsPerDay   uint32                 `protobuf:"varint,3,opt,name=inspections_per_day,json=inspectionsPerDay,proto3" json:"inspections_per_day,omitempty"`
+	InspectionsPerMonth uint32                 `protobuf:"varint,4,opt,name=inspections_per_month,json=inspectionsPerMonth,proto3" json:"inspections_per_month,omitempty"`
+	InspectionsForToday uint32                 `protobuf:"varint,5,opt,name=inspections_for_today,json=inspectionsForToday,proto3" json:"inspections_for_today,omitempty"`
+	InspectionsForMonth uint32                 `protobuf:"varint,6,opt,name=inspections_for_month,json=inspectionsForMonth,proto3" json:"inspections_for_month,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Members             []*OrganizationMember  `protobuf:"bytes,8,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_user_v1_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Organization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetInspectionsPerDay() uint32 {
+	if x != nil {
+		return x.InspectionsPerDay
+	}
+	return 0
+}
+
+func (x *Organization) GetInspectionsPerMonth() uint32 {
+	if x != nil {
+		return x.InspectionsPerMonth
+	}
+	return 0
+}
+
+func (x *Organization) GetInspectionsForToday() uint32 {
+	if x != nil {
+		return x.InspectionsForToday
+	}
+	return 0
+}
+
+func (x *Organization) GetInspectionsForMonth() uint32 {
+	if x != nil {
+		return x.InspectionsForMonth
+	}
+	return 0
+}
+
+func (x *Organization) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Organization) GetMembers() []*OrganizationMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// OrganizationMember участник организации
+type OrganizationMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // admin | member
+	FirstName     string                 `protobuf:"bytes,3,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,4,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	JoinedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationMember) Reset() {
+	*x = OrganizationMember{}
+	mi := &file_user_v1_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationMember) ProtoMessage() {}
+
+func (x *OrganizationMember) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationMember.ProtoReflect.Descriptor instead.
+func (*OrganizationMember) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *OrganizationMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+type OrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationResponse) Reset() {
+	*x = OrganizationResponse{}
+	mi := &file_user_v1_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationResponse) ProtoMessage() {}
+
+func (x *OrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationResponse.ProtoReflect.Descriptor instead.
+func (*OrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *OrganizationResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+// CreateOrganizationRequest запрос на создание организации
+type CreateOrganizationRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	InspectionsPerDay   uint32                 `protobuf:"varint,2,opt,name=inspections_per_day,json=inspectionsPerDay,proto3" json:"inspections_per_day,omitempty"`       // 0 - без дневного ограничения
+	InspectionsPerMonth uint32                 `protobuf:"varint,3,opt,name=inspections_per_month,json=inspectionsPerMonth,proto3" json:"inspections_per_month,omitempty"` // 0 - без месячного ограничения
+	AdminUserId         string                 `protobuf:"bytes,4,opt,name=admin_user_id,json=adminUserId,proto3" json:"admin_user_id,omitempty"`                          // UUID первого администратора организации
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrganizationRequest) GetInspectionsPerDay() uint32 {
+	if x != nil {
+		return x.InspectionsPerDay
+	}
+	return 0
+}
+
+func (x *CreateOrganizationRequest) GetInspectionsPerMonth() uint32 {
+	if x != nil {
+		return x.InspectionsPerMonth
+	}
+	return 0
+}
+
+func (x *CreateOrganizationRequest) GetAdminUserId() string {
+	if x != nil {
+		return x.AdminUserId
+	}
+	return ""
+}
+
+type GetOrganizationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetOrganizationRequest) Reset() {
+	*x = GetOrganizationRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationRequest) ProtoMessage() {}
+
+func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetOrganizationRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+type GetUserOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserOrganizationRequest) Reset() {
+	*x = GetUserOrganizationRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserOrganizationRequest) ProtoMessage() {}
+
+func (x *GetUserOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*GetUserOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetUserOrganizationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Добавить нового участника и изменить квоту может только администратор системы (actor_user_id),
+// роль существующего участника может менять и администратор организации
+type AddOrganizationMemberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,2,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role           string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"` // admin | member, по умолчанию member
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddOrganizationMemberRequest) Reset() {
+	*x = AddOrganizationMemberRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *AddOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AddOrganizationMemberRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *AddOrganizationMemberRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *AddOrganizationMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddOrganizationMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type RemoveOrganizationMemberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,2,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RemoveOrganizationMemberRequest) Reset() {
+	*x = RemoveOrganizationMemberRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *RemoveOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RemoveOrganizationMemberRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *RemoveOrganizationMemberRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *RemoveOrganizationMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UpdateOrganizationQuotaRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId      string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	ActorUserId         string                 `protobuf:"bytes,2,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	InspectionsPerDay   uint32                 `protobuf:"varint,3,opt,name=inspections_per_day,json=inspectionsPerDay,proto3" json:"inspections_per_day,omitempty"`       // 0 - без дневного ограничения
+	InspectionsPerMonth uint32                 `protobuf:"varint,4,opt,name=inspections_per_month,json=inspectionsPerMonth,proto3" json:"inspections_per_month,omitempty"` // 0 - без месячного ограничения
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UpdateOrganizationQuotaRequest) Reset() {
+	*x = UpdateOrganizationQuotaRequest{}
+	mi := &file_user_v1_user_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrganizationQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrganizationQuotaRequest) ProtoMessage() {}
+
+func (x *UpdateOrganizationQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_v1_user_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrganizationQuotaRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrganizationQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_user_v1_user_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateOrganizationQuotaRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *UpdateOrganizationQuotaRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *UpdateOrganizationQuotaRequest) GetInspectionsPerDay() uint32 {
+	if x != nil {
+		return x.InspectionsPerDay
+	}
+	return 0
+}
+
+func (x *UpdateOrganizationQuotaRequest) GetInspectionsPerMonth() uint32 {
+	if x != nil {
+		return x.InspectionsPerMonth
+	}
+	return 0
+}
+
 var File_user_v1_user_proto protoreflect.FileDescriptor
 
 const file_user_v1_user_proto_rawDesc = "" +
@@ -2115,15 +2725,21 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"\x04code\x18\x02 \x01(\tR\x04code\"\x16\n" +
 	"\x14ConfirmEmailResponse\"E\n" +
 	"+IncrementInspectionsForTodayByUserIdRequest\x12\x16\n" +
-	"\x06userId\x18\x01 \x01(\tR\x06userId\".\n" +
-	",IncrementInspectionsForTodayByUserIdResponse\"E\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\"p\n" +
+	",IncrementInspectionsForTodayByUserIdResponse\x12,\n" +
+	"\x0forganization_id\x18\x01 \x01(\tH\x00R\x0eorganizationId\x88\x01\x01B\x12\n" +
+	"\x10_organization_id\"\x87\x01\n" +
 	"+DecrementInspectionsForTodayByUserIdRequest\x12\x16\n" +
-	"\x06userId\x18\x01 \x01(\tR\x06userId\".\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12,\n" +
+	"\x0forganization_id\x18\x02 \x01(\tH\x00R\x0eorganizationId\x88\x01\x01B\x12\n" +
+	"\x10_organization_id\".\n" +
 	",DecrementInspectionsForTodayByUserIdResponse\"6\n" +
 	"\x1bCheckInspectionLimitRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"I\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x8b\x01\n" +
 	"\x1cCheckInspectionLimitResponse\x12)\n" +
-	"\x10inspections_left\x18\x01 \x01(\rR\x0finspectionsLeft\"K\n" +
+	"\x10inspections_left\x18\x01 \x01(\rR\x0finspectionsLeft\x12,\n" +
+	"\x0forganization_id\x18\x02 \x01(\tH\x00R\x0eorganizationId\x88\x01\x01B\x12\n" +
+	"\x10_organization_id\"K\n" +
 	"\x15ChangeUserRoleRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
 	"\bis_admin\x18\x02 \x01(\bR\aisAdmin\"L\n" +
@@ -2131,10 +2747,53 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"'\n" +
 	"\x0fRecoveryRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\"F\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"_\n" +
 	"\x10RecoveryResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xae\v\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\xf0\x02\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12.\n" +
+	"\x13inspections_per_day\x18\x03 \x01(\rR\x11inspectionsPerDay\x122\n" +
+	"\x15inspections_per_month\x18\x04 \x01(\rR\x13inspectionsPerMonth\x122\n" +
+	"\x15inspections_for_today\x18\x05 \x01(\rR\x13inspectionsForToday\x122\n" +
+	"\x15inspections_for_month\x18\x06 \x01(\rR\x13inspectionsForMonth\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x125\n" +
+	"\amembers\x18\b \x03(\v2\x1b.user.v1.OrganizationMemberR\amembers\"\xb6\x01\n" +
+	"\x12OrganizationMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x03 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x04 \x01(\tR\blastName\x127\n" +
+	"\tjoined_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\"Q\n" +
+	"\x14OrganizationResponse\x129\n" +
+	"\forganization\x18\x01 \x01(\v2\x15.user.v1.OrganizationR\forganization\"\xb7\x01\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12.\n" +
+	"\x13inspections_per_day\x18\x02 \x01(\rR\x11inspectionsPerDay\x122\n" +
+	"\x15inspections_per_month\x18\x03 \x01(\rR\x13inspectionsPerMonth\x12\"\n" +
+	"\radmin_user_id\x18\x04 \x01(\tR\vadminUserId\"A\n" +
+	"\x16GetOrganizationRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"5\n" +
+	"\x1aGetUserOrganizationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x98\x01\n" +
+	"\x1cAddOrganizationMemberRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\"\n" +
+	"\ractor_user_id\x18\x02 \x01(\tR\vactorUserId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"\x87\x01\n" +
+	"\x1fRemoveOrganizationMemberRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\"\n" +
+	"\ractor_user_id\x18\x02 \x01(\tR\vactorUserId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\xd1\x01\n" +
+	"\x1eUpdateOrganizationQuotaRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\"\n" +
+	"\ractor_user_id\x18\x02 \x01(\tR\vactorUserId\x12.\n" +
+	"\x13inspections_per_day\x18\x03 \x01(\rR\x11inspectionsPerDay\x122\n" +
+	"\x15inspections_per_month\x18\x04 \x01(\rR\x13inspectionsPerMonth2\xdc\x0f\n" +
 	"\vUserService\x12H\n" +
 	"\vGetUserInfo\x12\x1b.user.v1.GetUserInfoRequest\x1a\x1c.user.v1.GetUserInfoResponse\x12K\n" +
 	"\fRegisterUser\x12\x1c.user.v1.RegisterUserRequest\x1a\x1d.user.v1.RegisterUserResponse\x12K\n" +
@@ -2151,7 +2810,13 @@ const file_user_v1_user_proto_rawDesc = "" +
 	"$DecrementInspectionsForTodayByUserId\x124.user.v1.DecrementInspectionsForTodayByUserIdRequest\x1a5.user.v1.DecrementInspectionsForTodayByUserIdResponse\x12c\n" +
 	"\x14CheckInspectionLimit\x12$.user.v1.CheckInspectionLimitRequest\x1a%.user.v1.CheckInspectionLimitResponse\x12Q\n" +
 	"\x0eChangeUserRole\x12\x1e.user.v1.ChangeUserRoleRequest\x1a\x1f.user.v1.ChangeUserRoleResponse\x12?\n" +
-	"\bRecovery\x12\x18.user.v1.RecoveryRequest\x1a\x19.user.v1.RecoveryResponseB\tZ\auser/v1b\x06proto3"
+	"\bRecovery\x12\x18.user.v1.RecoveryRequest\x1a\x19.user.v1.RecoveryResponse\x12W\n" +
+	"\x12CreateOrganization\x12\".user.v1.CreateOrganizationRequest\x1a\x1d.user.v1.OrganizationResponse\x12Q\n" +
+	"\x0fGetOrganization\x12\x1f.user.v1.GetOrganizationRequest\x1a\x1d.user.v1.OrganizationResponse\x12Y\n" +
+	"\x13GetUserOrganization\x12#.user.v1.GetUserOrganizationRequest\x1a\x1d.user.v1.OrganizationResponse\x12]\n" +
+	"\x15AddOrganizationMember\x12%.user.v1.AddOrganizationMemberRequest\x1a\x1d.user.v1.OrganizationResponse\x12c\n" +
+	"\x18RemoveOrganizationMember\x12(.user.v1.RemoveOrganizationMemberRequest\x1a\x1d.user.v1.OrganizationResponse\x12a\n" +
+	"\x17UpdateOrganizationQuota\x12'.user.v1.UpdateOrganizationQuotaRequest\x1a\x1d.user.v1.OrganizationResponseB\tZ\auser/v1b\x06proto3"
 
 var (
 	file_user_v1_user_proto_rawDescOnce sync.Once
@@ -2165,7 +2830,7 @@ func file_user_v1_user_proto_rawDescGZIP() []byte {
 	return file_user_v1_user_proto_rawDescData
 }
 
-var file_user_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_user_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
 var file_user_v1_user_proto_goTypes = []any{
 	(*GetUserInfoRequest)(nil),                           // 0: user.v1.GetUserInfoRequest
 	(*GetUserInfoResponse)(nil),                          // 1: user.v1.GetUserInfoResponse
@@ -2202,57 +2867,82 @@ var file_user_v1_user_proto_goTypes = []any{
 	(*ChangeUserRoleResponse)(nil),                       // 32: user.v1.ChangeUserRoleResponse
 	(*RecoveryRequest)(nil),                              // 33: user.v1.RecoveryRequest
 	(*RecoveryResponse)(nil),                             // 34: user.v1.RecoveryResponse
-	nil,                                                  // 35: user.v1.GetFullNamesByIdRequest.IdsEntry
-	nil,                                                  // 36: user.v1.GetFullNamesByIdResponse.UsersEntry
-	(*timestamppb.Timestamp)(nil),                        // 37: google.protobuf.Timestamp
+	(*Organization)(nil),                                 // 35: user.v1.Organization
+	(*OrganizationMember)(nil),                           // 36: user.v1.OrganizationMember
+	(*OrganizationResponse)(nil),                         // 37: user.v1.OrganizationResponse
+	(*CreateOrganizationRequest)(nil),                    // 38: user.v1.CreateOrganizationRequest
+	(*GetOrganizationRequest)(nil),                       // 39: user.v1.GetOrganizationRequest
+	(*GetUserOrganizationRequest)(nil),                   // 40: user.v1.GetUserOrganizationRequest
+	(*AddOrganizationMemberRequest)(nil),                 // 41: user.v1.AddOrganizationMemberRequest
+	(*RemoveOrganizationMemberRequest)(nil),              // 42: user.v1.RemoveOrganizationMemberRequest
+	(*UpdateOrganizationQuotaRequest)(nil),               // 43: user.v1.UpdateOrganizationQuotaRequest
+	nil,                                                  // 44: user.v1.GetFullNamesByIdRequest.IdsEntry
+	nil,                                                  // 45: user.v1.GetFullNamesByIdResponse.UsersEntry
+	(*timestamppb.Timestamp)(nil),                        // 46: google.protobuf.Timestamp
 }
 var file_user_v1_user_proto_depIdxs = []int32{
-	37, // 0: user.v1.GetUserInfoResponse.registered_at:type_name -> google.protobuf.Timestamp
-	37, // 1: user.v1.GetUserInfoResponse.last_visit_at:type_name -> google.protobuf.Timestamp
-	37, // 2: user.v1.LoginResponse.registered_at:type_name -> google.protobuf.Timestamp
-	37, // 3: user.v1.LoginResponse.last_visit_at:type_name -> google.protobuf.Timestamp
+	46, // 0: user.v1.GetUserInfoResponse.registered_at:type_name -> google.protobuf.Timestamp
+	46, // 1: user.v1.GetUserInfoResponse.last_visit_at:type_name -> google.protobuf.Timestamp
+	46, // 2: user.v1.LoginResponse.registered_at:type_name -> google.protobuf.Timestamp
+	46, // 3: user.v1.LoginResponse.last_visit_at:type_name -> google.protobuf.Timestamp
 	11, // 4: user.v1.GetAllUsersResponse.users:type_name -> user.v1.UserInfo
-	35, // 5: user.v1.GetFullNamesByIdRequest.ids:type_name -> user.v1.GetFullNamesByIdRequest.IdsEntry
-	36, // 6: user.v1.GetFullNamesByIdResponse.users:type_name -> user.v1.GetFullNamesByIdResponse.UsersEntry
-	18, // 7: user.v1.GetFullNamesByIdRequest.IdsEntry.value:type_name -> user.v1.Empty
-	20, // 8: user.v1.GetFullNamesByIdResponse.UsersEntry.value:type_name -> user.v1.FullName
-	0,  // 9: user.v1.UserService.GetUserInfo:input_type -> user.v1.GetUserInfoRequest
-	2,  // 10: user.v1.UserService.RegisterUser:input_type -> user.v1.RegisterUserRequest
-	23, // 11: user.v1.UserService.ConfirmEmail:input_type -> user.v1.ConfirmEmailRequest
-	4,  // 12: user.v1.UserService.Login:input_type -> user.v1.LoginRequest
-	6,  // 13: user.v1.UserService.GetLoginById:input_type -> user.v1.GetLoginByIdRequest
-	8,  // 14: user.v1.UserService.GetUserByLogin:input_type -> user.v1.GetUserByLoginRequest
-	10, // 15: user.v1.UserService.GetAllUsers:input_type -> user.v1.GetAllUsersRequest
-	13, // 16: user.v1.UserService.GetUserDetailsById:input_type -> user.v1.GetUserDetailsByIdRequest
-	15, // 17: user.v1.UserService.UpdateInspectionsPerDay:input_type -> user.v1.UpdateInspectionsPerDayRequest
-	17, // 18: user.v1.UserService.GetFullNamesById:input_type -> user.v1.GetFullNamesByIdRequest
-	21, // 19: user.v1.UserService.RegisterVisit:input_type -> user.v1.RegisterVisitRequest
-	25, // 20: user.v1.UserService.IncrementInspectionsForTodayByUserId:input_type -> user.v1.IncrementInspectionsForTodayByUserIdRequest
-	27, // 21: user.v1.UserService.DecrementInspectionsForTodayByUserId:input_type -> user.v1.DecrementInspectionsForTodayByUserIdRequest
-	29, // 22: user.v1.UserService.CheckInspectionLimit:input_type -> user.v1.CheckInspectionLimitRequest
-	31, // 23: user.v1.UserService.ChangeUserRole:input_type -> user.v1.ChangeUserRoleRequest
-	33, // 24: user.v1.UserService.Recovery:input_type -> user.v1.RecoveryRequest
-	1,  // 25: user.v1.UserService.GetUserInfo:output_type -> user.v1.GetUserInfoResponse
-	3,  // 26: user.v1.UserService.RegisterUser:output_type -> user.v1.RegisterUserResponse
-	24, // 27: user.v1.UserService.ConfirmEmail:output_type -> user.v1.ConfirmEmailResponse
-	5,  // 28: user.v1.UserService.Login:output_type -> user.v1.LoginResponse
-	7,  // 29: user.v1.UserService.GetLoginById:output_type -> user.v1.GetLoginByIdResponse
-	9,  // 30: user.v1.UserService.GetUserByLogin:output_type -> user.v1.GetUserByLoginResponse
-	12, // 31: user.v1.UserService.GetAllUsers:output_type -> user.v1.GetAllUsersResponse
-	14, // 32: user.v1.UserService.GetUserDetailsById:output_type -> user.v1.GetUserDetailsByIdResponse
-	16, // 33: user.v1.UserService.UpdateInspectionsPerDay:output_type -> user.v1.UpdateInspectionsPerDayResponse
-	19, // 34: user.v1.UserService.GetFullNamesById:output_type -> user.v1.GetFullNamesByIdResponse
-	22, // 35: user.v1.UserService.RegisterVisit:output_type -> user.v1.RegisterVisitResponse
-	26, // 36: user.v1.UserService.IncrementInspectionsForTodayByUserId:output_type -> user.v1.IncrementInspectionsForTodayByUserIdResponse
-	28, // 37: user.v1.UserService.DecrementInspectionsForTodayByUserId:output_type -> user.v1.DecrementInspectionsForTodayByUserIdResponse
-	30, // 38: user.v1.UserService.CheckInspectionLimit:output_type -> user.v1.CheckInspectionLimitResponse
-	32, // 39: user.v1.UserService.ChangeUserRole:output_type -> user.v1.ChangeUserRoleResponse
-	34, // 40: user.v1.UserService.Recovery:output_type -> user.v1.RecoveryResponse
-	25, // [25:41] is the sub-list for method output_type
-	9,  // [9:25] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	44, // 5: user.v1.GetFullNamesByIdRequest.ids:type_name -> user.v1.GetFullNamesByIdRequest.IdsEntry
+	45, // 6: user.v1.GetFullNamesByIdResponse.users:type_name -> user.v1.GetFullNamesByIdResponse.UsersEntry
+	46, // 7: user.v1.Organization.created_at:type_name -> google.protobuf.Timestamp
+	36, // 8: user.v1.Organization.members:type_name -> user.v1.OrganizationMember
+	46, // 9: user.v1.OrganizationMember.joined_at:type_name -> google.protobuf.Timestamp
+	35, // 10: user.v1.OrganizationResponse.organization:type_name -> user.v1.Organization
+	18, // 11: user.v1.GetFullNamesByIdRequest.IdsEntry.value:type_name -> user.v1.Empty
+	20, // 12: user.v1.GetFullNamesByIdResponse.UsersEntry.value:type_name -> user.v1.FullName
+	0,  // 13: user.v1.UserService.GetUserInfo:input_type -> user.v1.GetUserInfoRequest
+	2,  // 14: user.v1.UserService.RegisterUser:input_type -> user.v1.RegisterUserRequest
+	23, // 15: user.v1.UserService.ConfirmEmail:input_type -> user.v1.ConfirmEmailRequest
+	4,  // 16: user.v1.UserService.Login:input_type -> user.v1.LoginRequest
+	6,  // 17: user.v1.UserService.GetLoginById:input_type -> user.v1.GetLoginByIdRequest
+	8,  // 18: user.v1.UserService.GetUserByLogin:input_type -> user.v1.GetUserByLoginRequest
+	10, // 19: user.v1.UserService.GetAllUsers:input_type -> user.v1.GetAllUsersRequest
+	13, // 20: user.v1.UserService.GetUserDetailsById:input_type -> user.v1.GetUserDetailsByIdRequest
+	15, // 21: user.v1.UserService.UpdateInspectionsPerDay:input_type -> user.v1.UpdateInspectionsPerDayRequest
+	17, // 22: user.v1.UserService.GetFullNamesById:input_type -> user.v1.GetFullNamesByIdRequest
+	21, // 23: user.v1.UserService.RegisterVisit:input_type -> user.v1.RegisterVisitRequest
+	25, // 24: user.v1.UserService.IncrementInspectionsForTodayByUserId:input_type -> user.v1.IncrementInspectionsForTodayByUserIdRequest
+	27, // 25: user.v1.UserService.DecrementInspectionsForTodayByUserId:input_type -> user.v1.DecrementInspectionsForTodayByUserIdRequest
+	29, // 26: user.v1.UserService.CheckInspectionLimit:input_type -> user.v1.CheckInspectionLimitRequest
+	31, // 27: user.v1.UserService.ChangeUserRole:input_type -> user.v1.ChangeUserRoleRequest
+	33, // 28: user.v1.UserService.Recovery:input_type -> user.v1.RecoveryRequest
+	38, // 29: user.v1.UserService.CreateOrganization:input_type -> user.v1.CreateOrganizationRequest
+	39, // 30: user.v1.UserService.GetOrganization:input_type -> user.v1.GetOrganizationRequest
+	40, // 31: user.v1.UserService.GetUserOrganization:input_type -> user.v1.GetUserOrganizationRequest
+	41, // 32: user.v1.UserService.AddOrganizationMember:input_type -> user.v1.AddOrganizationMemberRequest
+	42, // 33: user.v1.UserService.RemoveOrganizationMember:input_type -> user.v1.RemoveOrganizationMemberRequest
+	43, // 34: user.v1.UserService.UpdateOrganizationQuota:input_type -> user.v1.UpdateOrganizationQuotaRequest
+	1,  // 35: user.v1.UserService.GetUserInfo:output_type -> user.v1.GetUserInfoResponse
+	3,  // 36: user.v1.UserService.RegisterUser:output_type -> user.v1.RegisterUserResponse
+	24, // 37: user.v1.UserService.ConfirmEmail:output_type -> user.v1.ConfirmEmailResponse
+	5,  // 38: user.v1.UserService.Login:output_type -> user.v1.LoginResponse
+	7,  // 39: user.v1.UserService.GetLoginById:output_type -> user.v1.GetLoginByIdResponse
+	9,  // 40: user.v1.UserService.GetUserByLogin:output_type -> user.v1.GetUserByLoginResponse
+	12, // 41: user.v1.UserService.GetAllUsers:output_type -> user.v1.GetAllUsersResponse
+	14, // 42: user.v1.UserService.GetUserDetailsById:output_type -> user.v1.GetUserDetailsByIdResponse
+	16, // 43: user.v1.UserService.UpdateInspectionsPerDay:output_type -> user.v1.UpdateInspectionsPerDayResponse
+	19, // 44: user.v1.UserService.GetFullNamesById:output_type -> user.v1.GetFullNamesByIdResponse
+	22, // 45: user.v1.UserService.RegisterVisit:output_type -> user.v1.RegisterVisitResponse
+	26, // 46: user.v1.UserService.IncrementInspectionsForTodayByUserId:output_type -> user.v1.IncrementInspectionsForTodayByUserIdResponse
+	28, // 47: user.v1.UserService.DecrementInspectionsForTodayByUserId:output_type -> user.v1.DecrementInspectionsForTodayByUserIdResponse
+	30, // 48: user.v1.UserService.CheckInspectionLimit:output_type -> user.v1.CheckInspectionLimitResponse
+	32, // 49: user.v1.UserService.ChangeUserRole:output_type -> user.v1.ChangeUserRoleResponse
+	34, // 50: user.v1.UserService.Recovery:output_type -> user.v1.RecoveryResponse
+	37, // 51: user.v1.UserService.CreateOrganization:output_type -> user.v1.OrganizationResponse
+	37, // 52: user.v1.UserService.GetOrganization:output_type -> user.v1.OrganizationResponse
+	37, // 53: user.v1.UserService.GetUserOrganization:output_type -> user.v1.OrganizationResponse
+	37, // 54: user.v1.UserService.AddOrganizationMember:output_type -> user.v1.OrganizationResponse
+	37, // 55: user.v1.UserService.RemoveOrganizationMember:output_type -> user.v1.OrganizationResponse
+	37, // 56: user.v1.UserService.UpdateOrganizationQuota:output_type -> user.v1.OrganizationResponse
+	35, // [35:57] is the sub-list for method output_type
+	13, // [13:35] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_user_v1_user_proto_init() }
@@ -2260,13 +2950,16 @@ func file_user_v1_user_proto_init() {
 	if File_user_v1_user_proto != nil {
 		return
 	}
+	file_user_v1_user_proto_msgTypes[26].OneofWrappers = []any{}
+	file_user_v1_user_proto_msgTypes[27].OneofWrappers = []any{}
+	file_user_v1_user_proto_msgTypes[30].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_v1_user_proto_rawDesc), len(file_user_v1_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   37,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},